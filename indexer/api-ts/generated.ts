@@ -42,6 +42,47 @@ export interface WithdrawalItem {
   l1FinalizedTxHash: string;
   l1TokenAddress: string;
   l2TokenAddress: string;
+  status: WithdrawalStatus;
+}
+/**
+ * WithdrawalState ... Step of the multistep (bedrock) withdrawal process a withdrawal has reached
+ */
+export type WithdrawalState = string;
+/**
+ * WithdrawalInitiated ... No output covering the withdrawal has been proposed yet
+ */
+export const WithdrawalInitiated: WithdrawalState = "initiated";
+/**
+ * WithdrawalReadyToProve ... The withdrawal can be proven against the output at L2OutputIndex
+ */
+export const WithdrawalReadyToProve: WithdrawalState = "ready-to-prove";
+/**
+ * WithdrawalProven ... The withdrawal is proven and within the finalization period
+ */
+export const WithdrawalProven: WithdrawalState = "proven";
+/**
+ * WithdrawalReadyToFinalize ... The finalization period has elapsed since the withdrawal was proven
+ */
+export const WithdrawalReadyToFinalize: WithdrawalState = "ready-to-finalize";
+/**
+ * WithdrawalFinalized ... The withdrawal has been finalized on L1
+ */
+export const WithdrawalFinalized: WithdrawalState = "finalized";
+/**
+ * WithdrawalStatus ... Lifecycle of a withdrawal. Timestamps are omitted until the step has been reached,
+ * except for `readyToFinalizeTimestamp` which is known as soon as the withdrawal is proven
+ */
+export interface WithdrawalStatus {
+  state: WithdrawalState;
+  /**
+   * L2OutputIndex ... Index of the first output covering the withdrawal, used to build the withdrawal proof
+   */
+  l2OutputIndex?: string;
+  initiatedTimestamp: number /* uint64 */;
+  readyToProveTimestamp?: number /* uint64 */;
+  provenTimestamp?: number /* uint64 */;
+  readyToFinalizeTimestamp?: number /* uint64 */;
+  finalizedTimestamp?: number /* uint64 */;
 }
 /**
  * WithdrawalResponse ... Data model for API JSON response
@@ -51,6 +92,55 @@ export interface WithdrawalResponse {
   hasNextPage: boolean;
   items: WithdrawalItem[];
 }
+/**
+ * ERC721DepositItem ... NFT deposit item model for API responses
+ */
+export interface ERC721DepositItem {
+  guid: string;
+  from: string;
+  to: string;
+  timestamp: number /* uint64 */;
+  l1BlockHash: string;
+  l1TxHash: string;
+  l2TxHash: string;
+  crossDomainMessageHash: string;
+  tokenId: string;
+  l1TokenAddress: string;
+  l2TokenAddress: string;
+}
+/**
+ * ERC721DepositResponse ... Data model for API JSON response
+ */
+export interface ERC721DepositResponse {
+  cursor: string;
+  hasNextPage: boolean;
+  items: ERC721DepositItem[];
+}
+/**
+ * ERC721WithdrawalItem ... NFT withdrawal item model for API responses
+ */
+export interface ERC721WithdrawalItem {
+  guid: string;
+  from: string;
+  to: string;
+  transactionHash: string;
+  crossDomainMessageHash: string;
+  timestamp: number /* uint64 */;
+  l2BlockHash: string;
+  tokenId: string;
+  l1ProvenTxHash: string;
+  l1FinalizedTxHash: string;
+  l1TokenAddress: string;
+  l2TokenAddress: string;
+}
+/**
+ * ERC721WithdrawalResponse ... Data model for API JSON response
+ */
+export interface ERC721WithdrawalResponse {
+  cursor: string;
+  hasNextPage: boolean;
+  items: ERC721WithdrawalItem[];
+}
 export interface BridgeSupplyView {
   l1DepositSum: number /* float64 */;
   l2WithdrawalSum: number /* float64 */;
@@ -32,3 +32,7 @@ export const withdrawalEndoint = ({ baseUrl = '', address, cursor, limit }: Opti
   return [baseUrl, 'withdrawals', `${address}${createQueryString({ cursor, limit })}`].join('/')
 }
 
+
+export const withdrawalStatusEndpoint = ({ baseUrl = '', withdrawalHash }: { baseUrl?: string, withdrawalHash: `0x${string}` }): string => {
+  return [baseUrl, 'withdrawal', withdrawalHash].join('/')
+}
@@ -7,15 +7,19 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/indexer/api/graphql"
 	"github.com/ethereum-optimism/optimism/indexer/api/routes"
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
@@ -23,11 +27,15 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 )
 
-const ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
+const (
+	ethereumAddressRegex = `^0x[a-fA-F0-9]{40}$`
+	ethereumHashRegex    = `^0x[a-fA-F0-9]{64}$`
+)
 
 const (
 	MetricsNamespace = "op_indexer_api"
 	addressParam     = "{address:%s}"
+	hashParam        = "{hash:%s}"
 
 	// Endpoint paths
 	// NOTE - This can be further broken out over time as new version iterations
@@ -35,18 +43,45 @@ const (
 	HealthPath      = "/healthz"
 	DepositsPath    = "/api/v0/deposits/"
 	WithdrawalsPath = "/api/v0/withdrawals/"
+	WithdrawalPath  = "/api/v0/withdrawal/"
+
+	ERC721DepositsPath    = "/api/v0/erc721/deposits/"
+	ERC721WithdrawalsPath = "/api/v0/erc721/withdrawals/"
 
 	SupplyPath = "/api/v0/supply"
+
+	// AccountingPath serves the latest reconciliation report of each bridged token pair, and
+	// AccountingStuckPath the messages that failed to relay and have yet to be replayed
+	AccountingPath      = "/api/v0/accounting"
+	AccountingStuckPath = "/api/v0/accounting/stuck"
+
+	// ChainsPath lists the chains served when indexing several chains, each
+	// scoping its routes under the path with its chain id. See ChainPath
+	ChainsPath = "/api/v0/chains"
+
+	// GraphQLPath serves queries over HTTP POST requests. Subscriptions are served over WebSockets,
+	// along with queries, on the GraphQLWebSocketPath
+	GraphQLPath          = "/api/v0/graphql"
+	GraphQLWebSocketPath = "/api/v0/graphql/ws"
 )
 
+const apiPathPrefix = "/api/v0/"
+
+// ChainPath ... Scopes the path of an API route to the chain when serving several chains
+func ChainPath(chainID uint64, path string) string {
+	return fmt.Sprintf("%s/%d/%s", ChainsPath, chainID, strings.TrimPrefix(path, apiPathPrefix))
+}
+
 // Api ... Indexer API struct
 // TODO : Structured error responses
 type APIService struct {
 	log    log.Logger
 	router *chi.Mux
 
-	bv      database.BridgeTransfersView
-	dbClose func() error
+	chains []*chainService
+
+	// routes of each chain are scoped by its chain id
+	multiChain bool
 
 	metricsRegistry *prometheus.Registry
 
@@ -56,6 +91,20 @@ type APIService struct {
 	stopped atomic.Bool
 }
 
+// chainService ... The views of an L2 chain served by the API
+type chainService struct {
+	chainID uint64
+
+	bv      database.BridgeTransfersView
+	blocks  database.BlocksView
+	av      database.BridgeAccountingView
+	dbClose func() error
+
+	graphqlSchema *graphqlgo.Schema
+
+	finalizationPeriodSeconds uint64
+}
+
 // chiMetricsMiddleware ... Injects a metrics recorder into request processing middleware
 func chiMetricsMiddleware(rec metrics.HTTPRecorder) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -73,8 +122,21 @@ func NewApi(ctx context.Context, log log.Logger, cfg *Config) (*APIService, erro
 }
 
 func (a *APIService) initFromConfig(ctx context.Context, cfg *Config) error {
-	if err := a.initDB(ctx, cfg.DB); err != nil {
-		return fmt.Errorf("failed to init DB: %w", err)
+	if len(cfg.Chains) == 0 {
+		chain := &chainService{finalizationPeriodSeconds: cfg.FinalizationPeriodSeconds}
+		a.chains = append(a.chains, chain)
+		if err := chain.initDB(ctx, a.log, cfg.DB); err != nil {
+			return fmt.Errorf("failed to init DB: %w", err)
+		}
+	} else {
+		a.multiChain = true
+		for _, chainConfig := range cfg.Chains {
+			chain := &chainService{chainID: chainConfig.ChainID, finalizationPeriodSeconds: chainConfig.FinalizationPeriodSeconds}
+			a.chains = append(a.chains, chain)
+			if err := chain.initDB(ctx, a.log.New("chain_id", chainConfig.ChainID), chainConfig.DB); err != nil {
+				return fmt.Errorf("failed to init DB of chain %d: %w", chainConfig.ChainID, err)
+			}
+		}
 	}
 	if err := a.startMetricsServer(cfg.MetricsServer); err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
@@ -104,9 +166,11 @@ func (a *APIService) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop metrics server: %w", err))
 		}
 	}
-	if a.dbClose != nil {
-		if err := a.dbClose(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close DB: %w", err))
+	for _, chain := range a.chains {
+		if chain.dbClose != nil {
+			if err := chain.dbClose(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close DB: %w", err))
+			}
 		}
 	}
 	a.stopped.Store(true)
@@ -126,30 +190,67 @@ func (a *APIService) Addr() string {
 	return a.apiServer.Addr().String()
 }
 
-func (a *APIService) initDB(ctx context.Context, connector DBConnector) error {
-	db, err := connector.OpenDB(ctx, a.log)
+func (c *chainService) initDB(ctx context.Context, log log.Logger, connector DBConnector) error {
+	db, err := connector.OpenDB(ctx, log)
 	if err != nil {
 		return fmt.Errorf("failed to connect to databse: %w", err)
 	}
-	a.dbClose = db.Closer
-	a.bv = db.BridgeTransfers
+	c.dbClose = db.Closer
+	c.bv = db.BridgeTransfers
+	c.blocks = db.Blocks
+	c.av = db.BridgeAccounting
+
+	views := graphql.Views{BridgeTransfers: db.BridgeTransfers, BridgeMessages: db.BridgeMessages, Blocks: db.Blocks}
+	schema, err := graphql.NewSchema(log, views, db.BridgeUpdates, c.finalizationPeriodSeconds)
+	if err != nil {
+		return fmt.Errorf("failed to construct graphql schema: %w", err)
+	}
+	c.graphqlSchema = schema
 	return nil
 }
 
 func (a *APIService) initRouter(apiConfig config.ServerConfig) {
 	apiRouter := chi.NewRouter()
-	h := routes.NewRoutes(a.log, a.bv, apiRouter)
-
 	promRecorder := metrics.NewPromHTTPRecorder(a.metricsRegistry, MetricsNamespace)
 
 	apiRouter.Use(chiMetricsMiddleware(promRecorder))
-	apiRouter.Use(middleware.Timeout(time.Duration(apiConfig.WriteTimeout) * time.Second))
 	apiRouter.Use(middleware.Recoverer)
 	apiRouter.Use(middleware.Heartbeat(HealthPath))
 
-	apiRouter.Get(fmt.Sprintf(DepositsPath+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
-	apiRouter.Get(fmt.Sprintf(WithdrawalsPath+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
-	apiRouter.Get(SupplyPath, h.SupplyView)
+	chainIDs := make([]uint64, len(a.chains))
+	for i, chain := range a.chains {
+		chainIDs[i] = chain.chainID
+		log := a.log
+		path := func(path string) string { return path }
+		if a.multiChain {
+			log = log.New("chain_id", chain.chainID)
+			path = func(path string) string { return ChainPath(chain.chainID, path) }
+		}
+
+		h := routes.NewRoutes(log, chain.bv, chain.blocks, chain.av, apiRouter, chain.finalizationPeriodSeconds)
+
+		// Long-lived WebSocket connections are not subject to the request timeout
+		apiRouter.Get(path(GraphQLWebSocketPath), graphql.NewWebSocketHandler(log, chain.graphqlSchema).ServeHTTP)
+
+		apiRouter.Group(func(r chi.Router) {
+			r.Use(middleware.Timeout(time.Duration(apiConfig.WriteTimeout) * time.Second))
+
+			r.Get(fmt.Sprintf(path(DepositsPath)+addressParam, ethereumAddressRegex), h.L1DepositsHandler)
+			r.Get(fmt.Sprintf(path(WithdrawalsPath)+addressParam, ethereumAddressRegex), h.L2WithdrawalsHandler)
+			r.Get(fmt.Sprintf(path(WithdrawalPath)+hashParam, ethereumHashRegex), h.L2WithdrawalHandler)
+			r.Get(fmt.Sprintf(path(ERC721DepositsPath)+addressParam, ethereumAddressRegex), h.L1ERC721DepositsHandler)
+			r.Get(fmt.Sprintf(path(ERC721WithdrawalsPath)+addressParam, ethereumAddressRegex), h.L2ERC721WithdrawalsHandler)
+			r.Get(path(SupplyPath), h.SupplyView)
+			r.Get(path(AccountingPath), h.BridgeAccountingHandler)
+			r.Get(path(AccountingStuckPath), h.StuckMessagesHandler)
+			r.Post(path(GraphQLPath), (&relay.Handler{Schema: chain.graphqlSchema}).ServeHTTP)
+		})
+	}
+
+	if a.multiChain {
+		apiRouter.Get(ChainsPath, routes.ChainsHandler(a.log, chainIDs))
+	}
+
 	a.router = apiRouter
 }
 
@@ -4,16 +4,22 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/indexer/api/graphql"
 	"github.com/ethereum-optimism/optimism/indexer/api/models"
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,8 +30,9 @@ type MockBridgeTransfersView struct{}
 var mockAddress = "0x4204204204204204204204204204204204204204"
 
 var apiConfig = config.ServerConfig{
-	Host: "localhost",
-	Port: 0, // random port, to allow parallel tests
+	Host:         "localhost",
+	Port:         0, // random port, to allow parallel tests
+	WriteTimeout: 10,
 }
 
 var metricsConfig = config.ServerConfig{
@@ -43,6 +50,26 @@ var (
 		},
 	}
 
+	erc721Deposit = database.L1ERC721BridgeDeposit{
+		TransactionSourceHash: common.HexToHash("0xdef"),
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			CrossDomainMessageHash: common.HexToHash("0xabc"),
+			TokenPair:              database.TokenPair{LocalTokenAddress: common.HexToAddress("0x721"), RemoteTokenAddress: common.HexToAddress("0x722")},
+			TokenID:                big.NewInt(42),
+			Timestamp:              100,
+		},
+	}
+
+	erc721Withdrawal = database.L2ERC721BridgeWithdrawal{
+		TransactionWithdrawalHash: common.HexToHash("0x721"),
+		ERC721BridgeTransfer: database.ERC721BridgeTransfer{
+			CrossDomainMessageHash: common.HexToHash("0xabc"),
+			TokenPair:              database.TokenPair{LocalTokenAddress: common.HexToAddress("0x722"), RemoteTokenAddress: common.HexToAddress("0x721")},
+			TokenID:                big.NewInt(42),
+			Timestamp:              200,
+		},
+	}
+
 	withdrawal = database.L2BridgeWithdrawal{
 		TransactionWithdrawalHash: common.HexToHash("0x420"),
 		BridgeTransfer: database.BridgeTransfer{
@@ -82,6 +109,14 @@ func (mbv *MockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Add
 	}, nil
 }
 
+func (mbv *MockBridgeTransfersView) L1BridgeDepositWithTransactionHashes(hash common.Hash) (*database.L1BridgeDepositWithTransactionHashes, error) {
+	return nil, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsProvenByAddress(address common.Address, fromTimestamp, toTimestamp uint64) ([]database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	return nil, nil
+}
+
 func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
 	return &database.L2BridgeWithdrawalsResponse{
 		Withdrawals: []database.L2BridgeWithdrawalWithTransactionHashes{
@@ -96,6 +131,58 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.
 	}, nil
 }
 
+func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalWithTransactionHashes(hash common.Hash) (*database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	if hash != withdrawal.TransactionWithdrawalHash {
+		return nil, nil
+	}
+
+	return &database.L2BridgeWithdrawalWithTransactionHashes{
+		L2BridgeWithdrawal:      withdrawal,
+		L2TransactionHash:       common.HexToHash("0x789"),
+		L2BlockHash:             common.HexToHash("0x456"),
+		ProvenL1TransactionHash: common.HexToHash("0x123"),
+		ProvenL1Timestamp:       10,
+		L2OutputIndex:           big.NewInt(7),
+		OutputProposedTimestamp: 5,
+	}, nil
+}
+
+func (mbv *MockBridgeTransfersView) L1ERC721BridgeDeposit(hash common.Hash) (*database.L1ERC721BridgeDeposit, error) {
+	return &erc721Deposit, nil
+}
+
+func (mbv *MockBridgeTransfersView) L1ERC721BridgeDepositsByAddress(address common.Address, collection *common.Address, cursor string, limit int) (*database.L1ERC721BridgeDepositsResponse, error) {
+	deposits := []database.L1ERC721BridgeDepositWithTransactionHashes{}
+	if collection == nil || *collection == erc721Deposit.TokenPair.LocalTokenAddress || *collection == erc721Deposit.TokenPair.RemoteTokenAddress {
+		deposits = append(deposits, database.L1ERC721BridgeDepositWithTransactionHashes{
+			L1ERC721BridgeDeposit: erc721Deposit,
+			L1TransactionHash:     common.HexToHash("0x123"),
+			L2TransactionHash:     common.HexToHash("0x555"),
+			L1BlockHash:           common.HexToHash("0x456"),
+		})
+	}
+
+	return &database.L1ERC721BridgeDepositsResponse{Deposits: deposits}, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2ERC721BridgeWithdrawal(hash common.Hash) (*database.L2ERC721BridgeWithdrawal, error) {
+	return &erc721Withdrawal, nil
+}
+
+func (mbv *MockBridgeTransfersView) L2ERC721BridgeWithdrawalsByAddress(address common.Address, collection *common.Address, cursor string, limit int) (*database.L2ERC721BridgeWithdrawalsResponse, error) {
+	withdrawals := []database.L2ERC721BridgeWithdrawalWithTransactionHashes{}
+	if collection == nil || *collection == erc721Withdrawal.TokenPair.LocalTokenAddress || *collection == erc721Withdrawal.TokenPair.RemoteTokenAddress {
+		withdrawals = append(withdrawals, database.L2ERC721BridgeWithdrawalWithTransactionHashes{
+			L2ERC721BridgeWithdrawal: erc721Withdrawal,
+			L2TransactionHash:        common.HexToHash("0x789"),
+			L2BlockHash:              common.HexToHash("0x456"),
+			ProvenL1TransactionHash:  common.HexToHash("0x123"),
+		})
+	}
+
+	return &database.L2ERC721BridgeWithdrawalsResponse{Withdrawals: withdrawals}, nil
+}
+
 func (mbv *MockBridgeTransfersView) L1BridgeDepositSum() (float64, error) {
 	return 69, nil
 }
@@ -103,6 +190,16 @@ func (mbv *MockBridgeTransfersView) L2BridgeWithdrawalSum() (float64, error) {
 	return 420, nil
 }
 
+// MockBlocksView mocks the BlocksView interface
+type MockBlocksView struct {
+	database.BlocksView
+	l1Timestamp uint64
+}
+
+func (mbv *MockBlocksView) L1LatestBlockHeader() (*database.L1BlockHeader, error) {
+	return &database.L1BlockHeader{BlockHeader: database.BlockHeader{Number: big.NewInt(1), Timestamp: mbv.l1Timestamp}}, nil
+}
+
 func TestHealthz(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	cfg := &Config{
@@ -153,7 +250,7 @@ func TestL1BridgeDepositsHandler(t *testing.T) {
 func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	cfg := &Config{
-		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: &MockBlocksView{}},
 		HTTPServer:    apiConfig,
 		MetricsServer: metricsConfig,
 	}
@@ -184,3 +281,357 @@ func TestL2BridgeWithdrawalsByAddressHandler(t *testing.T) {
 	assert.Equal(t, resp.Items[0].Timestamp, withdrawal.Tx.Timestamp)
 
 }
+
+func TestL2BridgeWithdrawalHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	blocks := &MockBlocksView{l1Timestamp: 22}
+	cfg := &Config{
+		DB:                        &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, Blocks: blocks},
+		HTTPServer:                apiConfig,
+		MetricsServer:             metricsConfig,
+		FinalizationPeriodSeconds: 12,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	t.Run("Found", func(t *testing.T) {
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/withdrawal/%s", withdrawal.TransactionWithdrawalHash), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.WithdrawalItem
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+
+		assert.Equal(t, resp.Guid, withdrawal.TransactionWithdrawalHash.String())
+		assert.Equal(t, resp.L1ProvenTxHash, common.HexToHash("0x123").String())
+		assert.Equal(t, resp.Status.State, models.WithdrawalReadyToFinalize)
+		assert.Equal(t, resp.Status.L2OutputIndex, "7")
+		assert.Equal(t, resp.Status.ReadyToProveTimestamp, uint64(5))
+		assert.Equal(t, resp.Status.ProvenTimestamp, uint64(10))
+		assert.Equal(t, resp.Status.ReadyToFinalizeTimestamp, uint64(22))
+	})
+
+	t.Run("FinalizationPeriodNotElapsedOnL1", func(t *testing.T) {
+		blocks.l1Timestamp = 21
+		defer func() { blocks.l1Timestamp = 22 }()
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/withdrawal/%s", withdrawal.TransactionWithdrawalHash), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.WithdrawalItem
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		assert.Equal(t, resp.Status.State, models.WithdrawalProven)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/withdrawal/%s", common.HexToHash("0x999")), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+
+	t.Run("InvalidHash", func(t *testing.T) {
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+"/api/v0/withdrawal/0x1234", nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	})
+}
+
+func TestL1ERC721DepositsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	t.Run("All", func(t *testing.T) {
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/erc721/deposits/%s", mockAddress), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.ERC721DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+
+		assert.Equal(t, resp.Items[0].Guid, erc721Deposit.TransactionSourceHash.String())
+		assert.Equal(t, resp.Items[0].L1TxHash, common.HexToHash("0x123").String())
+		assert.Equal(t, resp.Items[0].L2TxHash, common.HexToHash("0x555").String())
+		assert.Equal(t, resp.Items[0].CrossDomainMessageHash, erc721Deposit.CrossDomainMessageHash.String())
+		assert.Equal(t, resp.Items[0].TokenId, "42")
+		assert.Equal(t, resp.Items[0].L1TokenAddress, erc721Deposit.TokenPair.LocalTokenAddress.String())
+		assert.Equal(t, resp.Items[0].L2TokenAddress, erc721Deposit.TokenPair.RemoteTokenAddress.String())
+		assert.Equal(t, resp.Items[0].Timestamp, erc721Deposit.Timestamp)
+	})
+
+	t.Run("Collection", func(t *testing.T) {
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/erc721/deposits/%s?collection=%s", mockAddress, common.HexToAddress("0x999")), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.ERC721DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Empty(t, resp.Items)
+	})
+
+	t.Run("InvalidCollection", func(t *testing.T) {
+		request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/erc721/deposits/%s?collection=0x1234", mockAddress), nil)
+		require.NoError(t, err)
+
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	})
+}
+
+func TestL2ERC721WithdrawalsHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	// filtered by the L1 address of the collection
+	request, err := http.NewRequest("GET", fmt.Sprintf("http://"+api.Addr()+"/api/v0/erc721/withdrawals/%s?collection=%s", mockAddress, erc721Withdrawal.TokenPair.RemoteTokenAddress), nil)
+	require.NoError(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp models.ERC721WithdrawalResponse
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 1)
+
+	assert.Equal(t, resp.Items[0].Guid, erc721Withdrawal.TransactionWithdrawalHash.String())
+	assert.Equal(t, resp.Items[0].TransactionHash, common.HexToHash("0x789").String())
+	assert.Equal(t, resp.Items[0].L2BlockHash, common.HexToHash("0x456").String())
+	assert.Equal(t, resp.Items[0].L1ProvenTxHash, common.HexToHash("0x123").String())
+	assert.Equal(t, resp.Items[0].L1FinalizedTxHash, common.Hash{}.String())
+	assert.Equal(t, resp.Items[0].TokenId, "42")
+	assert.Equal(t, resp.Items[0].L1TokenAddress, erc721Withdrawal.TokenPair.RemoteTokenAddress.String())
+	assert.Equal(t, resp.Items[0].L2TokenAddress, erc721Withdrawal.TokenPair.LocalTokenAddress.String())
+	assert.Equal(t, resp.Items[0].Timestamp, erc721Withdrawal.Timestamp)
+}
+
+func TestGraphQLHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	query := fmt.Sprintf(`{"query": "{ deposits(address: \"%s\") { items { l1TxHash l2TxHash } } }"}`, mockAddress)
+	request, err := http.NewRequest("POST", "http://"+api.Addr()+GraphQLPath, strings.NewReader(query))
+	assert.Nil(t, err)
+
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var resp struct {
+		Data struct {
+			Deposits models.DepositResponse `json:"deposits"`
+		} `json:"data"`
+	}
+	err = json.Unmarshal(responseRecorder.Body.Bytes(), &resp)
+	assert.Nil(t, err, responseRecorder.Body.String())
+
+	require.Len(t, resp.Data.Deposits.Items, 1, responseRecorder.Body.String())
+	assert.Equal(t, resp.Data.Deposits.Items[0].L1TxHash, common.HexToHash("0x123").String())
+	assert.Equal(t, resp.Data.Deposits.Items[0].L2TxHash, common.HexToHash("555").String())
+}
+
+func TestGraphQLWebSocketHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, api.Stop(context.Background())) }()
+
+	// Upgraded through the middleware of the running server
+	dialer := websocket.Dialer{Subprotocols: []string{graphql.Subprotocol}}
+	conn, _, err := dialer.Dial("ws://"+api.Addr()+GraphQLWebSocketPath, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	require.NoError(t, conn.WriteJSON(map[string]string{"type": "connection_init"}))
+
+	var ack map[string]string
+	require.NoError(t, conn.ReadJSON(&ack))
+	assert.Equal(t, "connection_ack", ack["type"])
+}
+
+// MockBridgeAccountingView mocks the BridgeAccountingView interface
+type MockBridgeAccountingView struct{}
+
+func (mav *MockBridgeAccountingView) BridgeTokenAccounting(l1Height, l2Height *big.Int) ([]database.BridgeTokenAccounting, error) {
+	return nil, nil
+}
+
+func (mav *MockBridgeAccountingView) BridgeTokenReports() ([]database.BridgeTokenReport, error) {
+	return []database.BridgeTokenReport{
+		{
+			L1TokenAddress:      common.HexToAddress("0x1"),
+			L2TokenAddress:      common.HexToAddress("0x2"),
+			L1BlockNumber:       big.NewInt(10),
+			L2BlockNumber:       big.NewInt(20),
+			L1EscrowBalance:     big.NewInt(100),
+			L2TotalSupply:       big.NewInt(70),
+			DepositsInFlight:    big.NewInt(20),
+			WithdrawalsInFlight: big.NewInt(10),
+		},
+		{
+			L1TokenAddress:      common.HexToAddress("0x3"),
+			L2TokenAddress:      common.HexToAddress("0x4"),
+			L1BlockNumber:       big.NewInt(10),
+			L2BlockNumber:       big.NewInt(20),
+			L1EscrowBalance:     big.NewInt(100),
+			L2TotalSupply:       big.NewInt(101),
+			DepositsInFlight:    big.NewInt(0),
+			WithdrawalsInFlight: big.NewInt(0),
+			StuckDeposits:       1,
+		},
+	}, nil
+}
+
+func (mav *MockBridgeAccountingView) StuckL1BridgeMessages(limit int) ([]database.L1BridgeMessage, error) {
+	failedEventGUID := uuid.New()
+	return []database.L1BridgeMessage{{BridgeMessage: database.BridgeMessage{
+		MessageHash:                   common.HexToHash("0xabc"),
+		Nonce:                         big.NewInt(1),
+		FailedRelayedMessageEventGUID: &failedEventGUID,
+		Tx:                            database.Transaction{Amount: big.NewInt(5)},
+	}}}, nil
+}
+
+func (mav *MockBridgeAccountingView) StuckL2BridgeMessages(limit int) ([]database.L2BridgeMessage, error) {
+	return nil, nil
+}
+
+func TestBridgeAccountingHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		DB:            &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}, BridgeAccounting: &MockBridgeAccountingView{}},
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest("GET", "http://"+api.Addr()+AccountingPath, nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var accountingResp models.BridgeAccountingResponse
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &accountingResp))
+	require.Equal(t, 1, accountingResp.Mismatches)
+	require.Len(t, accountingResp.Items, 2)
+	require.False(t, accountingResp.Items[0].Mismatch)
+	require.Equal(t, "0", accountingResp.Items[0].Discrepancy)
+	require.True(t, accountingResp.Items[1].Mismatch)
+	require.Equal(t, "-1", accountingResp.Items[1].Discrepancy)
+	require.Equal(t, 1, accountingResp.Items[1].StuckDeposits)
+
+	request, err = http.NewRequest("GET", "http://"+api.Addr()+AccountingStuckPath+"?limit=10", nil)
+	require.NoError(t, err)
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var stuckResp models.StuckMessagesResponse
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &stuckResp))
+	require.Len(t, stuckResp.Deposits, 1)
+	require.Empty(t, stuckResp.Withdrawals)
+	require.Equal(t, common.HexToHash("0xabc").String(), stuckResp.Deposits[0].MessageHash)
+	require.NotEmpty(t, stuckResp.Deposits[0].FailedEventGuid)
+
+	// invalid limits are rejected
+	request, err = http.NewRequest("GET", "http://"+api.Addr()+AccountingStuckPath+"?limit=-1", nil)
+	require.NoError(t, err)
+	responseRecorder = httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+}
+
+func TestMultiChainHandlers(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &Config{
+		HTTPServer:    apiConfig,
+		MetricsServer: metricsConfig,
+		Chains: []ChainConfig{
+			{ChainID: 10, DB: &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}}},
+			{ChainID: 8453, DB: &TestDBConnector{BridgeTransfers: &MockBridgeTransfersView{}}},
+		},
+	}
+	api, err := NewApi(context.Background(), logger, cfg)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest("GET", "http://"+api.Addr()+ChainsPath, nil)
+	require.NoError(t, err)
+	responseRecorder := httptest.NewRecorder()
+	api.router.ServeHTTP(responseRecorder, request)
+	require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var chainsResp models.ChainsResponse
+	require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &chainsResp))
+	require.Equal(t, []uint64{10, 8453}, chainsResp.Chains)
+
+	// routes are scoped by chain
+	for _, chainID := range []uint64{10, 8453} {
+		path := ChainPath(chainID, DepositsPath) + mockAddress
+		require.Equal(t, fmt.Sprintf("/api/v0/chains/%d/deposits/%s", chainID, mockAddress), path)
+
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+path, nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusOK, responseRecorder.Code)
+
+		var resp models.DepositResponse
+		require.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+	}
+
+	// unknown chains and unscoped routes are not served
+	for _, path := range []string{ChainPath(1, DepositsPath) + mockAddress, DepositsPath + mockAddress} {
+		request, err := http.NewRequest("GET", "http://"+api.Addr()+path, nil)
+		require.NoError(t, err)
+		responseRecorder := httptest.NewRecorder()
+		api.router.ServeHTTP(responseRecorder, request)
+		require.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	}
+}
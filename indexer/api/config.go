@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/indexer/api/graphql"
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 )
@@ -13,7 +15,16 @@ import (
 // DB represents the abstract DB access the API has.
 type DB struct {
 	BridgeTransfers database.BridgeTransfersView
-	Closer          func() error
+	BridgeMessages  database.BridgeMessagesView
+	Blocks          database.BlocksView
+
+	// BridgeAccounting serves the reconciliation reports of the bridged token pairs
+	BridgeAccounting database.BridgeAccountingView
+
+	// BridgeUpdates signals newly indexed bridge data, used to serve GraphQL subscriptions
+	BridgeUpdates graphql.Notifier
+
+	Closer func() error
 }
 
 // DBConfigConnector implements a fully config based DBConnector
@@ -26,19 +37,37 @@ func (cfg *DBConfigConnector) OpenDB(ctx context.Context, log log.Logger) (*DB,
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to databse: %w", err)
 	}
+	listener, err := database.NewBridgeUpdatesListener(ctx, log, cfg.DBConfig)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to listen for bridge updates: %w", err), db.Close())
+	}
 	return &DB{
-		BridgeTransfers: db.BridgeTransfers,
-		Closer:          db.Close,
+		BridgeTransfers:  db.BridgeTransfers,
+		BridgeMessages:   db.BridgeMessages,
+		Blocks:           db.Blocks,
+		BridgeAccounting: db.BridgeAccounting,
+		BridgeUpdates:    listener,
+		Closer: func() error {
+			return errors.Join(listener.Close(), db.Close())
+		},
 	}, nil
 }
 
 type TestDBConnector struct {
-	BridgeTransfers database.BridgeTransfersView
+	BridgeTransfers  database.BridgeTransfersView
+	BridgeMessages   database.BridgeMessagesView
+	Blocks           database.BlocksView
+	BridgeAccounting database.BridgeAccountingView
+	BridgeUpdates    graphql.Notifier
 }
 
 func (tdb *TestDBConnector) OpenDB(ctx context.Context, log log.Logger) (*DB, error) {
 	return &DB{
-		BridgeTransfers: tdb.BridgeTransfers,
+		BridgeTransfers:  tdb.BridgeTransfers,
+		BridgeMessages:   tdb.BridgeMessages,
+		Blocks:           tdb.Blocks,
+		BridgeAccounting: tdb.BridgeAccounting,
+		BridgeUpdates:    tdb.BridgeUpdates,
 		Closer: func() error {
 			log.Info("API service closed test DB view")
 			return nil
@@ -57,4 +86,20 @@ type Config struct {
 	DB            DBConnector
 	HTTPServer    config.ServerConfig
 	MetricsServer config.ServerConfig
+
+	// FinalizationPeriodSeconds of the L2OutputOracle, used to compute withdrawal lifecycles
+	FinalizationPeriodSeconds uint64
+
+	// Chains served when indexing several chains, replacing the single chain of the DB. The
+	// routes of each chain are scoped by its chain id, i.e /api/v0/chains/10/deposits/
+	Chains []ChainConfig
+}
+
+// ChainConfig for an L2 chain served by the API
+type ChainConfig struct {
+	ChainID uint64
+	DB      DBConnector
+
+	// FinalizationPeriodSeconds of the L2OutputOracle, used to compute withdrawal lifecycles
+	FinalizationPeriodSeconds uint64
 }
@@ -0,0 +1,367 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var mockAddress = common.HexToAddress("0x4204204204204204204204204204204204204204")
+
+// mockBridgeTransfersView serves a single deposit and withdrawal, whose state may be updated
+type mockBridgeTransfersView struct {
+	database.BridgeTransfersView
+
+	mu         sync.Mutex
+	deposit    database.L1BridgeDepositWithTransactionHashes
+	withdrawal database.L2BridgeWithdrawalWithTransactionHashes
+}
+
+func newMockBridgeTransfersView() *mockBridgeTransfersView {
+	return &mockBridgeTransfersView{
+		deposit: database.L1BridgeDepositWithTransactionHashes{
+			L1BridgeDeposit: database.L1BridgeDeposit{
+				TransactionSourceHash: common.HexToHash("0xabc"),
+				BridgeTransfer: database.BridgeTransfer{
+					Tx: database.Transaction{FromAddress: mockAddress, ToAddress: mockAddress, Amount: big.NewInt(1), Timestamp: 5_000_000_000},
+				},
+			},
+		},
+		withdrawal: database.L2BridgeWithdrawalWithTransactionHashes{
+			L2BridgeWithdrawal: database.L2BridgeWithdrawal{
+				TransactionWithdrawalHash: common.HexToHash("0x420"),
+				BridgeTransfer: database.BridgeTransfer{
+					Tx: database.Transaction{FromAddress: mockAddress, ToAddress: mockAddress, Amount: big.NewInt(2), Timestamp: 100},
+				},
+			},
+		},
+	}
+}
+
+func (m *mockBridgeTransfersView) L1BridgeDepositsByAddress(address common.Address, cursor string, limit int) (*database.L1BridgeDepositsResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &database.L1BridgeDepositsResponse{Deposits: []database.L1BridgeDepositWithTransactionHashes{m.deposit}}, nil
+}
+
+func (m *mockBridgeTransfersView) L2BridgeWithdrawalsByAddress(address common.Address, cursor string, limit int) (*database.L2BridgeWithdrawalsResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &database.L2BridgeWithdrawalsResponse{Withdrawals: []database.L2BridgeWithdrawalWithTransactionHashes{m.withdrawal}}, nil
+}
+
+func (m *mockBridgeTransfersView) L2BridgeWithdrawalWithTransactionHashes(hash common.Hash) (*database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hash != m.withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash {
+		return nil, nil
+	}
+	withdrawal := m.withdrawal
+	return &withdrawal, nil
+}
+
+func (m *mockBridgeTransfersView) L1BridgeDepositWithTransactionHashes(hash common.Hash) (*database.L1BridgeDepositWithTransactionHashes, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if hash != m.deposit.L1BridgeDeposit.TransactionSourceHash {
+		return nil, nil
+	}
+	deposit := m.deposit
+	return &deposit, nil
+}
+
+func (m *mockBridgeTransfersView) L2BridgeWithdrawalsProvenByAddress(address common.Address, fromTimestamp, toTimestamp uint64) ([]database.L2BridgeWithdrawalWithTransactionHashes, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	withdrawal := m.withdrawal
+	if withdrawal.L2BridgeWithdrawal.Tx.FromAddress != address || withdrawal.ProvenL1Timestamp <= fromTimestamp || withdrawal.ProvenL1Timestamp > toTimestamp {
+		return nil, nil
+	}
+	return []database.L2BridgeWithdrawalWithTransactionHashes{withdrawal}, nil
+}
+
+func (m *mockBridgeTransfersView) prove(timestamp uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.withdrawal.ProvenL1TransactionHash = common.HexToHash("0x1")
+	m.withdrawal.ProvenL1Timestamp = timestamp
+}
+
+type mockBlocksView struct {
+	database.BlocksView
+
+	mu          sync.Mutex
+	l1Timestamp uint64
+}
+
+func (m *mockBlocksView) L1BlockHeaderWithFilter(filter database.BlockHeader) (*database.L1BlockHeader, error) {
+	return &database.L1BlockHeader{BlockHeader: database.BlockHeader{Hash: common.HexToHash("0x1"), Number: filter.Number, Timestamp: 12}}, nil
+}
+
+func (m *mockBlocksView) L1LatestBlockHeader() (*database.L1BlockHeader, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &database.L1BlockHeader{BlockHeader: database.BlockHeader{Hash: common.HexToHash("0x1"), Number: big.NewInt(16), Timestamp: m.l1Timestamp}}, nil
+}
+
+func (m *mockBlocksView) setL1Timestamp(timestamp uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.l1Timestamp = timestamp
+}
+
+type mockNotifier struct {
+	mu          sync.Mutex
+	subscribers []chan database.BridgeUpdate
+}
+
+func (n *mockNotifier) Subscribe() (<-chan database.BridgeUpdate, func()) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ch := make(chan database.BridgeUpdate, 16)
+	n.subscribers = append(n.subscribers, ch)
+	return ch, func() {}
+}
+
+func (n *mockNotifier) notify(update database.BridgeUpdate) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, ch := range n.subscribers {
+		ch <- update
+	}
+}
+
+func (n *mockNotifier) drop() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, ch := range n.subscribers {
+		close(ch)
+	}
+	n.subscribers = nil
+}
+
+func (n *mockNotifier) numSubscribers() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.subscribers)
+}
+
+func newTestSchema(t *testing.T, transfers database.BridgeTransfersView, notifier Notifier) *graphql.Schema {
+	return newTestSchemaWithBlocks(t, transfers, &mockBlocksView{l1Timestamp: 12}, notifier)
+}
+
+func newTestSchemaWithBlocks(t *testing.T, transfers database.BridgeTransfersView, blocks database.BlocksView, notifier Notifier) *graphql.Schema {
+	logger := testlog.Logger(t, log.LvlInfo)
+	views := Views{BridgeTransfers: transfers, Blocks: blocks}
+	schema, err := NewSchema(logger, views, notifier, 100)
+	require.NoError(t, err)
+	return schema
+}
+
+func TestQueries(t *testing.T) {
+	schema := newTestSchema(t, newMockBridgeTransfersView(), nil)
+
+	t.Run("Deposits", func(t *testing.T) {
+		res := schema.Exec(context.Background(), `{ deposits(address: "`+mockAddress.String()+`", limit: 10) { hasNextPage items { guid amount timestamp } } }`, "", nil)
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"deposits": {"hasNextPage": false, "items": [{"guid": "`+common.HexToHash("0xabc").String()+`", "amount": "1", "timestamp": 5000000000}]}}`, string(res.Data))
+	})
+
+	t.Run("Withdrawal", func(t *testing.T) {
+		query := `query($hash: Bytes32!) { withdrawal(hash: $hash) { amount status { state provenTimestamp } } }`
+		res := schema.Exec(context.Background(), query, "", map[string]interface{}{"hash": common.HexToHash("0x420").String()})
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"withdrawal": {"amount": "2", "status": {"state": "initiated", "provenTimestamp": null}}}`, string(res.Data))
+
+		res = schema.Exec(context.Background(), query, "", map[string]interface{}{"hash": common.HexToHash("0x1").String()})
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"withdrawal": null}`, string(res.Data))
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		res := schema.Exec(context.Background(), `{ l1Block(number: "0x10") { hash number timestamp } }`, "", nil)
+		require.Empty(t, res.Errors)
+		require.JSONEq(t, `{"l1Block": {"hash": "`+common.HexToHash("0x1").String()+`", "number": 16, "timestamp": 12}}`, string(res.Data))
+
+		res = schema.Exec(context.Background(), `{ l1Block(number: 1, hash: "`+common.HexToHash("0x1").String()+`") { hash } }`, "", nil)
+		require.NotEmpty(t, res.Errors)
+	})
+
+	t.Run("InvalidArguments", func(t *testing.T) {
+		res := schema.Exec(context.Background(), `{ deposits(address: "0x123") { hasNextPage } }`, "", nil)
+		require.NotEmpty(t, res.Errors)
+
+		res = schema.Exec(context.Background(), `{ deposits(address: "`+mockAddress.String()+`", limit: 0) { hasNextPage } }`, "", nil)
+		require.NotEmpty(t, res.Errors)
+
+		res = schema.Exec(context.Background(), `{ withdrawal(hash: "0x420") { guid } }`, "", nil)
+		require.NotEmpty(t, res.Errors)
+	})
+}
+
+func TestBridgeUpdatesSubscription(t *testing.T) {
+	transfers, blocks, notifier := newMockBridgeTransfersView(), &mockBlocksView{l1Timestamp: 12}, &mockNotifier{}
+	schema := newTestSchemaWithBlocks(t, transfers, blocks, notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	query := `subscription { bridgeUpdates(address: "` + mockAddress.String() + `") { deposit { guid } withdrawal { guid status { state } } } }`
+	updates, err := schema.Subscribe(ctx, query, "", nil)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return notifier.numSubscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	expectUpdate := func(expected string) {
+		select {
+		case res := <-updates:
+			response := res.(*graphql.Response)
+			require.Empty(t, response.Errors)
+			require.JSONEq(t, expected, string(response.Data))
+		case <-time.After(time.Second):
+			t.Fatal("expected update")
+		}
+	}
+	expectNoUpdate := func() {
+		select {
+		case res := <-updates:
+			t.Fatalf("unexpected update: %v", res)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	withdrawalHash := common.HexToHash("0x420")
+	t.Run("OtherAddresses", func(t *testing.T) {
+		notifier.notify(database.BridgeUpdate{Kind: database.BridgeUpdateWithdrawal, Hash: withdrawalHash, Address: common.HexToAddress("0x1")})
+		expectNoUpdate()
+	})
+
+	t.Run("Deposit", func(t *testing.T) {
+		notifier.notify(database.BridgeUpdate{Kind: database.BridgeUpdateDeposit, Hash: common.HexToHash("0xabc"), Address: mockAddress})
+		expectUpdate(`{"bridgeUpdates": {"deposit": {"guid": "` + common.HexToHash("0xabc").String() + `"}, "withdrawal": null}}`)
+	})
+
+	t.Run("Withdrawal", func(t *testing.T) {
+		transfers.prove(10)
+		notifier.notify(database.BridgeUpdate{Kind: database.BridgeUpdateWithdrawal, Hash: withdrawalHash, Address: mockAddress})
+		expectUpdate(`{"bridgeUpdates": {"deposit": null, "withdrawal": {"guid": "` + withdrawalHash.String() + `", "status": {"state": "proven"}}}}`)
+	})
+
+	t.Run("Progress", func(t *testing.T) {
+		// The finalization period has yet to elapse
+		blocks.setL1Timestamp(100)
+		notifier.notify(database.BridgeUpdate{})
+		expectNoUpdate()
+
+		blocks.setL1Timestamp(110)
+		notifier.notify(database.BridgeUpdate{})
+		expectUpdate(`{"bridgeUpdates": {"deposit": null, "withdrawal": {"guid": "` + withdrawalHash.String() + `", "status": {"state": "ready-to-finalize"}}}}`)
+
+		// Already pushed once matured
+		blocks.setL1Timestamp(120)
+		notifier.notify(database.BridgeUpdate{})
+		expectNoUpdate()
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		notifier.drop()
+		select {
+		case _, ok := <-updates:
+			require.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("expected the subscription to end")
+		}
+	})
+}
+
+func TestBridgeUpdatesUnavailable(t *testing.T) {
+	schema := newTestSchema(t, newMockBridgeTransfersView(), nil)
+
+	query := `subscription { bridgeUpdates(address: "` + mockAddress.String() + `") { deposit { guid } } }`
+	updates, err := schema.Subscribe(context.Background(), query, "", nil)
+	require.NoError(t, err)
+
+	res := (<-updates).(*graphql.Response)
+	require.NotEmpty(t, res.Errors)
+}
+
+func TestWebSocketHandler(t *testing.T) {
+	schema := newTestSchema(t, newMockBridgeTransfersView(), &mockNotifier{})
+	server := httptest.NewServer(NewWebSocketHandler(testlog.Logger(t, log.LvlInfo), schema))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	read := func() wsMessage {
+		var msg wsMessage
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+		require.NoError(t, conn.ReadJSON(&msg))
+		return msg
+	}
+
+	require.NoError(t, conn.WriteJSON(wsMessage{Type: msgConnectionInit}))
+	require.Equal(t, msgConnectionAck, read().Type)
+
+	require.NoError(t, conn.WriteJSON(wsMessage{Type: msgPing}))
+	require.Equal(t, msgPong, read().Type)
+
+	// Queries are served as a single result followed by completion
+	payload, err := json.Marshal(subscribePayload{Query: `{ deposits(address: "` + mockAddress.String() + `") { items { amount } } }`})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(wsMessage{ID: "1", Type: msgSubscribe, Payload: payload}))
+
+	next := read()
+	require.Equal(t, "1", next.ID)
+	require.Equal(t, msgNext, next.Type)
+	require.JSONEq(t, `{"data": {"deposits": {"items": [{"amount": "1"}]}}}`, string(next.Payload))
+
+	complete := read()
+	require.Equal(t, "1", complete.ID)
+	require.Equal(t, msgComplete, complete.Type)
+
+	// Invalid operations are errored
+	payload, err = json.Marshal(subscribePayload{Query: `{ deposits { items { amount } } }`})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(wsMessage{ID: "2", Type: msgSubscribe, Payload: payload}))
+
+	errored := read()
+	require.Equal(t, "2", errored.ID)
+	require.Equal(t, msgError, errored.Type)
+}
+
+func TestWebSocketHandlerUnauthorized(t *testing.T) {
+	schema := newTestSchema(t, newMockBridgeTransfersView(), &mockNotifier{})
+	server := httptest.NewServer(NewWebSocketHandler(testlog.Logger(t, log.LvlInfo), schema))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{Subprotocol}}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// Operations prior to the connection initialisation close the connection
+	payload, err := json.Marshal(subscribePayload{Query: `{ supply { l1DepositSum } }`})
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteJSON(wsMessage{ID: "1", Type: msgSubscribe, Payload: payload}))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	_, _, err = conn.ReadMessage()
+	require.True(t, websocket.IsCloseError(err, closeUnauthorized))
+}
@@ -0,0 +1,440 @@
+package graphql
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/api/routes"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+const (
+	// defaultPageLimit ... Default page limit for pagination, matching the REST routes
+	defaultPageLimit = 100
+
+	// maxDepth ... Maximum depth of a query. The schema does not contain any recursive types
+	maxDepth = 10
+)
+
+// Views ... Database views served by the GraphQL API
+type Views struct {
+	BridgeTransfers database.BridgeTransfersView
+	BridgeMessages  database.BridgeMessagesView
+	Blocks          database.BlocksView
+}
+
+// Notifier ... Delivers the bridge updates notified by the indexer. The channel is closed
+// once the subscriber has been dropped
+type Notifier interface {
+	Subscribe() (<-chan database.BridgeUpdate, func())
+}
+
+// Resolver ... Root resolver of the GraphQL schema
+type Resolver struct {
+	log   log.Logger
+	views Views
+	v     *routes.Validator
+
+	// updates is optional. Subscriptions are unavailable if unset
+	updates Notifier
+
+	// finalizationPeriodSeconds is the L2OutputOracle finalization period used to
+	// compute when a proven withdrawal can be finalized
+	finalizationPeriodSeconds uint64
+}
+
+// NewSchema ... Construct the GraphQL schema resolved by the supplied views
+func NewSchema(log log.Logger, views Views, updates Notifier, finalizationPeriodSeconds uint64) (*graphql.Schema, error) {
+	resolver := &Resolver{
+		log:     log.New("api", "graphql"),
+		views:   views,
+		v:       &routes.Validator{},
+		updates: updates,
+
+		finalizationPeriodSeconds: finalizationPeriodSeconds,
+	}
+
+	return graphql.ParseSchema(schema, resolver, graphql.UseFieldResolvers(), graphql.MaxDepth(maxDepth))
+}
+
+/**
+ * Bridge Transfers
+ */
+
+type pageArgs struct {
+	Cursor *string
+	Limit  *int32
+}
+
+func (r *Resolver) pagination(args pageArgs) (string, int, error) {
+	cursor := ""
+	if args.Cursor != nil {
+		cursor = *args.Cursor
+	}
+	if err := r.v.ValidateCursor(cursor); err != nil {
+		return "", 0, err
+	}
+
+	limit := defaultPageLimit
+	if args.Limit != nil {
+		if *args.Limit <= 0 {
+			return "", 0, errors.New("limit must be greater than 0")
+		}
+		limit = int(*args.Limit)
+	}
+
+	return cursor, limit, nil
+}
+
+func (r *Resolver) Deposits(args struct {
+	Address Address
+	pageArgs
+}) (*depositPage, error) {
+	cursor, limit, err := r.pagination(args.pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	deposits, err := r.views.BridgeTransfers.L1BridgeDepositsByAddress(common.Address(args.Address), cursor, limit)
+	if err != nil {
+		r.log.Error("unable to read deposits from DB", "err", err)
+		return nil, errors.New("internal server error reading deposits")
+	}
+
+	return &depositPage{models.CreateDepositResponse(deposits)}, nil
+}
+
+func (r *Resolver) Withdrawals(args struct {
+	Address Address
+	pageArgs
+}) (*withdrawalPage, error) {
+	cursor, limit, err := r.pagination(args.pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := r.views.BridgeTransfers.L2BridgeWithdrawalsByAddress(common.Address(args.Address), cursor, limit)
+	if err != nil {
+		r.log.Error("unable to read withdrawals from DB", "err", err)
+		return nil, errors.New("internal server error reading withdrawals")
+	}
+
+	l1Timestamp, err := routes.LatestL1Timestamp(r.views.Blocks)
+	if err != nil {
+		r.log.Error("unable to read latest L1 block from DB", "err", err)
+		return nil, errors.New("internal server error reading withdrawals")
+	}
+
+	return &withdrawalPage{models.CreateWithdrawalResponse(withdrawals, r.finalizationPeriodSeconds, l1Timestamp)}, nil
+}
+
+func (r *Resolver) Withdrawal(args struct{ Hash Bytes32 }) (*withdrawal, error) {
+	result, err := r.views.BridgeTransfers.L2BridgeWithdrawalWithTransactionHashes(common.Hash(args.Hash))
+	if err != nil {
+		r.log.Error("unable to read withdrawal from DB", "err", err)
+		return nil, errors.New("internal server error reading withdrawal")
+	} else if result == nil {
+		return nil, nil
+	}
+
+	l1Timestamp, err := routes.LatestL1Timestamp(r.views.Blocks)
+	if err != nil {
+		r.log.Error("unable to read latest L1 block from DB", "err", err)
+		return nil, errors.New("internal server error reading withdrawal")
+	}
+
+	return &withdrawal{models.CreateWithdrawalItem(*result, r.finalizationPeriodSeconds, l1Timestamp)}, nil
+}
+
+func (r *Resolver) ERC721Deposits(args struct {
+	Address    Address
+	Collection *Address
+	pageArgs
+}) (*erc721DepositPage, error) {
+	cursor, limit, err := r.pagination(args.pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	deposits, err := r.views.BridgeTransfers.L1ERC721BridgeDepositsByAddress(common.Address(args.Address), (*common.Address)(args.Collection), cursor, limit)
+	if err != nil {
+		r.log.Error("unable to read erc721 deposits from DB", "err", err)
+		return nil, errors.New("internal server error reading erc721 deposits")
+	}
+
+	return &erc721DepositPage{models.CreateERC721DepositResponse(deposits)}, nil
+}
+
+func (r *Resolver) ERC721Withdrawals(args struct {
+	Address    Address
+	Collection *Address
+	pageArgs
+}) (*erc721WithdrawalPage, error) {
+	cursor, limit, err := r.pagination(args.pageArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := r.views.BridgeTransfers.L2ERC721BridgeWithdrawalsByAddress(common.Address(args.Address), (*common.Address)(args.Collection), cursor, limit)
+	if err != nil {
+		r.log.Error("unable to read erc721 withdrawals from DB", "err", err)
+		return nil, errors.New("internal server error reading erc721 withdrawals")
+	}
+
+	return &erc721WithdrawalPage{models.CreateERC721WithdrawalResponse(withdrawals)}, nil
+}
+
+func (r *Resolver) Supply() (*models.BridgeSupplyView, error) {
+	depositSum, err := r.views.BridgeTransfers.L1BridgeDepositSum()
+	if err != nil {
+		r.log.Error("unable to read deposits from DB", "err", err)
+		return nil, errors.New("internal server error reading deposits")
+	}
+
+	withdrawalSum, err := r.views.BridgeTransfers.L2BridgeWithdrawalSum()
+	if err != nil {
+		r.log.Error("unable to read withdrawals from DB", "err", err)
+		return nil, errors.New("internal server error reading withdrawals")
+	}
+
+	return &models.BridgeSupplyView{L1DepositSum: depositSum, L2WithdrawalSum: withdrawalSum}, nil
+}
+
+/**
+ * Bridge Messages
+ */
+
+func (r *Resolver) L1BridgeMessage(args struct{ Hash Bytes32 }) (*bridgeMessage, error) {
+	msg, err := r.views.BridgeMessages.L1BridgeMessage(common.Hash(args.Hash))
+	if err != nil {
+		r.log.Error("unable to read L1 bridge message from DB", "err", err)
+		return nil, errors.New("internal server error reading bridge message")
+	} else if msg == nil {
+		return nil, nil
+	}
+
+	return &bridgeMessage{msg.BridgeMessage, msg.TransactionSourceHash}, nil
+}
+
+func (r *Resolver) L2BridgeMessage(args struct{ Hash Bytes32 }) (*bridgeMessage, error) {
+	msg, err := r.views.BridgeMessages.L2BridgeMessage(common.Hash(args.Hash))
+	if err != nil {
+		r.log.Error("unable to read L2 bridge message from DB", "err", err)
+		return nil, errors.New("internal server error reading bridge message")
+	} else if msg == nil {
+		return nil, nil
+	}
+
+	return &bridgeMessage{msg.BridgeMessage, msg.TransactionWithdrawalHash}, nil
+}
+
+/**
+ * Blocks
+ */
+
+type blockArgs struct {
+	Hash   *Bytes32
+	Number *Long
+}
+
+func (r *Resolver) L1Block(args blockArgs) (*block, error) {
+	var header *database.L1BlockHeader
+	var err error
+	switch {
+	case args.Hash != nil && args.Number != nil:
+		return nil, errors.New("block must be looked up by either hash or number")
+	case args.Hash != nil:
+		header, err = r.views.Blocks.L1BlockHeader(common.Hash(*args.Hash))
+	case args.Number != nil:
+		header, err = r.views.Blocks.L1BlockHeaderWithFilter(database.BlockHeader{Number: new(big.Int).SetUint64(uint64(*args.Number))})
+	default:
+		header, err = r.views.Blocks.L1LatestBlockHeader()
+	}
+
+	if err != nil {
+		r.log.Error("unable to read L1 block header from DB", "err", err)
+		return nil, errors.New("internal server error reading block")
+	} else if header == nil {
+		return nil, nil
+	}
+	return &block{header.BlockHeader}, nil
+}
+
+func (r *Resolver) L2Block(args blockArgs) (*block, error) {
+	var header *database.L2BlockHeader
+	var err error
+	switch {
+	case args.Hash != nil && args.Number != nil:
+		return nil, errors.New("block must be looked up by either hash or number")
+	case args.Hash != nil:
+		header, err = r.views.Blocks.L2BlockHeader(common.Hash(*args.Hash))
+	case args.Number != nil:
+		header, err = r.views.Blocks.L2BlockHeaderWithFilter(database.BlockHeader{Number: new(big.Int).SetUint64(uint64(*args.Number))})
+	default:
+		header, err = r.views.Blocks.L2LatestBlockHeader()
+	}
+
+	if err != nil {
+		r.log.Error("unable to read L2 block header from DB", "err", err)
+		return nil, errors.New("internal server error reading block")
+	} else if header == nil {
+		return nil, nil
+	}
+	return &block{header.BlockHeader}, nil
+}
+
+func (r *Resolver) OutputProposal(args struct{ Index *Long }) (*outputProposal, error) {
+	var proposal *database.OutputProposal
+	var err error
+	if args.Index != nil {
+		proposal, err = r.views.Blocks.OutputProposal(new(big.Int).SetUint64(uint64(*args.Index)))
+	} else {
+		proposal, err = r.views.Blocks.LatestOutputProposal()
+	}
+
+	if err != nil {
+		r.log.Error("unable to read output proposal from DB", "err", err)
+		return nil, errors.New("internal server error reading output proposal")
+	} else if proposal == nil {
+		return nil, nil
+	}
+	return &outputProposal{*proposal}, nil
+}
+
+/**
+ * Object Resolvers. Fields are resolved from the API models unless
+ * the GraphQL representation differs
+ */
+
+type deposit struct{ models.DepositItem }
+
+func (d deposit) Timestamp() Long { return Long(d.DepositItem.Timestamp) }
+
+type depositPage struct{ models.DepositResponse }
+
+func (p depositPage) Items() []deposit {
+	items := make([]deposit, len(p.DepositResponse.Items))
+	for i := range p.DepositResponse.Items {
+		items[i] = deposit{p.DepositResponse.Items[i]}
+	}
+	return items
+}
+
+type withdrawal struct{ models.WithdrawalItem }
+
+func (w withdrawal) Timestamp() Long { return Long(w.WithdrawalItem.Timestamp) }
+
+func (w withdrawal) Status() withdrawalStatus { return withdrawalStatus{w.WithdrawalItem.Status} }
+
+type withdrawalStatus struct{ status models.WithdrawalStatus }
+
+func (s withdrawalStatus) State() string { return string(s.status.State) }
+
+func (s withdrawalStatus) L2OutputIndex() *string {
+	if s.status.L2OutputIndex == "" {
+		return nil
+	}
+	return &s.status.L2OutputIndex
+}
+
+func (s withdrawalStatus) InitiatedTimestamp() Long { return Long(s.status.InitiatedTimestamp) }
+
+func (s withdrawalStatus) ReadyToProveTimestamp() *Long {
+	return optionalLong(s.status.ReadyToProveTimestamp)
+}
+
+func (s withdrawalStatus) ProvenTimestamp() *Long { return optionalLong(s.status.ProvenTimestamp) }
+
+func (s withdrawalStatus) ReadyToFinalizeTimestamp() *Long {
+	return optionalLong(s.status.ReadyToFinalizeTimestamp)
+}
+
+func (s withdrawalStatus) FinalizedTimestamp() *Long {
+	return optionalLong(s.status.FinalizedTimestamp)
+}
+
+type withdrawalPage struct{ models.WithdrawalResponse }
+
+func (p withdrawalPage) Items() []withdrawal {
+	items := make([]withdrawal, len(p.WithdrawalResponse.Items))
+	for i := range p.WithdrawalResponse.Items {
+		items[i] = withdrawal{p.WithdrawalResponse.Items[i]}
+	}
+	return items
+}
+
+type erc721Deposit struct{ models.ERC721DepositItem }
+
+func (d erc721Deposit) Timestamp() Long { return Long(d.ERC721DepositItem.Timestamp) }
+
+type erc721DepositPage struct{ models.ERC721DepositResponse }
+
+func (p erc721DepositPage) Items() []erc721Deposit {
+	items := make([]erc721Deposit, len(p.ERC721DepositResponse.Items))
+	for i := range p.ERC721DepositResponse.Items {
+		items[i] = erc721Deposit{p.ERC721DepositResponse.Items[i]}
+	}
+	return items
+}
+
+type erc721Withdrawal struct{ models.ERC721WithdrawalItem }
+
+func (w erc721Withdrawal) Timestamp() Long { return Long(w.ERC721WithdrawalItem.Timestamp) }
+
+type erc721WithdrawalPage struct {
+	models.ERC721WithdrawalResponse
+}
+
+func (p erc721WithdrawalPage) Items() []erc721Withdrawal {
+	items := make([]erc721Withdrawal, len(p.ERC721WithdrawalResponse.Items))
+	for i := range p.ERC721WithdrawalResponse.Items {
+		items[i] = erc721Withdrawal{p.ERC721WithdrawalResponse.Items[i]}
+	}
+	return items
+}
+
+type bridgeMessage struct {
+	msg    database.BridgeMessage
+	txHash common.Hash
+}
+
+func (m bridgeMessage) MessageHash() string     { return m.msg.MessageHash.String() }
+func (m bridgeMessage) Nonce() string           { return m.msg.Nonce.String() }
+func (m bridgeMessage) TransactionHash() string { return m.txHash.String() }
+func (m bridgeMessage) From() string            { return m.msg.Tx.FromAddress.String() }
+func (m bridgeMessage) To() string              { return m.msg.Tx.ToAddress.String() }
+func (m bridgeMessage) Amount() string          { return m.msg.Tx.Amount.String() }
+func (m bridgeMessage) Data() string            { return hexutil.Encode(m.msg.Tx.Data) }
+func (m bridgeMessage) GasLimit() string        { return m.msg.GasLimit.String() }
+func (m bridgeMessage) Timestamp() Long         { return Long(m.msg.Tx.Timestamp) }
+func (m bridgeMessage) Relayed() bool           { return m.msg.RelayedMessageEventGUID != nil }
+
+func (m bridgeMessage) SentMessageEventGuid() string { return m.msg.SentMessageEventGUID.String() }
+
+func (m bridgeMessage) RelayedMessageEventGuid() *string {
+	if m.msg.RelayedMessageEventGUID == nil {
+		return nil
+	}
+	guid := m.msg.RelayedMessageEventGUID.String()
+	return &guid
+}
+
+type block struct{ header database.BlockHeader }
+
+func (b block) Hash() string       { return b.header.Hash.String() }
+func (b block) ParentHash() string { return b.header.ParentHash.String() }
+func (b block) Number() Long       { return Long(b.header.Number.Uint64()) }
+func (b block) Timestamp() Long    { return Long(b.header.Timestamp) }
+
+type outputProposal struct{ proposal database.OutputProposal }
+
+func (o outputProposal) OutputRoot() string  { return o.proposal.OutputRoot.String() }
+func (o outputProposal) L2OutputIndex() Long { return Long(o.proposal.L2OutputIndex.Uint64()) }
+func (o outputProposal) L2BlockNumber() Long { return Long(o.proposal.L2BlockNumber.Uint64()) }
@@ -0,0 +1,181 @@
+package graphql
+
+// schema ... GraphQL schema of the indexer API. Hashes, addresses and 256 bit
+// integers are served as hex and decimal strings, matching the REST routes
+const schema = `
+schema {
+    query: Query
+    subscription: Subscription
+}
+
+# Address is a 20 byte hex encoded account address
+scalar Address
+
+# Bytes32 is a 32 byte hex encoded value
+scalar Bytes32
+
+# Long is a 64 bit unsigned integer
+scalar Long
+
+type Deposit {
+    guid: String!
+    from: String!
+    to: String!
+    timestamp: Long!
+    l1BlockHash: String!
+    l1TxHash: String!
+    l2TxHash: String!
+    amount: String!
+    l1TokenAddress: String!
+    l2TokenAddress: String!
+}
+
+type DepositPage {
+    cursor: String!
+    hasNextPage: Boolean!
+    items: [Deposit!]!
+}
+
+# WithdrawalStatus is the step of the multistep (bedrock) withdrawal process a withdrawal has
+# reached. Timestamps are null until the step has been reached
+type WithdrawalStatus {
+    # One of initiated, ready-to-prove, proven, ready-to-finalize or finalized
+    state: String!
+    l2OutputIndex: String
+    initiatedTimestamp: Long!
+    readyToProveTimestamp: Long
+    provenTimestamp: Long
+    readyToFinalizeTimestamp: Long
+    finalizedTimestamp: Long
+}
+
+type Withdrawal {
+    guid: String!
+    from: String!
+    to: String!
+    transactionHash: String!
+    crossDomainMessageHash: String!
+    timestamp: Long!
+    l2BlockHash: String!
+    amount: String!
+    l1ProvenTxHash: String!
+    l1FinalizedTxHash: String!
+    l1TokenAddress: String!
+    l2TokenAddress: String!
+    status: WithdrawalStatus!
+}
+
+type WithdrawalPage {
+    cursor: String!
+    hasNextPage: Boolean!
+    items: [Withdrawal!]!
+}
+
+type ERC721Deposit {
+    guid: String!
+    from: String!
+    to: String!
+    timestamp: Long!
+    l1BlockHash: String!
+    l1TxHash: String!
+    l2TxHash: String!
+    crossDomainMessageHash: String!
+    tokenId: String!
+    l1TokenAddress: String!
+    l2TokenAddress: String!
+}
+
+type ERC721DepositPage {
+    cursor: String!
+    hasNextPage: Boolean!
+    items: [ERC721Deposit!]!
+}
+
+type ERC721Withdrawal {
+    guid: String!
+    from: String!
+    to: String!
+    transactionHash: String!
+    crossDomainMessageHash: String!
+    timestamp: Long!
+    l2BlockHash: String!
+    tokenId: String!
+    l1ProvenTxHash: String!
+    l1FinalizedTxHash: String!
+    l1TokenAddress: String!
+    l2TokenAddress: String!
+}
+
+type ERC721WithdrawalPage {
+    cursor: String!
+    hasNextPage: Boolean!
+    items: [ERC721Withdrawal!]!
+}
+
+type BridgeMessage {
+    messageHash: String!
+    nonce: String!
+    # Source hash of the deposit (L1) or hash of the withdrawal (L2) the message was sent with
+    transactionHash: String!
+    from: String!
+    to: String!
+    amount: String!
+    data: String!
+    gasLimit: String!
+    timestamp: Long!
+    sentMessageEventGuid: String!
+    relayedMessageEventGuid: String
+    relayed: Boolean!
+}
+
+type Block {
+    hash: String!
+    parentHash: String!
+    number: Long!
+    timestamp: Long!
+}
+
+type OutputProposal {
+    outputRoot: String!
+    l2OutputIndex: Long!
+    l2BlockNumber: Long!
+}
+
+type Supply {
+    l1DepositSum: Float!
+    l2WithdrawalSum: Float!
+}
+
+type Query {
+    deposits(address: Address!, cursor: String, limit: Int): DepositPage!
+    withdrawals(address: Address!, cursor: String, limit: Int): WithdrawalPage!
+    withdrawal(hash: Bytes32!): Withdrawal
+
+    # ERC721 transfers can be filtered by collection, which may be either the L1 or the L2 token address
+    erc721Deposits(address: Address!, collection: Address, cursor: String, limit: Int): ERC721DepositPage!
+    erc721Withdrawals(address: Address!, collection: Address, cursor: String, limit: Int): ERC721WithdrawalPage!
+
+    l1BridgeMessage(hash: Bytes32!): BridgeMessage
+    l2BridgeMessage(hash: Bytes32!): BridgeMessage
+
+    # Blocks are looked up by either hash or number, defaulting to the latest indexed block
+    l1Block(hash: Bytes32, number: Long): Block
+    l2Block(hash: Bytes32, number: Long): Block
+
+    # Defaults to the latest output proposal
+    outputProposal(index: Long): OutputProposal
+
+    supply: Supply!
+}
+
+# BridgeUpdate is either a new deposit or withdrawal, or one whose status has changed
+type BridgeUpdate {
+    deposit: Deposit
+    withdrawal: Withdrawal
+}
+
+type Subscription {
+    # Pushes new or status-changed deposits and withdrawals initiated by the address as they are indexed
+    bridgeUpdates(address: Address!): BridgeUpdate!
+}
+`
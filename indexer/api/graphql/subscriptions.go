@@ -0,0 +1,162 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum-optimism/optimism/indexer/api/routes"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+)
+
+type bridgeUpdate struct {
+	deposit    *deposit
+	withdrawal *withdrawal
+}
+
+func (u *bridgeUpdate) Deposit() *deposit       { return u.deposit }
+func (u *bridgeUpdate) Withdrawal() *withdrawal { return u.withdrawal }
+
+// BridgeUpdates ... Pushes new or status-changed deposits and withdrawals of the address. Updates are driven
+// by the notifications of the indexer, keyed by the hash of each changed transfer. Indexing progress additionally
+// pushes the proven withdrawals of the address whose finalization period has since elapsed. The subscription
+// ends when the notifier drops it, i.e when falling behind or losing the connection to the DB.
+func (r *Resolver) BridgeUpdates(ctx context.Context, args struct{ Address Address }) (<-chan *bridgeUpdate, error) {
+	if r.updates == nil {
+		return nil, errors.New("bridge update subscriptions are not available")
+	}
+
+	// Subscribe prior to reading the initial L1 state so that no updates are missed
+	notifications, unsubscribe := r.updates.Subscribe()
+	l1Timestamp, err := routes.LatestL1Timestamp(r.views.Blocks)
+	if err != nil {
+		unsubscribe()
+		r.log.Error("unable to read latest L1 block from DB", "err", err)
+		return nil, errors.New("internal server error reading bridge state")
+	}
+
+	watcher := &bridgeWatcher{r: r, address: common.Address(args.Address), l1Timestamp: l1Timestamp}
+	updates := make(chan *bridgeUpdate)
+	go func() {
+		defer close(updates)
+		defer unsubscribe()
+		for {
+			var notification database.BridgeUpdate
+			select {
+			case <-ctx.Done():
+				return
+			case n, ok := <-notifications:
+				if !ok {
+					return
+				}
+				notification = n
+			}
+
+			newUpdates, err := watcher.handle(notification)
+			if err != nil {
+				continue
+			}
+			for _, update := range newUpdates {
+				select {
+				case updates <- update:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// bridgeWatcher ... Resolves the notified bridge updates relevant to an address
+type bridgeWatcher struct {
+	r       *Resolver
+	address common.Address
+
+	// l1Timestamp is the latest indexed L1 timestamp observed by the watcher
+	l1Timestamp uint64
+}
+
+// handle resolves the transfers of the address affected by the notification. Transfers are read at the
+// time of the notification, such that the pushed status is always the latest indexed one
+func (w *bridgeWatcher) handle(update database.BridgeUpdate) ([]*bridgeUpdate, error) {
+	switch update.Kind {
+	case database.BridgeUpdateDeposit:
+		if update.Address != w.address {
+			return nil, nil
+		}
+		result, err := w.r.views.BridgeTransfers.L1BridgeDepositWithTransactionHashes(update.Hash)
+		if err != nil {
+			w.r.log.Error("unable to read deposit from DB", "hash", update.Hash, "err", err)
+			return nil, err
+		} else if result == nil || result.L1BridgeDeposit.Tx.FromAddress != w.address {
+			return nil, nil
+		}
+		return []*bridgeUpdate{{deposit: &deposit{models.CreateDepositItem(*result)}}}, nil
+
+	case database.BridgeUpdateWithdrawal:
+		if update.Address != w.address {
+			return nil, nil
+		}
+		result, err := w.r.views.BridgeTransfers.L2BridgeWithdrawalWithTransactionHashes(update.Hash)
+		if err != nil {
+			w.r.log.Error("unable to read withdrawal from DB", "hash", update.Hash, "err", err)
+			return nil, err
+		} else if result == nil || result.L2BridgeWithdrawal.Tx.FromAddress != w.address {
+			return nil, nil
+		}
+		l1Timestamp, err := w.latestL1Timestamp()
+		if err != nil {
+			return nil, err
+		}
+		return []*bridgeUpdate{{withdrawal: &withdrawal{models.CreateWithdrawalItem(*result, w.r.finalizationPeriodSeconds, l1Timestamp)}}}, nil
+
+	default:
+		// Indexing progress. Withdrawals proven within (prev - period, latest - period] have become finalizable
+		prevL1Timestamp := w.l1Timestamp
+		l1Timestamp, err := w.latestL1Timestamp()
+		if err != nil || l1Timestamp <= prevL1Timestamp {
+			return nil, err
+		}
+
+		period := w.r.finalizationPeriodSeconds
+		fromTimestamp, toTimestamp := saturatingSub(prevL1Timestamp, period), saturatingSub(l1Timestamp, period)
+		if fromTimestamp == toTimestamp {
+			return nil, nil
+		}
+
+		matured, err := w.r.views.BridgeTransfers.L2BridgeWithdrawalsProvenByAddress(w.address, fromTimestamp, toTimestamp)
+		if err != nil {
+			w.r.log.Error("unable to read proven withdrawals from DB", "address", w.address, "err", err)
+			return nil, err
+		}
+		updates := make([]*bridgeUpdate, len(matured))
+		for i, result := range matured {
+			updates[i] = &bridgeUpdate{withdrawal: &withdrawal{models.CreateWithdrawalItem(result, period, l1Timestamp)}}
+		}
+		return updates, nil
+	}
+}
+
+// latestL1Timestamp reads the latest indexed L1 timestamp, never regressing from the one already observed
+func (w *bridgeWatcher) latestL1Timestamp() (uint64, error) {
+	l1Timestamp, err := routes.LatestL1Timestamp(w.r.views.Blocks)
+	if err != nil {
+		w.r.log.Error("unable to read latest L1 block from DB", "err", err)
+		return 0, err
+	}
+	if l1Timestamp > w.l1Timestamp {
+		w.l1Timestamp = l1Timestamp
+	}
+	return w.l1Timestamp, nil
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
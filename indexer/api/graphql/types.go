@@ -0,0 +1,89 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Address ... GraphQL scalar of a non-zero account address
+type Address common.Address
+
+func (Address) ImplementsGraphQLType(name string) bool { return name == "Address" }
+
+func (a *Address) UnmarshalGraphQL(input interface{}) error {
+	str, ok := input.(string)
+	if !ok || !common.IsHexAddress(str) {
+		return errors.New("address must be represented as a valid hexadecimal string")
+	}
+
+	addr := common.HexToAddress(str)
+	if addr == (common.Address{}) {
+		return errors.New("address cannot be the zero address")
+	}
+
+	*a = Address(addr)
+	return nil
+}
+
+// Bytes32 ... GraphQL scalar of a 32 byte value, i.e a hash
+type Bytes32 common.Hash
+
+func (Bytes32) ImplementsGraphQLType(name string) bool { return name == "Bytes32" }
+
+func (b *Bytes32) UnmarshalGraphQL(input interface{}) error {
+	str, ok := input.(string)
+	if !ok || len(str) != 66 { // 0x + 64 chars
+		return errors.New("hash must be a 32 byte hex string")
+	}
+
+	hash, err := hexutil.Decode(str)
+	if err != nil {
+		return errors.New("hash must be represented as a valid hexadecimal string")
+	}
+
+	*b = Bytes32(common.BytesToHash(hash))
+	return nil
+}
+
+// Long ... GraphQL scalar of a 64 bit unsigned integer. Inputs may be either
+// numbers or decimal/hex strings since GraphQL integers are limited to 32 bits
+type Long uint64
+
+func (Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case int32:
+		if input < 0 {
+			return errors.New("long must be non-negative")
+		}
+		*l = Long(input)
+	case float64:
+		if input < 0 || input != float64(uint64(input)) {
+			return errors.New("long must be a non-negative integer")
+		}
+		*l = Long(input)
+	case string:
+		val, err := strconv.ParseUint(input, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid long: %w", err)
+		}
+		*l = Long(val)
+	default:
+		return fmt.Errorf("unexpected type %T for Long", input)
+	}
+	return nil
+}
+
+// optionalLong ... Returns nil for zero values, marking a step that has not been reached
+func optionalLong(val uint64) *Long {
+	if val == 0 {
+		return nil
+	}
+	l := Long(val)
+	return &l
+}
@@ -0,0 +1,265 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Subprotocol ... The graphql-ws protocol implemented by the WebSocket handler, supported by most GraphQL
+// clients. See https://github.com/enisdenjo/graphql-ws/blob/master/PROTOCOL.md
+const Subprotocol = "graphql-transport-ws"
+
+const (
+	connectionInitTimeout = 10 * time.Second
+	writeTimeout          = 10 * time.Second
+)
+
+// Message types
+const (
+	msgConnectionInit = "connection_init"
+	msgConnectionAck  = "connection_ack"
+	msgPing           = "ping"
+	msgPong           = "pong"
+	msgSubscribe      = "subscribe"
+	msgNext           = "next"
+	msgError          = "error"
+	msgComplete       = "complete"
+)
+
+// Close codes
+const (
+	closeBadRequest             = 4400
+	closeUnauthorized           = 4401
+	closeInitTimeout            = 4408
+	closeSubscriberExists       = 4409
+	closeTooManyInitRequests    = 4429
+	closeSubprotocolNotAccepted = 4406
+)
+
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type subscribePayload struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// WebSocketHandler ... Serves GraphQL operations, including subscriptions, over WebSockets
+type WebSocketHandler struct {
+	log      log.Logger
+	schema   *graphql.Schema
+	upgrader websocket.Upgrader
+}
+
+// NewWebSocketHandler ... Construct a new WebSocket handler for the schema
+func NewWebSocketHandler(log log.Logger, schema *graphql.Schema) *WebSocketHandler {
+	return &WebSocketHandler{
+		log:    log.New("api", "graphql-ws"),
+		schema: schema,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{Subprotocol},
+			// The API is public and read-only
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.log.Warn("unable to upgrade connection", "err", err)
+		return // the upgrader replies with an HTTP error
+	}
+
+	s := &wsSession{
+		log:           h.log.New("remote", r.RemoteAddr),
+		conn:          conn,
+		schema:        h.schema,
+		subscriptions: make(map[string]context.CancelFunc),
+	}
+	s.serve()
+}
+
+// wsSession ... A single graphql-ws connection. Messages are read by a single routine while
+// each operation is executed and written from a separate routine
+type wsSession struct {
+	log    log.Logger
+	conn   *websocket.Conn
+	schema *graphql.Schema
+
+	writeMu sync.Mutex
+
+	subscriptionsMu sync.Mutex
+	subscriptions   map[string]context.CancelFunc
+}
+
+func (s *wsSession) serve() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel() // stops all operations
+		s.conn.Close()
+	}()
+
+	if s.conn.Subprotocol() != Subprotocol {
+		s.close(closeSubprotocolNotAccepted, "Subprotocol not acceptable")
+		return
+	}
+
+	acknowledged := false
+	_ = s.conn.SetReadDeadline(time.Now().Add(connectionInitTimeout))
+	for {
+		var msg wsMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			var netErr net.Error
+			if !acknowledged && errors.As(err, &netErr) && netErr.Timeout() {
+				s.close(closeInitTimeout, "Connection initialisation timeout")
+			} else if isJSONError(err) {
+				s.close(closeBadRequest, "Invalid message received")
+			} else if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.log.Debug("closing connection", "err", err)
+			}
+			return
+		}
+
+		switch msg.Type {
+		case msgConnectionInit:
+			if acknowledged {
+				s.close(closeTooManyInitRequests, "Too many initialisation requests")
+				return
+			}
+			acknowledged = true
+			_ = s.conn.SetReadDeadline(time.Time{})
+			s.write(wsMessage{Type: msgConnectionAck})
+
+		case msgPing:
+			s.write(wsMessage{Type: msgPong})
+
+		case msgPong:
+
+		case msgSubscribe:
+			if !acknowledged {
+				s.close(closeUnauthorized, "Unauthorized")
+				return
+			}
+
+			var payload subscribePayload
+			if msg.ID == "" || json.Unmarshal(msg.Payload, &payload) != nil {
+				s.close(closeBadRequest, "Invalid message received")
+				return
+			}
+			if !s.subscribe(ctx, msg.ID, payload) {
+				s.close(closeSubscriberExists, "Subscriber for "+msg.ID+" already exists")
+				return
+			}
+
+		case msgComplete:
+			s.unsubscribe(msg.ID)
+
+		default:
+			s.close(closeBadRequest, "Invalid message received")
+			return
+		}
+	}
+}
+
+// subscribe executes the operation, returning false if an operation with the same id is already active
+func (s *wsSession) subscribe(ctx context.Context, id string, payload subscribePayload) bool {
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+	if _, ok := s.subscriptions[id]; ok {
+		return false
+	}
+
+	opCtx, cancel := context.WithCancel(ctx)
+	s.subscriptions[id] = cancel
+
+	go func() {
+		defer cancel()
+
+		responses, err := s.schema.Subscribe(opCtx, payload.Query, payload.OperationName, payload.Variables)
+		if err != nil {
+			s.log.Error("unable to execute operation", "err", err)
+			if s.unsubscribe(id) {
+				s.writePayload(id, msgError, []*gqlerrors.QueryError{gqlerrors.Errorf("%s", err)})
+			}
+			return
+		}
+
+		for res := range responses {
+			response := res.(*graphql.Response)
+			if response.Data == nil && len(response.Errors) > 0 {
+				// Errors prior to execution terminate the operation
+				if s.unsubscribe(id) {
+					s.writePayload(id, msgError, response.Errors)
+				}
+				return
+			}
+			s.writePayload(id, msgNext, response)
+		}
+
+		// Completed by the server unless the client already has
+		if s.unsubscribe(id) {
+			s.write(wsMessage{ID: id, Type: msgComplete})
+		}
+	}()
+
+	return true
+}
+
+// unsubscribe stops the operation, returning false if it was not active
+func (s *wsSession) unsubscribe(id string) bool {
+	s.subscriptionsMu.Lock()
+	defer s.subscriptionsMu.Unlock()
+	cancel, ok := s.subscriptions[id]
+	if ok {
+		cancel()
+		delete(s.subscriptions, id)
+	}
+	return ok
+}
+
+func (s *wsSession) writePayload(id, msgType string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		s.log.Error("unable to marshal payload", "err", err)
+		return
+	}
+	s.write(wsMessage{ID: id, Type: msgType, Payload: data})
+}
+
+func (s *wsSession) write(msg wsMessage) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := s.conn.WriteJSON(msg); err != nil {
+		s.log.Debug("unable to write message", "type", msg.Type, "err", err)
+	}
+}
+
+func (s *wsSession) close(code int, reason string) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	data := websocket.FormatCloseMessage(code, reason)
+	_ = s.conn.WriteControl(websocket.CloseMessage, data, time.Now().Add(writeTimeout))
+}
+
+func isJSONError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
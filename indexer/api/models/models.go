@@ -40,6 +40,39 @@ type WithdrawalItem struct {
 	L1FinalizedTxHash      string `json:"l1FinalizedTxHash"`
 	L1TokenAddress         string `json:"l1TokenAddress"`
 	L2TokenAddress         string `json:"l2TokenAddress"`
+
+	Status WithdrawalStatus `json:"status"`
+}
+
+// WithdrawalState ... Step of the multistep (bedrock) withdrawal process a withdrawal has reached
+type WithdrawalState string
+
+const (
+	// WithdrawalInitiated ... No output covering the withdrawal has been proposed yet
+	WithdrawalInitiated WithdrawalState = "initiated"
+	// WithdrawalReadyToProve ... The withdrawal can be proven against the output at L2OutputIndex
+	WithdrawalReadyToProve WithdrawalState = "ready-to-prove"
+	// WithdrawalProven ... The withdrawal is proven and within the finalization period
+	WithdrawalProven WithdrawalState = "proven"
+	// WithdrawalReadyToFinalize ... The finalization period has elapsed since the withdrawal was proven
+	WithdrawalReadyToFinalize WithdrawalState = "ready-to-finalize"
+	// WithdrawalFinalized ... The withdrawal has been finalized on L1
+	WithdrawalFinalized WithdrawalState = "finalized"
+)
+
+// WithdrawalStatus ... Lifecycle of a withdrawal. Timestamps are omitted until the step has been reached,
+// except for `readyToFinalizeTimestamp` which is known as soon as the withdrawal is proven
+type WithdrawalStatus struct {
+	State WithdrawalState `json:"state"`
+
+	// L2OutputIndex ... Index of the first output covering the withdrawal, used to build the withdrawal proof
+	L2OutputIndex string `json:"l2OutputIndex,omitempty"`
+
+	InitiatedTimestamp       uint64 `json:"initiatedTimestamp"`
+	ReadyToProveTimestamp    uint64 `json:"readyToProveTimestamp,omitempty"`
+	ProvenTimestamp          uint64 `json:"provenTimestamp,omitempty"`
+	ReadyToFinalizeTimestamp uint64 `json:"readyToFinalizeTimestamp,omitempty"`
+	FinalizedTimestamp       uint64 `json:"finalizedTimestamp,omitempty"`
 }
 
 // WithdrawalResponse ... Data model for API JSON response
@@ -49,42 +82,311 @@ type WithdrawalResponse struct {
 	Items       []WithdrawalItem `json:"items"`
 }
 
+// ERC721DepositItem ... NFT deposit item model for API responses
+type ERC721DepositItem struct {
+	Guid                   string `json:"guid"`
+	From                   string `json:"from"`
+	To                     string `json:"to"`
+	Timestamp              uint64 `json:"timestamp"`
+	L1BlockHash            string `json:"l1BlockHash"`
+	L1TxHash               string `json:"l1TxHash"`
+	L2TxHash               string `json:"l2TxHash"`
+	CrossDomainMessageHash string `json:"crossDomainMessageHash"`
+	TokenId                string `json:"tokenId"`
+	L1TokenAddress         string `json:"l1TokenAddress"`
+	L2TokenAddress         string `json:"l2TokenAddress"`
+}
+
+// ERC721DepositResponse ... Data model for API JSON response
+type ERC721DepositResponse struct {
+	Cursor      string              `json:"cursor"`
+	HasNextPage bool                `json:"hasNextPage"`
+	Items       []ERC721DepositItem `json:"items"`
+}
+
+// ERC721WithdrawalItem ... NFT withdrawal item model for API responses
+type ERC721WithdrawalItem struct {
+	Guid                   string `json:"guid"`
+	From                   string `json:"from"`
+	To                     string `json:"to"`
+	TransactionHash        string `json:"transactionHash"`
+	CrossDomainMessageHash string `json:"crossDomainMessageHash"`
+	Timestamp              uint64 `json:"timestamp"`
+	L2BlockHash            string `json:"l2BlockHash"`
+	TokenId                string `json:"tokenId"`
+	L1ProvenTxHash         string `json:"l1ProvenTxHash"`
+	L1FinalizedTxHash      string `json:"l1FinalizedTxHash"`
+	L1TokenAddress         string `json:"l1TokenAddress"`
+	L2TokenAddress         string `json:"l2TokenAddress"`
+}
+
+// ERC721WithdrawalResponse ... Data model for API JSON response
+type ERC721WithdrawalResponse struct {
+	Cursor      string                 `json:"cursor"`
+	HasNextPage bool                   `json:"hasNextPage"`
+	Items       []ERC721WithdrawalItem `json:"items"`
+}
+
 type BridgeSupplyView struct {
 	L1DepositSum    float64 `json:"l1DepositSum"`
 	L2WithdrawalSum float64 `json:"l2WithdrawalSum"`
 }
 
-// FIXME make a pure function that returns a struct instead of newWithdrawalResponse
-// newWithdrawalResponse ... Converts a database.L2BridgeWithdrawalsResponse to an api.WithdrawalResponse
-func CreateWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse) WithdrawalResponse {
+// TokenReportItem ... Reconciliation of the L1 escrow of a bridged token pair with its L2 supply
+type TokenReportItem struct {
+	L1TokenAddress      string `json:"l1TokenAddress"`
+	L2TokenAddress      string `json:"l2TokenAddress"`
+	L1BlockNumber       string `json:"l1BlockNumber"`
+	L2BlockNumber       string `json:"l2BlockNumber"`
+	L1EscrowBalance     string `json:"l1EscrowBalance"`
+	L2TotalSupply       string `json:"l2TotalSupply"`
+	DepositsInFlight    string `json:"depositsInFlight"`
+	WithdrawalsInFlight string `json:"withdrawalsInFlight"`
+	Discrepancy         string `json:"discrepancy"`
+	Mismatch            bool   `json:"mismatch"`
+	StuckDeposits       int    `json:"stuckDeposits"`
+	StuckWithdrawals    int    `json:"stuckWithdrawals"`
+	Timestamp           uint64 `json:"timestamp"`
+}
+
+// BridgeAccountingResponse ... Data model for API JSON response
+type BridgeAccountingResponse struct {
+	Mismatches int               `json:"mismatches"`
+	Items      []TokenReportItem `json:"items"`
+}
+
+// StuckMessageItem ... Bridge message that failed to relay and has yet to be replayed
+type StuckMessageItem struct {
+	MessageHash     string `json:"messageHash"`
+	Nonce           string `json:"nonce"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	Amount          string `json:"amount"`
+	Timestamp       uint64 `json:"timestamp"`
+	SentEventGuid   string `json:"sentEventGuid"`
+	FailedEventGuid string `json:"failedEventGuid"`
+}
+
+// StuckMessagesResponse ... Data model for API JSON response
+type StuckMessagesResponse struct {
+	Deposits    []StuckMessageItem `json:"deposits"`
+	Withdrawals []StuckMessageItem `json:"withdrawals"`
+}
+
+// ChainsResponse ... Lists the L2 chain ids served by the API
+type ChainsResponse struct {
+	Chains []uint64 `json:"chains"`
+}
+
+// CreateDepositResponse ... Converts a database.L1BridgeDepositsResponse to an api.DepositResponse
+func CreateDepositResponse(deposits *database.L1BridgeDepositsResponse) DepositResponse {
+	items := make([]DepositItem, len(deposits.Deposits))
+	for i, deposit := range deposits.Deposits {
+		items[i] = CreateDepositItem(deposit)
+	}
+
+	return DepositResponse{
+		Cursor:      deposits.Cursor,
+		HasNextPage: deposits.HasNextPage,
+		Items:       items,
+	}
+}
+
+// CreateDepositItem ... Converts a single database deposit to an api.DepositItem
+func CreateDepositItem(deposit database.L1BridgeDepositWithTransactionHashes) DepositItem {
+	return DepositItem{
+		Guid:           deposit.L1BridgeDeposit.TransactionSourceHash.String(),
+		L1BlockHash:    deposit.L1BlockHash.String(),
+		Timestamp:      deposit.L1BridgeDeposit.Tx.Timestamp,
+		L1TxHash:       deposit.L1TransactionHash.String(),
+		L2TxHash:       deposit.L2TransactionHash.String(),
+		From:           deposit.L1BridgeDeposit.Tx.FromAddress.String(),
+		To:             deposit.L1BridgeDeposit.Tx.ToAddress.String(),
+		Amount:         deposit.L1BridgeDeposit.Tx.Amount.String(),
+		L1TokenAddress: deposit.L1BridgeDeposit.TokenPair.LocalTokenAddress.String(),
+		L2TokenAddress: deposit.L1BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
+	}
+}
+
+// CreateWithdrawalResponse ... Converts a database.L2BridgeWithdrawalsResponse to an api.WithdrawalResponse.
+// The lifecycle state of each withdrawal is computed at the supplied L1 timestamp
+func CreateWithdrawalResponse(withdrawals *database.L2BridgeWithdrawalsResponse, finalizationPeriodSeconds uint64, l1Timestamp uint64) WithdrawalResponse {
 	items := make([]WithdrawalItem, len(withdrawals.Withdrawals))
 	for i, withdrawal := range withdrawals.Withdrawals {
+		items[i] = CreateWithdrawalItem(withdrawal, finalizationPeriodSeconds, l1Timestamp)
+	}
+
+	return WithdrawalResponse{
+		Cursor:      withdrawals.Cursor,
+		HasNextPage: withdrawals.HasNextPage,
+		Items:       items,
+	}
+}
+
+// CreateWithdrawalItem ... Converts a single database withdrawal to an api.WithdrawalItem
+func CreateWithdrawalItem(withdrawal database.L2BridgeWithdrawalWithTransactionHashes, finalizationPeriodSeconds uint64, l1Timestamp uint64) WithdrawalItem {
+	cdh := withdrawal.L2BridgeWithdrawal.CrossDomainMessageHash
+	if cdh == nil { // Zero value indicates that the withdrawal didn't have a cross domain message
+		cdh = &common.Hash{0}
+	}
+
+	return WithdrawalItem{
+		Guid:                   withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
+		L2BlockHash:            withdrawal.L2BlockHash.String(),
+		Timestamp:              withdrawal.L2BridgeWithdrawal.Tx.Timestamp,
+		From:                   withdrawal.L2BridgeWithdrawal.Tx.FromAddress.String(),
+		To:                     withdrawal.L2BridgeWithdrawal.Tx.ToAddress.String(),
+		TransactionHash:        withdrawal.L2TransactionHash.String(),
+		Amount:                 withdrawal.L2BridgeWithdrawal.Tx.Amount.String(),
+		CrossDomainMessageHash: cdh.String(),
+		L1ProvenTxHash:         withdrawal.ProvenL1TransactionHash.String(),
+		L1FinalizedTxHash:      withdrawal.FinalizedL1TransactionHash.String(),
+		L1TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
+		L2TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+		Status:                 CreateWithdrawalStatus(withdrawal, finalizationPeriodSeconds, l1Timestamp),
+	}
+}
+
+// CreateWithdrawalStatus ... Computes the lifecycle of a withdrawal at the supplied L1 timestamp. A proven
+// withdrawal can be finalized once the finalization period of the L2OutputOracle has elapsed
+func CreateWithdrawalStatus(withdrawal database.L2BridgeWithdrawalWithTransactionHashes, finalizationPeriodSeconds uint64, l1Timestamp uint64) WithdrawalStatus {
+	status := WithdrawalStatus{
+		State:              WithdrawalInitiated,
+		InitiatedTimestamp: withdrawal.L2BridgeWithdrawal.Tx.Timestamp,
+	}
+
+	if withdrawal.L2OutputIndex != nil {
+		status.State = WithdrawalReadyToProve
+		status.L2OutputIndex = withdrawal.L2OutputIndex.String()
+		status.ReadyToProveTimestamp = withdrawal.OutputProposedTimestamp
+	}
+
+	if withdrawal.ProvenL1Timestamp > 0 {
+		status.State = WithdrawalProven
+		status.ProvenTimestamp = withdrawal.ProvenL1Timestamp
+		status.ReadyToFinalizeTimestamp = withdrawal.ProvenL1Timestamp + finalizationPeriodSeconds
+		if l1Timestamp >= status.ReadyToFinalizeTimestamp {
+			status.State = WithdrawalReadyToFinalize
+		}
+	}
+
+	if withdrawal.FinalizedL1Timestamp > 0 {
+		status.State = WithdrawalFinalized
+		status.FinalizedTimestamp = withdrawal.FinalizedL1Timestamp
+	}
+
+	return status
+}
 
-		cdh := withdrawal.L2BridgeWithdrawal.CrossDomainMessageHash
-		if cdh == nil { // Zero value indicates that the withdrawal didn't have a cross domain message
-			cdh = &common.Hash{0}
+// CreateERC721DepositResponse ... Converts a database.L1ERC721BridgeDepositsResponse to an api.ERC721DepositResponse
+func CreateERC721DepositResponse(deposits *database.L1ERC721BridgeDepositsResponse) ERC721DepositResponse {
+	items := make([]ERC721DepositItem, len(deposits.Deposits))
+	for i, deposit := range deposits.Deposits {
+		items[i] = ERC721DepositItem{
+			Guid:                   deposit.L1ERC721BridgeDeposit.TransactionSourceHash.String(),
+			L1BlockHash:            deposit.L1BlockHash.String(),
+			Timestamp:              deposit.L1ERC721BridgeDeposit.Timestamp,
+			L1TxHash:               deposit.L1TransactionHash.String(),
+			L2TxHash:               deposit.L2TransactionHash.String(),
+			From:                   deposit.L1ERC721BridgeDeposit.FromAddress.String(),
+			To:                     deposit.L1ERC721BridgeDeposit.ToAddress.String(),
+			CrossDomainMessageHash: deposit.L1ERC721BridgeDeposit.CrossDomainMessageHash.String(),
+			TokenId:                deposit.L1ERC721BridgeDeposit.TokenID.String(),
+			L1TokenAddress:         deposit.L1ERC721BridgeDeposit.TokenPair.LocalTokenAddress.String(),
+			L2TokenAddress:         deposit.L1ERC721BridgeDeposit.TokenPair.RemoteTokenAddress.String(),
 		}
+	}
 
-		item := WithdrawalItem{
-			Guid:                   withdrawal.L2BridgeWithdrawal.TransactionWithdrawalHash.String(),
+	return ERC721DepositResponse{
+		Cursor:      deposits.Cursor,
+		HasNextPage: deposits.HasNextPage,
+		Items:       items,
+	}
+}
+
+// CreateERC721WithdrawalResponse ... Converts a database.L2ERC721BridgeWithdrawalsResponse to an api.ERC721WithdrawalResponse
+func CreateERC721WithdrawalResponse(withdrawals *database.L2ERC721BridgeWithdrawalsResponse) ERC721WithdrawalResponse {
+	items := make([]ERC721WithdrawalItem, len(withdrawals.Withdrawals))
+	for i, withdrawal := range withdrawals.Withdrawals {
+		items[i] = ERC721WithdrawalItem{
+			Guid:                   withdrawal.L2ERC721BridgeWithdrawal.TransactionWithdrawalHash.String(),
 			L2BlockHash:            withdrawal.L2BlockHash.String(),
-			Timestamp:              withdrawal.L2BridgeWithdrawal.Tx.Timestamp,
-			From:                   withdrawal.L2BridgeWithdrawal.Tx.FromAddress.String(),
-			To:                     withdrawal.L2BridgeWithdrawal.Tx.ToAddress.String(),
+			Timestamp:              withdrawal.L2ERC721BridgeWithdrawal.Timestamp,
+			From:                   withdrawal.L2ERC721BridgeWithdrawal.FromAddress.String(),
+			To:                     withdrawal.L2ERC721BridgeWithdrawal.ToAddress.String(),
 			TransactionHash:        withdrawal.L2TransactionHash.String(),
-			Amount:                 withdrawal.L2BridgeWithdrawal.Tx.Amount.String(),
-			CrossDomainMessageHash: cdh.String(),
+			CrossDomainMessageHash: withdrawal.L2ERC721BridgeWithdrawal.CrossDomainMessageHash.String(),
+			TokenId:                withdrawal.L2ERC721BridgeWithdrawal.TokenID.String(),
 			L1ProvenTxHash:         withdrawal.ProvenL1TransactionHash.String(),
 			L1FinalizedTxHash:      withdrawal.FinalizedL1TransactionHash.String(),
-			L1TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
-			L2TokenAddress:         withdrawal.L2BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
+			L1TokenAddress:         withdrawal.L2ERC721BridgeWithdrawal.TokenPair.RemoteTokenAddress.String(),
+			L2TokenAddress:         withdrawal.L2ERC721BridgeWithdrawal.TokenPair.LocalTokenAddress.String(),
 		}
-		items[i] = item
 	}
 
-	return WithdrawalResponse{
+	return ERC721WithdrawalResponse{
 		Cursor:      withdrawals.Cursor,
 		HasNextPage: withdrawals.HasNextPage,
 		Items:       items,
 	}
 }
+
+// CreateBridgeAccountingResponse ... Converts the database token reports to an api.BridgeAccountingResponse
+func CreateBridgeAccountingResponse(reports []database.BridgeTokenReport) BridgeAccountingResponse {
+	response := BridgeAccountingResponse{Items: make([]TokenReportItem, len(reports))}
+	for i, report := range reports {
+		if report.Mismatch() {
+			response.Mismatches++
+		}
+		response.Items[i] = TokenReportItem{
+			L1TokenAddress:      report.L1TokenAddress.String(),
+			L2TokenAddress:      report.L2TokenAddress.String(),
+			L1BlockNumber:       report.L1BlockNumber.String(),
+			L2BlockNumber:       report.L2BlockNumber.String(),
+			L1EscrowBalance:     report.L1EscrowBalance.String(),
+			L2TotalSupply:       report.L2TotalSupply.String(),
+			DepositsInFlight:    report.DepositsInFlight.String(),
+			WithdrawalsInFlight: report.WithdrawalsInFlight.String(),
+			Discrepancy:         report.Discrepancy().String(),
+			Mismatch:            report.Mismatch(),
+			StuckDeposits:       report.StuckDeposits,
+			StuckWithdrawals:    report.StuckWithdrawals,
+			Timestamp:           report.Timestamp,
+		}
+	}
+
+	return response
+}
+
+// CreateStuckMessagesResponse ... Converts the stuck database bridge messages to an api.StuckMessagesResponse
+func CreateStuckMessagesResponse(l1Messages []database.L1BridgeMessage, l2Messages []database.L2BridgeMessage) StuckMessagesResponse {
+	response := StuckMessagesResponse{
+		Deposits:    make([]StuckMessageItem, len(l1Messages)),
+		Withdrawals: make([]StuckMessageItem, len(l2Messages)),
+	}
+	for i, message := range l1Messages {
+		response.Deposits[i] = createStuckMessageItem(message.BridgeMessage)
+	}
+	for i, message := range l2Messages {
+		response.Withdrawals[i] = createStuckMessageItem(message.BridgeMessage)
+	}
+
+	return response
+}
+
+func createStuckMessageItem(message database.BridgeMessage) StuckMessageItem {
+	item := StuckMessageItem{
+		MessageHash:   message.MessageHash.String(),
+		Nonce:         message.Nonce.String(),
+		From:          message.Tx.FromAddress.String(),
+		To:            message.Tx.ToAddress.String(),
+		Amount:        message.Tx.Amount.String(),
+		Timestamp:     message.Tx.Timestamp,
+		SentEventGuid: message.SentMessageEventGUID.String(),
+	}
+	if message.FailedRelayedMessageEventGUID != nil {
+		item.FailedEventGuid = message.FailedRelayedMessageEventGUID.String()
+	}
+
+	return item
+}
@@ -2,6 +2,7 @@ package models_test
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -33,13 +34,15 @@ func TestCreateWithdrawal(t *testing.T) {
 						},
 					},
 				},
+				L2OutputIndex:           big.NewInt(8),
+				OutputProposedTimestamp: 9,
 			},
 		},
 	}
 
 	// (2) Create and validate response object
 
-	response := models.CreateWithdrawalResponse(dbWithdrawals)
+	response := models.CreateWithdrawalResponse(dbWithdrawals, 100, 10)
 	require.NotEmpty(t, response.Items)
 	require.Len(t, response.Items, 1)
 
@@ -62,3 +65,95 @@ func TestCreateWithdrawal(t *testing.T) {
 	}
 
 }
+
+func TestCreateWithdrawalStatus(t *testing.T) {
+	finalizationPeriod := uint64(100)
+	initiated := database.L2BridgeWithdrawalWithTransactionHashes{
+		L2BridgeWithdrawal: database.L2BridgeWithdrawal{
+			BridgeTransfer: database.BridgeTransfer{Tx: database.Transaction{Timestamp: 5}},
+		},
+	}
+
+	readyToProve := initiated
+	readyToProve.L2OutputIndex = big.NewInt(3)
+	readyToProve.OutputProposedTimestamp = 10
+
+	proven := readyToProve
+	proven.ProvenL1Timestamp = 20
+
+	finalized := proven
+	finalized.FinalizedL1Timestamp = 150
+
+	tests := []struct {
+		name       string
+		withdrawal database.L2BridgeWithdrawalWithTransactionHashes
+		now        uint64
+		expected   models.WithdrawalStatus
+	}{
+		{
+			name:       "Initiated",
+			withdrawal: initiated,
+			now:        1000,
+			expected:   models.WithdrawalStatus{State: models.WithdrawalInitiated, InitiatedTimestamp: 5},
+		},
+		{
+			name:       "ReadyToProve",
+			withdrawal: readyToProve,
+			now:        1000,
+			expected: models.WithdrawalStatus{
+				State:                 models.WithdrawalReadyToProve,
+				L2OutputIndex:         "3",
+				InitiatedTimestamp:    5,
+				ReadyToProveTimestamp: 10,
+			},
+		},
+		{
+			name:       "ProvenWithinFinalizationPeriod",
+			withdrawal: proven,
+			now:        119,
+			expected: models.WithdrawalStatus{
+				State:                    models.WithdrawalProven,
+				L2OutputIndex:            "3",
+				InitiatedTimestamp:       5,
+				ReadyToProveTimestamp:    10,
+				ProvenTimestamp:          20,
+				ReadyToFinalizeTimestamp: 120,
+			},
+		},
+		{
+			name:       "ReadyToFinalize",
+			withdrawal: proven,
+			now:        120,
+			expected: models.WithdrawalStatus{
+				State:                    models.WithdrawalReadyToFinalize,
+				L2OutputIndex:            "3",
+				InitiatedTimestamp:       5,
+				ReadyToProveTimestamp:    10,
+				ProvenTimestamp:          20,
+				ReadyToFinalizeTimestamp: 120,
+			},
+		},
+		{
+			name:       "Finalized",
+			withdrawal: finalized,
+			now:        1000,
+			expected: models.WithdrawalStatus{
+				State:                    models.WithdrawalFinalized,
+				L2OutputIndex:            "3",
+				InitiatedTimestamp:       5,
+				ReadyToProveTimestamp:    10,
+				ProvenTimestamp:          20,
+				ReadyToFinalizeTimestamp: 120,
+				FinalizedTimestamp:       150,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			status := models.CreateWithdrawalStatus(test.withdrawal, finalizationPeriod, test.now)
+			require.Equal(t, test.expected, status)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+)
+
+// BridgeAccountingHandler ... Handles /api/v0/accounting GET requests
+func (h Routes) BridgeAccountingHandler(w http.ResponseWriter, r *http.Request) {
+	if h.accountingView == nil {
+		http.Error(w, "bridge accounting is not served", http.StatusNotFound)
+		return
+	}
+
+	reports, err := h.accountingView.BridgeTokenReports()
+	if err != nil {
+		http.Error(w, "internal server error reading token reports", http.StatusInternalServerError)
+		h.logger.Error("unable to read token reports from DB", "err", err.Error())
+		return
+	}
+	response := models.CreateBridgeAccountingResponse(reports)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
+
+// StuckMessagesHandler ... Handles /api/v0/accounting/stuck GET requests
+func (h Routes) StuckMessagesHandler(w http.ResponseWriter, r *http.Request) {
+	if h.accountingView == nil {
+		http.Error(w, "bridge accounting is not served", http.StatusNotFound)
+		return
+	}
+
+	limitQuery := r.URL.Query().Get("limit")
+	limit, err := h.v.ParseValidateLimit(limitQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid query params", "err", err)
+		return
+	}
+
+	l1Messages, err := h.accountingView.StuckL1BridgeMessages(limit)
+	if err != nil {
+		http.Error(w, "internal server error reading stuck deposits", http.StatusInternalServerError)
+		h.logger.Error("unable to read stuck deposits from DB", "err", err.Error())
+		return
+	}
+
+	l2Messages, err := h.accountingView.StuckL2BridgeMessages(limit)
+	if err != nil {
+		http.Error(w, "internal server error reading stuck withdrawals", http.StatusInternalServerError)
+		h.logger.Error("unable to read stuck withdrawals from DB", "err", err.Error())
+		return
+	}
+	response := models.CreateStuckMessagesResponse(l1Messages, l2Messages)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("error writing response", "err", err)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+)
+
+// ChainsHandler ... Handles /api/v0/chains GET requests when serving several chains
+func ChainsHandler(logger log.Logger, chainIDs []uint64) http.HandlerFunc {
+	response := models.ChainsResponse{Chains: chainIDs}
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := jsonResponse(w, response, http.StatusOK)
+		if err != nil {
+			logger.Error("error writing response", "err", err)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package routes
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
 )
 
 const (
@@ -30,3 +32,16 @@ func jsonResponse(w http.ResponseWriter, data interface{}, statusCode int) error
 
 	return nil
 }
+
+// LatestL1Timestamp ... Returns the timestamp of the latest indexed L1 block, used as the current
+// time of the withdrawal lifecycle so that it is consistent with the indexed proofs and finalizations
+func LatestL1Timestamp(blocks database.BlocksView) (uint64, error) {
+	header, err := blocks.L1LatestBlockHeader()
+	if err != nil {
+		return 0, err
+	} else if header == nil {
+		return 0, nil
+	}
+
+	return header.Timestamp, nil
+}
@@ -0,0 +1,121 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum-optimism/optimism/indexer/api/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+)
+
+// L1ERC721DepositsHandler ... Handles /api/v0/erc721/deposits/{address} GET requests
+func (h Routes) L1ERC721DepositsHandler(w http.ResponseWriter, r *http.Request) {
+	addressValue := chi.URLParam(r, "address")
+	collectionQuery := r.URL.Query().Get("collection")
+	cursor := r.URL.Query().Get("cursor")
+	limitQuery := r.URL.Query().Get("limit")
+
+	address, err := h.v.ParseValidateAddress(addressValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid address param", "param", addressValue, "err", err)
+		return
+	}
+
+	collection, err := h.parseValidateCollection(collectionQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid collection param", "param", collectionQuery, "err", err)
+		return
+	}
+
+	err = h.v.ValidateCursor(cursor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid cursor param", "param", cursor, "err", err)
+		return
+	}
+
+	limit, err := h.v.ParseValidateLimit(limitQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid limit param", "param", limitQuery, "err", err)
+		return
+	}
+
+	deposits, err := h.view.L1ERC721BridgeDepositsByAddress(address, collection, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading erc721 deposits", http.StatusInternalServerError)
+		h.logger.Error("Unable to read erc721 deposits from DB", "err", err.Error())
+		return
+	}
+	response := models.CreateERC721DepositResponse(deposits)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// L2ERC721WithdrawalsHandler ... Handles /api/v0/erc721/withdrawals/{address} GET requests
+func (h Routes) L2ERC721WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
+	addressValue := chi.URLParam(r, "address")
+	collectionQuery := r.URL.Query().Get("collection")
+	cursor := r.URL.Query().Get("cursor")
+	limitQuery := r.URL.Query().Get("limit")
+
+	address, err := h.v.ParseValidateAddress(addressValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid address param", "param", addressValue, "err", err)
+		return
+	}
+
+	collection, err := h.parseValidateCollection(collectionQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid collection param", "param", collectionQuery, "err", err)
+		return
+	}
+
+	err = h.v.ValidateCursor(cursor)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid cursor param", "param", cursor, "err", err)
+		return
+	}
+
+	limit, err := h.v.ParseValidateLimit(limitQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid limit param", "param", limitQuery, "err", err)
+		return
+	}
+
+	withdrawals, err := h.view.L2ERC721BridgeWithdrawalsByAddress(address, collection, cursor, limit)
+	if err != nil {
+		http.Error(w, "Internal server error reading erc721 withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read erc721 withdrawals from DB", "err", err.Error())
+		return
+	}
+	response := models.CreateERC721WithdrawalResponse(withdrawals)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// parseValidateCollection ... Parses the optional collection query parameter, which
+// may be either the L1 or L2 address of the ERC721 token
+func (h Routes) parseValidateCollection(collection string) (*common.Address, error) {
+	if collection == "" {
+		return nil, nil
+	}
+
+	addr, err := h.v.ParseValidateAddress(collection)
+	if err != nil {
+		return nil, err
+	}
+	return &addr, nil
+}
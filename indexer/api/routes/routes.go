@@ -10,15 +10,27 @@ import (
 type Routes struct {
 	logger log.Logger
 	view   database.BridgeTransfersView
+	blocks database.BlocksView
 	router *chi.Mux
 	v      *Validator
+
+	// accountingView serves the bridge accounting reports. Nil if not served by the DB
+	accountingView database.BridgeAccountingView
+
+	// finalizationPeriodSeconds is the L2OutputOracle finalization period used to
+	// compute when a proven withdrawal can be finalized
+	finalizationPeriodSeconds uint64
 }
 
 // NewRoutes ... Construct a new route handler instance
-func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, r *chi.Mux) Routes {
+func NewRoutes(logger log.Logger, bv database.BridgeTransfersView, blocks database.BlocksView, av database.BridgeAccountingView, r *chi.Mux, finalizationPeriodSeconds uint64) Routes {
 	return Routes{
 		logger: logger,
 		view:   bv,
+		blocks: blocks,
 		router: r,
+
+		accountingView:            av,
+		finalizationPeriodSeconds: finalizationPeriodSeconds,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Validator ... Validates API user request parameters
@@ -44,6 +45,20 @@ func (v *Validator) ParseValidateAddress(addr string) (common.Address, error) {
 	return parsedAddr, nil
 }
 
+// ParseValidateHash ... Validates and parses a 32 byte hash path parameter
+func (v *Validator) ParseValidateHash(hash string) (common.Hash, error) {
+	if len(hash) != 66 { // 0x + 64 chars
+		return common.Hash{}, errors.New("hash must be a 32 byte hex string")
+	}
+
+	b, err := hexutil.Decode(hash)
+	if err != nil {
+		return common.Hash{}, errors.New("hash must be represented as a valid hexadecimal string")
+	}
+
+	return common.BytesToHash(b), nil
+}
+
 // ValidateCursor ... Validates and parses the cursor query parameter
 func (v *Validator) ValidateCursor(cursor string) error {
 	if cursor == "" {
@@ -40,7 +40,47 @@ func (h Routes) L2WithdrawalsHandler(w http.ResponseWriter, r *http.Request) {
 		h.logger.Error("Unable to read withdrawals from DB", "err", err.Error())
 		return
 	}
-	response := models.CreateWithdrawalResponse(withdrawals)
+	l1Timestamp, err := LatestL1Timestamp(h.blocks)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawals", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L1 block from DB", "err", err.Error())
+		return
+	}
+	response := models.CreateWithdrawalResponse(withdrawals, h.finalizationPeriodSeconds, l1Timestamp)
+
+	err = jsonResponse(w, response, http.StatusOK)
+	if err != nil {
+		h.logger.Error("Error writing response", "err", err.Error())
+	}
+}
+
+// L2WithdrawalHandler ... Handles /api/v0/withdrawal/{hash} GET requests
+func (h Routes) L2WithdrawalHandler(w http.ResponseWriter, r *http.Request) {
+	hashValue := chi.URLParam(r, "hash")
+
+	withdrawalHash, err := h.v.ParseValidateHash(hashValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		h.logger.Error("Invalid hash param", "param", hashValue, "err", err)
+		return
+	}
+
+	withdrawal, err := h.view.L2BridgeWithdrawalWithTransactionHashes(withdrawalHash)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal", http.StatusInternalServerError)
+		h.logger.Error("Unable to read withdrawal from DB", "err", err.Error())
+		return
+	} else if withdrawal == nil {
+		http.Error(w, "Withdrawal not found", http.StatusNotFound)
+		return
+	}
+	l1Timestamp, err := LatestL1Timestamp(h.blocks)
+	if err != nil {
+		http.Error(w, "Internal server error reading withdrawal", http.StatusInternalServerError)
+		h.logger.Error("Unable to read latest L1 block from DB", "err", err.Error())
+		return
+	}
+	response := models.CreateWithdrawalItem(*withdrawal, h.finalizationPeriodSeconds, l1Timestamp)
 
 	err = jsonResponse(w, response, http.StatusOK)
 	if err != nil {
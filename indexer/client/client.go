@@ -24,6 +24,7 @@ const (
 	deposits    = "get_deposits"
 	withdrawals = "get_withdrawals"
 	sum         = "get_sum"
+	accounting  = "get_accounting"
 )
 
 // Option ... Provides configuration through callback injection
@@ -224,3 +225,21 @@ func (c *Client) GetWithdrawalsByAddress(l2Address common.Address, cursor string
 
 	return wResponse, nil
 }
+
+// GetBridgeAccounting ... Returns the latest reconciliation report of each bridged token pair,
+// comparing the L1 escrow balance with the L2 supply and value in flight
+func (c *Client) GetBridgeAccounting() (*models.BridgeAccountingResponse, error) {
+	url := c.cfg.BaseURL + api.AccountingPath
+
+	resp, err := c.doRecordRequest(accounting, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var bar *models.BridgeAccountingResponse
+	if err := json.Unmarshal(resp, &bar); err != nil {
+		return nil, err
+	}
+
+	return bar, nil
+}
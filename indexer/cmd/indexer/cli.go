@@ -2,17 +2,29 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum-optimism/optimism/indexer"
 	"github.com/ethereum-optimism/optimism/indexer/api"
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/etl"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/indexer/processors"
+	"github.com/ethereum-optimism/optimism/indexer/processors/bridge"
+	"github.com/ethereum-optimism/optimism/indexer/processors/contracts"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 )
 
@@ -30,6 +42,57 @@ var (
 		Usage:   "path to migrations folder",
 		EnvVars: []string{"INDEXER_MIGRATIONS_DIR"},
 	}
+	L2ChainIDFlag = &cli.Uint64Flag{
+		Name:  "l2-chain-id",
+		Usage: "l2 chain id of the chain to operate on. Required when indexing several chains",
+	}
+	LayerFlag = &cli.StringFlag{
+		Name:     "layer",
+		Usage:    "layer to operate on (l1 or l2)",
+		Required: true,
+	}
+	FromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "first block height of the range (inclusive)",
+		Required: true,
+	}
+	ToFlag = &cli.Uint64Flag{
+		Name:  "to",
+		Usage: "last block height of the range (inclusive)",
+	}
+	ContractFlag = &cli.StringSliceFlag{
+		Name:  "contract",
+		Usage: "name or address of a contract to backfill. Defaults to all configured contracts of the layer",
+	}
+	BatchSizeFlag = &cli.Uint64Flag{
+		Name:  "batch-size",
+		Value: 500,
+		Usage: "number of blocks handled per batch",
+	}
+	L1FromFlag = &cli.Uint64Flag{
+		Name:  "l1-from",
+		Usage: "first l1 block height to reprocess (inclusive)",
+	}
+	L1ToFlag = &cli.Uint64Flag{
+		Name:  "l1-to",
+		Usage: "last l1 block height to reprocess (inclusive)",
+	}
+	L2FromFlag = &cli.Uint64Flag{
+		Name:  "l2-from",
+		Usage: "first l2 block height to reprocess (inclusive)",
+	}
+	L2ToFlag = &cli.Uint64Flag{
+		Name:  "l2-to",
+		Usage: "last l2 block height to reprocess (inclusive)",
+	}
+	ProcessorFlag = &cli.StringFlag{
+		Name:  "processor",
+		Usage: "bridge events to reprocess (initiated or finalized). Defaults to both",
+	}
+	CanonicalFlag = &cli.BoolFlag{
+		Name:  "canonical",
+		Usage: "also verify that indexed headers are canonical according to the configured rpc",
+	}
 )
 
 func runIndexer(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycle, error) {
@@ -57,11 +120,40 @@ func runApi(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, erro
 		return nil, err
 	}
 
+	// The finalization period of each chain is read from its L2OutputOracle
+	l1Client, err := node.DialEthClient(ctx.Context, cfg.RPCs.L1RPC, node.NewMetrics(metrics.NewRegistry(), "l1"))
+	if err != nil {
+		log.Error("failed to dial L1 rpc", "err", err)
+		return nil, err
+	}
+	defer l1Client.Close()
+
 	apiCfg := &api.Config{
-		DB:            &api.DBConfigConnector{DBConfig: cfg.DB},
 		HTTPServer:    cfg.HTTPServer,
 		MetricsServer: cfg.MetricsServer,
 	}
+	if !cfg.MultiChain() {
+		finalizationPeriodSeconds, err := contracts.L2OutputOracleFinalizationPeriodSeconds(l1Client, cfg.Chain.L1Contracts.L2OutputOracleProxy)
+		if err != nil {
+			log.Error("failed to read finalization period", "err", err)
+			return nil, err
+		}
+		apiCfg.DB = &api.DBConfigConnector{DBConfig: cfg.DB}
+		apiCfg.FinalizationPeriodSeconds = finalizationPeriodSeconds
+	} else {
+		for _, chain := range cfg.Chains {
+			finalizationPeriodSeconds, err := contracts.L2OutputOracleFinalizationPeriodSeconds(l1Client, chain.L1Contracts.L2OutputOracleProxy)
+			if err != nil {
+				log.Error("failed to read finalization period", "chain_id", chain.L2ChainID, "err", err)
+				return nil, err
+			}
+			apiCfg.Chains = append(apiCfg.Chains, api.ChainConfig{
+				ChainID:                   chain.L2ChainID,
+				DB:                        &api.DBConfigConnector{DBConfig: cfg.DB.ForChain(chain.L2ChainID)},
+				FinalizationPeriodSeconds: finalizationPeriodSeconds,
+			})
+		}
+	}
 
 	return api.NewApi(ctx.Context, log, apiCfg)
 }
@@ -80,22 +172,282 @@ func runMigrations(ctx *cli.Context) error {
 		return err
 	}
 
-	db, err := database.NewDB(ctx.Context, log, cfg.DB)
+	dbConfigs := []config.DBConfig{cfg.DB}
+	if cfg.MultiChain() {
+		// The state of each chain is isolated in its own schema
+		dbConfigs = dbConfigs[:0]
+		for _, chain := range cfg.Chains {
+			dbConfigs = append(dbConfigs, cfg.DB.ForChain(chain.L2ChainID))
+		}
+	}
+
+	migrationsDir := ctx.String(MigrationsFlag.Name)
+	for _, dbConfig := range dbConfigs {
+		if err := migrate(ctx.Context, log, dbConfig, migrationsDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func migrate(ctx context.Context, log log.Logger, dbConfig config.DBConfig, migrationsDir string) error {
+	db, err := database.NewDB(ctx, log, dbConfig)
 	if err != nil {
 		log.Error("failed to connect to database", "err", err)
 		return err
 	}
 	defer db.Close()
 
-	migrationsDir := ctx.String(MigrationsFlag.Name)
 	return db.ExecuteSQLMigration(migrationsDir)
 }
 
+func runBackfill(ctx *cli.Context) error {
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+
+	log := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx)).New("role", "backfill")
+	oplog.SetGlobalLogHandler(log.GetHandler())
+	log.Info("running backfill...")
+
+	cfg, err := config.LoadConfig(log, ctx.String(ConfigFlag.Name))
+	if err != nil {
+		log.Error("failed to load config", "err", err)
+		return err
+	}
+	chain, dbConfig, err := selectChain(cfg, ctx.Uint64(L2ChainIDFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	layer := ctx.String(LayerFlag.Name)
+	contracts, err := backfillContracts(chain, layer, ctx.StringSlice(ContractFlag.Name))
+	if err != nil {
+		return err
+	}
+	if !ctx.IsSet(ToFlag.Name) {
+		return fmt.Errorf("--%s is required", ToFlag.Name)
+	}
+	backfillCfg := etl.BackfillConfig{
+		FromHeight: new(big.Int).SetUint64(ctx.Uint64(FromFlag.Name)),
+		ToHeight:   new(big.Int).SetUint64(ctx.Uint64(ToFlag.Name)),
+		Contracts:  contracts,
+		BatchSize:  ctx.Uint64(BatchSizeFlag.Name),
+	}
+
+	rpc := cfg.RPCs.L1RPC
+	if layer == "l2" {
+		rpc = chain.L2RPC
+	}
+	client, err := node.DialEthClient(ctx.Context, rpc, node.NewMetrics(metrics.NewRegistry(), layer))
+	if err != nil {
+		log.Error("failed to dial rpc", "layer", layer, "err", err)
+		return err
+	}
+	defer client.Close()
+
+	db, err := database.NewDB(ctx.Context, log, dbConfig)
+	if err != nil {
+		log.Error("failed to connect to database", "err", err)
+		return err
+	}
+	defer db.Close()
+
+	if layer == "l2" {
+		return etl.BackfillL2(ctx.Context, log, db, client, backfillCfg)
+	}
+	return etl.BackfillL1(ctx.Context, log, db, client, backfillCfg)
+}
+
+func runReprocess(ctx *cli.Context) error {
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+
+	log := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx)).New("role", "reprocess")
+	oplog.SetGlobalLogHandler(log.GetHandler())
+	log.Info("running reprocess...")
+
+	cfg, err := config.LoadConfig(log, ctx.String(ConfigFlag.Name))
+	if err != nil {
+		log.Error("failed to load config", "err", err)
+		return err
+	}
+	chain, dbConfig, err := selectChain(cfg, ctx.Uint64(L2ChainIDFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	reprocessCfg, err := reprocessConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := database.NewDB(ctx.Context, log, dbConfig)
+	if err != nil {
+		log.Error("failed to connect to database", "err", err)
+		return err
+	}
+	defer db.Close()
+
+	return processors.ReprocessBridgeEvents(ctx.Context, log, db, bridge.NewMetrics(metrics.NewRegistry()), chain, reprocessCfg)
+}
+
+func runVerify(ctx *cli.Context) error {
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+
+	log := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx)).New("role", "verify")
+	oplog.SetGlobalLogHandler(log.GetHandler())
+	log.Info("running verify...")
+
+	cfg, err := config.LoadConfig(log, ctx.String(ConfigFlag.Name))
+	if err != nil {
+		log.Error("failed to load config", "err", err)
+		return err
+	}
+	chain, dbConfig, err := selectChain(cfg, ctx.Uint64(L2ChainIDFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	layer := ctx.String(LayerFlag.Name)
+	if layer != "l1" && layer != "l2" {
+		return fmt.Errorf("unknown layer %q", layer)
+	}
+	verifyCfg := etl.VerifyConfig{
+		FromHeight: new(big.Int).SetUint64(ctx.Uint64(FromFlag.Name)),
+		BatchSize:  int(ctx.Uint64(BatchSizeFlag.Name)),
+	}
+	if ctx.IsSet(ToFlag.Name) {
+		verifyCfg.ToHeight = new(big.Int).SetUint64(ctx.Uint64(ToFlag.Name))
+	}
+
+	if ctx.Bool(CanonicalFlag.Name) {
+		rpc := cfg.RPCs.L1RPC
+		if layer == "l2" {
+			rpc = chain.L2RPC
+		}
+		client, err := node.DialEthClient(ctx.Context, rpc, node.NewMetrics(metrics.NewRegistry(), layer))
+		if err != nil {
+			log.Error("failed to dial rpc", "layer", layer, "err", err)
+			return err
+		}
+		defer client.Close()
+		verifyCfg.Client = client
+	}
+
+	db, err := database.NewDB(ctx.Context, log, dbConfig)
+	if err != nil {
+		log.Error("failed to connect to database", "err", err)
+		return err
+	}
+	defer db.Close()
+
+	if layer == "l2" {
+		return etl.VerifyL2Headers(ctx.Context, log, db, verifyCfg)
+	}
+	return etl.VerifyL1Headers(ctx.Context, log, db, verifyCfg)
+}
+
+// selectChain returns the configuration of the chain to operate on, along with the database storing its state
+func selectChain(cfg config.Config, l2ChainID uint64) (config.ChainConfig, config.DBConfig, error) {
+	if !cfg.MultiChain() {
+		if l2ChainID != 0 && l2ChainID != cfg.Chain.L2ChainID {
+			return config.ChainConfig{}, config.DBConfig{}, fmt.Errorf("l2 chain id %d is not indexed", l2ChainID)
+		}
+		return cfg.Chain, cfg.DB, nil
+	}
+
+	if l2ChainID == 0 {
+		return config.ChainConfig{}, config.DBConfig{}, fmt.Errorf("--%s is required when indexing several chains", L2ChainIDFlag.Name)
+	}
+	for _, chain := range cfg.Chains {
+		if chain.L2ChainID == l2ChainID {
+			return chain, cfg.DB.ForChain(l2ChainID), nil
+		}
+	}
+	return config.ChainConfig{}, config.DBConfig{}, fmt.Errorf("l2 chain id %d is not indexed", l2ChainID)
+}
+
+// backfillContracts resolves the named contracts, or addresses, among the configured contracts of the layer
+func backfillContracts(chain config.ChainConfig, layer string, names []string) ([]common.Address, error) {
+	configured := make(map[string]common.Address)
+	addConfigured := func(name string, addr common.Address) error {
+		if addr != (common.Address{}) {
+			configured[strings.ToLower(name)] = addr
+		}
+		return nil
+	}
+
+	switch layer {
+	case "l1":
+		_ = chain.L1Contracts.ForEach(addConfigured)
+	case "l2":
+		_ = chain.L2Contracts.ForEach(addConfigured)
+	default:
+		return nil, fmt.Errorf("unknown layer %q", layer)
+	}
+
+	var contracts []common.Address
+	if len(names) == 0 {
+		for _, addr := range configured {
+			contracts = append(contracts, addr)
+		}
+		return contracts, nil
+	}
+
+	for _, name := range names {
+		if common.IsHexAddress(name) {
+			contracts = append(contracts, common.HexToAddress(name))
+		} else if addr, ok := configured[strings.ToLower(name)]; ok {
+			contracts = append(contracts, addr)
+		} else {
+			return nil, fmt.Errorf("unknown %s contract %q", layer, name)
+		}
+	}
+	return contracts, nil
+}
+
+func reprocessConfig(ctx *cli.Context) (processors.ReprocessConfig, error) {
+	cfg := processors.ReprocessConfig{BatchSize: ctx.Uint64(BatchSizeFlag.Name)}
+	switch processor := ctx.String(ProcessorFlag.Name); processor {
+	case "":
+		cfg.Initiated, cfg.Finalized = true, true
+	case "initiated":
+		cfg.Initiated = true
+	case "finalized":
+		cfg.Finalized = true
+	default:
+		return cfg, fmt.Errorf("unknown processor %q", processor)
+	}
+
+	if ctx.IsSet(L1FromFlag.Name) != ctx.IsSet(L1ToFlag.Name) {
+		return cfg, fmt.Errorf("--%s and --%s must be set together", L1FromFlag.Name, L1ToFlag.Name)
+	} else if ctx.IsSet(L1FromFlag.Name) {
+		cfg.L1FromHeight = new(big.Int).SetUint64(ctx.Uint64(L1FromFlag.Name))
+		cfg.L1ToHeight = new(big.Int).SetUint64(ctx.Uint64(L1ToFlag.Name))
+	}
+
+	if ctx.IsSet(L2FromFlag.Name) != ctx.IsSet(L2ToFlag.Name) {
+		return cfg, fmt.Errorf("--%s and --%s must be set together", L2FromFlag.Name, L2ToFlag.Name)
+	} else if ctx.IsSet(L2FromFlag.Name) {
+		cfg.L2FromHeight = new(big.Int).SetUint64(ctx.Uint64(L2FromFlag.Name))
+		cfg.L2ToHeight = new(big.Int).SetUint64(ctx.Uint64(L2ToFlag.Name))
+	}
+
+	if cfg.L1FromHeight == nil && cfg.L2FromHeight == nil {
+		return cfg, errors.New("no ranges to reprocess")
+	}
+	return cfg, nil
+}
+
 func newCli(GitCommit string, GitDate string) *cli.App {
 	flags := []cli.Flag{ConfigFlag}
 	flags = append(flags, oplog.CLIFlags("INDEXER")...)
 	migrationFlags := []cli.Flag{MigrationsFlag, ConfigFlag}
 	migrationFlags = append(migrationFlags, oplog.CLIFlags("INDEXER")...)
+	backfillFlags := []cli.Flag{ConfigFlag, L2ChainIDFlag, LayerFlag, FromFlag, ToFlag, ContractFlag, BatchSizeFlag}
+	backfillFlags = append(backfillFlags, oplog.CLIFlags("INDEXER")...)
+	reprocessFlags := []cli.Flag{ConfigFlag, L2ChainIDFlag, L1FromFlag, L1ToFlag, L2FromFlag, L2ToFlag, ProcessorFlag, BatchSizeFlag}
+	reprocessFlags = append(reprocessFlags, oplog.CLIFlags("INDEXER")...)
+	verifyFlags := []cli.Flag{ConfigFlag, L2ChainIDFlag, LayerFlag, FromFlag, ToFlag, CanonicalFlag, BatchSizeFlag}
+	verifyFlags = append(verifyFlags, oplog.CLIFlags("INDEXER")...)
 	return &cli.App{
 		Version:              params.VersionWithCommit(GitCommit, GitDate),
 		Description:          "An indexer of all optimism events with a serving api layer",
@@ -119,6 +471,24 @@ func newCli(GitCommit string, GitDate string) *cli.App {
 				Description: "Runs the database migrations",
 				Action:      runMigrations,
 			},
+			{
+				Name:        "backfill",
+				Flags:       backfillFlags,
+				Description: "Indexes the logs of contracts within an already indexed range of blocks",
+				Action:      runBackfill,
+			},
+			{
+				Name:        "reprocess",
+				Flags:       reprocessFlags,
+				Description: "Re-runs the bridge processor over a range of already indexed events",
+				Action:      runReprocess,
+			},
+			{
+				Name:        "verify",
+				Flags:       verifyFlags,
+				Description: "Verifies the integrity of a range of indexed block headers",
+				Action:      runVerify,
+			},
 			{
 				Name:        "version",
 				Description: "print version",
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -21,7 +22,12 @@ const (
 
 // Config represents the `indexer.toml` file used to configure the indexer
 type Config struct {
-	Chain         ChainConfig  `toml:"chain"`
+	Chain ChainConfig `toml:"chain"`
+
+	// Chains configures several L2 chains, settling on the same L1, to be indexed
+	// by a single deployment. Mutually exclusive with the single `[chain]`
+	Chains []ChainConfig `toml:"chains"`
+
 	RPCs          RPCsConfig   `toml:"rpcs"`
 	DB            DBConfig     `toml:"db"`
 	HTTPServer    ServerConfig `toml:"http"`
@@ -100,6 +106,13 @@ type ChainConfig struct {
 	Preset           int
 	L1StartingHeight uint `toml:"l1-starting-height"`
 
+	// Chain id of the L2, defaulting to the preset. The indexed state of each chain
+	// is isolated by this id when indexing several chains
+	L2ChainID uint64 `toml:"l2-chain-id"`
+
+	// RPC of the L2. Defaults to `rpcs.l2-rpc` when indexing a single chain
+	L2RPC string `toml:"l2-rpc"`
+
 	L1Contracts L1Contracts `toml:"l1-contracts"`
 	L2Contracts L2Contracts `toml:"-"`
 
@@ -107,8 +120,8 @@ type ChainConfig struct {
 	L1BedrockStartingHeight uint `toml:"-"`
 	L2BedrockStartingHeight uint `toml:"-"`
 
-	// These configuration options will be removed once
-	// native reorg handling is implemented
+	// Reorgs are handled natively by rolling back to the common ancestor. A
+	// larger depth reduces the amount of rolled back state at the cost of latency
 	L1ConfirmationDepth uint `toml:"l1-confirmation-depth"`
 	L2ConfirmationDepth uint `toml:"l2-confirmation-depth"`
 
@@ -117,6 +130,10 @@ type ChainConfig struct {
 
 	L1HeaderBufferSize uint `toml:"l1-header-buffer-size"`
 	L2HeaderBufferSize uint `toml:"l2-header-buffer-size"`
+
+	// Interval, in milliseconds, at which the L1 escrow of each bridged token is reconciled
+	// with its L2 supply. Disabled when unset
+	AccountingInterval uint `toml:"accounting-interval"`
 }
 
 // RPCsConfig configures the RPC urls
@@ -132,6 +149,21 @@ type DBConfig struct {
 	Name     string `toml:"name"`
 	User     string `toml:"user"`
 	Password string `toml:"password"`
+
+	// Schema the indexed state is stored in, only set when indexing several chains
+	Schema string `toml:"-"`
+}
+
+// ForChain returns the configuration of the DB storing the indexed state of the L2 chain,
+// isolated in its own postgres schema
+func (c DBConfig) ForChain(chainID uint64) DBConfig {
+	c.Schema = fmt.Sprintf("chain_%d", chainID)
+	return c
+}
+
+// MultiChain returns true when several L2 chains are configured to be indexed
+func (c Config) MultiChain() bool {
+	return len(c.Chains) > 0
 }
 
 // Configures the server
@@ -166,22 +198,18 @@ func LoadConfig(log log.Logger, path string) (Config, error) {
 		return cfg, err
 	}
 
-	if cfg.Chain.Preset == DevnetPresetId {
-		preset, err := DevnetPreset()
-		if err != nil {
+	if cfg.MultiChain() {
+		if err := loadChainsConfig(log, &cfg, md, data); err != nil {
 			return cfg, err
 		}
 
-		log.Info("detected preset", "preset", DevnetPresetId, "name", preset.Name)
-		cfg.Chain = preset.ChainConfig
-	} else if cfg.Chain.Preset != 0 {
-		preset, ok := Presets[cfg.Chain.Preset]
-		if !ok {
-			return cfg, fmt.Errorf("unknown preset: %d", cfg.Chain.Preset)
-		}
+		log.Info("loaded chains config", "chains", len(cfg.Chains))
+		return cfg, nil
+	}
 
-		log.Info("detected preset", "preset", cfg.Chain.Preset, "name", preset.Name)
-		cfg.Chain = preset.ChainConfig
+	cfg.Chain, err = loadPreset(log, cfg.Chain)
+	if err != nil {
+		return cfg, err
 	}
 
 	// Setup L2Contracts from predeploys
@@ -196,24 +224,110 @@ func LoadConfig(log log.Logger, path string) (Config, error) {
 		}
 	}
 
-	// Defaults for any unset options
+	if cfg.Chain.L2RPC == "" {
+		cfg.Chain.L2RPC = cfg.RPCs.L2RPC
+	}
+
+	applyChainDefaults(&cfg.Chain)
 
-	if cfg.Chain.L1PollingInterval == 0 {
-		cfg.Chain.L1PollingInterval = defaultLoopInterval
+	log.Info("loaded chain config", "config", cfg.Chain)
+	return cfg, nil
+}
+
+// loadChainsConfig resolves the presets of every configured `[[chains]]` entry, with
+// precedence given to the values set in the config file
+func loadChainsConfig(log log.Logger, cfg *Config, md toml.MetaData, data []byte) error {
+	if md.IsDefined("chain") {
+		return errors.New("`chain` and `chains` cannot both be configured")
+	}
+	if cfg.RPCs.L2RPC != "" {
+		return errors.New("`rpcs.l2-rpc` cannot be configured with `chains`, configure the `l2-rpc` of each chain")
 	}
 
-	if cfg.Chain.L2PollingInterval == 0 {
-		cfg.Chain.L2PollingInterval = defaultLoopInterval
+	// Each entry is re-decoded over its preset
+	var file struct {
+		Chains []toml.Primitive `toml:"chains"`
+	}
+	fileMd, err := toml.Decode(string(data), &file)
+	if err != nil {
+		log.Error("failed to decode config file", "err", err)
+		return err
 	}
 
-	if cfg.Chain.L1HeaderBufferSize == 0 {
-		cfg.Chain.L1HeaderBufferSize = defaultHeaderBufferSize
+	chainIDs := make(map[uint64]bool, len(cfg.Chains))
+	for i := range cfg.Chains {
+		chain, err := loadPreset(log, cfg.Chains[i])
+		if err != nil {
+			return err
+		}
+
+		chain.L2Contracts = L2ContractsFromPredeploys()
+		if chain.Preset > 0 {
+			if err := fileMd.PrimitiveDecode(file.Chains[i], &chain); err != nil {
+				log.Error("failed to decode config file", "err", err)
+				return err
+			}
+		}
+
+		applyChainDefaults(&chain)
+		if chain.L2ChainID == 0 {
+			return fmt.Errorf("chains[%d]: l2-chain-id must be configured", i)
+		} else if chainIDs[chain.L2ChainID] {
+			return fmt.Errorf("chains[%d]: l2 chain %d configured more than once", i, chain.L2ChainID)
+		} else if chain.L2RPC == "" {
+			return fmt.Errorf("chains[%d]: l2-rpc must be configured", i)
+		}
+		chainIDs[chain.L2ChainID] = true
+
+		cfg.Chains[i] = chain
+		log.Info("loaded chain config", "l2_chain_id", chain.L2ChainID, "config", chain)
+	}
+
+	return nil
+}
+
+// loadPreset returns the preset configuration of the chain, if any
+func loadPreset(log log.Logger, chain ChainConfig) (ChainConfig, error) {
+	if chain.Preset == DevnetPresetId {
+		preset, err := DevnetPreset()
+		if err != nil {
+			return chain, err
+		}
+
+		log.Info("detected preset", "preset", DevnetPresetId, "name", preset.Name)
+		return preset.ChainConfig, nil
+	} else if chain.Preset != 0 {
+		preset, ok := Presets[chain.Preset]
+		if !ok {
+			return chain, fmt.Errorf("unknown preset: %d", chain.Preset)
+		}
+
+		log.Info("detected preset", "preset", chain.Preset, "name", preset.Name)
+		return preset.ChainConfig, nil
 	}
 
-	if cfg.Chain.L2HeaderBufferSize == 0 {
-		cfg.Chain.L2HeaderBufferSize = defaultHeaderBufferSize
+	return chain, nil
+}
+
+// applyChainDefaults sets the defaults for any unset options
+func applyChainDefaults(chain *ChainConfig) {
+	if chain.L2ChainID == 0 {
+		chain.L2ChainID = uint64(chain.Preset)
 	}
 
-	log.Info("loaded chain config", "config", cfg.Chain)
-	return cfg, nil
+	if chain.L1PollingInterval == 0 {
+		chain.L1PollingInterval = defaultLoopInterval
+	}
+
+	if chain.L2PollingInterval == 0 {
+		chain.L2PollingInterval = defaultLoopInterval
+	}
+
+	if chain.L1HeaderBufferSize == 0 {
+		chain.L1HeaderBufferSize = defaultHeaderBufferSize
+	}
+
+	if chain.L2HeaderBufferSize == 0 {
+		chain.L2HeaderBufferSize = defaultHeaderBufferSize
+	}
 }
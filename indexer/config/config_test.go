@@ -303,3 +303,139 @@ func TestThrowsOnUnknownKeys(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "unknown fields in config file")
 }
+
+func TestLoadConfigMultiChain(t *testing.T) {
+	tmpfile, err := os.CreateTemp("", "test_multi_chain.toml")
+	require.NoError(t, err)
+	defer os.Remove(tmpfile.Name())
+	defer tmpfile.Close()
+
+	testData := `
+		[[chains]]
+		preset = 10
+		l2-rpc = "https://op.example.com"
+		l1-confirmation-depth = 50
+
+		[[chains]]
+		preset = 8453
+		l2-rpc = "https://base.example.com"
+
+		[chains.l1-contracts]
+		optimism-portal = "0x0000000000000000000000000000000000000001"
+
+		[rpcs]
+		l1-rpc = "https://l1.example.com"
+
+		[db]
+		name = "indexer"
+	`
+
+	data := []byte(testData)
+	err = os.WriteFile(tmpfile.Name(), data, 0644)
+	require.NoError(t, err)
+
+	err = tmpfile.Close()
+	require.NoError(t, err)
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	conf, err := LoadConfig(logger, tmpfile.Name())
+	require.NoError(t, err)
+	require.True(t, conf.MultiChain())
+	require.Len(t, conf.Chains, 2)
+
+	// presets are loaded together, with precedence given to the config file
+	op, base := conf.Chains[0], conf.Chains[1]
+	require.Equal(t, uint64(10), op.L2ChainID)
+	require.Equal(t, "https://op.example.com", op.L2RPC)
+	require.Equal(t, uint(50), op.L1ConfirmationDepth)
+	require.Equal(t, Presets[10].ChainConfig.L1Contracts, op.L1Contracts)
+	require.Equal(t, L2ContractsFromPredeploys(), op.L2Contracts)
+
+	require.Equal(t, uint64(8453), base.L2ChainID)
+	require.Equal(t, "https://base.example.com", base.L2RPC)
+	require.Equal(t, uint(0), base.L1ConfirmationDepth)
+	require.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000001"), base.L1Contracts.OptimismPortalProxy)
+	require.Equal(t, Presets[8453].ChainConfig.L1Contracts.L1StandardBridgeProxy, base.L1Contracts.L1StandardBridgeProxy)
+	require.Equal(t, uint(defaultLoopInterval), base.L2PollingInterval)
+
+	// the state of each chain is isolated in its own schema
+	require.Equal(t, "chain_10", conf.DB.ForChain(op.L2ChainID).Schema)
+	require.Equal(t, "", conf.DB.Schema)
+}
+
+func TestLoadConfigMultiChainErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "single chain configured",
+			data: `
+				[chain]
+				preset = 10
+
+				[[chains]]
+				preset = 8453
+				l2-rpc = "https://base.example.com"
+			`,
+			err: "`chain` and `chains` cannot both be configured",
+		},
+		{
+			name: "shared l2 rpc",
+			data: `
+				[[chains]]
+				preset = 8453
+
+				[rpcs]
+				l2-rpc = "https://base.example.com"
+			`,
+			err: "`rpcs.l2-rpc` cannot be configured with `chains`",
+		},
+		{
+			name: "missing l2 rpc",
+			data: `
+				[[chains]]
+				preset = 8453
+			`,
+			err: "chains[0]: l2-rpc must be configured",
+		},
+		{
+			name: "missing chain id",
+			data: `
+				[[chains]]
+				l2-rpc = "https://l2.example.com"
+			`,
+			err: "chains[0]: l2-chain-id must be configured",
+		},
+		{
+			name: "duplicate chain",
+			data: `
+				[[chains]]
+				preset = 10
+				l2-rpc = "https://op.example.com"
+
+				[[chains]]
+				l2-chain-id = 10
+				l2-rpc = "https://op.example.com"
+			`,
+			err: "chains[1]: l2 chain 10 configured more than once",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpfile, err := os.CreateTemp("", "test_multi_chain.toml")
+			require.NoError(t, err)
+			defer os.Remove(tmpfile.Name())
+
+			_, err = tmpfile.WriteString(test.data)
+			require.NoError(t, err)
+			require.NoError(t, tmpfile.Close())
+
+			logger := testlog.Logger(t, log.LvlInfo)
+			_, err = LoadConfig(logger, tmpfile.Name())
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
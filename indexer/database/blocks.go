@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -49,16 +50,29 @@ type L2BlockHeader struct {
 	BlockHeader `gorm:"embedded"`
 }
 
+type OutputProposal struct {
+	L2OutputIndex *big.Int    `gorm:"primaryKey;serializer:u256"`
+	OutputRoot    common.Hash `gorm:"serializer:bytes"`
+	L2BlockNumber *big.Int    `gorm:"serializer:u256"`
+
+	OutputProposedGUID uuid.UUID
+}
+
 type BlocksView interface {
 	L1BlockHeader(common.Hash) (*L1BlockHeader, error)
 	L1BlockHeaderWithFilter(BlockHeader) (*L1BlockHeader, error)
 	L1BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*L1BlockHeader, error)
 	L1LatestBlockHeader() (*L1BlockHeader, error)
+	L1BlockHeadersInRange(fromHeight, toHeight *big.Int, limit int) ([]L1BlockHeader, error)
 
 	L2BlockHeader(common.Hash) (*L2BlockHeader, error)
 	L2BlockHeaderWithFilter(BlockHeader) (*L2BlockHeader, error)
 	L2BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB) (*L2BlockHeader, error)
 	L2LatestBlockHeader() (*L2BlockHeader, error)
+	L2BlockHeadersInRange(fromHeight, toHeight *big.Int, limit int) ([]L2BlockHeader, error)
+
+	OutputProposal(index *big.Int) (*OutputProposal, error)
+	LatestOutputProposal() (*OutputProposal, error)
 }
 
 type BlocksDB interface {
@@ -66,6 +80,9 @@ type BlocksDB interface {
 
 	StoreL1BlockHeaders([]L1BlockHeader) error
 	StoreL2BlockHeaders([]L2BlockHeader) error
+
+	StoreOutputProposals([]OutputProposal) error
+	DeleteOutputProposalsFrom(index *big.Int) error
 }
 
 /**
@@ -128,6 +145,17 @@ func (db *blocksDB) L1LatestBlockHeader() (*L1BlockHeader, error) {
 	return &l1Header, nil
 }
 
+// L1BlockHeadersInRange returns up to `limit` indexed headers within the inclusive range in ascending order
+func (db *blocksDB) L1BlockHeadersInRange(fromHeight, toHeight *big.Int, limit int) ([]L1BlockHeader, error) {
+	var l1Headers []L1BlockHeader
+	result := db.gorm.Where("number >= ? AND number <= ?", fromHeight, toHeight).Order("number ASC").Limit(limit).Find(&l1Headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return l1Headers, nil
+}
+
 // L2
 
 func (db *blocksDB) StoreL2BlockHeaders(headers []L2BlockHeader) error {
@@ -173,3 +201,65 @@ func (db *blocksDB) L2LatestBlockHeader() (*L2BlockHeader, error) {
 
 	return &l2Header, nil
 }
+
+// L2BlockHeadersInRange returns up to `limit` indexed headers within the inclusive range in ascending order
+func (db *blocksDB) L2BlockHeadersInRange(fromHeight, toHeight *big.Int, limit int) ([]L2BlockHeader, error) {
+	var l2Headers []L2BlockHeader
+	result := db.gorm.Where("number >= ? AND number <= ?", fromHeight, toHeight).Order("number ASC").Limit(limit).Find(&l2Headers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return l2Headers, nil
+}
+
+// Output Proposals
+
+// StoreOutputProposals persists the supplied proposals. A proposal for an index that was
+// previously deleted by the L2OutputOracle challenger replaces the stored proposal.
+func (db *blocksDB) StoreOutputProposals(outputs []OutputProposal) error {
+	replaced := db.gorm.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "l2_output_index"}},
+		DoUpdates: clause.AssignmentColumns([]string{"output_root", "l2_block_number", "output_proposed_guid"}),
+	})
+
+	result := replaced.Create(&outputs)
+	return result.Error
+}
+
+// DeleteOutputProposalsFrom removes all proposals with an index greater than or equal to the supplied
+// index, mirroring `OutputsDeleted` emitted by the L2OutputOracle
+func (db *blocksDB) DeleteOutputProposalsFrom(index *big.Int) error {
+	result := db.gorm.Where("l2_output_index >= ?", index.String()).Delete(&OutputProposal{})
+	if result.Error == nil && result.RowsAffected > 0 {
+		db.log.Warn("deleted output proposals", "from_index", index, "size", result.RowsAffected)
+	}
+
+	return result.Error
+}
+
+func (db *blocksDB) OutputProposal(index *big.Int) (*OutputProposal, error) {
+	var outputProposal OutputProposal
+	result := db.gorm.Where(&OutputProposal{L2OutputIndex: index}).Take(&outputProposal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &outputProposal, nil
+}
+
+func (db *blocksDB) LatestOutputProposal() (*OutputProposal, error) {
+	var outputProposal OutputProposal
+	result := db.gorm.Order("l2_output_index DESC").Take(&outputProposal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &outputProposal, nil
+}
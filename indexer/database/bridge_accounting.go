@@ -0,0 +1,219 @@
+package database
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+/**
+ * Types
+ */
+
+// BridgeTokenAccounting is the indexed StandardBridge activity of an L1 & L2 token pair, bounded by
+// the L1 & L2 heights the activity is accounted up to.
+type BridgeTokenAccounting struct {
+	L1TokenAddress common.Address `gorm:"serializer:bytes"`
+	L2TokenAddress common.Address `gorm:"serializer:bytes"`
+
+	// Initiated on L1 (escrowed), of which relayed on L2 (minted)
+	Deposited       *big.Int `gorm:"serializer:u256"`
+	DepositsRelayed *big.Int `gorm:"serializer:u256"`
+
+	// Initiated on L2 (burned), of which finalized on L1 (released from escrow)
+	Withdrawn            *big.Int `gorm:"serializer:u256"`
+	WithdrawalsFinalized *big.Int `gorm:"serializer:u256"`
+
+	// Messages that failed to relay, which are stuck until successfully replayed
+	StuckDeposits    int
+	StuckWithdrawals int
+}
+
+// BridgeTokenReport reconciles the L1 escrow balance of a token pair with its L2 supply at the reported heights.
+// The escrow should equal the L2 supply along with the value in flight between the two chains.
+type BridgeTokenReport struct {
+	L1TokenAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+	L2TokenAddress common.Address `gorm:"primaryKey;serializer:bytes"`
+
+	L1BlockNumber *big.Int `gorm:"serializer:u256"`
+	L2BlockNumber *big.Int `gorm:"serializer:u256"`
+
+	L1EscrowBalance     *big.Int `gorm:"serializer:u256"`
+	L2TotalSupply       *big.Int `gorm:"serializer:u256"`
+	DepositsInFlight    *big.Int `gorm:"serializer:u256"`
+	WithdrawalsInFlight *big.Int `gorm:"serializer:u256"`
+
+	StuckDeposits    int
+	StuckWithdrawals int
+	Timestamp        uint64
+}
+
+// Discrepancy is the value escrowed on L1 that isn't accounted for by the L2 supply and the value in flight.
+// A negative discrepancy indicates L2 supply that isn't backed by the escrow.
+func (r BridgeTokenReport) Discrepancy() *big.Int {
+	accounted := new(big.Int).Add(r.L2TotalSupply, r.DepositsInFlight)
+	accounted.Add(accounted, r.WithdrawalsInFlight)
+	return accounted.Sub(r.L1EscrowBalance, accounted)
+}
+
+func (r BridgeTokenReport) Mismatch() bool {
+	return r.Discrepancy().Sign() != 0
+}
+
+type BridgeAccountingView interface {
+	BridgeTokenAccounting(l1Height, l2Height *big.Int) ([]BridgeTokenAccounting, error)
+	BridgeTokenReports() ([]BridgeTokenReport, error)
+
+	StuckL1BridgeMessages(limit int) ([]L1BridgeMessage, error)
+	StuckL2BridgeMessages(limit int) ([]L2BridgeMessage, error)
+}
+
+type BridgeAccountingDB interface {
+	BridgeAccountingView
+
+	StoreBridgeTokenReports([]BridgeTokenReport) error
+}
+
+/**
+ * Implementation
+ */
+
+type bridgeAccountingDB struct {
+	log  log.Logger
+	gorm *gorm.DB
+}
+
+func newBridgeAccountingDB(log log.Logger, db *gorm.DB) BridgeAccountingDB {
+	return &bridgeAccountingDB{log: log.New("table", "bridge_accounting"), gorm: db}
+}
+
+// BridgeTokenAccounting aggregates the StandardBridge transfers of each ERC20 token pair. Deposits are accounted
+// when initiated by the supplied L1 height and relayed by the L2 height, and withdrawals vice versa. ETH is not
+// escrowed by the StandardBridge and is excluded.
+func (db *bridgeAccountingDB) BridgeTokenAccounting(l1Height, l2Height *big.Int) ([]BridgeTokenAccounting, error) {
+	// addresses are stored lowercased
+	ethAddressString := strings.ToLower(ETHTokenPair.LocalTokenAddress.String())
+
+	var deposits []BridgeTokenAccounting
+	depositsQuery := db.gorm.Table("l1_bridge_deposits").Select(`
+l1_bridge_deposits.local_token_address AS l1_token_address, l1_bridge_deposits.remote_token_address AS l2_token_address,
+SUM(l1_bridge_deposits.amount) AS deposited,
+COALESCE(SUM(l1_bridge_deposits.amount) FILTER (WHERE l2_block_headers.number IS NOT NULL), 0) AS deposits_relayed,
+COUNT(*) FILTER (WHERE l2_block_headers.number IS NULL AND l1_bridge_messages.failed_relayed_message_event_guid IS NOT NULL) AS stuck_deposits`)
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = l1_bridge_deposits.transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_bridge_messages ON l1_bridge_messages.message_hash = l1_bridge_deposits.cross_domain_message_hash")
+	depositsQuery = depositsQuery.Joins("LEFT JOIN l2_contract_events ON l2_contract_events.guid = l1_bridge_messages.relayed_message_event_guid")
+	depositsQuery = depositsQuery.Joins("LEFT JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash AND l2_block_headers.number <= ?", l2Height.String())
+	depositsQuery = depositsQuery.Where("l1_block_headers.number <= ? AND l1_bridge_deposits.local_token_address != ?", l1Height.String(), ethAddressString)
+	result := depositsQuery.Group("l1_bridge_deposits.local_token_address, l1_bridge_deposits.remote_token_address").Scan(&deposits)
+	if result.Error != nil {
+		return nil, fmt.Errorf("unable to aggregate deposits: %w", result.Error)
+	}
+
+	var withdrawals []BridgeTokenAccounting
+	withdrawalsQuery := db.gorm.Table("l2_bridge_withdrawals").Select(`
+l2_bridge_withdrawals.remote_token_address AS l1_token_address, l2_bridge_withdrawals.local_token_address AS l2_token_address,
+SUM(l2_bridge_withdrawals.amount) AS withdrawn,
+COALESCE(SUM(l2_bridge_withdrawals.amount) FILTER (WHERE l1_block_headers.number IS NOT NULL), 0) AS withdrawals_finalized,
+COUNT(*) FILTER (WHERE l1_block_headers.number IS NULL AND l2_bridge_messages.failed_relayed_message_event_guid IS NOT NULL) AS stuck_withdrawals`)
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON l2_transaction_withdrawals.withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_bridge_messages ON l2_bridge_messages.message_hash = l2_bridge_withdrawals.cross_domain_message_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events ON l1_contract_events.guid = l2_bridge_messages.relayed_message_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash AND l1_block_headers.number <= ?", l1Height.String())
+	withdrawalsQuery = withdrawalsQuery.Where("l2_block_headers.number <= ? AND l2_bridge_withdrawals.local_token_address != ?", l2Height.String(), ethAddressString)
+	result = withdrawalsQuery.Group("l2_bridge_withdrawals.local_token_address, l2_bridge_withdrawals.remote_token_address").Scan(&withdrawals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("unable to aggregate withdrawals: %w", result.Error)
+	}
+
+	return mergeBridgeTokenAccounting(deposits, withdrawals), nil
+}
+
+// mergeBridgeTokenAccounting combines the aggregated deposits & withdrawals of each token pair
+func mergeBridgeTokenAccounting(deposits, withdrawals []BridgeTokenAccounting) []BridgeTokenAccounting {
+	type tokenPair struct{ l1, l2 common.Address }
+	pairs := make(map[tokenPair]int, len(deposits))
+	accounting := make([]BridgeTokenAccounting, 0, len(deposits)+len(withdrawals))
+	for _, deposit := range deposits {
+		deposit.Withdrawn, deposit.WithdrawalsFinalized = new(big.Int), new(big.Int)
+		pairs[tokenPair{deposit.L1TokenAddress, deposit.L2TokenAddress}] = len(accounting)
+		accounting = append(accounting, deposit)
+	}
+
+	for _, withdrawal := range withdrawals {
+		if i, ok := pairs[tokenPair{withdrawal.L1TokenAddress, withdrawal.L2TokenAddress}]; ok {
+			accounting[i].Withdrawn = withdrawal.Withdrawn
+			accounting[i].WithdrawalsFinalized = withdrawal.WithdrawalsFinalized
+			accounting[i].StuckWithdrawals = withdrawal.StuckWithdrawals
+			continue
+		}
+
+		withdrawal.Deposited, withdrawal.DepositsRelayed = new(big.Int), new(big.Int)
+		accounting = append(accounting, withdrawal)
+	}
+
+	return accounting
+}
+
+func (db *bridgeAccountingDB) StoreBridgeTokenReports(reports []BridgeTokenReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	// Only the latest report of each token pair is kept
+	upsert := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "l1_token_address"}, {Name: "l2_token_address"}}, UpdateAll: true})
+	result := upsert.Create(&reports)
+	return result.Error
+}
+
+func (db *bridgeAccountingDB) BridgeTokenReports() ([]BridgeTokenReport, error) {
+	var reports []BridgeTokenReport
+	result := db.gorm.Order("l1_token_address ASC, l2_token_address ASC").Find(&reports)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return reports, nil
+}
+
+// StuckL1BridgeMessages retrieves the most recent messages sent from L1 that failed to relay on L2 and have yet to be replayed
+func (db *bridgeAccountingDB) StuckL1BridgeMessages(limit int) ([]L1BridgeMessage, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var messages []L1BridgeMessage
+	query := db.gorm.Where("failed_relayed_message_event_guid IS NOT NULL AND relayed_message_event_guid IS NULL")
+	result := query.Order("timestamp DESC").Limit(limit).Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return messages, nil
+}
+
+// StuckL2BridgeMessages retrieves the most recent messages sent from L2 that failed to relay on L1 and have yet to be replayed
+func (db *bridgeAccountingDB) StuckL2BridgeMessages(limit int) ([]L2BridgeMessage, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	var messages []L2BridgeMessage
+	query := db.gorm.Where("failed_relayed_message_event_guid IS NOT NULL AND relayed_message_event_guid IS NULL")
+	result := query.Order("timestamp DESC").Limit(limit).Find(&messages)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,229 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+/**
+ * Types
+ */
+
+type ERC721BridgeTransfer struct {
+	CrossDomainMessageHash common.Hash `gorm:"serializer:bytes"`
+
+	FromAddress common.Address `gorm:"serializer:bytes"`
+	ToAddress   common.Address `gorm:"serializer:bytes"`
+	TokenPair   TokenPair      `gorm:"embedded"`
+	TokenID     *big.Int       `gorm:"serializer:u256"`
+	Data        Bytes          `gorm:"serializer:bytes"`
+	Timestamp   uint64
+}
+
+type L1ERC721BridgeDeposit struct {
+	ERC721BridgeTransfer  `gorm:"embedded"`
+	TransactionSourceHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+}
+
+type L1ERC721BridgeDepositWithTransactionHashes struct {
+	L1ERC721BridgeDeposit L1ERC721BridgeDeposit `gorm:"embedded"`
+
+	L1BlockHash       common.Hash `gorm:"serializer:bytes"`
+	L1TransactionHash common.Hash `gorm:"serializer:bytes"`
+	L2TransactionHash common.Hash `gorm:"serializer:bytes"`
+}
+
+type L1ERC721BridgeDepositsResponse struct {
+	Deposits    []L1ERC721BridgeDepositWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+type L2ERC721BridgeWithdrawal struct {
+	ERC721BridgeTransfer      `gorm:"embedded"`
+	TransactionWithdrawalHash common.Hash `gorm:"primaryKey;serializer:bytes"`
+}
+
+type L2ERC721BridgeWithdrawalWithTransactionHashes struct {
+	L2ERC721BridgeWithdrawal L2ERC721BridgeWithdrawal `gorm:"embedded"`
+	L2TransactionHash        common.Hash              `gorm:"serializer:bytes"`
+	L2BlockHash              common.Hash              `gorm:"serializer:bytes"`
+
+	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
+	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+}
+
+type L2ERC721BridgeWithdrawalsResponse struct {
+	Withdrawals []L2ERC721BridgeWithdrawalWithTransactionHashes
+	Cursor      string
+	HasNextPage bool
+}
+
+/**
+ * NFTs Bridged (Deposited) from L1
+ */
+
+func (db *bridgeTransfersDB) StoreL1ERC721BridgeDeposits(deposits []L1ERC721BridgeDeposit) error {
+	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "transaction_source_hash"}}, DoNothing: true})
+	result := deduped.Create(&deposits)
+	if result.Error == nil && int(result.RowsAffected) < len(deposits) {
+		db.log.Warn("ignored L1 erc721 bridge transfer duplicates", "duplicates", len(deposits)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+func (db *bridgeTransfersDB) L1ERC721BridgeDeposit(txSourceHash common.Hash) (*L1ERC721BridgeDeposit, error) {
+	var deposit L1ERC721BridgeDeposit
+	result := db.gorm.Where(&L1ERC721BridgeDeposit{TransactionSourceHash: txSourceHash}).Take(&deposit)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &deposit, nil
+}
+
+// L1ERC721BridgeDepositsByAddress retrieves a list of NFT deposits initiated by the specified address, coupled with the L1/L2
+// transaction hashes that complete the bridge transaction. If set, only deposits of the supplied collection are returned, which
+// may be either the L1 or the L2 token address.
+func (db *bridgeTransfersDB) L1ERC721BridgeDepositsByAddress(address common.Address, collection *common.Address, cursor string, limit int) (*L1ERC721BridgeDepositsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	depositsQuery := db.gorm.Model(&L1ERC721BridgeDeposit{})
+	depositsQuery = depositsQuery.Where(&ERC721BridgeTransfer{FromAddress: address})
+	if collection != nil {
+		depositsQuery = depositsQuery.Where("local_token_address = ? OR remote_token_address = ?", hexutil.Encode(collection[:]), hexutil.Encode(collection[:]))
+	}
+
+	if cursor != "" {
+		sourceHash := common.HexToHash(cursor)
+		var txDeposit L1TransactionDeposit
+		result := db.gorm.Model(&L1TransactionDeposit{}).Where(&L1TransactionDeposit{SourceHash: sourceHash}).Take(&txDeposit)
+		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unable to find transaction with supplied cursor source hash %s: %w", sourceHash, result.Error)
+		}
+		depositsQuery = depositsQuery.Where("l1_erc721_bridge_deposits.timestamp <= ?", txDeposit.Tx.Timestamp)
+	}
+
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	depositsQuery = depositsQuery.Select(`
+l1_erc721_bridge_deposits.from_address, l1_erc721_bridge_deposits.to_address, l1_erc721_bridge_deposits.token_id, l1_erc721_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_erc721_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	depositsQuery = depositsQuery.Order("timestamp DESC").Limit(limit + 1)
+
+	deposits := []L1ERC721BridgeDepositWithTransactionHashes{}
+	result := depositsQuery.Find(&deposits)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(deposits) > limit {
+		hasNextPage = true
+		nextCursor = deposits[limit].L1ERC721BridgeDeposit.TransactionSourceHash.String()
+		deposits = deposits[:limit]
+	}
+
+	response := &L1ERC721BridgeDepositsResponse{Deposits: deposits, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
+
+/**
+ * NFTs Bridged (Withdrawn) from L2
+ */
+
+func (db *bridgeTransfersDB) StoreL2ERC721BridgeWithdrawals(withdrawals []L2ERC721BridgeWithdrawal) error {
+	deduped := db.gorm.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "transaction_withdrawal_hash"}}, DoNothing: true})
+	result := deduped.Create(&withdrawals)
+	if result.Error == nil && int(result.RowsAffected) < len(withdrawals) {
+		db.log.Warn("ignored L2 erc721 bridge transfer duplicates", "duplicates", len(withdrawals)-int(result.RowsAffected))
+	}
+
+	return result.Error
+}
+
+func (db *bridgeTransfersDB) L2ERC721BridgeWithdrawal(txWithdrawalHash common.Hash) (*L2ERC721BridgeWithdrawal, error) {
+	var withdrawal L2ERC721BridgeWithdrawal
+	result := db.gorm.Where(&L2ERC721BridgeWithdrawal{TransactionWithdrawalHash: txWithdrawalHash}).Take(&withdrawal)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+// L2ERC721BridgeWithdrawalsByAddress retrieves a list of NFT withdrawals initiated by the specified address, coupled with the L1/L2
+// transaction hashes that complete the bridge transaction. If set, only withdrawals of the supplied collection are returned, which
+// may be either the L1 or the L2 token address.
+func (db *bridgeTransfersDB) L2ERC721BridgeWithdrawalsByAddress(address common.Address, collection *common.Address, cursor string, limit int) (*L2ERC721BridgeWithdrawalsResponse, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be greater than 0")
+	}
+
+	withdrawalsQuery := db.gorm.Model(&L2ERC721BridgeWithdrawal{})
+	withdrawalsQuery = withdrawalsQuery.Where(&ERC721BridgeTransfer{FromAddress: address})
+	if collection != nil {
+		withdrawalsQuery = withdrawalsQuery.Where("local_token_address = ? OR remote_token_address = ?", hexutil.Encode(collection[:]), hexutil.Encode(collection[:]))
+	}
+
+	if cursor != "" {
+		withdrawalHash := common.HexToHash(cursor)
+		var txWithdrawal L2TransactionWithdrawal
+		result := db.gorm.Model(&L2TransactionWithdrawal{}).Where(&L2TransactionWithdrawal{WithdrawalHash: withdrawalHash}).Take(&txWithdrawal)
+		if result.Error != nil || errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("unable to find transaction with supplied cursor withdrawal hash %s: %w", withdrawalHash, result.Error)
+		}
+		withdrawalsQuery = withdrawalsQuery.Where("l2_erc721_bridge_withdrawals.timestamp <= ?", txWithdrawal.Tx.Timestamp)
+	}
+
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_erc721_bridge_withdrawals.transaction_withdrawal_hash")
+	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	withdrawalsQuery = withdrawalsQuery.Select(`
+l2_erc721_bridge_withdrawals.from_address, l2_erc721_bridge_withdrawals.to_address, l2_erc721_bridge_withdrawals.token_id, l2_erc721_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+l2_erc721_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+	withdrawalsQuery = withdrawalsQuery.Order("timestamp DESC").Limit(limit + 1)
+
+	withdrawals := []L2ERC721BridgeWithdrawalWithTransactionHashes{}
+	result := withdrawalsQuery.Find(&withdrawals)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	nextCursor := ""
+	hasNextPage := false
+	if len(withdrawals) > limit {
+		hasNextPage = true
+		nextCursor = withdrawals[limit].L2ERC721BridgeWithdrawal.TransactionWithdrawalHash.String()
+		withdrawals = withdrawals[:limit]
+	}
+
+	response := &L2ERC721BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
+	return response, nil
+}
@@ -25,6 +25,9 @@ type BridgeMessage struct {
 	SentMessageEventGUID    uuid.UUID
 	RelayedMessageEventGUID *uuid.UUID
 
+	// The latest failed relay. The message is stuck until successfully replayed
+	FailedRelayedMessageEventGUID *uuid.UUID
+
 	Tx       Transaction `gorm:"embedded"`
 	GasLimit *big.Int    `gorm:"serializer:u256"`
 }
@@ -57,9 +60,11 @@ type BridgeMessagesDB interface {
 
 	StoreL1BridgeMessages([]L1BridgeMessage) error
 	MarkRelayedL1BridgeMessage(common.Hash, uuid.UUID) error
+	MarkFailedRelayedL1BridgeMessage(common.Hash, uuid.UUID) error
 
 	StoreL2BridgeMessages([]L2BridgeMessage) error
 	MarkRelayedL2BridgeMessage(common.Hash, uuid.UUID) error
+	MarkFailedRelayedL2BridgeMessage(common.Hash, uuid.UUID) error
 
 	StoreL2BridgeMessageV1MessageHashes([]L2BridgeMessageVersionedMessageHash) error
 }
@@ -127,6 +132,19 @@ func (db bridgeMessagesDB) MarkRelayedL1BridgeMessage(messageHash common.Hash, r
 	return result.Error
 }
 
+func (db bridgeMessagesDB) MarkFailedRelayedL1BridgeMessage(messageHash common.Hash, failedRelayEvent uuid.UUID) error {
+	message, err := db.L1BridgeMessage(messageHash)
+	if err != nil {
+		return err
+	} else if message == nil {
+		return fmt.Errorf("L1BridgeMessage %s not found", messageHash)
+	}
+
+	message.FailedRelayedMessageEventGUID = &failedRelayEvent
+	result := db.gorm.Save(message)
+	return result.Error
+}
+
 /**
  * Arbitrary Messages Sent from L2
  */
@@ -201,3 +219,16 @@ func (db bridgeMessagesDB) MarkRelayedL2BridgeMessage(messageHash common.Hash, r
 	result := db.gorm.Save(message)
 	return result.Error
 }
+
+func (db bridgeMessagesDB) MarkFailedRelayedL2BridgeMessage(messageHash common.Hash, failedRelayEvent uuid.UUID) error {
+	message, err := db.L2BridgeMessage(messageHash)
+	if err != nil {
+		return err
+	} else if message == nil {
+		return fmt.Errorf("L2BridgeMessage %s not found", messageHash)
+	}
+
+	message.FailedRelayedMessageEventGUID = &failedRelayEvent
+	result := db.gorm.Save(message)
+	return result.Error
+}
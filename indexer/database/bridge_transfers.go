@@ -3,6 +3,7 @@ package database
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -57,6 +58,15 @@ type L2BridgeWithdrawalWithTransactionHashes struct {
 
 	ProvenL1TransactionHash    common.Hash `gorm:"serializer:bytes"`
 	FinalizedL1TransactionHash common.Hash `gorm:"serializer:bytes"`
+
+	// Multistep (bedrock) lifecycle timestamps. Zero when the step has not been reached
+	ProvenL1Timestamp    uint64
+	FinalizedL1Timestamp uint64
+
+	// The first output proposal covering the L2 block the withdrawal was initiated in, which the
+	// withdrawal can be proven against. Unset until such an output has been proposed
+	L2OutputIndex           *big.Int `gorm:"serializer:u256"`
+	OutputProposedTimestamp uint64
 }
 
 type BridgeTransfersView interface {
@@ -64,11 +74,20 @@ type BridgeTransfersView interface {
 	L1BridgeDepositSum() (float64, error)
 	L1BridgeDepositWithFilter(BridgeTransfer) (*L1BridgeDeposit, error)
 	L1BridgeDepositsByAddress(common.Address, string, int) (*L1BridgeDepositsResponse, error)
+	L1BridgeDepositWithTransactionHashes(common.Hash) (*L1BridgeDepositWithTransactionHashes, error)
 
 	L2BridgeWithdrawal(common.Hash) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalSum() (float64, error)
 	L2BridgeWithdrawalWithFilter(BridgeTransfer) (*L2BridgeWithdrawal, error)
 	L2BridgeWithdrawalsByAddress(common.Address, string, int) (*L2BridgeWithdrawalsResponse, error)
+	L2BridgeWithdrawalWithTransactionHashes(common.Hash) (*L2BridgeWithdrawalWithTransactionHashes, error)
+	L2BridgeWithdrawalsProvenByAddress(common.Address, uint64, uint64) ([]L2BridgeWithdrawalWithTransactionHashes, error)
+
+	L1ERC721BridgeDeposit(common.Hash) (*L1ERC721BridgeDeposit, error)
+	L1ERC721BridgeDepositsByAddress(common.Address, *common.Address, string, int) (*L1ERC721BridgeDepositsResponse, error)
+
+	L2ERC721BridgeWithdrawal(common.Hash) (*L2ERC721BridgeWithdrawal, error)
+	L2ERC721BridgeWithdrawalsByAddress(common.Address, *common.Address, string, int) (*L2ERC721BridgeWithdrawalsResponse, error)
 }
 
 type BridgeTransfersDB interface {
@@ -76,6 +95,9 @@ type BridgeTransfersDB interface {
 
 	StoreL1BridgeDeposits([]L1BridgeDeposit) error
 	StoreL2BridgeWithdrawals([]L2BridgeWithdrawal) error
+
+	StoreL1ERC721BridgeDeposits([]L1ERC721BridgeDeposit) error
+	StoreL2ERC721BridgeWithdrawals([]L2ERC721BridgeWithdrawal) error
 }
 
 /**
@@ -167,29 +189,16 @@ func (db *bridgeTransfersDB) L1BridgeDepositsByAddress(address common.Address, c
 		cursorClause = fmt.Sprintf("l1_transaction_deposits.timestamp <= %d", txDeposit.Tx.Timestamp)
 	}
 
-	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
-
 	// Coalesce l1 transaction deposits that are simply ETH sends
-	ethTransactionDeposits := db.gorm.Model(&L1TransactionDeposit{})
+	ethTransactionDeposits := db.l1TransactionDepositsQuery()
 	ethTransactionDeposits = ethTransactionDeposits.Where(&Transaction{FromAddress: address}).Where("amount > 0")
-	ethTransactionDeposits = ethTransactionDeposits.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = initiated_l1_event_guid")
-	ethTransactionDeposits = ethTransactionDeposits.Select(`
-from_address, to_address, amount, data, source_hash AS transaction_source_hash,
-l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
-l1_transaction_deposits.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
 	ethTransactionDeposits = ethTransactionDeposits.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
 		ethTransactionDeposits = ethTransactionDeposits.Where(cursorClause)
 	}
 
-	depositsQuery := db.gorm.Model(&L1BridgeDeposit{})
+	depositsQuery := db.l1BridgeDepositsQuery()
 	depositsQuery = depositsQuery.Where(&Transaction{FromAddress: address})
-	depositsQuery = depositsQuery.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
-	depositsQuery = depositsQuery.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
-	depositsQuery = depositsQuery.Select(`
-l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
-l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
-l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
 	depositsQuery = depositsQuery.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
 		depositsQuery = depositsQuery.Where(cursorClause)
@@ -219,6 +228,49 @@ l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, re
 	return response, nil
 }
 
+// L1BridgeDepositWithTransactionHashes retrieves a single deposit by its transaction source hash, coupled with the
+// L1/L2 transaction hashes surfaced by `L1BridgeDepositsByAddress`. Deposits that did not go through the
+// StandardBridge are returned as ETH deposits.
+func (db *bridgeTransfersDB) L1BridgeDepositWithTransactionHashes(sourceHash common.Hash) (*L1BridgeDepositWithTransactionHashes, error) {
+	var deposit L1BridgeDepositWithTransactionHashes
+	result := db.l1BridgeDepositsQuery().Where("l1_transaction_deposits.source_hash = ?", sourceHash.String()).Take(&deposit)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		result = db.l1TransactionDepositsQuery().Where("l1_transaction_deposits.source_hash = ?", sourceHash.String()).Take(&deposit)
+	}
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &deposit, nil
+}
+
+// l1TransactionDepositsQuery selects transaction deposits as ETH deposits, joined with the initiating L1 event
+func (db *bridgeTransfersDB) l1TransactionDepositsQuery() *gorm.DB {
+	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
+
+	query := db.gorm.Model(&L1TransactionDeposit{})
+	query = query.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = initiated_l1_event_guid")
+	return query.Select(`
+from_address, to_address, amount, data, source_hash AS transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_transaction_deposits.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+}
+
+// l1BridgeDepositsQuery selects StandardBridge deposits, joined with the initiating L1 event
+func (db *bridgeTransfersDB) l1BridgeDepositsQuery() *gorm.DB {
+	query := db.gorm.Model(&L1BridgeDeposit{})
+	query = query.Joins("INNER JOIN l1_transaction_deposits ON l1_transaction_deposits.source_hash = transaction_source_hash")
+	query = query.Joins("INNER JOIN l1_contract_events ON l1_contract_events.guid = l1_transaction_deposits.initiated_l1_event_guid")
+	return query.Select(`
+l1_bridge_deposits.from_address, l1_bridge_deposits.to_address, l1_bridge_deposits.amount, l1_bridge_deposits.data, transaction_source_hash,
+l2_transaction_hash, l1_contract_events.transaction_hash AS l1_transaction_hash, l1_contract_events.block_hash as l1_block_hash,
+l1_bridge_deposits.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+}
+
 /**
  * Tokens Bridged (Withdrawn) from L2
  */
@@ -300,34 +352,16 @@ func (db *bridgeTransfersDB) L2BridgeWithdrawalsByAddress(address common.Address
 	//   - (A) ETH sends from L2 to L1
 	//   - (B) Bridge withdrawals from L2 to L1
 
-	// TODO join with l1_bridged_tokens and l2_bridged_tokens
-	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
-
 	// Coalesce l2 transaction withdrawals that are simply ETH sends
-	ethTransactionWithdrawals := db.gorm.Model(&L2TransactionWithdrawal{})
+	ethTransactionWithdrawals := db.l2TransactionWithdrawalsQuery()
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Where(&Transaction{FromAddress: address}).Where("amount > 0")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
-	ethTransactionWithdrawals = ethTransactionWithdrawals.Select(`
-from_address, to_address, amount, data, withdrawal_hash AS transaction_withdrawal_hash,
-l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
-l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
 	ethTransactionWithdrawals = ethTransactionWithdrawals.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
 		ethTransactionWithdrawals = ethTransactionWithdrawals.Where(cursorClause)
 	}
 
-	withdrawalsQuery := db.gorm.Model(&L2BridgeWithdrawal{})
+	withdrawalsQuery := db.l2BridgeWithdrawalsQuery()
 	withdrawalsQuery = withdrawalsQuery.Where(&Transaction{FromAddress: address})
-	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
-	withdrawalsQuery = withdrawalsQuery.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
-	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
-	withdrawalsQuery = withdrawalsQuery.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
-	withdrawalsQuery = withdrawalsQuery.Select(`
-l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
-l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
-l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
 	withdrawalsQuery = withdrawalsQuery.Order("timestamp DESC").Limit(limit + 1)
 	if cursorClause != "" {
 		withdrawalsQuery = withdrawalsQuery.Where(cursorClause)
@@ -359,3 +393,92 @@ l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address,
 	response := &L2BridgeWithdrawalsResponse{Withdrawals: withdrawals, Cursor: nextCursor, HasNextPage: hasNextPage}
 	return response, nil
 }
+
+// L2BridgeWithdrawalWithTransactionHashes retrieves a single withdrawal by its withdrawal hash, coupled with the
+// L1/L2 transaction hashes and lifecycle information surfaced by `L2BridgeWithdrawalsByAddress`. Withdrawals
+// that did not go through the StandardBridge are returned as ETH withdrawals.
+func (db *bridgeTransfersDB) L2BridgeWithdrawalWithTransactionHashes(withdrawalHash common.Hash) (*L2BridgeWithdrawalWithTransactionHashes, error) {
+	var withdrawal L2BridgeWithdrawalWithTransactionHashes
+	result := db.l2BridgeWithdrawalsQuery().Where("l2_transaction_withdrawals.withdrawal_hash = ?", withdrawalHash.String()).Take(&withdrawal)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		result = db.l2TransactionWithdrawalsQuery().Where("l2_transaction_withdrawals.withdrawal_hash = ?", withdrawalHash.String()).Take(&withdrawal)
+	}
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, result.Error
+	}
+
+	return &withdrawal, nil
+}
+
+// L2BridgeWithdrawalsProvenByAddress retrieves the unfinalized withdrawals initiated by the specified address that
+// were proven after `fromTimestamp` and no later than `toTimestamp`, in the order they were proven.
+func (db *bridgeTransfersDB) L2BridgeWithdrawalsProvenByAddress(address common.Address, fromTimestamp, toTimestamp uint64) ([]L2BridgeWithdrawalWithTransactionHashes, error) {
+	provenClause := "proven_l1_events.timestamp > ? AND proven_l1_events.timestamp <= ? AND l2_transaction_withdrawals.finalized_l1_event_guid IS NULL"
+
+	withdrawalsQuery := db.l2BridgeWithdrawalsQuery().Where(&Transaction{FromAddress: address})
+	withdrawalsQuery = withdrawalsQuery.Where(provenClause, fromTimestamp, toTimestamp)
+
+	ethTransactionWithdrawals := db.l2TransactionWithdrawalsQuery().Where(&Transaction{FromAddress: address})
+	ethTransactionWithdrawals = ethTransactionWithdrawals.Where("l2_transaction_withdrawals.amount > 0").Where(provenClause, fromTimestamp, toTimestamp)
+
+	query := db.gorm.Table("(?) AS withdrawals", withdrawalsQuery)
+	query = query.Joins("UNION (?)", ethTransactionWithdrawals)
+	query = query.Select("*").Order("proven_l1_timestamp ASC")
+
+	withdrawals := []L2BridgeWithdrawalWithTransactionHashes{}
+	result := query.Find(&withdrawals)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return withdrawals, nil
+}
+
+// l2TransactionWithdrawalsQuery selects transaction withdrawals as ETH withdrawals, joined with the
+// events and output proposal that make up the withdrawal lifecycle
+func (db *bridgeTransfersDB) l2TransactionWithdrawalsQuery() *gorm.DB {
+	ethAddressString := predeploys.LegacyERC20ETHAddr.String()
+
+	query := db.gorm.Model(&L2TransactionWithdrawal{})
+	query = withdrawalLifecycleJoins(query)
+	return query.Select(`
+from_address, to_address, amount, data, withdrawal_hash AS transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+`+withdrawalLifecycleColumns+`,
+l2_transaction_withdrawals.timestamp, NULL AS cross_domain_message_hash, ? AS local_token_address, ? AS remote_token_address`, ethAddressString, ethAddressString)
+}
+
+// l2BridgeWithdrawalsQuery selects StandardBridge withdrawals, joined with the events and output proposal
+// that make up the withdrawal lifecycle
+func (db *bridgeTransfersDB) l2BridgeWithdrawalsQuery() *gorm.DB {
+	query := db.gorm.Model(&L2BridgeWithdrawal{})
+	query = query.Joins("INNER JOIN l2_transaction_withdrawals ON withdrawal_hash = l2_bridge_withdrawals.transaction_withdrawal_hash")
+	query = withdrawalLifecycleJoins(query)
+	return query.Select(`
+l2_bridge_withdrawals.from_address, l2_bridge_withdrawals.to_address, l2_bridge_withdrawals.amount, l2_bridge_withdrawals.data, transaction_withdrawal_hash,
+l2_contract_events.transaction_hash AS l2_transaction_hash, l2_contract_events.block_hash as l2_block_hash, proven_l1_events.transaction_hash AS proven_l1_transaction_hash, finalized_l1_events.transaction_hash AS finalized_l1_transaction_hash,
+` + withdrawalLifecycleColumns + `,
+l2_bridge_withdrawals.timestamp, cross_domain_message_hash, local_token_address, remote_token_address`)
+}
+
+const withdrawalLifecycleColumns = `COALESCE(proven_l1_events.timestamp, 0) AS proven_l1_timestamp, COALESCE(finalized_l1_events.timestamp, 0) AS finalized_l1_timestamp,
+outputs.l2_output_index, COALESCE(outputs.timestamp, 0) AS output_proposed_timestamp`
+
+// withdrawalLifecycleJoins joins a query over `l2_transaction_withdrawals` with the initiating L2 event, the
+// L1 prove & finalize events, and the earliest output proposal covering the L2 block of the withdrawal
+func withdrawalLifecycleJoins(query *gorm.DB) *gorm.DB {
+	query = query.Joins("INNER JOIN l2_contract_events ON l2_contract_events.guid = l2_transaction_withdrawals.initiated_l2_event_guid")
+	query = query.Joins("INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash")
+	query = query.Joins("LEFT JOIN l1_contract_events AS proven_l1_events ON proven_l1_events.guid = l2_transaction_withdrawals.proven_l1_event_guid")
+	query = query.Joins("LEFT JOIN l1_contract_events AS finalized_l1_events ON finalized_l1_events.guid = l2_transaction_withdrawals.finalized_l1_event_guid")
+	return query.Joins(`LEFT JOIN LATERAL (
+SELECT output_proposals.l2_output_index, output_events.timestamp FROM output_proposals
+INNER JOIN l1_contract_events AS output_events ON output_events.guid = output_proposals.output_proposed_guid
+WHERE output_proposals.l2_block_number >= l2_block_headers.number
+ORDER BY output_proposals.l2_block_number ASC LIMIT 1
+) AS outputs ON TRUE`)
+}
@@ -11,6 +11,7 @@ import (
 	_ "github.com/ethereum-optimism/optimism/indexer/database/serializers"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -23,29 +24,26 @@ type DB struct {
 	gorm *gorm.DB
 	log  log.Logger
 
+	// postgres schema of the indexed chain, if isolated in its own
+	schema string
+
 	Blocks             BlocksDB
 	ContractEvents     ContractEventsDB
 	BridgeTransfers    BridgeTransfersDB
 	BridgeMessages     BridgeMessagesDB
 	BridgeTransactions BridgeTransactionsDB
+	BridgeAccounting   BridgeAccountingDB
 }
 
 // NewDB connects to the configured DB, and provides client-bindings to it.
 // The initial connection may fail, or the dial may be cancelled with the provided context.
 func NewDB(ctx context.Context, log log.Logger, dbConfig config.DBConfig) (*DB, error) {
 	log = log.New("module", "db")
-
-	dsn := fmt.Sprintf("host=%s dbname=%s sslmode=disable", dbConfig.Host, dbConfig.Name)
-	if dbConfig.Port != 0 {
-		dsn += fmt.Sprintf(" port=%d", dbConfig.Port)
-	}
-	if dbConfig.User != "" {
-		dsn += fmt.Sprintf(" user=%s", dbConfig.User)
-	}
-	if dbConfig.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", dbConfig.Password)
+	if dbConfig.Schema != "" {
+		log = log.New("schema", dbConfig.Schema)
 	}
 
+	dsn := dataSourceName(dbConfig)
 	gormConfig := gorm.Config{
 		Logger: newLogger(log),
 
@@ -76,27 +74,51 @@ func NewDB(ctx context.Context, log log.Logger, dbConfig config.DBConfig) (*DB,
 	db := &DB{
 		gorm:               gorm,
 		log:                log,
+		schema:             dbConfig.Schema,
 		Blocks:             newBlocksDB(log, gorm),
 		ContractEvents:     newContractEventsDB(log, gorm),
 		BridgeTransfers:    newBridgeTransfersDB(log, gorm),
 		BridgeMessages:     newBridgeMessagesDB(log, gorm),
 		BridgeTransactions: newBridgeTransactionsDB(log, gorm),
+		BridgeAccounting:   newBridgeAccountingDB(log, gorm),
 	}
 
 	return db, nil
 }
 
+// dataSourceName constructs the postgres connection string for the configured DB
+func dataSourceName(dbConfig config.DBConfig) string {
+	dsn := fmt.Sprintf("host=%s dbname=%s sslmode=disable", dbConfig.Host, dbConfig.Name)
+	if dbConfig.Port != 0 {
+		dsn += fmt.Sprintf(" port=%d", dbConfig.Port)
+	}
+	if dbConfig.User != "" {
+		dsn += fmt.Sprintf(" user=%s", dbConfig.User)
+	}
+	if dbConfig.Password != "" {
+		dsn += fmt.Sprintf(" password=%s", dbConfig.Password)
+	}
+	if dbConfig.Schema != "" {
+		// Shared types, i.e UINT256, remain resolvable from the public schema
+		dsn += fmt.Sprintf(" search_path=%s,public", dbConfig.Schema)
+	}
+	return dsn
+}
+
 // Transaction executes all operations conducted with the supplied database in a single
 // transaction. If the supplied function errors, the transaction is rolled back.
 func (db *DB) Transaction(fn func(db *DB) error) error {
 	return db.gorm.Transaction(func(tx *gorm.DB) error {
 		txDB := &DB{
 			gorm:               tx,
+			log:                db.log,
+			schema:             db.schema,
 			Blocks:             newBlocksDB(db.log, tx),
 			ContractEvents:     newContractEventsDB(db.log, tx),
 			BridgeTransfers:    newBridgeTransfersDB(db.log, tx),
 			BridgeMessages:     newBridgeMessagesDB(db.log, tx),
 			BridgeTransactions: newBridgeTransactionsDB(db.log, tx),
+			BridgeAccounting:   newBridgeAccountingDB(db.log, tx),
 		}
 
 		return fn(txDB)
@@ -114,6 +136,13 @@ func (db *DB) Close() error {
 }
 
 func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
+	if db.schema != "" {
+		db.log.Info("creating schema")
+		if err := db.gorm.Exec("CREATE SCHEMA IF NOT EXISTS " + pgx.Identifier{db.schema}.Sanitize()).Error; err != nil {
+			return errors.Wrap(err, "Error creating schema")
+		}
+	}
+
 	err := filepath.Walk(migrationsFolder, func(path string, info os.FileInfo, err error) error {
 		// Check for any walking error
 		if err != nil {
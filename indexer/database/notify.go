@@ -0,0 +1,248 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// BridgeUpdatesChannel is the postgres notification channel signalled whenever new
+// bridge data has been committed by the bridge processor
+const BridgeUpdatesChannel = "indexer_bridge_updates"
+
+// bridgeUpdatesChannel returns the notification channel of the schema. Notifications are
+// database wide, such that each chain indexed in its own schema is signalled separately
+func bridgeUpdatesChannel(schema string) string {
+	if schema == "" {
+		return BridgeUpdatesChannel
+	}
+	return BridgeUpdatesChannel + "_" + schema
+}
+
+// BridgeUpdate is the payload of a notification on the BridgeUpdatesChannel. Updates are keyed by
+// the hash of the transfer that changed, the deposit source hash or the withdrawal hash, along with
+// the address that initiated it. An update without a kind signals indexing progress, i.e a newly
+// indexed L1 state that may have matured proven withdrawals.
+type BridgeUpdate struct {
+	Kind    string         `json:"kind,omitempty"`
+	Hash    common.Hash    `json:"hash"`
+	Address common.Address `json:"address"`
+}
+
+const (
+	BridgeUpdateDeposit    = "deposit"
+	BridgeUpdateWithdrawal = "withdrawal"
+)
+
+// bridgeUpdatesSubscriberBuffer is the number of updates buffered per subscriber. A subscriber
+// that falls behind by more is dropped rather than blocking the listener.
+const bridgeUpdatesSubscriberBuffer = 256
+
+// NotifyBridgeUpdates signals indexing progress to all listeners of the BridgeUpdatesChannel. When called
+// within a transaction, the notification is only delivered once the transaction has been committed
+func (db *DB) NotifyBridgeUpdates() error {
+	return db.gorm.Exec("SELECT pg_notify(?, '')", bridgeUpdatesChannel(db.schema)).Error
+}
+
+// NotifyInitiatedL1BridgeUpdates notifies the deposits initiated within the inclusive L1 range, along
+// with the withdrawals covered by the output proposals made within the range
+func (db *DB) NotifyInitiatedL1BridgeUpdates(fromHeight, toHeight *big.Int) error {
+	channel := bridgeUpdatesChannel(db.schema)
+	deposits := db.gorm.Exec(`
+SELECT pg_notify(?, json_build_object('kind', ?::text, 'hash', deposits.source_hash, 'address', COALESCE(l1_bridge_deposits.from_address, deposits.from_address))::text)
+FROM l1_transaction_deposits AS deposits
+LEFT JOIN l1_bridge_deposits ON l1_bridge_deposits.transaction_source_hash = deposits.source_hash
+INNER JOIN l1_contract_events ON l1_contract_events.guid = deposits.initiated_l1_event_guid
+INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash
+WHERE l1_block_headers.number BETWEEN ? AND ? AND (l1_bridge_deposits.transaction_source_hash IS NOT NULL OR deposits.amount > 0)`,
+		channel, BridgeUpdateDeposit, fromHeight.String(), toHeight.String())
+	if deposits.Error != nil {
+		return deposits.Error
+	}
+
+	// outputs are proposed in order, such that the newly covered L2 blocks are bounded by the latest
+	// proposal preceding the range and the latest proposal within it
+	outputsQuery := `SELECT MAX(output_proposals.l2_block_number) FROM output_proposals
+INNER JOIN l1_contract_events ON l1_contract_events.guid = output_proposals.output_proposed_guid
+INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash
+WHERE l1_block_headers.number <= ?`
+	condition := fmt.Sprintf("l2_block_headers.number > COALESCE((%s), -1) AND l2_block_headers.number <= (%s)", outputsQuery, outputsQuery)
+	return db.notifyWithdrawals(condition, new(big.Int).Sub(fromHeight, big.NewInt(1)).String(), toHeight.String())
+}
+
+// NotifyFinalizedL1BridgeUpdates notifies the withdrawals proven or finalized within the inclusive L1 range
+func (db *DB) NotifyFinalizedL1BridgeUpdates(fromHeight, toHeight *big.Int) error {
+	condition := `EXISTS (SELECT 1 FROM l1_contract_events
+INNER JOIN l1_block_headers ON l1_block_headers.hash = l1_contract_events.block_hash
+WHERE l1_contract_events.guid IN (withdrawals.proven_l1_event_guid, withdrawals.finalized_l1_event_guid) AND l1_block_headers.number BETWEEN ? AND ?)`
+	return db.notifyWithdrawals(condition, fromHeight.String(), toHeight.String())
+}
+
+// NotifyInitiatedL2BridgeUpdates notifies the withdrawals initiated within the inclusive L2 range
+func (db *DB) NotifyInitiatedL2BridgeUpdates(fromHeight, toHeight *big.Int) error {
+	return db.notifyWithdrawals("l2_block_headers.number BETWEEN ? AND ?", fromHeight.String(), toHeight.String())
+}
+
+// notifyWithdrawals notifies every withdrawal, surfaced by the BridgeTransfersView, matching the condition
+func (db *DB) notifyWithdrawals(condition string, args ...interface{}) error {
+	query := `
+SELECT pg_notify(?, json_build_object('kind', ?::text, 'hash', withdrawals.withdrawal_hash, 'address', COALESCE(l2_bridge_withdrawals.from_address, withdrawals.from_address))::text)
+FROM l2_transaction_withdrawals AS withdrawals
+LEFT JOIN l2_bridge_withdrawals ON l2_bridge_withdrawals.transaction_withdrawal_hash = withdrawals.withdrawal_hash
+INNER JOIN l2_contract_events ON l2_contract_events.guid = withdrawals.initiated_l2_event_guid
+INNER JOIN l2_block_headers ON l2_block_headers.hash = l2_contract_events.block_hash
+WHERE (l2_bridge_withdrawals.transaction_withdrawal_hash IS NOT NULL OR withdrawals.amount > 0) AND ` + condition
+
+	args = append([]interface{}{bridgeUpdatesChannel(db.schema), BridgeUpdateWithdrawal}, args...)
+	return db.gorm.Exec(query, args...).Error
+}
+
+// BridgeUpdatesListener listens for notifications on the BridgeUpdatesChannel over a dedicated
+// connection, fanning them out to all subscribers. Subscribers that fall behind are dropped, as
+// are all subscribers when the connection is lost, since updates may have been missed.
+type BridgeUpdatesListener struct {
+	log     log.Logger
+	dsn     string
+	channel string
+
+	mu          sync.Mutex
+	subscribers map[chan BridgeUpdate]struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBridgeUpdatesListener connects to the configured DB and starts listening for bridge updates.
+// The initial connection may fail, or the dial may be cancelled with the provided context.
+func NewBridgeUpdatesListener(ctx context.Context, log log.Logger, dbConfig config.DBConfig) (*BridgeUpdatesListener, error) {
+	channel := bridgeUpdatesChannel(dbConfig.Schema)
+	l := &BridgeUpdatesListener{
+		log:         log.New("module", "db", "channel", channel),
+		dsn:         dataSourceName(dbConfig),
+		channel:     channel,
+		subscribers: make(map[chan BridgeUpdate]struct{}),
+		done:        make(chan struct{}),
+	}
+
+	conn, err := l.listen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	go l.loop(loopCtx, conn)
+	return l, nil
+}
+
+// Subscribe returns a channel receiving every bridge update, along with a function to unsubscribe
+// with. The channel is closed when the subscriber is dropped or the listener is closed.
+func (l *BridgeUpdatesListener) Subscribe() (<-chan BridgeUpdate, func()) {
+	ch := make(chan BridgeUpdate, bridgeUpdatesSubscriberBuffer)
+
+	l.mu.Lock()
+	l.subscribers[ch] = struct{}{}
+	l.mu.Unlock()
+
+	return ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subscribers[ch]; ok {
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (l *BridgeUpdatesListener) Close() error {
+	l.cancel()
+	<-l.done
+	return nil
+}
+
+func (l *BridgeUpdatesListener) listen(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		conn.Close(ctx)
+		return nil, fmt.Errorf("failed to listen for bridge updates: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (l *BridgeUpdatesListener) loop(ctx context.Context, conn *pgx.Conn) {
+	defer close(l.done)
+	defer l.dropSubscribers()
+
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err == nil {
+			l.handleNotification(notification.Payload)
+			continue
+		}
+
+		conn.Close(context.Background())
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Updates may be missed while disconnected
+		l.log.Warn("lost connection, dropping subscribers and reconnecting", "err", err)
+		l.dropSubscribers()
+		for {
+			conn, err = retry.Do[*pgx.Conn](ctx, 10, retryStrategy, func() (*pgx.Conn, error) { return l.listen(ctx) })
+			if ctx.Err() != nil {
+				return
+			} else if err == nil {
+				break
+			}
+			l.log.Error("unable to reconnect", "err", err)
+		}
+	}
+}
+
+func (l *BridgeUpdatesListener) handleNotification(payload string) {
+	var update BridgeUpdate
+	if payload != "" {
+		if err := json.Unmarshal([]byte(payload), &update); err != nil {
+			l.log.Error("invalid bridge update payload", "payload", payload, "err", err)
+			return
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subscribers {
+		select {
+		case ch <- update:
+		default:
+			l.log.Warn("dropping slow bridge updates subscriber")
+			delete(l.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (l *BridgeUpdatesListener) dropSubscribers() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subscribers {
+		delete(l.subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"math/big"
+)
+
+/**
+ * Reorg Handling
+ *
+ * Removing a block header cascades to the contract events emitted within it and all of the
+ * bridge data initiated by those events. Bridge data that was initiated on the other chain
+ * but finalized by a reorged event must instead be reset so that it can be re-finalized when
+ * the canonical event is re-indexed.
+ */
+
+// RewindL1 removes all indexed L1 state above the supplied height in a single transaction
+func (db *DB) RewindL1(height *big.Int) error {
+	return db.Transaction(func(tx *DB) error {
+		headers := tx.gorm.Table("l1_block_headers").Select("hash").Where("number > ?", height.String())
+		events := tx.gorm.Table("l1_contract_events").Select("guid").Where("block_hash IN (?)", headers)
+
+		// Withdrawals proven or finalized in a reorged L1 block
+		result := tx.gorm.Table("l2_transaction_withdrawals").Where("proven_l1_event_guid IN (?)", events).Update("proven_l1_event_guid", nil)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected > 0 {
+			db.log.Warn("reset reorged withdrawal proofs", "size", result.RowsAffected)
+		}
+
+		result = tx.gorm.Table("l2_transaction_withdrawals").Where("finalized_l1_event_guid IN (?)", events).
+			Updates(map[string]interface{}{"finalized_l1_event_guid": nil, "succeeded": nil})
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected > 0 {
+			db.log.Warn("reset reorged withdrawal finalizations", "size", result.RowsAffected)
+		}
+
+		// L2 messages relayed in a reorged L1 block
+		result = tx.gorm.Table("l2_bridge_messages").Where("relayed_message_event_guid IN (?)", events).Update("relayed_message_event_guid", nil)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected > 0 {
+			db.log.Warn("reset reorged relayed L2 messages", "size", result.RowsAffected)
+		}
+
+		result = tx.gorm.Table("l2_bridge_messages").Where("failed_relayed_message_event_guid IN (?)", events).Update("failed_relayed_message_event_guid", nil)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected > 0 {
+			db.log.Warn("reset reorged failed relays of L2 messages", "size", result.RowsAffected)
+		}
+
+		// Cascades to the events, deposits, messages, bridge deposits and output proposals
+		result = tx.gorm.Where("number > ?", height.String()).Delete(&L1BlockHeader{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		db.log.Warn("rewound L1 state", "height", height, "deleted_headers", result.RowsAffected)
+		return nil
+	})
+}
+
+// RewindL2 removes all indexed L2 state above the supplied height in a single transaction
+func (db *DB) RewindL2(height *big.Int) error {
+	return db.Transaction(func(tx *DB) error {
+		headers := tx.gorm.Table("l2_block_headers").Select("hash").Where("number > ?", height.String())
+		events := tx.gorm.Table("l2_contract_events").Select("guid").Where("block_hash IN (?)", headers)
+
+		// L1 messages relayed in a reorged L2 block
+		result := tx.gorm.Table("l1_bridge_messages").Where("relayed_message_event_guid IN (?)", events).Update("relayed_message_event_guid", nil)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected > 0 {
+			db.log.Warn("reset reorged relayed L1 messages", "size", result.RowsAffected)
+		}
+
+		result = tx.gorm.Table("l1_bridge_messages").Where("failed_relayed_message_event_guid IN (?)", events).Update("failed_relayed_message_event_guid", nil)
+		if result.Error != nil {
+			return result.Error
+		} else if result.RowsAffected > 0 {
+			db.log.Warn("reset reorged failed relays of L1 messages", "size", result.RowsAffected)
+		}
+
+		// The versioned message hashes do not cascade with the removal of the sent message
+		sentMessages := tx.gorm.Table("l2_bridge_messages").Select("message_hash").Where("sent_message_event_guid IN (?)", events)
+		result = tx.gorm.Exec("DELETE FROM l2_bridge_message_versioned_message_hashes WHERE message_hash IN (?)", sentMessages)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// Cascades to the events, withdrawals, messages and bridge withdrawals
+		result = tx.gorm.Where("number > ?", height.String()).Delete(&L2BlockHeader{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		db.log.Warn("rewound L2 state", "height", height, "deleted_headers", result.RowsAffected)
+		return nil
+	})
+}
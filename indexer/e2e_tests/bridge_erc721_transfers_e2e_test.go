@@ -0,0 +1,155 @@
+package e2e_tests
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	e2etest_utils "github.com/ethereum-optimism/optimism/indexer/e2e_tests/utils"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	"github.com/stretchr/testify/require"
+)
+
+// optimismMintableERC721ABI is the subset of the OptimismMintableERC721 ABI used to mint and move tokens
+const optimismMintableERC721ABI = `[
+	{"type":"function","name":"safeMint","stateMutability":"nonpayable","inputs":[{"name":"_to","type":"address"},{"name":"_tokenId","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"ownerOf","stateMutability":"view","inputs":[{"name":"tokenId","type":"uint256"}],"outputs":[{"name":"","type":"address"}]}
+]`
+
+func TestE2EBridgeTransfersERC721(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+
+	erc721Abi, err := abi.JSON(strings.NewReader(optimismMintableERC721ABI))
+	require.NoError(t, err)
+	transact := func(client *ethclient.Client, tx *types.Transaction, err error) *types.Receipt {
+		require.NoError(t, err)
+		receipt, err := wait.ForReceiptOK(context.Background(), client, tx.Hash())
+		require.NoError(t, err)
+		return receipt
+	}
+
+	// The L1 collection is created by a factory with alice as its bridge, so that alice can mint
+	_, deployTx, l1Factory, err := bindings.DeployOptimismMintableERC721Factory(l1Opts, testSuite.L1Client, aliceAddr, testSuite.OpCfg.L2ChainIDBig())
+	transact(testSuite.L1Client, deployTx, err)
+	tx, err := l1Factory.CreateOptimismMintableERC721(l1Opts, common.Address{0x01}, "L1 Collection", "L1C")
+	receipt := transact(testSuite.L1Client, tx, err)
+	require.Len(t, receipt.Logs, 1)
+	created, err := l1Factory.ParseOptimismMintableERC721Created(*receipt.Logs[0])
+	require.NoError(t, err)
+	l1Token := created.LocalToken
+	l1Collection := bind.NewBoundContract(l1Token, erc721Abi, testSuite.L1Client, testSuite.L1Client, testSuite.L1Client)
+
+	// The L2 collection is created by the factory predeploy
+	l2Factory, err := bindings.NewOptimismMintableERC721Factory(predeploys.OptimismMintableERC721FactoryAddr, testSuite.L2Client)
+	require.NoError(t, err)
+	tx, err = l2Factory.CreateOptimismMintableERC721(l2Opts, l1Token, "L2 Collection", "L2C")
+	receipt = transact(testSuite.L2Client, tx, err)
+	require.Len(t, receipt.Logs, 1)
+	created, err = l2Factory.ParseOptimismMintableERC721Created(*receipt.Logs[0])
+	require.NoError(t, err)
+	l2Token := created.LocalToken
+	l2Collection := bind.NewBoundContract(l2Token, erc721Abi, testSuite.L2Client, testSuite.L2Client, testSuite.L2Client)
+
+	tokenId := big.NewInt(1)
+	tx, err = l1Collection.Transact(l1Opts, "safeMint", aliceAddr, tokenId)
+	transact(testSuite.L1Client, tx, err)
+	tx, err = l1Collection.Transact(l1Opts, "approve", testSuite.OpCfg.L1Deployments.L1ERC721BridgeProxy, tokenId)
+	transact(testSuite.L1Client, tx, err)
+
+	// (1) Deposit the token
+	l1ERC721Bridge, err := bindings.NewL1ERC721Bridge(testSuite.OpCfg.L1Deployments.L1ERC721BridgeProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	tx, err = l1ERC721Bridge.BridgeERC721(l1Opts, l1Token, l2Token, tokenId, 200_000, []byte{byte(1)})
+	depositReceipt := transact(testSuite.L1Client, tx, err)
+	depositInfo, err := e2etest_utils.ParseDepositInfo(depositReceipt)
+	require.NoError(t, err)
+	depositMessage, err := e2etest_utils.ParseCrossDomainMessage(depositReceipt)
+	require.NoError(t, err)
+
+	// wait for the token to be minted on L2
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		var owner []interface{}
+		if err := l2Collection.Call(&bind.CallOpts{}, &owner, "ownerOf", tokenId); err != nil {
+			return false, nil // not minted yet
+		}
+		return owner[0].(common.Address) == aliceAddr, nil
+	}))
+
+	// (2) Withdraw the token
+	l2ERC721Bridge, err := bindings.NewL2ERC721Bridge(predeploys.L2ERC721BridgeAddr, testSuite.L2Client)
+	require.NoError(t, err)
+	tx, err = l2ERC721Bridge.BridgeERC721(l2Opts, l2Token, l1Token, tokenId, 200_000, []byte{byte(2)})
+	withdrawReceipt := transact(testSuite.L2Client, tx, err)
+	msgPassed, err := withdrawals.ParseMessagePassed(withdrawReceipt)
+	require.NoError(t, err)
+	withdrawalHash, err := withdrawals.WithdrawalHash(msgPassed)
+	require.NoError(t, err)
+	withdrawMessage, err := e2etest_utils.ParseCrossDomainMessage(withdrawReceipt)
+	require.NoError(t, err)
+
+	// wait for processor catchup
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header, l2Header := testSuite.Indexer.BridgeProcessor.LastL1Header, testSuite.Indexer.BridgeProcessor.LastL2Header
+		return l1Header != nil && l1Header.Number.Cmp(depositReceipt.BlockNumber) >= 0 &&
+			l2Header != nil && l2Header.Number.Cmp(withdrawReceipt.BlockNumber) >= 0, nil
+	}))
+
+	// The deposit is correlated with the cross domain message and portal deposit of the bridge transaction
+	deposits, err := testSuite.DB.BridgeTransfers.L1ERC721BridgeDepositsByAddress(aliceAddr, &l2Token, "", 100)
+	require.NoError(t, err)
+	require.Len(t, deposits.Deposits, 1)
+	deposit := deposits.Deposits[0]
+	require.Equal(t, depositReceipt.TxHash, deposit.L1TransactionHash)
+	require.Equal(t, depositInfo.DepositTx.SourceHash, deposit.L1ERC721BridgeDeposit.TransactionSourceHash)
+	require.Equal(t, depositMessage.MessageHash, deposit.L1ERC721BridgeDeposit.CrossDomainMessageHash)
+	require.Equal(t, l1Token, deposit.L1ERC721BridgeDeposit.TokenPair.LocalTokenAddress)
+	require.Equal(t, l2Token, deposit.L1ERC721BridgeDeposit.TokenPair.RemoteTokenAddress)
+	require.Equal(t, tokenId.Uint64(), deposit.L1ERC721BridgeDeposit.TokenID.Uint64())
+	require.Equal(t, aliceAddr, deposit.L1ERC721BridgeDeposit.FromAddress)
+	require.Equal(t, aliceAddr, deposit.L1ERC721BridgeDeposit.ToAddress)
+	require.Equal(t, []byte{byte(1)}, []byte(deposit.L1ERC721BridgeDeposit.Data))
+
+	sentMessage, err := testSuite.DB.BridgeMessages.L1BridgeMessage(depositMessage.MessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, sentMessage)
+	require.Equal(t, depositInfo.DepositTx.SourceHash, sentMessage.TransactionSourceHash)
+
+	// The withdrawal is correlated with the cross domain message and message passer withdrawal of the bridge transaction
+	withdrawalsResponse, err := testSuite.DB.BridgeTransfers.L2ERC721BridgeWithdrawalsByAddress(aliceAddr, &l1Token, "", 100)
+	require.NoError(t, err)
+	require.Len(t, withdrawalsResponse.Withdrawals, 1)
+	withdrawal := withdrawalsResponse.Withdrawals[0]
+	require.Equal(t, withdrawReceipt.TxHash, withdrawal.L2TransactionHash)
+	require.Equal(t, withdrawalHash, withdrawal.L2ERC721BridgeWithdrawal.TransactionWithdrawalHash)
+	require.Equal(t, withdrawMessage.MessageHash, withdrawal.L2ERC721BridgeWithdrawal.CrossDomainMessageHash)
+	require.Equal(t, l2Token, withdrawal.L2ERC721BridgeWithdrawal.TokenPair.LocalTokenAddress)
+	require.Equal(t, l1Token, withdrawal.L2ERC721BridgeWithdrawal.TokenPair.RemoteTokenAddress)
+	require.Equal(t, tokenId.Uint64(), withdrawal.L2ERC721BridgeWithdrawal.TokenID.Uint64())
+	require.Equal(t, aliceAddr, withdrawal.L2ERC721BridgeWithdrawal.FromAddress)
+	require.Equal(t, aliceAddr, withdrawal.L2ERC721BridgeWithdrawal.ToAddress)
+	require.Equal(t, []byte{byte(2)}, []byte(withdrawal.L2ERC721BridgeWithdrawal.Data))
+
+	withdrawnMessage, err := testSuite.DB.BridgeMessages.L2BridgeMessage(withdrawMessage.MessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, withdrawnMessage)
+	require.Equal(t, withdrawalHash, withdrawnMessage.TransactionWithdrawalHash)
+}
@@ -0,0 +1,192 @@
+package e2e_tests
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	e2etest_utils "github.com/ethereum-optimism/optimism/indexer/e2e_tests/utils"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2ERewindBridgeState(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	optimismPortal, err := bindings.NewOptimismPortal(testSuite.OpCfg.L1Deployments.OptimismPortalProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l1CrossDomainMessenger, err := bindings.NewL1CrossDomainMessenger(testSuite.OpCfg.L1Deployments.L1CrossDomainMessengerProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l2CrossDomainMessenger, err := bindings.NewL2CrossDomainMessenger(predeploys.L2CrossDomainMessengerAddr, testSuite.L2Client)
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l1Opts.Value = big.NewInt(params.Ether)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+	l2Opts.Value = big.NewInt(params.Ether)
+
+	// Ensure L1 has enough funds for the withdrawal
+	fundTx, err := optimismPortal.Receive(l1Opts)
+	require.NoError(t, err)
+	_, err = wait.ForReceiptOK(context.Background(), testSuite.L1Client, fundTx.Hash())
+	require.NoError(t, err)
+
+	// (1) The withdrawal is initiated on L2 before the deposit is relayed on L2
+	withdrawMsgTx, err := l2CrossDomainMessenger.SendMessage(l2Opts, aliceAddr, nil, 100_000)
+	require.NoError(t, err)
+	withdrawMsgReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, withdrawMsgTx.Hash())
+	require.NoError(t, err)
+	msgPassed, err := withdrawals.ParseMessagePassed(withdrawMsgReceipt)
+	require.NoError(t, err)
+	withdrawalHash, err := withdrawals.WithdrawalHash(msgPassed)
+	require.NoError(t, err)
+	withdrawMsg, err := e2etest_utils.ParseCrossDomainMessage(withdrawMsgReceipt)
+	require.NoError(t, err)
+
+	// (2) The deposit is initiated on L1 before the withdrawal is proven on L1
+	depositMsgTx, err := l1CrossDomainMessenger.SendMessage(l1Opts, aliceAddr, nil, 100_000)
+	require.NoError(t, err)
+	depositMsgReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositMsgTx.Hash())
+	require.NoError(t, err)
+	depositInfo, err := e2etest_utils.ParseDepositInfo(depositMsgReceipt)
+	require.NoError(t, err)
+	depositMsg, err := e2etest_utils.ParseCrossDomainMessage(depositMsgReceipt)
+	require.NoError(t, err)
+
+	// wait for the deposit to be indexed to look up its relay on L2
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		deposit, err := testSuite.DB.BridgeTransactions.L1TransactionDeposit(depositInfo.DepositTx.SourceHash)
+		return deposit != nil, err
+	}))
+	deposit, err := testSuite.DB.BridgeTransactions.L1TransactionDeposit(depositInfo.DepositTx.SourceHash)
+	require.NoError(t, err)
+	relayReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, deposit.L2TransactionHash)
+	require.NoError(t, err)
+	require.Greater(t, relayReceipt.BlockNumber.Uint64(), withdrawMsgReceipt.BlockNumber.Uint64())
+
+	// (3) Prove and finalize the withdrawal
+	proveReceipt, finalizeReceipt := op_e2e.ProveAndFinalizeWithdrawal(t, *testSuite.OpCfg, testSuite.L1Client, testSuite.OpSys.EthInstances["sequencer"], testSuite.OpCfg.Secrets.Alice, withdrawMsgReceipt)
+	require.Greater(t, proveReceipt.BlockNumber.Uint64(), depositMsgReceipt.BlockNumber.Uint64())
+
+	// waitIndexed waits for the bridge processor to index the relay of the deposit on L2 and the
+	// finalization of the withdrawal on L1, and checks the references to them
+	waitIndexed := func(ix *indexer.Indexer) {
+		require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+			l1Header, l2Header := ix.BridgeProcessor.LastFinalizedL1Header, ix.BridgeProcessor.LastFinalizedL2Header
+			return l1Header != nil && l1Header.Number.Cmp(finalizeReceipt.BlockNumber) >= 0 &&
+				l2Header != nil && l2Header.Number.Cmp(relayReceipt.BlockNumber) >= 0, nil
+		}))
+
+		withdrawal, err := ix.DB.BridgeTransactions.L2TransactionWithdrawal(withdrawalHash)
+		require.NoError(t, err)
+		require.NotNil(t, withdrawal)
+		require.NotNil(t, withdrawal.ProvenL1EventGUID)
+		event, err := ix.DB.ContractEvents.L1ContractEvent(*withdrawal.ProvenL1EventGUID)
+		require.NoError(t, err)
+		require.Equal(t, proveReceipt.TxHash, event.TransactionHash)
+		require.NotNil(t, withdrawal.FinalizedL1EventGUID)
+		event, err = ix.DB.ContractEvents.L1ContractEvent(*withdrawal.FinalizedL1EventGUID)
+		require.NoError(t, err)
+		require.Equal(t, finalizeReceipt.TxHash, event.TransactionHash)
+		require.NotNil(t, withdrawal.Succeeded)
+		require.True(t, *withdrawal.Succeeded)
+
+		sentMessage, err := ix.DB.BridgeMessages.L2BridgeMessage(withdrawMsg.MessageHash)
+		require.NoError(t, err)
+		require.NotNil(t, sentMessage)
+		require.NotNil(t, sentMessage.RelayedMessageEventGUID)
+		event, err = ix.DB.ContractEvents.L1ContractEvent(*sentMessage.RelayedMessageEventGUID)
+		require.NoError(t, err)
+		require.Equal(t, finalizeReceipt.TxHash, event.TransactionHash)
+
+		depositMessage, err := ix.DB.BridgeMessages.L1BridgeMessage(depositMsg.MessageHash)
+		require.NoError(t, err)
+		require.NotNil(t, depositMessage)
+		require.NotNil(t, depositMessage.RelayedMessageEventGUID)
+		l2Event, err := ix.DB.ContractEvents.L2ContractEvent(*depositMessage.RelayedMessageEventGUID)
+		require.NoError(t, err)
+		require.Equal(t, relayReceipt.TxHash, l2Event.TransactionHash)
+	}
+	waitIndexed(testSuite.Indexer)
+
+	withdrawal, err := testSuite.DB.BridgeTransactions.L2TransactionWithdrawal(withdrawalHash)
+	require.NoError(t, err)
+	provenGUID := *withdrawal.ProvenL1EventGUID
+	depositMessage, err := testSuite.DB.BridgeMessages.L1BridgeMessage(depositMsg.MessageHash)
+	require.NoError(t, err)
+	relayedGUID := *depositMessage.RelayedMessageEventGUID
+
+	// (4) Rewind L1 between the deposit and the proof, and L2 between the withdrawal and the relay
+	require.NoError(t, testSuite.Indexer.Stop(context.Background()))
+	db, err := database.NewDB(context.Background(), testlog.Logger(t, log.LvlInfo), testSuite.IndexerCfg.DB)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	l1Height := new(big.Int).Sub(proveReceipt.BlockNumber, big.NewInt(1))
+	l2Height := new(big.Int).Sub(relayReceipt.BlockNumber, big.NewInt(1))
+	require.NoError(t, db.RewindL1(l1Height))
+	require.NoError(t, db.RewindL2(l2Height))
+
+	// the state above the heights is removed
+	l1Header, err := db.Blocks.L1LatestBlockHeader()
+	require.NoError(t, err)
+	require.LessOrEqual(t, l1Header.Number.Cmp(l1Height), 0)
+	l2Header, err := db.Blocks.L2LatestBlockHeader()
+	require.NoError(t, err)
+	require.LessOrEqual(t, l2Header.Number.Cmp(l2Height), 0)
+	provenEvent, err := db.ContractEvents.L1ContractEvent(provenGUID)
+	require.NoError(t, err)
+	require.Nil(t, provenEvent)
+	relayedEvent, err := db.ContractEvents.L2ContractEvent(relayedGUID)
+	require.NoError(t, err)
+	require.Nil(t, relayedEvent)
+
+	// the bridge state initiated below the heights remains, with the references to the removed events reset
+	withdrawal, err = db.BridgeTransactions.L2TransactionWithdrawal(withdrawalHash)
+	require.NoError(t, err)
+	require.NotNil(t, withdrawal)
+	require.Nil(t, withdrawal.ProvenL1EventGUID)
+	require.Nil(t, withdrawal.FinalizedL1EventGUID)
+	require.Nil(t, withdrawal.Succeeded)
+
+	sentMessage, err := db.BridgeMessages.L2BridgeMessage(withdrawMsg.MessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, sentMessage)
+	require.Nil(t, sentMessage.RelayedMessageEventGUID)
+
+	deposit, err = db.BridgeTransactions.L1TransactionDeposit(depositInfo.DepositTx.SourceHash)
+	require.NoError(t, err)
+	require.NotNil(t, deposit)
+	depositMessage, err = db.BridgeMessages.L1BridgeMessage(depositMsg.MessageHash)
+	require.NoError(t, err)
+	require.NotNil(t, depositMessage)
+	require.Nil(t, depositMessage.RelayedMessageEventGUID)
+
+	// (5) A restarted indexer re-indexes the removed state
+	ix, err := indexer.NewIndexer(context.Background(), testlog.Logger(t, log.LvlInfo).New("role", "indexer"), testSuite.IndexerCfg, func(cause error) {
+		if cause != nil {
+			t.Fatalf("indexer shut down with critical error: %v", cause)
+		}
+	})
+	require.NoError(t, err)
+	require.NoError(t, ix.Start(context.Background()))
+	t.Cleanup(func() {
+		require.NoError(t, ix.Stop(context.Background()), "cleanly shut down indexer")
+	})
+	waitIndexed(ix)
+}
@@ -0,0 +1,210 @@
+package e2e_tests
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/processors"
+	"github.com/ethereum-optimism/optimism/indexer/processors/bridge"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestE2EReprocessBridgeEventsIdempotent(t *testing.T) {
+	testSuite := createE2ETestSuite(t)
+
+	optimismPortal, err := bindings.NewOptimismPortal(testSuite.OpCfg.L1Deployments.OptimismPortalProxy, testSuite.L1Client)
+	require.NoError(t, err)
+	l2ToL1MessagePasser, err := bindings.NewL2ToL1MessagePasser(predeploys.L2ToL1MessagePasserAddr, testSuite.L2Client)
+	require.NoError(t, err)
+
+	aliceAddr := testSuite.OpCfg.Secrets.Addresses().Alice
+	l1Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L1ChainIDBig())
+	require.NoError(t, err)
+	l1Opts.Value = big.NewInt(params.Ether)
+	l2Opts, err := bind.NewKeyedTransactorWithChainID(testSuite.OpCfg.Secrets.Alice, testSuite.OpCfg.L2ChainIDBig())
+	require.NoError(t, err)
+	l2Opts.Value = big.NewInt(params.Ether)
+
+	depositTx, err := optimismPortal.DepositTransaction(l1Opts, aliceAddr, big.NewInt(0), 100_000, false, nil)
+	require.NoError(t, err)
+	depositReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L1Client, depositTx.Hash())
+	require.NoError(t, err)
+
+	withdrawTx, err := l2ToL1MessagePasser.InitiateWithdrawal(l2Opts, aliceAddr, big.NewInt(100_000), nil)
+	require.NoError(t, err)
+	withdrawReceipt, err := wait.ForReceiptOK(context.Background(), testSuite.L2Client, withdrawTx.Hash())
+	require.NoError(t, err)
+	msgPassed, err := withdrawals.ParseMessagePassed(withdrawReceipt)
+	require.NoError(t, err)
+	withdrawalHash, err := withdrawals.WithdrawalHash(msgPassed)
+	require.NoError(t, err)
+
+	// wait for processor catchup, including an output proposal covering the withdrawal
+	require.NoError(t, wait.For(context.Background(), 500*time.Millisecond, func() (bool, error) {
+		l1Header, l2Header := testSuite.Indexer.BridgeProcessor.LastL1Header, testSuite.Indexer.BridgeProcessor.LastL2Header
+		if l1Header == nil || l1Header.Number.Cmp(depositReceipt.BlockNumber) < 0 || l2Header == nil || l2Header.Number.Cmp(withdrawReceipt.BlockNumber) < 0 {
+			return false, nil
+		}
+		output, err := testSuite.DB.Blocks.LatestOutputProposal()
+		return output != nil && output.L2BlockNumber.Cmp(withdrawReceipt.BlockNumber) >= 0, err
+	}))
+
+	deposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100)
+	require.NoError(t, err)
+	withdrawal, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalWithTransactionHashes(withdrawalHash)
+	require.NoError(t, err)
+	require.NotNil(t, withdrawal)
+	output, err := testSuite.DB.Blocks.LatestOutputProposal()
+	require.NoError(t, err)
+
+	// Re-processing everything indexed so far leaves the indexed bridge state untouched
+	reprocessCfg := processors.ReprocessConfig{
+		L1FromHeight: bigint.Zero,
+		L1ToHeight:   testSuite.Indexer.BridgeProcessor.LastL1Header.Number,
+		L2FromHeight: bigint.Zero,
+		L2ToHeight:   testSuite.Indexer.BridgeProcessor.LastL2Header.Number,
+		Initiated:    true,
+		Finalized:    true,
+		BatchSize:    50,
+	}
+	for i := 0; i < 2; i++ {
+		err := processors.ReprocessBridgeEvents(context.Background(), testlog.Logger(t, log.LvlInfo), testSuite.DB,
+			bridge.NewMetrics(metrics.NewRegistry()), testSuite.Indexer.Chains[0].Config, reprocessCfg)
+		require.NoError(t, err)
+
+		reprocessedDeposits, err := testSuite.DB.BridgeTransfers.L1BridgeDepositsByAddress(aliceAddr, "", 100)
+		require.NoError(t, err)
+		require.Equal(t, deposits.Deposits, reprocessedDeposits.Deposits)
+
+		reprocessedWithdrawal, err := testSuite.DB.BridgeTransfers.L2BridgeWithdrawalWithTransactionHashes(withdrawalHash)
+		require.NoError(t, err)
+		require.Equal(t, withdrawal, reprocessedWithdrawal)
+
+		reprocessedOutput, err := testSuite.DB.Blocks.OutputProposal(output.L2OutputIndex)
+		require.NoError(t, err)
+		require.Equal(t, output, reprocessedOutput)
+	}
+}
+
+func TestE2EReprocessReplaysOutputProposals(t *testing.T) {
+	db := openTestDatabase(t)
+	t.Parallel()
+
+	l2OutputOracle := common.HexToAddress("0x420")
+	l2OutputOracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+
+	// L1 blocks 1..6, one event each. Output 2 is deleted at block 4 and re-proposed at block 5
+	var headers []database.L1BlockHeader
+	var events []database.L1ContractEvent
+	addEvent := func(topics []common.Hash, data []byte) database.L1ContractEvent {
+		number := int64(len(headers) + 1)
+		header := &types.Header{Number: big.NewInt(number), Time: uint64(number)}
+		if len(headers) > 0 {
+			header.ParentHash = headers[len(headers)-1].Hash
+		}
+		headers = append(headers, database.L1BlockHeader{BlockHeader: database.BlockHeaderFromHeader(header)})
+
+		rlpLog := &types.Log{Address: l2OutputOracle, Topics: topics, Data: data, BlockNumber: uint64(number), BlockHash: header.Hash(), TxHash: common.BigToHash(big.NewInt(number))}
+		event := database.L1ContractEvent{ContractEvent: database.ContractEventFromLog(rlpLog, header.Time)}
+		events = append(events, event)
+		return event
+	}
+	proposeOutput := func(index, l2BlockNumber int64, outputRoot common.Hash) database.L1ContractEvent {
+		event := l2OutputOracleAbi.Events["OutputProposed"]
+		data, err := event.Inputs.NonIndexed().Pack(big.NewInt(int64(len(headers) + 1)))
+		require.NoError(t, err)
+		return addEvent([]common.Hash{event.ID, outputRoot, common.BigToHash(big.NewInt(index)), common.BigToHash(big.NewInt(l2BlockNumber))}, data)
+	}
+	deleteOutputs := func(prevNextIndex, newNextIndex int64) database.L1ContractEvent {
+		event := l2OutputOracleAbi.Events["OutputsDeleted"]
+		return addEvent([]common.Hash{event.ID, common.BigToHash(big.NewInt(prevNextIndex)), common.BigToHash(big.NewInt(newNextIndex))}, nil)
+	}
+
+	proposeOutput(0, 10, common.HexToHash("0xa"))
+	proposeOutput(1, 20, common.HexToHash("0xb"))
+	proposeOutput(2, 30, common.HexToHash("0xc"))
+	deleteOutputs(3, 2)
+	reproposed := proposeOutput(2, 30, common.HexToHash("0xd"))
+	latest := proposeOutput(3, 40, common.HexToHash("0xe"))
+
+	// Reprocessing is bounded by the latest indexed deposit, marking the progress of the live processor
+	deposit := database.L1TransactionDeposit{
+		SourceHash:           common.HexToHash("0x1"),
+		L2TransactionHash:    common.HexToHash("0x2"),
+		InitiatedL1EventGUID: latest.GUID,
+		Tx:                   database.Transaction{Amount: big.NewInt(0), Data: []byte{}, Timestamp: latest.Timestamp},
+		GasLimit:             big.NewInt(0),
+	}
+	require.NoError(t, db.Transaction(func(tx *database.DB) error {
+		if err := tx.Blocks.StoreL1BlockHeaders(headers); err != nil {
+			return err
+		}
+		if err := tx.ContractEvents.StoreL1ContractEvents(events); err != nil {
+			return err
+		}
+		return tx.BridgeTransactions.StoreL1TransactionDeposits([]database.L1TransactionDeposit{deposit})
+	}))
+
+	chainConfig := config.ChainConfig{L1Contracts: config.L1Contracts{L2OutputOracleProxy: l2OutputOracle}}
+	reprocess := func(fromHeight, toHeight int64) {
+		cfg := processors.ReprocessConfig{L1FromHeight: big.NewInt(fromHeight), L1ToHeight: big.NewInt(toHeight), Initiated: true, BatchSize: 2}
+		err := processors.ReprocessBridgeEvents(context.Background(), testlog.Logger(t, log.LvlInfo), db, bridge.NewMetrics(metrics.NewRegistry()), chainConfig, cfg)
+		require.NoError(t, err)
+	}
+	requireOutputs := func() {
+		output, err := db.Blocks.OutputProposal(big.NewInt(2))
+		require.NoError(t, err)
+		require.NotNil(t, output)
+		require.Equal(t, common.HexToHash("0xd"), output.OutputRoot)
+		require.Equal(t, reproposed.GUID, output.OutputProposedGUID)
+
+		output, err = db.Blocks.LatestOutputProposal()
+		require.NoError(t, err)
+		require.NotNil(t, output)
+		require.Equal(t, uint64(3), output.L2OutputIndex.Uint64())
+		require.Equal(t, latest.GUID, output.OutputProposedGUID)
+	}
+
+	// Initial processing of the entire range
+	reprocess(1, 6)
+	requireOutputs()
+
+	// Reprocessing the deletion removes the outputs proposed after the range, which are then replayed
+	reprocess(3, 4)
+	requireOutputs()
+
+	// Idempotent
+	reprocess(1, 6)
+	requireOutputs()
+}
+
+// openTestDatabase opens a migrated test database, without starting a rollup system
+func openTestDatabase(t *testing.T) *database.DB {
+	dbName := setupTestDatabase(t)
+	dbConfig := config.DBConfig{Host: "127.0.0.1", Port: 5432, Name: dbName, User: os.Getenv("DB_USER")}
+
+	db, err := database.NewDB(context.Background(), testlog.Logger(t, log.LvlInfo), dbConfig)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
@@ -40,8 +40,9 @@ type E2ETestSuite struct {
 	API    *api.APIService
 
 	// Indexer
-	DB      *database.DB
-	Indexer *indexer.Indexer
+	DB         *database.DB
+	Indexer    *indexer.Indexer
+	IndexerCfg *config.Config
 
 	// Rollup
 	OpCfg *op_e2e.SystemConfig
@@ -120,7 +121,10 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 	require.NoError(t, ix.Start(context.Background()), "cleanly start indexer")
 
 	t.Cleanup(func() {
-		require.NoError(t, ix.Stop(context.Background()), "cleanly shut down indexer")
+		// tests may stop the indexer themselves to restart it
+		if !ix.Stopped() {
+			require.NoError(t, ix.Stop(context.Background()), "cleanly shut down indexer")
+		}
 	})
 
 	apiLog := testlog.Logger(t, log.LvlInfo).New("role", "indexer_api")
@@ -160,6 +164,7 @@ func createE2ETestSuite(t *testing.T) E2ETestSuite {
 		Client:          client,
 		DB:              ix.DB,
 		Indexer:         ix,
+		IndexerCfg:      indexerCfg,
 		OpCfg:           &opCfg,
 		OpSys:           opSys,
 		L1Client:        opSys.Clients["l1"],
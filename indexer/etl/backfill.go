@@ -0,0 +1,177 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// BackfillConfig configures the range of blocks, and the contracts, to backfill
+type BackfillConfig struct {
+	FromHeight *big.Int
+	ToHeight   *big.Int
+	Contracts  []common.Address
+
+	// Number of blocks extracted & persisted per batch
+	BatchSize uint64
+}
+
+// BackfillL1 extracts and persists the logs emitted by the configured contracts within an already traversed range
+// of L1 blocks, along with their headers. Logs that have already been indexed are ignored, such that the range can
+// be backfilled alongside the L1ETL. Each batch is persisted in its own short-lived transaction.
+func BackfillL1(ctx context.Context, log log.Logger, db *database.DB, client node.EthClient, cfg BackfillConfig) error {
+	log = log.New("etl", "l1", "mode", "backfill")
+	latestHeader, err := db.Blocks.L1LatestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("unable to query latest indexed header: %w", err)
+	}
+
+	var latestHeight *big.Int
+	if latestHeader != nil {
+		latestHeight = latestHeader.Number
+	}
+
+	return backfill(ctx, log, client, cfg, latestHeight, func(headers []database.BlockHeader, events []database.ContractEvent) error {
+		l1BlockHeaders := make([]database.L1BlockHeader, len(headers))
+		for i := range headers {
+			l1BlockHeaders[i] = database.L1BlockHeader{BlockHeader: headers[i]}
+		}
+		l1ContractEvents := make([]database.L1ContractEvent, len(events))
+		for i := range events {
+			l1ContractEvents[i] = database.L1ContractEvent{ContractEvent: events[i]}
+		}
+
+		return db.Transaction(func(tx *database.DB) error {
+			if err := tx.Blocks.StoreL1BlockHeaders(l1BlockHeaders); err != nil {
+				return err
+			}
+			return tx.ContractEvents.StoreL1ContractEvents(l1ContractEvents)
+		})
+	})
+}
+
+// BackfillL2 extracts and persists the logs emitted by the configured contracts within an already traversed range
+// of L2 blocks. Logs that have already been indexed are ignored, such that the range can be backfilled alongside
+// the L2ETL. Each batch is persisted in its own short-lived transaction.
+func BackfillL2(ctx context.Context, log log.Logger, db *database.DB, client node.EthClient, cfg BackfillConfig) error {
+	log = log.New("etl", "l2", "mode", "backfill")
+	latestHeader, err := db.Blocks.L2LatestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("unable to query latest indexed header: %w", err)
+	}
+
+	var latestHeight *big.Int
+	if latestHeader != nil {
+		latestHeight = latestHeader.Number
+	}
+
+	return backfill(ctx, log, client, cfg, latestHeight, func(headers []database.BlockHeader, events []database.ContractEvent) error {
+		l2BlockHeaders := make([]database.L2BlockHeader, len(headers))
+		for i := range headers {
+			l2BlockHeaders[i] = database.L2BlockHeader{BlockHeader: headers[i]}
+		}
+		l2ContractEvents := make([]database.L2ContractEvent, len(events))
+		for i := range events {
+			l2ContractEvents[i] = database.L2ContractEvent{ContractEvent: events[i]}
+		}
+
+		return db.Transaction(func(tx *database.DB) error {
+			if err := tx.Blocks.StoreL2BlockHeaders(l2BlockHeaders); err != nil {
+				return err
+			}
+			return tx.ContractEvents.StoreL2ContractEvents(l2ContractEvents)
+		})
+	})
+}
+
+func backfill(ctx context.Context, log log.Logger, client node.EthClient, cfg BackfillConfig, latestHeight *big.Int,
+	store func([]database.BlockHeader, []database.ContractEvent) error) error {
+	if len(cfg.Contracts) == 0 {
+		return errors.New("no contracts to backfill")
+	} else if cfg.BatchSize == 0 {
+		return errors.New("batch size must be greater than zero")
+	} else if cfg.FromHeight.Cmp(cfg.ToHeight) > 0 {
+		return fmt.Errorf("from height %d is greater than to height %d", cfg.FromHeight, cfg.ToHeight)
+	}
+
+	// Blocks beyond the indexed state are yet to be traversed by the ETL
+	if latestHeight == nil || cfg.ToHeight.Cmp(latestHeight) > 0 {
+		return fmt.Errorf("to height %d is beyond the latest indexed height %v", cfg.ToHeight, latestHeight)
+	}
+
+	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
+	for fromHeight := cfg.FromHeight; fromHeight.Cmp(cfg.ToHeight) <= 0; {
+		toHeight := bigint.Clamp(fromHeight, cfg.ToHeight, cfg.BatchSize)
+		batchLog := log.New("batch_start_block_number", fromHeight, "batch_end_block_number", toHeight)
+
+		if _, err := retry.Do[interface{}](ctx, 10, retryStrategy, func() (interface{}, error) {
+			headers, events, err := extractBackfillBatch(client, cfg.Contracts, fromHeight, toHeight)
+			if err != nil {
+				batchLog.Warn("unable to extract batch", "err", err)
+				return nil, err
+			} else if len(events) == 0 {
+				batchLog.Info("no logs in batch")
+				return nil, nil
+			}
+
+			if err := store(headers, events); err != nil {
+				batchLog.Error("unable to persist batch", "err", err)
+				return nil, fmt.Errorf("unable to persist batch: %w", err)
+			}
+
+			batchLog.Info("backfilled batch", "headers", len(headers), "logs", len(events))
+			return nil, nil
+		}); err != nil {
+			return err
+		}
+
+		fromHeight = new(big.Int).Add(toHeight, bigint.One)
+	}
+
+	log.Info("finished backfill", "from_block_number", cfg.FromHeight, "to_block_number", cfg.ToHeight)
+	return nil
+}
+
+// extractBackfillBatch returns the logs emitted by the contracts within the range, along with their headers
+func extractBackfillBatch(client node.EthClient, contracts []common.Address, fromHeight, toHeight *big.Int) ([]database.BlockHeader, []database.ContractEvent, error) {
+	logs, err := client.FilterLogs(ethereum.FilterQuery{FromBlock: fromHeight, ToBlock: toHeight, Addresses: contracts})
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to extract logs: %w", err)
+	} else if logs.ToBlockHeader.Number.Cmp(toHeight) != 0 {
+		return nil, nil, fmt.Errorf("mismatch in FilterLog#ToBlock number: %d", logs.ToBlockHeader.Number)
+	}
+
+	headerMap := make(map[common.Hash]*types.Header)
+	var headers []database.BlockHeader
+	events := make([]database.ContractEvent, len(logs.Logs))
+	for i := range logs.Logs {
+		log := logs.Logs[i]
+		header, ok := headerMap[log.BlockHash]
+		if !ok {
+			header, err = client.BlockHeaderByHash(log.BlockHash)
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to query header %s: %w", log.BlockHash, err)
+			} else if header.Number.Uint64() != log.BlockNumber {
+				return nil, nil, fmt.Errorf("log found with an inconsistent block %s", log.BlockHash)
+			}
+
+			headerMap[log.BlockHash] = header
+			headers = append(headers, database.BlockHeaderFromHeader(header))
+		}
+
+		events[i] = database.ContractEventFromLog(&logs.Logs[i], header.Time)
+	}
+
+	return headers, events, nil
+}
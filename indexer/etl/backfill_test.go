@@ -0,0 +1,132 @@
+package etl
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBackfillRanges(t *testing.T) {
+	contract := common.HexToAddress("0x42")
+	header := func(number int64) *types.Header {
+		return &types.Header{Number: big.NewInt(number), Time: uint64(number)}
+	}
+	filterLogs := func(client *node.MockEthClient, from, to int64, logs []types.Log) {
+		query := ethereum.FilterQuery{FromBlock: big.NewInt(from), ToBlock: big.NewInt(to), Addresses: []common.Address{contract}}
+		client.On("FilterLogs", query).Return(node.Logs{Logs: logs, ToBlockHeader: header(to)}, nil).Once()
+	}
+
+	type batch struct {
+		headers []database.BlockHeader
+		events  []database.ContractEvent
+	}
+	run := func(client *node.MockEthClient, cfg BackfillConfig, latestHeight *big.Int) ([]batch, error) {
+		var batches []batch
+		err := backfill(context.Background(), testlog.Logger(t, log.LvlInfo), client, cfg, latestHeight, func(headers []database.BlockHeader, events []database.ContractEvent) error {
+			batches = append(batches, batch{headers, events})
+			return nil
+		})
+		return batches, err
+	}
+
+	t.Run("Batches", func(t *testing.T) {
+		client := new(node.MockEthClient)
+		block3, block5 := header(3), header(5)
+		filterLogs(client, 1, 2, nil)
+		filterLogs(client, 3, 4, []types.Log{
+			{Address: contract, BlockHash: block3.Hash(), BlockNumber: 3, Index: 0},
+			{Address: contract, BlockHash: block3.Hash(), BlockNumber: 3, Index: 1},
+		})
+		filterLogs(client, 5, 5, []types.Log{{Address: contract, BlockHash: block5.Hash(), BlockNumber: 5}})
+		client.On("BlockHeaderByHash", block3.Hash()).Return(block3, nil).Once()
+		client.On("BlockHeaderByHash", block5.Hash()).Return(block5, nil).Once()
+
+		cfg := BackfillConfig{FromHeight: big.NewInt(1), ToHeight: big.NewInt(5), Contracts: []common.Address{contract}, BatchSize: 2}
+		batches, err := run(client, cfg, big.NewInt(10))
+		require.NoError(t, err)
+		client.AssertExpectations(t)
+
+		// Batches without logs are not persisted and headers are only fetched once per block
+		require.Len(t, batches, 2)
+		require.Len(t, batches[0].headers, 1)
+		require.Equal(t, block3.Hash(), batches[0].headers[0].Hash)
+		require.Len(t, batches[0].events, 2)
+		require.Equal(t, uint64(3), batches[0].events[0].Timestamp)
+
+		require.Len(t, batches[1].headers, 1)
+		require.Equal(t, block5.Hash(), batches[1].headers[0].Hash)
+		require.Len(t, batches[1].events, 1)
+	})
+
+	t.Run("SingleBlock", func(t *testing.T) {
+		client := new(node.MockEthClient)
+		filterLogs(client, 7, 7, nil)
+
+		cfg := BackfillConfig{FromHeight: big.NewInt(7), ToHeight: big.NewInt(7), Contracts: []common.Address{contract}, BatchSize: 100}
+		batches, err := run(client, cfg, big.NewInt(7))
+		require.NoError(t, err)
+		require.Empty(t, batches)
+		client.AssertExpectations(t)
+	})
+
+	t.Run("InvalidRanges", func(t *testing.T) {
+		valid := BackfillConfig{FromHeight: big.NewInt(1), ToHeight: big.NewInt(5), Contracts: []common.Address{contract}, BatchSize: 2}
+
+		cfg := valid
+		cfg.FromHeight = big.NewInt(6)
+		_, err := run(new(node.MockEthClient), cfg, big.NewInt(10))
+		require.ErrorContains(t, err, "greater than to height")
+
+		// Blocks beyond the indexed state are yet to be traversed
+		_, err = run(new(node.MockEthClient), valid, big.NewInt(4))
+		require.ErrorContains(t, err, "beyond the latest indexed height")
+		_, err = run(new(node.MockEthClient), valid, nil)
+		require.ErrorContains(t, err, "beyond the latest indexed height")
+
+		cfg = valid
+		cfg.BatchSize = 0
+		_, err = run(new(node.MockEthClient), cfg, big.NewInt(10))
+		require.Error(t, err)
+
+		cfg = valid
+		cfg.Contracts = nil
+		_, err = run(new(node.MockEthClient), cfg, big.NewInt(10))
+		require.Error(t, err)
+	})
+}
+
+func TestExtractBackfillBatch(t *testing.T) {
+	contract := common.HexToAddress("0x42")
+	query := ethereum.FilterQuery{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2), Addresses: []common.Address{contract}}
+
+	t.Run("ToBlockMismatch", func(t *testing.T) {
+		client := new(node.MockEthClient)
+		client.On("FilterLogs", query).Return(node.Logs{ToBlockHeader: &types.Header{Number: big.NewInt(1)}}, nil)
+
+		_, _, err := extractBackfillBatch(client, []common.Address{contract}, big.NewInt(1), big.NewInt(2))
+		require.ErrorContains(t, err, "mismatch in FilterLog#ToBlock number")
+	})
+
+	t.Run("InconsistentBlock", func(t *testing.T) {
+		client := new(node.MockEthClient)
+		block := &types.Header{Number: big.NewInt(2)}
+		logs := []types.Log{{Address: contract, BlockHash: block.Hash(), BlockNumber: 1}}
+		client.On("FilterLogs", query).Return(node.Logs{Logs: logs, ToBlockHeader: block}, nil)
+		client.On("BlockHeaderByHash", mock.Anything).Return(block, nil)
+
+		_, _, err := extractBackfillBatch(client, []common.Address{contract}, big.NewInt(1), big.NewInt(2))
+		require.ErrorContains(t, err, "inconsistent block")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -16,6 +17,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
+// errReorgDetected indicates that the extracted batch is inconsistent with the provider's
+// view of the chain. The indexed state is rolled back to the common ancestor and re-indexed
+var errReorgDetected = errors.New("reorg detected")
+
 type Config struct {
 	LoopIntervalMsec uint
 	HeaderBufferSize uint
@@ -41,6 +46,13 @@ type ETL struct {
 	// in the event of failures in order to retry.
 	headers []types.Header
 
+	// Batches handed off to the consumer that have yet to be persisted
+	inflight sync.WaitGroup
+
+	// Rolls back the indexed state that's no longer canonical, returning the common
+	// ancestor from which traversal resumes. Supplied by the L1 & L2 ETLs.
+	reorgHandler func() (*types.Header, error)
+
 	worker *clock.LoopFn
 }
 
@@ -80,7 +92,11 @@ func (etl *ETL) tick(_ context.Context) {
 		etl.log.Info("retrying previous batch")
 	} else {
 		newHeaders, err := etl.headerTraversal.NextHeaders(etl.headerBufferSize)
-		if err != nil {
+		if errors.Is(err, node.ErrHeaderTraversalAndProviderMismatchedState) {
+			etl.log.Warn("last traversed header no longer canonical", "number", etl.headerTraversal.LastTraversedHeader().Number)
+			done(etl.handleReorg())
+			return
+		} else if err != nil {
 			etl.log.Error("error querying for headers", "err", err)
 		} else if len(newHeaders) == 0 {
 			etl.log.Warn("no new headers. etl at head?")
@@ -96,13 +112,39 @@ func (etl *ETL) tick(_ context.Context) {
 
 	// only clear the reference if we were able to process this batch
 	err := etl.processBatch(etl.headers)
-	if err == nil {
+	if errors.Is(err, errReorgDetected) {
+		err = etl.handleReorg()
+	} else if err == nil {
 		etl.headers = nil
 	}
 
 	done(err)
 }
 
+// handleReorg rolls back the indexed state to the common ancestor with the provider
+// and rewinds the header traversal such that the canonical chain is re-indexed
+func (etl *ETL) handleReorg() error {
+	etl.metrics.RecordReorg()
+
+	// the consumer must have persisted all handed off batches prior to the rollback
+	etl.inflight.Wait()
+	ancestor, err := etl.reorgHandler()
+	if err != nil {
+		etl.log.Error("unable to rollback reorged state", "err", err)
+		return err
+	}
+
+	if ancestor != nil {
+		etl.log.Warn("rewinding to common ancestor", "number", ancestor.Number, "hash", ancestor.Hash())
+	} else {
+		etl.log.Warn("rewinding to genesis")
+	}
+
+	etl.headers = nil
+	etl.headerTraversal.Rewind(ancestor)
+	return nil
+}
+
 func (etl *ETL) processBatch(headers []types.Header) error {
 	if len(headers) == 0 {
 		return nil
@@ -131,8 +173,8 @@ func (etl *ETL) processBatch(headers []types.Header) error {
 		batchLog.Warn("mismatch in FilterLog#ToBlock number", "queried_to_block_number", lastHeader.Number, "reported_to_block_number", logs.ToBlockHeader.Number)
 		return fmt.Errorf("mismatch in FilterLog#ToBlock number")
 	} else if logs.ToBlockHeader.Hash() != lastHeader.Hash() {
-		batchLog.Error("mismatch in FitlerLog#ToBlock block hash!!!", "queried_to_block_hash", lastHeader.Hash().String(), "reported_to_block_hash", logs.ToBlockHeader.Hash().String())
-		return fmt.Errorf("mismatch in FitlerLog#ToBlock block hash!!!")
+		batchLog.Warn("mismatch in FilterLog#ToBlock block hash", "queried_to_block_hash", lastHeader.Hash().String(), "reported_to_block_hash", logs.ToBlockHeader.Hash().String())
+		return fmt.Errorf("mismatch in FilterLog#ToBlock block hash: %w", errReorgDetected)
 	}
 
 	if len(logs.Logs) > 0 {
@@ -143,16 +185,15 @@ func (etl *ETL) processBatch(headers []types.Header) error {
 		log := logs.Logs[i]
 		headersWithLog[log.BlockHash] = true
 		if _, ok := headerMap[log.BlockHash]; !ok {
-			// NOTE. Definitely an error state if the none of the headers were re-orged out in between
-			// the blocks and logs retrieval operations. Unlikely as long as the confirmation depth has
-			// been appropriately set or when we get to natively handling reorgs.
-			batchLog.Error("log found with block hash not in the batch", "block_hash", logs.Logs[i].BlockHash, "log_index", logs.Logs[i].Index)
-			return errors.New("parsed log with a block hash not in the batch")
+			// The headers were reorged out in between the blocks and logs retrieval operations
+			batchLog.Warn("log found with block hash not in the batch", "block_hash", logs.Logs[i].BlockHash, "log_index", logs.Logs[i].Index)
+			return fmt.Errorf("parsed log with a block hash not in the batch: %w", errReorgDetected)
 		}
 	}
 
 	// ensure we use unique downstream references for the etl batch
 	headersRef := headers
+	etl.inflight.Add(1)
 	etl.etlBatches <- &ETLBatch{Logger: batchLog, Headers: headersRef, HeaderMap: headerMap, Logs: logs.Logs, HeadersWithLog: headersWithLog}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"gorm.io/gorm"
+
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
@@ -29,56 +32,97 @@ type L1ETL struct {
 
 	tasks tasks.Group
 
-	db *database.DB
+	chains []*l1EtlChain
 
 	mu        sync.Mutex
 	listeners []chan interface{}
 }
 
+// L1ETLChain configures an L2 chain whose L1 contracts are indexed by a shared L1ETL
+type L1ETLChain struct {
+	ChainID     uint64
+	DB          *database.DB
+	Contracts   config.L1Contracts
+	StartHeight *big.Int
+}
+
+// l1EtlChain is the state of an L2 chain indexed by the L1ETL. Only the logs
+// emitted by the chain's contracts, and their headers, are persisted in its DB
+type l1EtlChain struct {
+	log         log.Logger
+	db          *database.DB
+	contracts   map[common.Address]bool
+	startHeight *big.Int
+
+	// headers up to this height are skipped, set when starting without indexed state
+	skipHeight *big.Int
+}
+
 // NewL1ETL creates a new L1ETL instance that will start indexing from different starting points
 // depending on the state of the database and the supplied start height.
 func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, client node.EthClient,
 	contracts config.L1Contracts, shutdown context.CancelCauseFunc) (*L1ETL, error) {
+	chain := L1ETLChain{DB: db, Contracts: contracts, StartHeight: cfg.StartHeight}
+	return NewSharedL1ETL(cfg, log, []L1ETLChain{chain}, metrics, client, shutdown)
+}
+
+// NewSharedL1ETL creates a new L1ETL instance indexing the L1 contracts of several L2 chains settling
+// on the same L1. Each batch is persisted in the DB of every chain, filtered to the chain's contracts.
+// Traversal starts from the chain furthest behind, superseding the start height of the supplied config.
+func NewSharedL1ETL(cfg Config, log log.Logger, chains []L1ETLChain, metrics Metricer, client node.EthClient,
+	shutdown context.CancelCauseFunc) (*L1ETL, error) {
 	log = log.New("etl", "l1")
+	if len(chains) == 0 {
+		return nil, errors.New("no chains configured")
+	}
 
 	zeroAddr := common.Address{}
 	l1Contracts := []common.Address{}
-	if err := contracts.ForEach(func(name string, addr common.Address) error {
-		// Since we dont have backfill support yet, we want to make sure all expected
-		// contracts are specified to ensure consistent behavior. Once backfill support
-		// is ready, we can relax this requirement.
-		if addr == zeroAddr && !strings.HasPrefix(name, "Legacy") {
-			log.Error("address not configured", "name", name)
-			return errors.New("all L1Contracts must be configured")
+	filterContracts := make(map[common.Address]bool)
+	etlChains := make([]*l1EtlChain, len(chains))
+
+	// Determine the starting height for traversal
+	var fromHeader *types.Header
+	for i, chain := range chains {
+		chainLog := log
+		if chain.ChainID != 0 {
+			chainLog = log.New("chain_id", chain.ChainID)
 		}
 
-		log.Info("configured contract", "name", name, "addr", addr)
-		l1Contracts = append(l1Contracts, addr)
-		return nil
-	}); err != nil {
-		return nil, err
-	}
+		contracts := make(map[common.Address]bool)
+		if err := chain.Contracts.ForEach(func(name string, addr common.Address) error {
+			// Since we dont have backfill support yet, we want to make sure all expected
+			// contracts are specified to ensure consistent behavior. Once backfill support
+			// is ready, we can relax this requirement.
+			if addr == zeroAddr && !strings.HasPrefix(name, "Legacy") {
+				chainLog.Error("address not configured", "name", name)
+				return errors.New("all L1Contracts must be configured")
+			}
 
-	latestHeader, err := db.Blocks.L1LatestBlockHeader()
-	if err != nil {
-		return nil, err
-	}
+			chainLog.Info("configured contract", "name", name, "addr", addr)
+			// contracts may be shared by several chains
+			if !filterContracts[addr] {
+				filterContracts[addr] = true
+				l1Contracts = append(l1Contracts, addr)
+			}
+			contracts[addr] = true
+			return nil
+		}); err != nil {
+			return nil, err
+		}
 
-	// Determine the starting height for traversal
-	var fromHeader *types.Header
-	if latestHeader != nil {
-		log.Info("detected last indexed block", "number", latestHeader.Number, "hash", latestHeader.Hash)
-		fromHeader = latestHeader.RLPHeader.Header()
-	} else if cfg.StartHeight.BitLen() > 0 {
-		log.Info("no indexed state starting from supplied L1 height", "height", cfg.StartHeight.String())
-		header, err := client.BlockHeaderByNumber(cfg.StartHeight)
+		etlChain := &l1EtlChain{log: chainLog, db: chain.DB, contracts: contracts, startHeight: chain.StartHeight}
+		startHeader, err := etlChain.startHeader(client)
 		if err != nil {
-			return nil, fmt.Errorf("could not fetch starting block header: %w", err)
+			return nil, err
 		}
 
-		fromHeader = header
-	} else {
-		log.Info("no indexed state, starting from genesis")
+		if i == 0 {
+			fromHeader = startHeader
+		} else {
+			fromHeader = earliestHeader(fromHeader, startHeader)
+		}
+		etlChains[i] = etlChain
 	}
 
 	// NOTE - The use of un-buffered channel here assumes that downstream consumers
@@ -86,31 +130,68 @@ func NewL1ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 	// When the producer closes the channel we stop consuming from it.
 	etlBatches := make(chan *ETLBatch)
 
-	etl := ETL{
-		loopInterval:     time.Duration(cfg.LoopIntervalMsec) * time.Millisecond,
-		headerBufferSize: uint64(cfg.HeaderBufferSize),
-
-		log:             log,
-		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
-		contracts:       l1Contracts,
-		etlBatches:      etlBatches,
-
-		EthClient: client,
-	}
-
 	resCtx, resCancel := context.WithCancel(context.Background())
-	return &L1ETL{
-		ETL:          etl,
+	l1Etl := &L1ETL{
+		ETL: ETL{
+			loopInterval:     time.Duration(cfg.LoopIntervalMsec) * time.Millisecond,
+			headerBufferSize: uint64(cfg.HeaderBufferSize),
+
+			log:             log,
+			metrics:         metrics,
+			headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+			contracts:       l1Contracts,
+			etlBatches:      etlBatches,
+
+			EthClient: client,
+		},
 		LatestHeader: fromHeader,
 
-		db:             db,
+		chains:         etlChains,
 		resourceCtx:    resCtx,
 		resourceCancel: resCancel,
 		tasks: tasks.Group{HandleCrit: func(err error) {
 			shutdown(fmt.Errorf("critical error in L1 ETL: %w", err))
 		}},
-	}, nil
+	}
+
+	l1Etl.reorgHandler = l1Etl.handleReorg
+	return l1Etl, nil
+}
+
+// startHeader returns the header from which the chain is indexed, resuming from the last
+// indexed header if present. A nil header indicates that the chain is indexed from genesis
+func (c *l1EtlChain) startHeader(client node.EthClient) (*types.Header, error) {
+	latestHeader, err := c.db.Blocks.L1LatestBlockHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	c.skipHeight = nil
+	if latestHeader != nil {
+		c.log.Info("detected last indexed block", "number", latestHeader.Number, "hash", latestHeader.Hash)
+		return latestHeader.RLPHeader.Header(), nil
+	} else if c.startHeight != nil && c.startHeight.BitLen() > 0 {
+		c.log.Info("no indexed state starting from supplied L1 height", "height", c.startHeight.String())
+		c.skipHeight = c.startHeight
+		header, err := client.BlockHeaderByNumber(c.startHeight)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch starting block header: %w", err)
+		}
+		return header, nil
+	}
+
+	c.log.Info("no indexed state, starting from genesis")
+	return nil, nil
+}
+
+// earliestHeader returns the lower of the two headers, where nil represents genesis
+func earliestHeader(a, b *types.Header) *types.Header {
+	if a == nil || b == nil {
+		return nil
+	} else if b.Number.Cmp(a.Number) < 0 {
+		return b
+	}
+	return a
 }
 
 func (l1Etl *L1ETL) Close() error {
@@ -142,7 +223,9 @@ func (l1Etl *L1ETL) Start() error {
 	// start ETL batch consumer
 	l1Etl.tasks.Go(func() error {
 		for batch := range l1Etl.etlBatches {
-			if err := l1Etl.handleBatch(batch); err != nil {
+			err := l1Etl.handleBatch(batch)
+			l1Etl.inflight.Done()
+			if err != nil {
 				return fmt.Errorf("failed to handle batch, stopping L2 ETL: %w", err)
 			}
 		}
@@ -153,10 +236,50 @@ func (l1Etl *L1ETL) Start() error {
 }
 
 func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
-	// Index incoming batches (only L1 blocks that have an emitted log)
-	l1BlockHeaders := make([]database.L1BlockHeader, 0, len(batch.Headers))
+	for _, chain := range l1Etl.chains {
+		if err := l1Etl.storeBatch(chain, batch); err != nil {
+			return err
+		}
+	}
+
+	batch.Logger.Info("indexed batch")
+	l1Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+
+	// Notify Listeners
+	l1Etl.mu.Lock()
+	defer l1Etl.mu.Unlock()
+	for i := range l1Etl.listeners {
+		select {
+		case l1Etl.listeners[i] <- struct{}{}:
+		default:
+			// do nothing if the listener hasn't picked
+			// up the previous notif
+		}
+	}
+
+	return nil
+}
+
+// storeBatch persists the logs emitted by the chain's contracts in the batch, along with their headers
+func (l1Etl *L1ETL) storeBatch(chain *l1EtlChain, batch *ETLBatch) error {
+	// Index incoming batches (only L1 blocks that have an emitted log). When sharing traversal with other
+	// chains, blocks at or prior to the chain's starting height may be traversed and are skipped
+	l1ContractEvents := make([]database.L1ContractEvent, 0, len(batch.Logs))
+	headersWithLog := make(map[common.Hash]bool, len(batch.HeadersWithLog))
+	for i := range batch.Logs {
+		header := batch.HeaderMap[batch.Logs[i].BlockHash]
+		if !chain.contracts[batch.Logs[i].Address] || chain.skipsHeader(header) {
+			continue
+		}
+
+		headersWithLog[header.Hash()] = true
+		l1ContractEvents = append(l1ContractEvents, database.L1ContractEvent{ContractEvent: database.ContractEventFromLog(&batch.Logs[i], header.Time)})
+		l1Etl.ETL.metrics.RecordIndexedLog(batch.Logs[i].Address)
+	}
+
+	l1BlockHeaders := make([]database.L1BlockHeader, 0, len(headersWithLog))
 	for i := range batch.Headers {
-		if _, ok := batch.HeadersWithLog[batch.Headers[i].Hash()]; ok {
+		if _, ok := headersWithLog[batch.Headers[i].Hash()]; ok {
 			l1BlockHeaders = append(l1BlockHeaders, database.L1BlockHeader{BlockHeader: database.BlockHeaderFromHeader(&batch.Headers[i])})
 		}
 	}
@@ -166,17 +289,10 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 		return nil
 	}
 
-	l1ContractEvents := make([]database.L1ContractEvent, len(batch.Logs))
-	for i := range batch.Logs {
-		timestamp := batch.HeaderMap[batch.Logs[i].BlockHash].Time
-		l1ContractEvents[i] = database.L1ContractEvent{ContractEvent: database.ContractEventFromLog(&batch.Logs[i], timestamp)}
-		l1Etl.ETL.metrics.RecordIndexedLog(batch.Logs[i].Address)
-	}
-
 	// Continually try to persist this batch. If it fails after 10 attempts, we simply error out
 	retryStrategy := &retry.ExponentialStrategy{Min: 1000, Max: 20_000, MaxJitter: 250}
 	if _, err := retry.Do[interface{}](l1Etl.resourceCtx, 10, retryStrategy, func() (interface{}, error) {
-		if err := l1Etl.db.Transaction(func(tx *database.DB) error {
+		if err := chain.db.Transaction(func(tx *database.DB) error {
 			if err := tx.Blocks.StoreL1BlockHeaders(l1BlockHeaders); err != nil {
 				return err
 			}
@@ -199,22 +315,81 @@ func (l1Etl *L1ETL) handleBatch(batch *ETLBatch) error {
 		return err
 	}
 
-	batch.Logger.Info("indexed batch")
-	l1Etl.LatestHeader = &batch.Headers[len(batch.Headers)-1]
+	return nil
+}
 
-	// Notify Listeners
-	l1Etl.mu.Lock()
-	defer l1Etl.mu.Unlock()
-	for i := range l1Etl.listeners {
-		select {
-		case l1Etl.listeners[i] <- struct{}{}:
-		default:
-			// do nothing if the listener hasn't picked
-			// up the previous notif
+// skipsHeader returns true if the header is at or prior to the configured starting height of a
+// chain that started without indexed state, which is only traversed when shared with other chains
+func (c *l1EtlChain) skipsHeader(header *types.Header) bool {
+	return c.skipHeight != nil && header.Number.Cmp(c.skipHeight) <= 0
+}
+
+// handleReorg rolls back the indexed L1 state of every chain to the latest indexed header that is still
+// part of the canonical chain. Traversal resumes from the earliest common ancestor across the chains
+func (l1Etl *L1ETL) handleReorg() (*types.Header, error) {
+	var ancestor *types.Header
+	for i, chain := range l1Etl.chains {
+		chainAncestor, err := l1Etl.rollback(chain)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			ancestor = chainAncestor
+		} else {
+			ancestor = earliestHeader(ancestor, chainAncestor)
 		}
 	}
 
-	return nil
+	l1Etl.LatestHeader = ancestor
+	if ancestor != nil {
+		l1Etl.ETL.metrics.RecordIndexedLatestHeight(ancestor.Number)
+	}
+	return ancestor, nil
+}
+
+// rollback rolls back the indexed L1 state of the chain to the latest indexed header that is still part of
+// the canonical chain. Since only L1 headers with emitted logs are indexed, the returned ancestor may be
+// further back than the fork point. This is fine as the unindexed headers are re-traversed.
+func (l1Etl *L1ETL) rollback(chain *l1EtlChain) (*types.Header, error) {
+	indexedHeader, err := chain.db.Blocks.L1LatestBlockHeader()
+	if err != nil {
+		return nil, fmt.Errorf("unable to query latest indexed header: %w", err)
+	}
+
+	if indexedHeader == nil {
+		// Nothing to rollback. Restart traversal from the configured starting height
+		chain.log.Warn("no indexed state to rollback")
+		return chain.startHeader(l1Etl.EthClient)
+	}
+
+	for indexedHeader != nil {
+		header, err := l1Etl.EthClient.BlockHeaderByNumber(indexedHeader.Number)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query canonical header %d: %w", indexedHeader.Number, err)
+		} else if header != nil && header.Hash() == indexedHeader.Hash {
+			break
+		}
+
+		chain.log.Warn("indexed header reorged", "number", indexedHeader.Number, "hash", indexedHeader.Hash)
+		number := indexedHeader.Number
+		indexedHeader, err = chain.db.Blocks.L1BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB {
+			return db.Where("number < ?", number).Order("number DESC")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to query indexed header: %w", err)
+		}
+	}
+
+	if indexedHeader == nil {
+		return nil, errors.New("no common ancestor found with the indexed L1 state")
+	}
+
+	if err := chain.db.RewindL1(indexedHeader.Number); err != nil {
+		return nil, fmt.Errorf("unable to rewind L1 state: %w", err)
+	}
+
+	return indexedHeader.RLPHeader.Header(), nil
 }
 
 // Notify returns a channel that'll receive a value every time new data has
@@ -115,3 +115,47 @@ func TestL1ETLConstruction(t *testing.T) {
 		})
 	}
 }
+
+func TestSharedL1ETLConstruction(t *testing.T) {
+	etlMetrics := NewMetrics(metrics.NewRegistry(), "l1")
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	client := new(node.MockEthClient)
+	client.On("BlockHeaderByNumber", mock.MatchedBy(bigint.Matcher(100))).Return(
+		&types.Header{Number: big.NewInt(100)}, nil)
+
+	// resumes from the last indexed header
+	resumedDB := database.NewMockDB()
+	resumedDB.MockBlocks.On("L1LatestBlockHeader").Return(
+		&database.L1BlockHeader{BlockHeader: database.BlockHeader{RLPHeader: &database.RLPHeader{Number: big.NewInt(69)}}}, nil)
+
+	// starts from the configured height
+	newDB := database.NewMockDB()
+	newDB.MockBlocks.On("L1LatestBlockHeader").Return(nil, nil)
+
+	chains := []L1ETLChain{
+		{ChainID: 10, DB: resumedDB.DB, Contracts: config.Presets[10].ChainConfig.L1Contracts, StartHeight: big.NewInt(100)},
+		{ChainID: 8453, DB: newDB.DB, Contracts: config.Presets[8453].ChainConfig.L1Contracts, StartHeight: big.NewInt(100)},
+	}
+
+	etl, err := NewSharedL1ETL(Config{}, logger, chains, etlMetrics, client, func(cause error) {
+		t.Fatalf("crit error: %v", cause)
+	})
+	require.NoError(t, err)
+
+	// traversal starts from the chain furthest behind
+	require.Equal(t, big.NewInt(69), etl.headerTraversal.LastTraversedHeader().Number)
+
+	// logs of the contracts of all chains are extracted
+	for _, chain := range chains {
+		require.NoError(t, chain.Contracts.ForEach(func(name string, addr common.Address) error {
+			require.Contains(t, etl.contracts, addr)
+			return nil
+		}))
+	}
+
+	// only headers after the starting height of a chain without indexed state are persisted
+	require.False(t, etl.chains[0].skipsHeader(&types.Header{Number: big.NewInt(70)}))
+	require.True(t, etl.chains[1].skipsHeader(&types.Header{Number: big.NewInt(100)}))
+	require.False(t, etl.chains[1].skipsHeader(&types.Header{Number: big.NewInt(101)}))
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"gorm.io/gorm"
+
 	"github.com/ethereum-optimism/optimism/indexer/config"
 	"github.com/ethereum-optimism/optimism/indexer/database"
 	"github.com/ethereum-optimism/optimism/indexer/node"
@@ -70,22 +72,21 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 	}
 
 	etlBatches := make(chan *ETLBatch)
-	etl := ETL{
-		loopInterval:     time.Duration(cfg.LoopIntervalMsec) * time.Millisecond,
-		headerBufferSize: uint64(cfg.HeaderBufferSize),
-
-		log:             log,
-		metrics:         metrics,
-		headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
-		contracts:       l2Contracts,
-		etlBatches:      etlBatches,
-
-		EthClient: client,
-	}
 
 	resCtx, resCancel := context.WithCancel(context.Background())
-	return &L2ETL{
-		ETL:          etl,
+	l2Etl := &L2ETL{
+		ETL: ETL{
+			loopInterval:     time.Duration(cfg.LoopIntervalMsec) * time.Millisecond,
+			headerBufferSize: uint64(cfg.HeaderBufferSize),
+
+			log:             log,
+			metrics:         metrics,
+			headerTraversal: node.NewHeaderTraversal(client, fromHeader, cfg.ConfirmationDepth),
+			contracts:       l2Contracts,
+			etlBatches:      etlBatches,
+
+			EthClient: client,
+		},
 		LatestHeader: fromHeader,
 
 		resourceCtx:    resCtx,
@@ -94,7 +95,10 @@ func NewL2ETL(cfg Config, log log.Logger, db *database.DB, metrics Metricer, cli
 		tasks: tasks.Group{HandleCrit: func(err error) {
 			shutdown(fmt.Errorf("critical error in L2 ETL: %w", err))
 		}},
-	}, nil
+	}
+
+	l2Etl.reorgHandler = l2Etl.handleReorg
+	return l2Etl, nil
 }
 
 func (l2Etl *L2ETL) Close() error {
@@ -127,7 +131,9 @@ func (l2Etl *L2ETL) Start() error {
 	// start ETL batch consumer
 	l2Etl.tasks.Go(func() error {
 		for batch := range l2Etl.etlBatches {
-			if err := l2Etl.handleBatch(batch); err != nil {
+			err := l2Etl.handleBatch(batch)
+			l2Etl.inflight.Done()
+			if err != nil {
 				return fmt.Errorf("failed to handle batch, stopping L2 ETL: %w", err)
 			}
 		}
@@ -195,6 +201,51 @@ func (l2Etl *L2ETL) handleBatch(batch *ETLBatch) error {
 	return nil
 }
 
+// handleReorg rolls back the indexed L2 state to the latest indexed header that
+// is still part of the canonical chain
+func (l2Etl *L2ETL) handleReorg() (*types.Header, error) {
+	indexedHeader, err := l2Etl.db.Blocks.L2LatestBlockHeader()
+	if err != nil {
+		return nil, fmt.Errorf("unable to query latest indexed header: %w", err)
+	} else if indexedHeader == nil {
+		// Nothing to rollback. Restart traversal from genesis
+		l2Etl.log.Warn("no indexed state to rollback")
+		l2Etl.LatestHeader = nil
+		return nil, nil
+	}
+
+	for indexedHeader != nil {
+		header, err := l2Etl.EthClient.BlockHeaderByNumber(indexedHeader.Number)
+		if err != nil {
+			return nil, fmt.Errorf("unable to query canonical header %d: %w", indexedHeader.Number, err)
+		} else if header != nil && header.Hash() == indexedHeader.Hash {
+			break
+		}
+
+		l2Etl.log.Warn("indexed header reorged", "number", indexedHeader.Number, "hash", indexedHeader.Hash)
+		number := indexedHeader.Number
+		indexedHeader, err = l2Etl.db.Blocks.L2BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB {
+			return db.Where("number < ?", number).Order("number DESC")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to query indexed header: %w", err)
+		}
+	}
+
+	if indexedHeader == nil {
+		return nil, errors.New("no common ancestor found with the indexed L2 state")
+	}
+
+	if err := l2Etl.db.RewindL2(indexedHeader.Number); err != nil {
+		return nil, fmt.Errorf("unable to rewind L2 state: %w", err)
+	}
+
+	ancestor := indexedHeader.RLPHeader.Header()
+	l2Etl.LatestHeader = ancestor
+	l2Etl.ETL.metrics.RecordIndexedLatestHeight(ancestor.Number)
+	return ancestor, nil
+}
+
 // Notify returns a channel that'll receive a value every time new data has
 // been persisted by the L2ETL
 func (l2Etl *L2ETL) Notify() <-chan interface{} {
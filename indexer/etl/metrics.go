@@ -15,6 +15,7 @@ var (
 type Metricer interface {
 	RecordInterval() (done func(err error))
 	RecordLatestHeight(height *big.Int)
+	RecordReorg()
 
 	// Indexed Batches
 	RecordIndexedLatestHeight(height *big.Int)
@@ -27,13 +28,14 @@ type etlMetrics struct {
 	intervalDuration prometheus.Histogram
 	intervalFailures prometheus.Counter
 	latestHeight     prometheus.Gauge
+	reorgs           prometheus.Counter
 
 	indexedLatestHeight prometheus.Gauge
 	indexedHeaders      prometheus.Counter
 	indexedLogs         *prometheus.CounterVec
 }
 
-func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
+func NewMetrics(registry prometheus.Registerer, subsystem string) Metricer {
 	factory := metrics.With(registry)
 	return &etlMetrics{
 		intervalTick: factory.NewCounter(prometheus.CounterOpts{
@@ -60,6 +62,12 @@ func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
 			Name:      "latest_height",
 			Help:      "the latest height reported by the connected client",
 		}),
+		reorgs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Subsystem: subsystem,
+			Name:      "reorgs_total",
+			Help:      "number of times the etl has rolled back indexed state due to a reorg",
+		}),
 		indexedLatestHeight: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: MetricsNamespace,
 			Subsystem: subsystem,
@@ -98,6 +106,10 @@ func (m *etlMetrics) RecordLatestHeight(height *big.Int) {
 	m.latestHeight.Set(float64(height.Uint64()))
 }
 
+func (m *etlMetrics) RecordReorg() {
+	m.reorgs.Inc()
+}
+
 func (m *etlMetrics) RecordIndexedLatestHeight(height *big.Int) {
 	m.indexedLatestHeight.Set(float64(height.Uint64()))
 }
@@ -0,0 +1,167 @@
+package etl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+)
+
+// ErrVerificationFailed indicates that the indexed headers are inconsistent
+var ErrVerificationFailed = errors.New("indexed headers failed verification")
+
+// VerifyConfig configures the range of indexed headers to verify
+type VerifyConfig struct {
+	FromHeight *big.Int
+
+	// Defaults to the latest indexed header when unset
+	ToHeight *big.Int
+
+	// When set, headers are also verified to be canonical according to the client
+	Client node.EthClient
+
+	// Number of headers read per batch
+	BatchSize int
+}
+
+// VerifyL1Headers verifies the hash chain of the indexed L1 headers. Since only L1 headers with emitted logs
+// are indexed, parent hashes are only verified between indexed headers of consecutive heights.
+func VerifyL1Headers(ctx context.Context, log log.Logger, db *database.DB, cfg VerifyConfig) error {
+	log = log.New("etl", "l1", "mode", "verify")
+	if cfg.ToHeight == nil {
+		latestHeader, err := db.Blocks.L1LatestBlockHeader()
+		if err != nil {
+			return fmt.Errorf("unable to query latest indexed header: %w", err)
+		} else if latestHeader == nil {
+			log.Info("no indexed headers to verify")
+			return nil
+		}
+		cfg.ToHeight = latestHeader.Number
+	}
+
+	return verifyHeaders(ctx, log, cfg, false, func(fromHeight *big.Int) ([]database.BlockHeader, error) {
+		l1Headers, err := db.Blocks.L1BlockHeadersInRange(fromHeight, cfg.ToHeight, cfg.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		headers := make([]database.BlockHeader, len(l1Headers))
+		for i := range l1Headers {
+			headers[i] = l1Headers[i].BlockHeader
+		}
+		return headers, nil
+	})
+}
+
+// VerifyL2Headers verifies the hash chain of the indexed L2 headers, which must be contiguous
+func VerifyL2Headers(ctx context.Context, log log.Logger, db *database.DB, cfg VerifyConfig) error {
+	log = log.New("etl", "l2", "mode", "verify")
+	if cfg.ToHeight == nil {
+		latestHeader, err := db.Blocks.L2LatestBlockHeader()
+		if err != nil {
+			return fmt.Errorf("unable to query latest indexed header: %w", err)
+		} else if latestHeader == nil {
+			log.Info("no indexed headers to verify")
+			return nil
+		}
+		cfg.ToHeight = latestHeader.Number
+	}
+
+	return verifyHeaders(ctx, log, cfg, true, func(fromHeight *big.Int) ([]database.BlockHeader, error) {
+		l2Headers, err := db.Blocks.L2BlockHeadersInRange(fromHeight, cfg.ToHeight, cfg.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		headers := make([]database.BlockHeader, len(l2Headers))
+		for i := range l2Headers {
+			headers[i] = l2Headers[i].BlockHeader
+		}
+		return headers, nil
+	})
+}
+
+func verifyHeaders(ctx context.Context, log log.Logger, cfg VerifyConfig, contiguous bool, headersFrom func(*big.Int) ([]database.BlockHeader, error)) error {
+	if cfg.BatchSize <= 0 {
+		return errors.New("batch size must be greater than zero")
+	}
+
+	var prevHeader *database.BlockHeader
+	verified, failures := 0, 0
+	for fromHeight := cfg.FromHeight; fromHeight.Cmp(cfg.ToHeight) <= 0; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		headers, err := headersFrom(fromHeight)
+		if err != nil {
+			return fmt.Errorf("unable to query indexed headers: %w", err)
+		} else if len(headers) == 0 {
+			break
+		}
+
+		for i := range headers {
+			header := &headers[i]
+			for _, failure := range verifyHeader(cfg.Client, prevHeader, header, contiguous) {
+				log.Error("header failed verification", "number", header.Number, "hash", header.Hash, "reason", failure)
+				failures++
+			}
+			prevHeader = header
+		}
+
+		verified += len(headers)
+		log.Info("verified headers", "to_block_number", prevHeader.Number, "verified", verified, "failures", failures)
+		fromHeight = new(big.Int).Add(prevHeader.Number, bigint.One)
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%w: %d failures across %d headers", ErrVerificationFailed, failures, verified)
+	}
+
+	log.Info("finished verification", "verified", verified)
+	return nil
+}
+
+// verifyHeader returns the reasons the header fails verification, if any
+func verifyHeader(client node.EthClient, prevHeader, header *database.BlockHeader, contiguous bool) []string {
+	var failures []string
+	if header.RLPHeader == nil {
+		return append(failures, "missing rlp header")
+	}
+
+	// the stored header fields must be consistent with the stored RLP
+	rlpHeader := header.RLPHeader.Header()
+	if rlpHeader.Hash() != header.Hash {
+		failures = append(failures, fmt.Sprintf("rlp header hash %s mismatch", rlpHeader.Hash()))
+	}
+	if rlpHeader.ParentHash != header.ParentHash || rlpHeader.Number.Cmp(header.Number) != 0 || rlpHeader.Time != header.Timestamp {
+		failures = append(failures, "rlp header fields mismatch")
+	}
+
+	// linked with the previously indexed header
+	if prevHeader != nil {
+		expectedNumber := new(big.Int).Add(prevHeader.Number, bigint.One)
+		if header.Number.Cmp(expectedNumber) == 0 {
+			if header.ParentHash != prevHeader.Hash {
+				failures = append(failures, fmt.Sprintf("parent hash %s does not match the previous header %s", header.ParentHash, prevHeader.Hash))
+			}
+		} else if contiguous {
+			failures = append(failures, fmt.Sprintf("gap in indexed headers after %d", prevHeader.Number))
+		}
+	}
+
+	if client != nil {
+		canonicalHeader, err := client.BlockHeaderByNumber(header.Number)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("unable to query canonical header: %s", err))
+		} else if canonicalHeader.Hash() != header.Hash {
+			failures = append(failures, fmt.Sprintf("not canonical, canonical hash %s", canonicalHeader.Hash()))
+		}
+	}
+
+	return failures
+}
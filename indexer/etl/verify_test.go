@@ -0,0 +1,67 @@
+package etl
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+)
+
+func TestVerifyHeader(t *testing.T) {
+	parent := database.BlockHeaderFromHeader(&types.Header{Number: big.NewInt(1), Time: 1})
+	header := database.BlockHeaderFromHeader(&types.Header{ParentHash: parent.Hash, Number: big.NewInt(2), Time: 2})
+	unlinked := database.BlockHeaderFromHeader(&types.Header{ParentHash: common.Hash{0xff}, Number: big.NewInt(2), Time: 2})
+	skipped := database.BlockHeaderFromHeader(&types.Header{ParentHash: common.Hash{0xff}, Number: big.NewInt(3), Time: 3})
+
+	t.Run("valid", func(t *testing.T) {
+		require.Empty(t, verifyHeader(nil, nil, &parent, false))
+		require.Empty(t, verifyHeader(nil, &parent, &header, true))
+	})
+
+	t.Run("missing rlp header", func(t *testing.T) {
+		missing := header
+		missing.RLPHeader = nil
+		require.Len(t, verifyHeader(nil, &parent, &missing, false), 1)
+	})
+
+	t.Run("inconsistent rlp header", func(t *testing.T) {
+		tampered := header
+		tampered.Timestamp = 3
+		require.Len(t, verifyHeader(nil, &parent, &tampered, false), 1)
+
+		tampered = header
+		tampered.Hash = common.Hash{0xff}
+		require.Len(t, verifyHeader(nil, nil, &tampered, false), 1)
+	})
+
+	t.Run("parent hash mismatch", func(t *testing.T) {
+		require.Len(t, verifyHeader(nil, &parent, &unlinked, false), 1)
+	})
+
+	t.Run("gap in headers", func(t *testing.T) {
+		// gaps are only failures for contiguous headers
+		require.Empty(t, verifyHeader(nil, &parent, &skipped, false))
+		require.Len(t, verifyHeader(nil, &parent, &skipped, true), 1)
+	})
+
+	t.Run("canonical", func(t *testing.T) {
+		client := new(node.MockEthClient)
+		client.On("BlockHeaderByNumber", big.NewInt(2)).Return(header.RLPHeader.Header(), nil)
+		require.Empty(t, verifyHeader(client, &parent, &header, true))
+
+		client = new(node.MockEthClient)
+		client.On("BlockHeaderByNumber", big.NewInt(2)).Return(unlinked.RLPHeader.Header(), nil)
+		require.Len(t, verifyHeader(client, &parent, &header, true), 1)
+
+		client = new(node.MockEthClient)
+		client.On("BlockHeaderByNumber", big.NewInt(2)).Return((*types.Header)(nil), errors.New("unavailable"))
+		require.Len(t, verifyHeader(client, &parent, &header, true), 1)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/ethereum-optimism/optimism/indexer/etl"
 	"github.com/ethereum-optimism/optimism/indexer/node"
 	"github.com/ethereum-optimism/optimism/indexer/processors"
+	"github.com/ethereum-optimism/optimism/indexer/processors/accounting"
 	"github.com/ethereum-optimism/optimism/indexer/processors/bridge"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -30,10 +31,11 @@ import (
 // indexing the configured L1 and L2 chains
 type Indexer struct {
 	log log.Logger
-	DB  *database.DB
+
+	// DB of the indexed chain, only set when indexing a single chain
+	DB *database.DB
 
 	l1Client node.EthClient
-	l2Client node.EthClient
 
 	// api server only really serves a /health endpoint here, but this may change in the future
 	apiServer *httputil.HTTPServer
@@ -42,10 +44,15 @@ type Indexer struct {
 
 	metricsRegistry *prometheus.Registry
 
-	L1ETL           *etl.L1ETL
+	// L1ETL is shared by all indexed chains
+	L1ETL *etl.L1ETL
+
+	// L2ETL & BridgeProcessor of the indexed chain, only set when indexing a single chain
 	L2ETL           *etl.L2ETL
 	BridgeProcessor *processors.BridgeProcessor
 
+	Chains []*ChainIndexer
+
 	// shutdown requests the service that maintains the indexer to shut down,
 	// and provides the error-cause of the critical failure (if any).
 	shutdown context.CancelCauseFunc
@@ -53,6 +60,22 @@ type Indexer struct {
 	stopped atomic.Bool
 }
 
+// ChainIndexer contains the resources indexing a single L2 chain. The
+// L1 contracts of the chain are indexed by the shared L1ETL
+type ChainIndexer struct {
+	Config config.ChainConfig
+	DB     *database.DB
+
+	l2Client        node.EthClient
+	metricsRegistry prometheus.Registerer
+
+	L2ETL           *etl.L2ETL
+	BridgeProcessor *processors.BridgeProcessor
+
+	// Only set when bridge accounting is enabled for the chain
+	AccountingProcessor *processors.AccountingProcessor
+}
+
 // NewIndexer initializes an instance of the Indexer
 func NewIndexer(ctx context.Context, log log.Logger, cfg *config.Config, shutdown context.CancelCauseFunc) (*Indexer, error) {
 	out := &Indexer{
@@ -72,11 +95,18 @@ func (ix *Indexer) Start(ctx context.Context) error {
 	if err := ix.L1ETL.Start(); err != nil {
 		return fmt.Errorf("failed to start L1 ETL: %w", err)
 	}
-	if err := ix.L2ETL.Start(); err != nil {
-		return fmt.Errorf("failed to start L2 ETL: %w", err)
-	}
-	if err := ix.BridgeProcessor.Start(); err != nil {
-		return fmt.Errorf("failed to start bridge processor: %w", err)
+	for _, chain := range ix.Chains {
+		if err := chain.L2ETL.Start(); err != nil {
+			return fmt.Errorf("failed to start L2 ETL of chain %d: %w", chain.Config.L2ChainID, err)
+		}
+		if err := chain.BridgeProcessor.Start(); err != nil {
+			return fmt.Errorf("failed to start bridge processor of chain %d: %w", chain.Config.L2ChainID, err)
+		}
+		if chain.AccountingProcessor != nil {
+			if err := chain.AccountingProcessor.Start(); err != nil {
+				return fmt.Errorf("failed to start accounting processor of chain %d: %w", chain.Config.L2ChainID, err)
+			}
+		}
 	}
 	return nil
 }
@@ -90,15 +120,23 @@ func (ix *Indexer) Stop(ctx context.Context) error {
 		}
 	}
 
-	if ix.L2ETL != nil {
-		if err := ix.L2ETL.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close L2 ETL: %w", err))
+	for _, chain := range ix.Chains {
+		if chain.L2ETL != nil {
+			if err := chain.L2ETL.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close L2 ETL of chain %d: %w", chain.Config.L2ChainID, err))
+			}
 		}
-	}
 
-	if ix.BridgeProcessor != nil {
-		if err := ix.BridgeProcessor.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close bridge processor: %w", err))
+		if chain.BridgeProcessor != nil {
+			if err := chain.BridgeProcessor.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close bridge processor of chain %d: %w", chain.Config.L2ChainID, err))
+			}
+		}
+
+		if chain.AccountingProcessor != nil {
+			if err := chain.AccountingProcessor.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close accounting processor of chain %d: %w", chain.Config.L2ChainID, err))
+			}
 		}
 	}
 
@@ -106,8 +144,10 @@ func (ix *Indexer) Stop(ctx context.Context) error {
 	if ix.l1Client != nil {
 		ix.l1Client.Close()
 	}
-	if ix.l2Client != nil {
-		ix.l2Client.Close()
+	for _, chain := range ix.Chains {
+		if chain.l2Client != nil {
+			chain.l2Client.Close()
+		}
 	}
 
 	if ix.apiServer != nil {
@@ -117,9 +157,11 @@ func (ix *Indexer) Stop(ctx context.Context) error {
 	}
 
 	// DB connection can be closed last, after all its potential users have shut down
-	if ix.DB != nil {
-		if err := ix.DB.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close DB: %w", err))
+	for _, chain := range ix.Chains {
+		if chain.DB != nil {
+			if err := chain.DB.Close(); err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to close DB of chain %d: %w", chain.Config.L2ChainID, err))
+			}
 		}
 	}
 
@@ -141,21 +183,52 @@ func (ix *Indexer) Stopped() bool {
 }
 
 func (ix *Indexer) initFromConfig(ctx context.Context, cfg *config.Config) error {
-	if err := ix.initRPCClients(ctx, cfg.RPCs); err != nil {
-		return fmt.Errorf("failed to start RPC clients: %w", err)
+	if err := ix.initL1RPCClient(ctx, cfg.RPCs); err != nil {
+		return fmt.Errorf("failed to start L1 RPC client: %w", err)
 	}
-	if err := ix.initDB(ctx, cfg.DB); err != nil {
-		return fmt.Errorf("failed to init DB: %w", err)
+
+	if !cfg.MultiChain() {
+		// The state of a single chain is indexed in the public schema of the DB
+		chain := &ChainIndexer{Config: cfg.Chain, metricsRegistry: ix.metricsRegistry}
+		if chain.Config.L2RPC == "" {
+			chain.Config.L2RPC = cfg.RPCs.L2RPC
+		}
+		ix.Chains = append(ix.Chains, chain)
+		if err := ix.initChain(ctx, chain, cfg.DB); err != nil {
+			return err
+		}
+	} else {
+		for _, chainConfig := range cfg.Chains {
+			// Metrics of each chain are distinguished by the chain id
+			chainLabels := prometheus.Labels{"chain_id": strconv.FormatUint(chainConfig.L2ChainID, 10)}
+			chain := &ChainIndexer{Config: chainConfig, metricsRegistry: prometheus.WrapRegistererWith(chainLabels, ix.metricsRegistry)}
+			ix.Chains = append(ix.Chains, chain)
+			if err := ix.initChain(ctx, chain, cfg.DB.ForChain(chainConfig.L2ChainID)); err != nil {
+				return fmt.Errorf("failed to init chain %d: %w", chainConfig.L2ChainID, err)
+			}
+		}
 	}
-	if err := ix.initL1ETL(cfg.Chain); err != nil {
+
+	if err := ix.initL1ETL(); err != nil {
 		return fmt.Errorf("failed to init L1 ETL: %w", err)
 	}
-	if err := ix.initL2ETL(cfg.Chain); err != nil {
-		return fmt.Errorf("failed to init L2 ETL: %w", err)
+	for _, chain := range ix.Chains {
+		if err := ix.initBridgeProcessor(chain); err != nil {
+			return fmt.Errorf("failed to init Bridge-Processor: %w", err)
+		}
+		if chain.Config.AccountingInterval > 0 {
+			if err := ix.initAccountingProcessor(chain); err != nil {
+				return fmt.Errorf("failed to init Accounting-Processor: %w", err)
+			}
+		}
 	}
-	if err := ix.initBridgeProcessor(cfg.Chain); err != nil {
-		return fmt.Errorf("failed to init Bridge-Processor: %w", err)
+
+	if !cfg.MultiChain() {
+		ix.DB = ix.Chains[0].DB
+		ix.L2ETL = ix.Chains[0].L2ETL
+		ix.BridgeProcessor = ix.Chains[0].BridgeProcessor
 	}
+
 	if err := ix.startHttpServer(ctx, cfg.HTTPServer); err != nil {
 		return fmt.Errorf("failed to start HTTP server: %w", err)
 	}
@@ -165,69 +238,134 @@ func (ix *Indexer) initFromConfig(ctx context.Context, cfg *config.Config) error
 	return nil
 }
 
-func (ix *Indexer) initRPCClients(ctx context.Context, rpcsConfig config.RPCsConfig) error {
+func (ix *Indexer) initL1RPCClient(ctx context.Context, rpcsConfig config.RPCsConfig) error {
 	l1EthClient, err := node.DialEthClient(ctx, rpcsConfig.L1RPC, node.NewMetrics(ix.metricsRegistry, "l1"))
 	if err != nil {
 		return fmt.Errorf("failed to dial L1 client: %w", err)
 	}
 	ix.l1Client = l1EthClient
+	return nil
+}
 
-	l2EthClient, err := node.DialEthClient(ctx, rpcsConfig.L2RPC, node.NewMetrics(ix.metricsRegistry, "l2"))
+// initChain connects to the L2 and DB of the chain, initializing its L2 ETL
+func (ix *Indexer) initChain(ctx context.Context, chain *ChainIndexer, dbConfig config.DBConfig) error {
+	if err := chain.initL2RPCClient(ctx); err != nil {
+		return fmt.Errorf("failed to start L2 RPC client: %w", err)
+	}
+	if err := chain.initDB(ctx, ix.log, dbConfig); err != nil {
+		return fmt.Errorf("failed to init DB: %w", err)
+	}
+	if err := chain.initL2ETL(ix.log, ix.shutdown); err != nil {
+		return fmt.Errorf("failed to init L2 ETL: %w", err)
+	}
+	return nil
+}
+
+func (chain *ChainIndexer) initL2RPCClient(ctx context.Context) error {
+	l2EthClient, err := node.DialEthClient(ctx, chain.Config.L2RPC, node.NewMetrics(chain.metricsRegistry, "l2"))
 	if err != nil {
 		return fmt.Errorf("failed to dial L2 client: %w", err)
 	}
-	ix.l2Client = l2EthClient
+	chain.l2Client = l2EthClient
+
+	if chain.Config.L2ChainID != 0 {
+		chainID, err := l2EthClient.ChainID()
+		if err != nil {
+			return fmt.Errorf("failed to query L2 chain id: %w", err)
+		} else if chainID.Uint64() != chain.Config.L2ChainID {
+			return fmt.Errorf("configured L2 chain id %d does not match the L2 RPC chain id %d", chain.Config.L2ChainID, chainID)
+		}
+	}
 	return nil
 }
 
-func (ix *Indexer) initDB(ctx context.Context, cfg config.DBConfig) error {
-	db, err := database.NewDB(ctx, ix.log, cfg)
+func (chain *ChainIndexer) initDB(ctx context.Context, log log.Logger, cfg config.DBConfig) error {
+	db, err := database.NewDB(ctx, log, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
-	ix.DB = db
+	chain.DB = db
 	return nil
 }
 
-func (ix *Indexer) initL1ETL(chainConfig config.ChainConfig) error {
-	l1Cfg := etl.Config{
-		LoopIntervalMsec:  chainConfig.L1PollingInterval,
-		HeaderBufferSize:  chainConfig.L1HeaderBufferSize,
-		ConfirmationDepth: big.NewInt(int64(chainConfig.L1ConfirmationDepth)),
-		StartHeight:       big.NewInt(int64(chainConfig.L1StartingHeight)),
+func (chain *ChainIndexer) initL2ETL(log log.Logger, shutdown context.CancelCauseFunc) error {
+	// L2 (defaults to predeploy contracts)
+	l2Cfg := etl.Config{
+		LoopIntervalMsec:  chain.Config.L2PollingInterval,
+		HeaderBufferSize:  chain.Config.L2HeaderBufferSize,
+		ConfirmationDepth: big.NewInt(int64(chain.Config.L2ConfirmationDepth)),
 	}
-	l1Etl, err := etl.NewL1ETL(l1Cfg, ix.log, ix.DB, etl.NewMetrics(ix.metricsRegistry, "l1"),
-		ix.l1Client, chainConfig.L1Contracts, ix.shutdown)
+	if chain.Config.L2ChainID != 0 {
+		log = log.New("chain_id", chain.Config.L2ChainID)
+	}
+	l2Etl, err := etl.NewL2ETL(l2Cfg, log, chain.DB, etl.NewMetrics(chain.metricsRegistry, "l2"),
+		chain.l2Client, chain.Config.L2Contracts, shutdown)
 	if err != nil {
 		return err
 	}
-	ix.L1ETL = l1Etl
+	chain.L2ETL = l2Etl
 	return nil
 }
 
-func (ix *Indexer) initL2ETL(chainConfig config.ChainConfig) error {
-	// L2 (defaults to predeploy contracts)
-	l2Cfg := etl.Config{
-		LoopIntervalMsec:  chainConfig.L2PollingInterval,
-		HeaderBufferSize:  chainConfig.L2HeaderBufferSize,
-		ConfirmationDepth: big.NewInt(int64(chainConfig.L2ConfirmationDepth)),
+// initL1ETL initializes the L1 ETL shared by all chains. Traversal is as frequent, and as
+// confirmed, as required by the most demanding chain
+func (ix *Indexer) initL1ETL() error {
+	var l1Cfg etl.Config
+	etlChains := make([]etl.L1ETLChain, len(ix.Chains))
+	for i, chain := range ix.Chains {
+		chainConfig := chain.Config
+		if i == 0 || chainConfig.L1PollingInterval < l1Cfg.LoopIntervalMsec {
+			l1Cfg.LoopIntervalMsec = chainConfig.L1PollingInterval
+		}
+		if i == 0 || chainConfig.L1HeaderBufferSize < l1Cfg.HeaderBufferSize {
+			l1Cfg.HeaderBufferSize = chainConfig.L1HeaderBufferSize
+		}
+		if i == 0 || int64(chainConfig.L1ConfirmationDepth) > l1Cfg.ConfirmationDepth.Int64() {
+			l1Cfg.ConfirmationDepth = big.NewInt(int64(chainConfig.L1ConfirmationDepth))
+		}
+
+		etlChains[i] = etl.L1ETLChain{
+			ChainID:     chainConfig.L2ChainID,
+			DB:          chain.DB,
+			Contracts:   chainConfig.L1Contracts,
+			StartHeight: big.NewInt(int64(chainConfig.L1StartingHeight)),
+		}
 	}
-	l2Etl, err := etl.NewL2ETL(l2Cfg, ix.log, ix.DB, etl.NewMetrics(ix.metricsRegistry, "l2"),
-		ix.l2Client, chainConfig.L2Contracts, ix.shutdown)
+
+	l1Etl, err := etl.NewSharedL1ETL(l1Cfg, ix.log, etlChains, etl.NewMetrics(ix.metricsRegistry, "l1"),
+		ix.l1Client, ix.shutdown)
 	if err != nil {
 		return err
 	}
-	ix.L2ETL = l2Etl
+	ix.L1ETL = l1Etl
 	return nil
 }
 
-func (ix *Indexer) initBridgeProcessor(chainConfig config.ChainConfig) error {
+func (ix *Indexer) initBridgeProcessor(chain *ChainIndexer) error {
+	log := ix.log
+	if chain.Config.L2ChainID != 0 {
+		log = log.New("chain_id", chain.Config.L2ChainID)
+	}
 	bridgeProcessor, err := processors.NewBridgeProcessor(
-		ix.log, ix.DB, bridge.NewMetrics(ix.metricsRegistry), ix.L1ETL, ix.L2ETL, chainConfig, ix.shutdown)
+		log, chain.DB, bridge.NewMetrics(chain.metricsRegistry), ix.L1ETL, chain.L2ETL, chain.Config, ix.shutdown)
+	if err != nil {
+		return err
+	}
+	chain.BridgeProcessor = bridgeProcessor
+	return nil
+}
+
+func (ix *Indexer) initAccountingProcessor(chain *ChainIndexer) error {
+	log := ix.log
+	if chain.Config.L2ChainID != 0 {
+		log = log.New("chain_id", chain.Config.L2ChainID)
+	}
+	accountingProcessor, err := processors.NewAccountingProcessor(
+		log, chain.DB, accounting.NewMetrics(chain.metricsRegistry), ix.l1Client, chain.l2Client, chain.Config)
 	if err != nil {
 		return err
 	}
-	ix.BridgeProcessor = bridgeProcessor
+	chain.AccountingProcessor = accountingProcessor
 	return nil
 }
 
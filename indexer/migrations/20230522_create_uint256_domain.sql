@@ -0,0 +1,15 @@
+/**
+ * SHARED TYPES
+ *
+ * Applied ahead of the initial schema. Chains indexed in their own schema resolve UINT256 through
+ * their `search_path`, which always includes the public schema. Creating the domain there first
+ * ensures every chain schema shares it, rather than the first chain schema owning it.
+ */
+
+DO $$
+BEGIN
+    IF to_regtype('public.uint256') IS NULL THEN
+        CREATE DOMAIN public.UINT256 AS NUMERIC
+            CHECK (VALUE >= 0 AND VALUE < POWER(CAST(2 AS NUMERIC), CAST(256 AS NUMERIC)) AND SCALE(VALUE) = 0);
+    END IF;
+END $$;
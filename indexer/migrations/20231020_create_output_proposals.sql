@@ -0,0 +1,13 @@
+/**
+ * ROLLUP STATE
+ */
+
+-- L2OutputOracle
+CREATE TABLE IF NOT EXISTS output_proposals (
+    l2_output_index UINT256 PRIMARY KEY,
+    output_root     VARCHAR NOT NULL,
+    l2_block_number UINT256 NOT NULL,
+
+    output_proposed_guid VARCHAR NOT NULL UNIQUE REFERENCES l1_contract_events(guid) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS output_proposals_l2_block_number ON output_proposals(l2_block_number);
@@ -0,0 +1,30 @@
+/**
+ * BRIDGE ACCOUNTING
+ */
+
+-- CrossDomainMessenger. The latest failed relay of a message, which is stuck until successfully replayed
+ALTER TABLE l1_bridge_messages ADD COLUMN IF NOT EXISTS failed_relayed_message_event_guid VARCHAR REFERENCES l2_contract_events(guid) ON DELETE SET NULL;
+ALTER TABLE l2_bridge_messages ADD COLUMN IF NOT EXISTS failed_relayed_message_event_guid VARCHAR REFERENCES l1_contract_events(guid) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS l1_bridge_messages_failed_relayed_message_event_guid ON l1_bridge_messages(failed_relayed_message_event_guid);
+CREATE INDEX IF NOT EXISTS l2_bridge_messages_failed_relayed_message_event_guid ON l2_bridge_messages(failed_relayed_message_event_guid);
+
+-- StandardBridge. The latest reconciliation of the L1 escrow and L2 supply of each bridged token pair
+CREATE TABLE IF NOT EXISTS bridge_token_reports (
+    l1_token_address VARCHAR NOT NULL,
+    l2_token_address VARCHAR NOT NULL,
+
+    -- Heights of the on-chain balances & indexed state
+    l1_block_number UINT256 NOT NULL,
+    l2_block_number UINT256 NOT NULL,
+
+    l1_escrow_balance     UINT256 NOT NULL,
+    l2_total_supply       UINT256 NOT NULL,
+    deposits_in_flight    UINT256 NOT NULL,
+    withdrawals_in_flight UINT256 NOT NULL,
+
+    stuck_deposits    INTEGER NOT NULL,
+    stuck_withdrawals INTEGER NOT NULL,
+    timestamp         INTEGER NOT NULL CHECK (timestamp > 0),
+
+    PRIMARY KEY (l1_token_address, l2_token_address)
+);
@@ -32,6 +32,8 @@ const (
 )
 
 type EthClient interface {
+	ChainID() (*big.Int, error)
+
 	BlockHeaderByNumber(*big.Int) (*types.Header, error)
 	BlockHeaderByHash(common.Hash) (*types.Header, error)
 	BlockHeadersByRange(*big.Int, *big.Int) ([]types.Header, error)
@@ -41,6 +43,9 @@ type EthClient interface {
 	StorageHash(common.Address, *big.Int) (common.Hash, error)
 	FilterLogs(ethereum.FilterQuery) (Logs, error)
 
+	// CallContract executes the message call against the state at the supplied height
+	CallContract(ethereum.CallMsg, *big.Int) ([]byte, error)
+
 	// Close closes the underlying RPC connection.
 	// RPC close does not return any errors, but does shut down e.g. a websocket connection.
 	Close()
@@ -96,6 +101,19 @@ func (c *clnt) BlockHeaderByHash(hash common.Hash) (*types.Header, error) {
 	return header, nil
 }
 
+// ChainID retrieves the chain id of the connected node
+func (c *clnt) ChainID() (*big.Int, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	var chainID hexutil.Big
+	if err := c.rpc.CallContext(ctxwt, &chainID, "eth_chainId"); err != nil {
+		return nil, err
+	}
+
+	return (*big.Int)(&chainID), nil
+}
+
 // BlockHeaderByNumber retrieves the block header attributed to the supplied height
 func (c *clnt) BlockHeaderByNumber(number *big.Int) (*types.Header, error) {
 	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
@@ -196,6 +214,20 @@ func (c *clnt) StorageHash(address common.Address, blockNumber *big.Int) (common
 	return proof.StorageHash, nil
 }
 
+// CallContract executes the message call against the state at the supplied height, returning the output
+func (c *clnt) CallContract(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	ctxwt, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+	defer cancel()
+
+	var output hexutil.Bytes
+	err := c.rpc.CallContext(ctxwt, &output, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
 func (c *clnt) Close() {
 	c.rpc.Close()
 }
@@ -305,3 +337,20 @@ func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
 	}
 	return arg, nil
 }
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	return arg
+}
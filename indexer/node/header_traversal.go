@@ -47,6 +47,14 @@ func (f *HeaderTraversal) LastTraversedHeader() *types.Header {
 	return f.lastTraversedHeader
 }
 
+// Rewind resets the traversal to continue from the supplied header, i.e the
+// next set of headers will start at `header.Number + 1`. Used to re-traverse
+// the canonical chain after a reorg has been detected. A nil header rewinds
+// the traversal back to genesis.
+func (f *HeaderTraversal) Rewind(header *types.Header) {
+	f.lastTraversedHeader = header
+}
+
 // NextHeaders retrieves the next set of headers that have been
 // marked as finalized by the connected client, bounded by the supplied size
 func (f *HeaderTraversal) NextHeaders(maxSize uint64) ([]types.Header, error) {
@@ -90,8 +98,8 @@ func (f *HeaderTraversal) NextHeaders(maxSize uint64) ([]types.Header, error) {
 	if numHeaders == 0 {
 		return nil, nil
 	} else if f.lastTraversedHeader != nil && headers[0].ParentHash != f.lastTraversedHeader.Hash() {
-		// The indexer's state is in an irrecoverable state relative to the provider. This
-		// should never happen since the indexer is dealing with only finalized blocks.
+		// The last traversed header is no longer part of the canonical chain. The
+		// caller is expected to find the common ancestor and `Rewind` to it
 		return nil, ErrHeaderTraversalAndProviderMismatchedState
 	}
 
@@ -133,3 +133,36 @@ func TestHeaderTraversalMismatchedProviderStateError(t *testing.T) {
 	require.Nil(t, headers)
 	require.Equal(t, ErrHeaderTraversalAndProviderMismatchedState, err)
 }
+
+func TestHeaderTraversalRewind(t *testing.T) {
+	client := new(MockEthClient)
+
+	// start from genesis
+	headerTraversal := NewHeaderTraversal(client, nil, bigint.Zero)
+
+	// blocks [0..4]
+	headers := makeHeaders(5, nil)
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&headers[4], nil).Times(1) // Times so that we can override next
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(0)), mock.MatchedBy(bigint.Matcher(4))).Return(headers, nil)
+	_, err := headerTraversal.NextHeaders(5)
+	require.NoError(t, err)
+
+	// blocks [3..5] reorged. The next batch does not chain onto the traversed state
+	reorgedHeaders := makeHeaders(3, &headers[2])
+	reorgedHeaders[0].Extra = []byte("reorg")
+	reorgedHeaders = append(reorgedHeaders[:1], makeHeaders(2, &reorgedHeaders[0])...)
+	client.On("BlockHeaderByNumber", (*big.Int)(nil)).Return(&reorgedHeaders[2], nil)
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(5)), mock.MatchedBy(bigint.Matcher(5))).Return(reorgedHeaders[2:], nil)
+	_, err = headerTraversal.NextHeaders(5)
+	require.Equal(t, ErrHeaderTraversalAndProviderMismatchedState, err)
+
+	// rewinding to the common ancestor continues traversal on the canonical chain
+	headerTraversal.Rewind(&headers[2])
+	require.Equal(t, uint64(2), headerTraversal.LastTraversedHeader().Number.Uint64())
+
+	client.On("BlockHeadersByRange", mock.MatchedBy(bigint.Matcher(3)), mock.MatchedBy(bigint.Matcher(5))).Return(reorgedHeaders, nil)
+	newHeaders, err := headerTraversal.NextHeaders(5)
+	require.NoError(t, err)
+	require.Len(t, newHeaders, 3)
+	require.Equal(t, reorgedHeaders[2].Hash(), headerTraversal.LastTraversedHeader().Hash())
+}
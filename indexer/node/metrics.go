@@ -27,7 +27,7 @@ type clientMetrics struct {
 	rpcClientResponsesTotal         *prometheus.CounterVec
 }
 
-func NewMetrics(registry *prometheus.Registry, subsystem string) Metricer {
+func NewMetrics(registry prometheus.Registerer, subsystem string) Metricer {
 	factory := metrics.With(registry)
 	return &clientMetrics{
 		rpcClientRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
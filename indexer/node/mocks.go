@@ -16,6 +16,11 @@ type MockEthClient struct {
 	mock.Mock
 }
 
+func (m *MockEthClient) ChainID() (*big.Int, error) {
+	args := m.Called()
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
 func (m *MockEthClient) BlockHeaderByNumber(number *big.Int) (*types.Header, error) {
 	args := m.Called(number)
 	return args.Get(0).(*types.Header), args.Error(1)
@@ -46,5 +51,10 @@ func (m *MockEthClient) FilterLogs(query ethereum.FilterQuery) (Logs, error) {
 	return args.Get(0).(Logs), args.Error(1)
 }
 
+func (m *MockEthClient) CallContract(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args := m.Called(msg, blockNumber)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockEthClient) Close() {
 }
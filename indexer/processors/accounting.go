@@ -0,0 +1,202 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/indexer/processors/accounting"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// AccountingProcessor periodically reconciles the L1StandardBridge escrow of each bridged token pair with the
+// supply of the L2 token, reporting mismatches, value in flight and stuck messages.
+type AccountingProcessor struct {
+	log     log.Logger
+	db      *database.DB
+	metrics accounting.Metricer
+
+	l1Client    node.EthClient
+	l2Client    node.EthClient
+	chainConfig config.ChainConfig
+
+	// L1 tokens minted & burned by the L1StandardBridge are not escrowed and not reported.
+	// Only accessed by the worker routine.
+	mintableL1Tokens map[common.Address]bool
+
+	interval time.Duration
+	worker   *clock.LoopFn
+}
+
+func NewAccountingProcessor(log log.Logger, db *database.DB, metrics accounting.Metricer, l1Client, l2Client node.EthClient, chainConfig config.ChainConfig) (*AccountingProcessor, error) {
+	if chainConfig.AccountingInterval == 0 {
+		return nil, errors.New("accounting interval must be greater than zero")
+	}
+
+	return &AccountingProcessor{
+		log:              log.New("processor", "accounting"),
+		db:               db,
+		metrics:          metrics,
+		l1Client:         l1Client,
+		l2Client:         l2Client,
+		chainConfig:      chainConfig,
+		mintableL1Tokens: make(map[common.Address]bool),
+		interval:         time.Duration(chainConfig.AccountingInterval) * time.Millisecond,
+	}, nil
+}
+
+func (a *AccountingProcessor) Start() error {
+	if a.worker != nil {
+		return errors.New("already started")
+	}
+
+	a.log.Info("starting accounting processor...")
+	a.worker = clock.NewLoopFn(clock.SystemClock, func(_ context.Context) {
+		done := a.metrics.RecordInterval()
+		done(a.reconcile())
+	}, nil, a.interval)
+	return nil
+}
+
+func (a *AccountingProcessor) Close() error {
+	if a.worker == nil {
+		return nil // worker was not running
+	}
+	return a.worker.Close()
+}
+
+// reconcile reports the token pairs at the heights the indexed bridge state is known to be processed up to. The
+// on-chain balances are queried at these same heights such that they are consistent with the value in flight.
+func (a *AccountingProcessor) reconcile() error {
+	l1Height, l2Height, err := a.reportHeights()
+	if err != nil {
+		a.log.Error("failed to query processed bridge state", "err", err)
+		return err
+	} else if l1Height == nil || l2Height == nil {
+		a.log.Info("no processed bridge state to reconcile")
+		return nil
+	}
+
+	tokenAccounting, err := a.db.BridgeAccounting.BridgeTokenAccounting(l1Height, l2Height)
+	if err != nil {
+		a.log.Error("failed to aggregate bridged tokens", "err", err)
+		return err
+	}
+
+	timestamp := uint64(time.Now().Unix())
+	reports := make([]database.BridgeTokenReport, 0, len(tokenAccounting))
+	for i := range tokenAccounting {
+		report, err := a.tokenReport(tokenAccounting[i], l1Height, l2Height, timestamp)
+		if err != nil {
+			a.log.Error("failed to reconcile token pair", "l1_token", tokenAccounting[i].L1TokenAddress, "l2_token", tokenAccounting[i].L2TokenAddress, "err", err)
+			return err
+		} else if report != nil {
+			reports = append(reports, *report)
+		}
+	}
+
+	if err := a.db.BridgeAccounting.StoreBridgeTokenReports(reports); err != nil {
+		a.log.Error("failed to store token reports", "err", err)
+		return err
+	}
+
+	mismatches := 0
+	for _, report := range reports {
+		a.metrics.RecordTokenReport(report)
+		if report.Mismatch() {
+			mismatches++
+			a.log.Warn("token pair escrow does not reconcile", "l1_token", report.L1TokenAddress, "l2_token", report.L2TokenAddress,
+				"l1_escrow_balance", report.L1EscrowBalance, "l2_total_supply", report.L2TotalSupply, "discrepancy", report.Discrepancy())
+		}
+		if report.StuckDeposits > 0 || report.StuckWithdrawals > 0 {
+			a.log.Warn("stuck bridge messages", "l1_token", report.L1TokenAddress, "l2_token", report.L2TokenAddress,
+				"stuck_deposits", report.StuckDeposits, "stuck_withdrawals", report.StuckWithdrawals)
+		}
+	}
+	a.metrics.RecordMismatches(mismatches)
+
+	a.log.Info("reconciled token pairs", "size", len(reports), "mismatches", mismatches, "l1_block_number", l1Height, "l2_block_number", l2Height)
+	return nil
+}
+
+// tokenReport reconciles the token pair, returning nil if the L1 token is not escrowed by the L1StandardBridge
+func (a *AccountingProcessor) tokenReport(tokenAccounting database.BridgeTokenAccounting, l1Height, l2Height *big.Int, timestamp uint64) (*database.BridgeTokenReport, error) {
+	l1Token, l2Token := tokenAccounting.L1TokenAddress, tokenAccounting.L2TokenAddress
+	if !a.mintableL1Tokens[l1Token] {
+		mintable, err := accounting.IsOptimismMintableERC20(a.l1Client, l1Token, l1Height)
+		if err != nil {
+			return nil, err
+		} else if mintable {
+			a.mintableL1Tokens[l1Token] = true
+		}
+	}
+	if a.mintableL1Tokens[l1Token] {
+		return nil, nil
+	}
+
+	l1EscrowBalance, err := accounting.L1EscrowBalance(a.l1Client, a.chainConfig.L1Contracts.L1StandardBridgeProxy, l1Token, l2Token, l1Height)
+	if err != nil {
+		return nil, err
+	}
+	l2TotalSupply, err := accounting.L2TotalSupply(a.l2Client, l2Token, l2Height)
+	if err != nil {
+		return nil, err
+	}
+
+	report := accounting.NewTokenReport(tokenAccounting, l1EscrowBalance, l2TotalSupply, l1Height, l2Height, timestamp)
+	return &report, nil
+}
+
+// reportHeights returns the L1 & L2 heights the bridge state is processed up to. Since deposits are included on
+// L2 in blocks following their L1 origin, the L2 height is bounded by the timestamp of the L1 height such that
+// any deposit relayed by the L2 height was initiated by the L1 height.
+func (a *AccountingProcessor) reportHeights() (*big.Int, *big.Int, error) {
+	l1Header, err := a.db.BridgeTransactions.L1LatestBlockHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	finalizedL1Header, err := a.db.BridgeTransactions.L1LatestFinalizedBlockHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	l2Header, err := a.db.BridgeTransactions.L2LatestBlockHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	finalizedL2Header, err := a.db.BridgeTransactions.L2LatestFinalizedBlockHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+	if l1Header == nil || l2Header == nil {
+		return nil, nil, nil
+	}
+
+	if finalizedL1Header != nil && finalizedL1Header.Number.Cmp(l1Header.Number) < 0 {
+		l1Header = finalizedL1Header
+	}
+	if finalizedL2Header != nil && finalizedL2Header.Number.Cmp(l2Header.Number) < 0 {
+		l2Header = finalizedL2Header
+	}
+
+	l2HeightBound, l1Timestamp := l2Header.Number, l1Header.Timestamp
+	l2Header, err = a.db.Blocks.L2BlockHeaderWithScope(func(db *gorm.DB) *gorm.DB {
+		return db.Where("number <= ? AND timestamp <= ?", l2HeightBound.String(), l1Timestamp).Order("number DESC")
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query bounded L2 header: %w", err)
+	} else if l2Header == nil {
+		return nil, nil, nil
+	}
+
+	return l1Header.Number, l2Header.Number, nil
+}
@@ -0,0 +1,113 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+var (
+	// ERC165 interface ids of the ILegacyMintableERC20 & IOptimismMintableERC20 interfaces. The
+	// StandardBridge burns, rather than escrows, tokens supporting either
+	legacyMintableERC20InterfaceID   = [4]byte{0x1d, 0x1d, 0x8b, 0x63}
+	optimismMintableERC20InterfaceID = [4]byte{0xec, 0x4f, 0xc8, 0xe3}
+)
+
+// L1EscrowBalance returns the balance of the L1 token escrowed by the L1StandardBridge for the L2 token
+func L1EscrowBalance(client node.EthClient, l1StandardBridge, l1Token, l2Token common.Address, height *big.Int) (*big.Int, error) {
+	l1StandardBridgeAbi, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, err := l1StandardBridgeAbi.Pack("deposits", l1Token, l2Token)
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.CallContract(ethereum.CallMsg{To: &l1StandardBridge, Data: calldata}, height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query escrow balance: %w", err)
+	}
+
+	balance, err := l1StandardBridgeAbi.Unpack("deposits", output)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode escrow balance: %w", err)
+	}
+	return balance[0].(*big.Int), nil
+}
+
+// L2TotalSupply returns the total supply of the L2 token
+func L2TotalSupply(client node.EthClient, l2Token common.Address, height *big.Int) (*big.Int, error) {
+	erc20Abi, err := bindings.OptimismMintableERC20MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	calldata, err := erc20Abi.Pack("totalSupply")
+	if err != nil {
+		return nil, err
+	}
+	output, err := client.CallContract(ethereum.CallMsg{To: &l2Token, Data: calldata}, height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query total supply: %w", err)
+	}
+
+	totalSupply, err := erc20Abi.Unpack("totalSupply", output)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode total supply: %w", err)
+	}
+	return totalSupply[0].(*big.Int), nil
+}
+
+// IsOptimismMintableERC20 returns true if the token is minted & burned by the StandardBridge, rather than
+// escrowed, mirroring the ERC165 interface check of the StandardBridge.
+func IsOptimismMintableERC20(client node.EthClient, token common.Address, height *big.Int) (bool, error) {
+	erc20Abi, err := bindings.OptimismMintableERC20MetaData.GetAbi()
+	if err != nil {
+		return false, err
+	}
+
+	for _, interfaceID := range [][4]byte{legacyMintableERC20InterfaceID, optimismMintableERC20InterfaceID} {
+		calldata, err := erc20Abi.Pack("supportsInterface", interfaceID)
+		if err != nil {
+			return false, err
+		}
+
+		// Tokens without ERC165 support revert or return no output
+		output, err := client.CallContract(ethereum.CallMsg{To: &token, Data: calldata}, height)
+		if err != nil || len(output) != 32 {
+			continue
+		}
+
+		supported, err := erc20Abi.Unpack("supportsInterface", output)
+		if err == nil && supported[0].(bool) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NewTokenReport reconciles the on-chain L1 escrow balance and L2 supply of a token pair with the indexed
+// value in flight. Deposits are in flight until relayed on L2 and withdrawals until finalized on L1.
+func NewTokenReport(accounting database.BridgeTokenAccounting, l1EscrowBalance, l2TotalSupply, l1Height, l2Height *big.Int, timestamp uint64) database.BridgeTokenReport {
+	return database.BridgeTokenReport{
+		L1TokenAddress:      accounting.L1TokenAddress,
+		L2TokenAddress:      accounting.L2TokenAddress,
+		L1BlockNumber:       l1Height,
+		L2BlockNumber:       l2Height,
+		L1EscrowBalance:     l1EscrowBalance,
+		L2TotalSupply:       l2TotalSupply,
+		DepositsInFlight:    new(big.Int).Sub(accounting.Deposited, accounting.DepositsRelayed),
+		WithdrawalsInFlight: new(big.Int).Sub(accounting.Withdrawn, accounting.WithdrawalsFinalized),
+		StuckDeposits:       accounting.StuckDeposits,
+		StuckWithdrawals:    accounting.StuckWithdrawals,
+		Timestamp:           timestamp,
+	}
+}
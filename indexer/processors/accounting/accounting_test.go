@@ -0,0 +1,95 @@
+package accounting
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+func TestL1EscrowBalance(t *testing.T) {
+	l1StandardBridgeAbi, err := bindings.L1StandardBridgeMetaData.GetAbi()
+	require.NoError(t, err)
+
+	l1StandardBridge, l1Token, l2Token := common.HexToAddress("0x1"), common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	calldata, err := l1StandardBridgeAbi.Pack("deposits", l1Token, l2Token)
+	require.NoError(t, err)
+	output, err := l1StandardBridgeAbi.Methods["deposits"].Outputs.Pack(big.NewInt(100))
+	require.NoError(t, err)
+
+	client := &node.MockEthClient{}
+	client.On("CallContract", ethereum.CallMsg{To: &l1StandardBridge, Data: calldata}, big.NewInt(10)).Return(output, nil)
+
+	balance, err := L1EscrowBalance(client, l1StandardBridge, l1Token, l2Token, big.NewInt(10))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), balance)
+}
+
+func TestIsOptimismMintableERC20(t *testing.T) {
+	erc20Abi, err := bindings.OptimismMintableERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	supported, err := erc20Abi.Methods["supportsInterface"].Outputs.Pack(true)
+	require.NoError(t, err)
+	unsupported, err := erc20Abi.Methods["supportsInterface"].Outputs.Pack(false)
+	require.NoError(t, err)
+
+	legacyCalldata, err := erc20Abi.Pack("supportsInterface", legacyMintableERC20InterfaceID)
+	require.NoError(t, err)
+	calldata, err := erc20Abi.Pack("supportsInterface", optimismMintableERC20InterfaceID)
+	require.NoError(t, err)
+
+	t.Run("mintable", func(t *testing.T) {
+		token := common.HexToAddress("0x1")
+		client := &node.MockEthClient{}
+		client.On("CallContract", ethereum.CallMsg{To: &token, Data: legacyCalldata}, mock.Anything).Return(unsupported, nil)
+		client.On("CallContract", ethereum.CallMsg{To: &token, Data: calldata}, mock.Anything).Return(supported, nil)
+
+		mintable, err := IsOptimismMintableERC20(client, token, big.NewInt(10))
+		require.NoError(t, err)
+		require.True(t, mintable)
+	})
+
+	t.Run("without erc165 support", func(t *testing.T) {
+		token := common.HexToAddress("0x2")
+		client := &node.MockEthClient{}
+		client.On("CallContract", ethereum.CallMsg{To: &token, Data: legacyCalldata}, mock.Anything).Return([]byte{}, errors.New("execution reverted"))
+		client.On("CallContract", ethereum.CallMsg{To: &token, Data: calldata}, mock.Anything).Return([]byte{}, nil)
+
+		mintable, err := IsOptimismMintableERC20(client, token, big.NewInt(10))
+		require.NoError(t, err)
+		require.False(t, mintable)
+	})
+}
+
+func TestNewTokenReport(t *testing.T) {
+	tokenAccounting := database.BridgeTokenAccounting{
+		L1TokenAddress:       common.HexToAddress("0x1"),
+		L2TokenAddress:       common.HexToAddress("0x2"),
+		Deposited:            big.NewInt(100),
+		DepositsRelayed:      big.NewInt(80),
+		Withdrawn:            big.NewInt(30),
+		WithdrawalsFinalized: big.NewInt(20),
+		StuckDeposits:        1,
+	}
+
+	// 100 deposited - 30 withdrawn = 70 escrowed, with 20 deposits & 10 withdrawals in flight
+	report := NewTokenReport(tokenAccounting, big.NewInt(70), big.NewInt(40), big.NewInt(10), big.NewInt(20), 1)
+	require.Equal(t, big.NewInt(20), report.DepositsInFlight)
+	require.Equal(t, big.NewInt(10), report.WithdrawalsInFlight)
+	require.Equal(t, 1, report.StuckDeposits)
+	require.Zero(t, report.Discrepancy().Sign())
+	require.False(t, report.Mismatch())
+
+	// L2 supply not backed by the escrow
+	report = NewTokenReport(tokenAccounting, big.NewInt(70), big.NewInt(45), big.NewInt(10), big.NewInt(20), 1)
+	require.Equal(t, big.NewInt(-5), report.Discrepancy())
+	require.True(t, report.Mismatch())
+}
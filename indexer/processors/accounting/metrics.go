@@ -0,0 +1,130 @@
+package accounting
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	MetricsNamespace string = "op_indexer_accounting"
+)
+
+type Metricer interface {
+	RecordInterval() (done func(err error))
+
+	RecordTokenReport(report database.BridgeTokenReport)
+	RecordMismatches(size int)
+}
+
+type accountingMetrics struct {
+	intervalTick     prometheus.Counter
+	intervalDuration prometheus.Histogram
+	intervalFailures prometheus.Counter
+
+	l1EscrowBalance     *prometheus.GaugeVec
+	l2TotalSupply       *prometheus.GaugeVec
+	inFlight            *prometheus.GaugeVec
+	discrepancy         *prometheus.GaugeVec
+	stuckMessages       *prometheus.GaugeVec
+	mismatchedTokenPair *prometheus.GaugeVec
+	mismatches          prometheus.Gauge
+}
+
+func NewMetrics(registry prometheus.Registerer) Metricer {
+	factory := metrics.With(registry)
+	tokenPairLabels := []string{"l1_token", "l2_token"}
+	return &accountingMetrics{
+		intervalTick: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "intervals_total",
+			Help:      "number of times the accounting loop has run",
+		}),
+		intervalDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: MetricsNamespace,
+			Name:      "interval_seconds",
+			Help:      "duration elapsed in the accounting loop",
+		}),
+		intervalFailures: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "interval_failures_total",
+			Help:      "number of failures encountered",
+		}),
+		l1EscrowBalance: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "l1_escrow_balance",
+			Help:      "balance of the l1 token escrowed by the l1 standard bridge",
+		}, tokenPairLabels),
+		l2TotalSupply: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "l2_total_supply",
+			Help:      "total supply of the l2 token",
+		}, tokenPairLabels),
+		inFlight: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "in_flight",
+			Help:      "value of the initiated, but unfinalized, transfers (deposit|withdrawal)",
+		}, append(tokenPairLabels, "kind")),
+		discrepancy: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "discrepancy",
+			Help:      "escrowed value unaccounted for by the l2 supply and value in flight",
+		}, tokenPairLabels),
+		stuckMessages: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "stuck_messages",
+			Help:      "number of transfers that failed to relay and have yet to be replayed (deposit|withdrawal)",
+		}, append(tokenPairLabels, "kind")),
+		mismatchedTokenPair: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "mismatch",
+			Help:      "1 if the escrow of the token pair does not reconcile with the l2 supply and value in flight",
+		}, tokenPairLabels),
+		mismatches: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: MetricsNamespace,
+			Name:      "mismatches",
+			Help:      "number of token pairs whose escrow does not reconcile",
+		}),
+	}
+}
+
+func (m *accountingMetrics) RecordInterval() func(error) {
+	m.intervalTick.Inc()
+	timer := prometheus.NewTimer(m.intervalDuration)
+	return func(err error) {
+		timer.ObserveDuration()
+		if err != nil {
+			m.intervalFailures.Inc()
+		}
+	}
+}
+
+func (m *accountingMetrics) RecordTokenReport(report database.BridgeTokenReport) {
+	l1Token, l2Token := report.L1TokenAddress.String(), report.L2TokenAddress.String()
+	m.l1EscrowBalance.WithLabelValues(l1Token, l2Token).Set(toFloat(report.L1EscrowBalance))
+	m.l2TotalSupply.WithLabelValues(l1Token, l2Token).Set(toFloat(report.L2TotalSupply))
+	m.inFlight.WithLabelValues(l1Token, l2Token, "deposit").Set(toFloat(report.DepositsInFlight))
+	m.inFlight.WithLabelValues(l1Token, l2Token, "withdrawal").Set(toFloat(report.WithdrawalsInFlight))
+	m.discrepancy.WithLabelValues(l1Token, l2Token).Set(toFloat(report.Discrepancy()))
+	m.stuckMessages.WithLabelValues(l1Token, l2Token, "deposit").Set(float64(report.StuckDeposits))
+	m.stuckMessages.WithLabelValues(l1Token, l2Token, "withdrawal").Set(float64(report.StuckWithdrawals))
+
+	mismatch := 0.0
+	if report.Mismatch() {
+		mismatch = 1
+	}
+	m.mismatchedTokenPair.WithLabelValues(l1Token, l2Token).Set(mismatch)
+}
+
+func (m *accountingMetrics) RecordMismatches(size int) {
+	m.mismatches.Set(float64(size))
+}
+
+// toFloat converts the token amount, in its smallest unit, to a float
+func toFloat(amount *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(amount).Float64()
+	return f
+}
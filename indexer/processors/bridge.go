@@ -124,6 +124,10 @@ func (b *BridgeProcessor) Close() error {
 func (b *BridgeProcessor) onL1Data() error {
 	latestL1Header := b.l1Etl.LatestHeader
 	b.log.Info("notified of new L1 state", "l1_etl_block_number", latestL1Header.Number)
+	if err := b.reloadL1State(); err != nil {
+		b.log.Error("failed to reload reorged bridge state", "err", err)
+		return err
+	}
 
 	var errs error
 	if err := b.processInitiatedL1Events(); err != nil {
@@ -150,6 +154,10 @@ func (b *BridgeProcessor) onL2Data() error {
 		return nil // skip genesis
 	}
 	b.log.Info("notified of new L2 state", "l2_etl_block_number", b.l2Etl.LatestHeader.Number)
+	if err := b.reloadL2State(); err != nil {
+		b.log.Error("failed to reload reorged bridge state", "err", err)
+		return err
+	}
 
 	var errs error
 	if err := b.processInitiatedL2Events(); err != nil {
@@ -169,6 +177,92 @@ func (b *BridgeProcessor) onL2Data() error {
 	return errs
 }
 
+// Reorg Handling. The ETLs rollback any indexed state that is reorged out, cascading to the derived
+// bridge data. When the last visited header is no longer indexed, we reload the checkpoint from the
+// remaining bridge state and re-process. Re-processing already indexed bridge events is a no-op.
+
+// reloadL1State reloads `LastL1Header` & `LastFinalizedL2Header` if rolled back. A removed L1 header
+// also removes the deposits that may have been marked as relayed on L2.
+func (b *BridgeProcessor) reloadL1State() error {
+	reloadFinalizedL2Header := false
+	if b.LastL1Header != nil {
+		l1Header, err := b.db.Blocks.L1BlockHeader(b.LastL1Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last visited L1 header: %w", err)
+		} else if l1Header == nil {
+			latestL1Header, err := b.db.BridgeTransactions.L1LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+
+			b.log.Warn("last visited L1 header reorged", "block_number", b.LastL1Header.Number, "block_hash", b.LastL1Header.Hash)
+			b.LastL1Header = latestL1Header
+			reloadFinalizedL2Header = true
+		}
+	}
+
+	if !reloadFinalizedL2Header && b.LastFinalizedL2Header != nil {
+		l2Header, err := b.db.Blocks.L2BlockHeader(b.LastFinalizedL2Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last finalized L2 header: %w", err)
+		}
+		reloadFinalizedL2Header = l2Header == nil
+	}
+
+	if reloadFinalizedL2Header {
+		latestFinalizedL2Header, err := b.db.BridgeTransactions.L2LatestFinalizedBlockHeader()
+		if err != nil {
+			return err
+		}
+
+		b.log.Warn("reloaded finalized L2 state", "finalized_l2_block", latestFinalizedL2Header)
+		b.LastFinalizedL2Header = latestFinalizedL2Header
+	}
+
+	return nil
+}
+
+// reloadL2State reloads `LastL2Header` & `LastFinalizedL1Header` if rolled back. A removed L2 header
+// also removes the withdrawals that may have been proven or finalized on L1.
+func (b *BridgeProcessor) reloadL2State() error {
+	reloadFinalizedL1Header := false
+	if b.LastL2Header != nil {
+		l2Header, err := b.db.Blocks.L2BlockHeader(b.LastL2Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last visited L2 header: %w", err)
+		} else if l2Header == nil {
+			latestL2Header, err := b.db.BridgeTransactions.L2LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+
+			b.log.Warn("last visited L2 header reorged", "block_number", b.LastL2Header.Number, "block_hash", b.LastL2Header.Hash)
+			b.LastL2Header = latestL2Header
+			reloadFinalizedL1Header = true
+		}
+	}
+
+	if !reloadFinalizedL1Header && b.LastFinalizedL1Header != nil {
+		l1Header, err := b.db.Blocks.L1BlockHeader(b.LastFinalizedL1Header.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to query last finalized L1 header: %w", err)
+		}
+		reloadFinalizedL1Header = l1Header == nil
+	}
+
+	if reloadFinalizedL1Header {
+		latestFinalizedL1Header, err := b.db.BridgeTransactions.L1LatestFinalizedBlockHeader()
+		if err != nil {
+			return err
+		}
+
+		b.log.Warn("reloaded finalized L1 state", "finalized_l1_block", latestFinalizedL1Header)
+		b.LastFinalizedL1Header = latestFinalizedL1Header
+	}
+
+	return nil
+}
+
 // Process Initiated Bridge Events
 
 func (b *BridgeProcessor) processInitiatedL1Events() error {
@@ -194,31 +288,15 @@ func (b *BridgeProcessor) processInitiatedL1Events() error {
 
 	fromL1Height, toL1Height := new(big.Int).Add(lastL1BlockNumber, bigint.One), latestL1Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l1BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L1BedrockStartingHeight))
-		if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 { // OP Mainnet & OP Goerli Only.
-			legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
-			if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
-				legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
-			legacyBridgeLog.Info("scanning for initiated bridge events")
-			if err := bridge.LegacyL1ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
-				return err
-			} else if legacyToL1Height.Cmp(toL1Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l1BedrockStartingHeight)
-			fromL1Height = l1BedrockStartingHeight
+		if err := processInitiatedL1Range(l1BridgeLog, tx, b.metrics, b.chainConfig, fromL1Height, toL1Height); err != nil {
+			return err
 		}
-
-		l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
-		l1BridgeLog.Info("scanning for initiated bridge events")
-		return bridge.L1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, fromL1Height, toL1Height)
+		return tx.NotifyInitiatedL1BridgeUpdates(fromL1Height, toL1Height)
 	}); err != nil {
 		return err
 	}
 
+	b.notifyBridgeUpdates()
 	b.LastL1Header = latestL1Header
 	b.metrics.RecordL1LatestHeight(latestL1Header.Number)
 	return nil
@@ -247,31 +325,15 @@ func (b *BridgeProcessor) processInitiatedL2Events() error {
 
 	fromL2Height, toL2Height := new(big.Int).Add(lastL2BlockNumber, bigint.One), latestL2Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l2BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L2BedrockStartingHeight))
-		if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 { // OP Mainnet & OP Goerli Only
-			legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
-			if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
-				legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
-			legacyBridgeLog.Info("scanning for initiated bridge events")
-			if err := bridge.LegacyL2ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.Preset, b.chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
-				return err
-			} else if legacyToL2Height.Cmp(toL2Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock")
-			fromL2Height = l2BedrockStartingHeight
+		if err := processInitiatedL2Range(l2BridgeLog, tx, b.metrics, b.chainConfig, fromL2Height, toL2Height); err != nil {
+			return err
 		}
-
-		l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
-		l2BridgeLog.Info("scanning for initiated bridge events")
-		return bridge.L2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, fromL2Height, toL2Height)
+		return tx.NotifyInitiatedL2BridgeUpdates(fromL2Height, toL2Height)
 	}); err != nil {
 		return err
 	}
 
+	b.notifyBridgeUpdates()
 	b.LastL2Header = latestL2Header
 	b.metrics.RecordL2LatestHeight(latestL2Header.Number)
 	return nil
@@ -303,31 +365,15 @@ func (b *BridgeProcessor) processFinalizedL1Events() error {
 
 	fromL1Height, toL1Height := new(big.Int).Add(lastFinalizedL1BlockNumber, bigint.One), latestL1Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l1BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L1BedrockStartingHeight))
-		if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 {
-			legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
-			if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
-				legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
-			legacyBridgeLog.Info("scanning for finalized bridge events")
-			if err := bridge.LegacyL1ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
-				return err
-			} else if legacyToL1Height.Cmp(toL1Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock")
-			fromL1Height = l1BedrockStartingHeight
+		if err := processFinalizedL1Range(l1BridgeLog, tx, b.metrics, b.chainConfig, fromL1Height, toL1Height); err != nil {
+			return err
 		}
-
-		l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
-		l1BridgeLog.Info("scanning for finalized bridge events")
-		return bridge.L1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, b.metrics, b.chainConfig.L1Contracts, fromL1Height, toL1Height)
+		return tx.NotifyFinalizedL1BridgeUpdates(fromL1Height, toL1Height)
 	}); err != nil {
 		return err
 	}
 
+	b.notifyBridgeUpdates()
 	b.LastFinalizedL1Header = latestL1Header
 	b.metrics.RecordL1LatestFinalizedHeight(latestL1Header.Number)
 	return nil
@@ -357,32 +403,127 @@ func (b *BridgeProcessor) processFinalizedL2Events() error {
 
 	fromL2Height, toL2Height := new(big.Int).Add(lastFinalizedL2BlockNumber, bigint.One), latestL2Header.Number
 	if err := b.db.Transaction(func(tx *database.DB) error {
-		l2BedrockStartingHeight := big.NewInt(int64(b.chainConfig.L2BedrockStartingHeight))
-		if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 {
-			legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
-			if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
-				legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
-			}
-
-			legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
-			legacyBridgeLog.Info("scanning for finalized bridge events")
-			if err := bridge.LegacyL2ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
-				return err
-			} else if legacyToL2Height.Cmp(toL2Height) == 0 {
-				return nil // a-ok! Entire range was legacy blocks
-			}
-			legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l2BedrockStartingHeight)
-			fromL2Height = l2BedrockStartingHeight
-		}
-
-		l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
-		l2BridgeLog.Info("scanning for finalized bridge events")
-		return bridge.L2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, b.metrics, b.chainConfig.L2Contracts, fromL2Height, toL2Height)
+		return processFinalizedL2Range(l2BridgeLog, tx, b.metrics, b.chainConfig, fromL2Height, toL2Height)
 	}); err != nil {
 		return err
 	}
 
+	b.notifyBridgeUpdates()
 	b.LastFinalizedL2Header = latestL2Header
 	b.metrics.RecordL2LatestFinalizedHeight(latestL2Header.Number)
 	return nil
 }
+
+// processInitiatedL1Range indexes the initiated L1 bridge events, and output proposals, within the inclusive range
+func processInitiatedL1Range(l1BridgeLog log.Logger, tx *database.DB, metrics bridge.L1Metricer, chainConfig config.ChainConfig, fromL1Height, toL1Height *big.Int) error {
+	l1BedrockStartingHeight := big.NewInt(int64(chainConfig.L1BedrockStartingHeight))
+	if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 { // OP Mainnet & OP Goerli Only.
+		legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
+		if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
+			legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
+		legacyBridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.LegacyL1ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
+			return err
+		} else if legacyToL1Height.Cmp(toL1Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l1BedrockStartingHeight)
+		fromL1Height = l1BedrockStartingHeight
+	}
+
+	l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
+	l1BridgeLog.Info("scanning for initiated bridge events")
+	if err := bridge.L1ProcessInitiatedBridgeEvents(l1BridgeLog, tx, metrics, chainConfig.L1Contracts, fromL1Height, toL1Height); err != nil {
+		return err
+	}
+
+	l1BridgeLog.Info("scanning for output proposals")
+	return bridge.L1ProcessOutputProposals(l1BridgeLog, tx, metrics, chainConfig.L1Contracts, fromL1Height, toL1Height)
+}
+
+// processInitiatedL2Range indexes the initiated L2 bridge events within the inclusive range
+func processInitiatedL2Range(l2BridgeLog log.Logger, tx *database.DB, metrics bridge.L2Metricer, chainConfig config.ChainConfig, fromL2Height, toL2Height *big.Int) error {
+	l2BedrockStartingHeight := big.NewInt(int64(chainConfig.L2BedrockStartingHeight))
+	if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 { // OP Mainnet & OP Goerli Only
+		legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
+		if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
+			legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
+		legacyBridgeLog.Info("scanning for initiated bridge events")
+		if err := bridge.LegacyL2ProcessInitiatedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.Preset, chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
+			return err
+		} else if legacyToL2Height.Cmp(toL2Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock")
+		fromL2Height = l2BedrockStartingHeight
+	}
+
+	l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
+	l2BridgeLog.Info("scanning for initiated bridge events")
+	return bridge.L2ProcessInitiatedBridgeEvents(l2BridgeLog, tx, metrics, chainConfig.L2Contracts, fromL2Height, toL2Height)
+}
+
+// processFinalizedL1Range indexes the L1 finalization of L2 bridge events within the inclusive range
+func processFinalizedL1Range(l1BridgeLog log.Logger, tx *database.DB, metrics bridge.L1Metricer, chainConfig config.ChainConfig, fromL1Height, toL1Height *big.Int) error {
+	l1BedrockStartingHeight := big.NewInt(int64(chainConfig.L1BedrockStartingHeight))
+	if l1BedrockStartingHeight.Cmp(fromL1Height) > 0 {
+		legacyFromL1Height, legacyToL1Height := fromL1Height, toL1Height
+		if l1BedrockStartingHeight.Cmp(toL1Height) <= 0 {
+			legacyToL1Height = new(big.Int).Sub(l1BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l1BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL1Height, "to_block_number", legacyToL1Height)
+		legacyBridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.LegacyL1ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.L1Contracts, legacyFromL1Height, legacyToL1Height); err != nil {
+			return err
+		} else if legacyToL1Height.Cmp(toL1Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock")
+		fromL1Height = l1BedrockStartingHeight
+	}
+
+	l1BridgeLog = l1BridgeLog.New("from_block_number", fromL1Height, "to_block_number", toL1Height)
+	l1BridgeLog.Info("scanning for finalized bridge events")
+	return bridge.L1ProcessFinalizedBridgeEvents(l1BridgeLog, tx, metrics, chainConfig.L1Contracts, fromL1Height, toL1Height)
+}
+
+// processFinalizedL2Range indexes the L2 finalization of L1 bridge events within the inclusive range
+func processFinalizedL2Range(l2BridgeLog log.Logger, tx *database.DB, metrics bridge.L2Metricer, chainConfig config.ChainConfig, fromL2Height, toL2Height *big.Int) error {
+	l2BedrockStartingHeight := big.NewInt(int64(chainConfig.L2BedrockStartingHeight))
+	if l2BedrockStartingHeight.Cmp(fromL2Height) > 0 {
+		legacyFromL2Height, legacyToL2Height := fromL2Height, toL2Height
+		if l2BedrockStartingHeight.Cmp(toL2Height) <= 0 {
+			legacyToL2Height = new(big.Int).Sub(l2BedrockStartingHeight, bigint.One)
+		}
+
+		legacyBridgeLog := l2BridgeLog.New("mode", "legacy", "from_block_number", legacyFromL2Height, "to_block_number", legacyToL2Height)
+		legacyBridgeLog.Info("scanning for finalized bridge events")
+		if err := bridge.LegacyL2ProcessFinalizedBridgeEvents(legacyBridgeLog, tx, metrics, chainConfig.L2Contracts, legacyFromL2Height, legacyToL2Height); err != nil {
+			return err
+		} else if legacyToL2Height.Cmp(toL2Height) == 0 {
+			return nil // a-ok! Entire range was legacy blocks
+		}
+		legacyBridgeLog.Info("detected switch to bedrock", "bedrock_block_number", l2BedrockStartingHeight)
+		fromL2Height = l2BedrockStartingHeight
+	}
+
+	l2BridgeLog = l2BridgeLog.New("from_block_number", fromL2Height, "to_block_number", toL2Height)
+	l2BridgeLog.Info("scanning for finalized bridge events")
+	return bridge.L2ProcessFinalizedBridgeEvents(l2BridgeLog, tx, metrics, chainConfig.L2Contracts, fromL2Height, toL2Height)
+}
+
+// notifyBridgeUpdates signals listeners, i.e the API, that the indexed state has progressed. Changed transfers
+// are notified within the transaction that indexed them, this signal only matures proven withdrawals. A failed
+// signal therefore only delays those updates until the next one
+func (b *BridgeProcessor) notifyBridgeUpdates() {
+	if err := b.db.NotifyBridgeUpdates(); err != nil {
+		b.log.Warn("failed to notify bridge updates", "err", err)
+	}
+}
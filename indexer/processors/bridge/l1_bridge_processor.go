@@ -20,6 +20,7 @@ import (
 //  1. OptimismPortal
 //  2. L1CrossDomainMessenger
 //  3. L1StandardBridge
+//  4. L1ERC721Bridge
 func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal
 	optimismPortalTxDeposits, err := contracts.OptimismPortalTransactionDepositEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
@@ -138,14 +139,128 @@ func L1ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// (4) L1ERC721Bridge
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected erc721 bridge deposits", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedCollections := make(map[common.Address]int)
+	erc721BridgeDeposits := make([]database.L1ERC721BridgeDeposit, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		// Unlike the StandardBridge, the ERC721Bridge emits the initiated event after sending the message. The
+		// cross domain message hash & deposit source hash are extracted from the preceding events, accounting for
+		// the SentMessageExtension1 event emitted by the messenger
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if sentMessage.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
+		}
+
+		portalDeposit, ok := portalDeposits[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 3}]
+		if !ok {
+			return fmt.Errorf("expected TransactionDeposit preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if portalDeposit.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch, bridge_tx_hash = %s, deposit_tx_hash = %s", initiatedBridge.Event.TransactionHash, portalDeposit.Event.TransactionHash)
+		}
+
+		bridgedCollections[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = sentMessage.BridgeMessage.MessageHash
+		erc721BridgeDeposits[i] = database.L1ERC721BridgeDeposit{
+			TransactionSourceHash: portalDeposit.DepositTx.SourceHash,
+			ERC721BridgeTransfer:  initiatedBridge.BridgeTransfer,
+		}
+	}
+	if len(erc721BridgeDeposits) > 0 {
+		if err := db.BridgeTransfers.StoreL1ERC721BridgeDeposits(erc721BridgeDeposits); err != nil {
+			return err
+		}
+		for tokenAddr, size := range bridgedCollections {
+			metrics.RecordL1InitiatedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	return nil
 }
 
+// L1ProcessOutputProposals will query the database for outputs proposed to, or deleted from, the L2OutputOracle
+// between the specified block range. Outputs are required to determine when a withdrawal can be proven.
+func L1ProcessOutputProposals(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
+	outputProposals, err := contracts.L2OutputOracleOutputProposedEvents(l1Contracts.L2OutputOracleProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	outputDeletions, err := contracts.L2OutputOracleOutputsDeletedEvents(l1Contracts.L2OutputOracleProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(outputProposals) > 0 {
+		log.Info("detected output proposals", "size", len(outputProposals))
+	}
+
+	// Deletions are rare, applied in order with the proposals that surround them. Since L1 block timestamps
+	// are unique, (timestamp, log index) totally orders the events within the range.
+	proposals := make([]database.OutputProposal, 0, len(outputProposals))
+	for i := range outputProposals {
+		outputProposal := outputProposals[i]
+		for len(outputDeletions) > 0 && eventBefore(outputDeletions[0].Event, outputProposal.Event) {
+			if err := storeOutputProposals(db, proposals); err != nil {
+				return err
+			}
+			proposals = proposals[:0]
+			if err := db.Blocks.DeleteOutputProposalsFrom(outputDeletions[0].NewNextOutputIndex); err != nil {
+				return fmt.Errorf("failed to delete output proposals. tx_hash = %s: %w", outputDeletions[0].Event.TransactionHash, err)
+			}
+			outputDeletions = outputDeletions[1:]
+		}
+
+		proposals = append(proposals, database.OutputProposal{
+			OutputRoot:         outputProposal.OutputRoot,
+			L2OutputIndex:      outputProposal.L2OutputIndex,
+			L2BlockNumber:      outputProposal.L2BlockNumber,
+			OutputProposedGUID: outputProposal.Event.GUID,
+		})
+	}
+	if err := storeOutputProposals(db, proposals); err != nil {
+		return err
+	}
+	for i := range outputDeletions {
+		if err := db.Blocks.DeleteOutputProposalsFrom(outputDeletions[i].NewNextOutputIndex); err != nil {
+			return fmt.Errorf("failed to delete output proposals. tx_hash = %s: %w", outputDeletions[i].Event.TransactionHash, err)
+		}
+	}
+
+	if len(outputProposals) > 0 {
+		metrics.RecordL1OutputProposals(len(outputProposals))
+	}
+
+	return nil
+}
+
+func storeOutputProposals(db *database.DB, proposals []database.OutputProposal) error {
+	if len(proposals) == 0 {
+		return nil
+	}
+	return db.Blocks.StoreOutputProposals(proposals)
+}
+
+func eventBefore(a, b *database.ContractEvent) bool {
+	return a.Timestamp < b.Timestamp || (a.Timestamp == b.Timestamp && a.LogIndex < b.LogIndex)
+}
+
 // L1ProcessFinalizedBridgeEvent will query the database for all the finalization markers for all initiated
 // bridge events. This covers every part of the multi-layered stack:
 //  1. OptimismPortal (Bedrock prove & finalize steps)
-//  2. L1CrossDomainMessenger (relayMessage marker)
+//  2. L1CrossDomainMessenger (relayMessage & failed relay markers)
 //  3. L1StandardBridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
+//  4. L1ERC721Bridge (no-op, since this is simply a wrapper over the L1CrossDomainMessenger)
 func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1Metricer, l1Contracts config.L1Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) OptimismPortal (proven withdrawals)
 	provenWithdrawals, err := contracts.OptimismPortalWithdrawalProvenEvents(l1Contracts.OptimismPortalProxy, db, fromHeight, toHeight)
@@ -252,6 +367,35 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// Failed relays leave the message stuck until successfully replayed
+	crossDomainFailedRelayedMessages, err := contracts.CrossDomainMessengerFailedRelayedMessageEvents("l1", l1Contracts.L1CrossDomainMessengerProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(crossDomainFailedRelayedMessages) > 0 {
+		log.Warn("detected failed relayed messages", "size", len(crossDomainFailedRelayedMessages))
+	}
+
+	for i := range crossDomainFailedRelayedMessages {
+		failedRelayedMessage := crossDomainFailedRelayedMessages[i]
+		message, err := db.BridgeMessages.L2BridgeMessage(failedRelayedMessage.MessageHash)
+		if err != nil {
+			return err
+		} else if message == nil {
+			if _, ok := ovm1.L1RelayedMessages[failedRelayedMessage.MessageHash]; ok {
+				continue
+			}
+			return fmt.Errorf("missing indexed L2CrossDomainMessager message! tx_hash = %s", failedRelayedMessage.Event.TransactionHash.String())
+		}
+
+		if err := db.BridgeMessages.MarkFailedRelayedL2BridgeMessage(failedRelayedMessage.MessageHash, failedRelayedMessage.Event.GUID); err != nil {
+			return fmt.Errorf("failed to mark failed relay of cross domain message. tx_hash = %s: %w", failedRelayedMessage.Event.TransactionHash, err)
+		}
+	}
+	if len(crossDomainFailedRelayedMessages) > 0 {
+		metrics.RecordL1CrossDomainFailedRelayedMessages(len(crossDomainFailedRelayedMessages))
+	}
+
 	// (4) L1StandardBridge
 	// - Nothing actionable on the database. Since the StandardBridge is layered ontop of the
 	// CrossDomainMessenger, there's no need for any sanity or invariant checks as the previous step
@@ -273,6 +417,26 @@ func L1ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L1M
 		}
 	}
 
+	// (5) L1ERC721Bridge
+	// - Nothing actionable on the database. Same as the StandardBridge, the finalization status
+	// of the transfer is tracked by the relayed cross domain message.
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l1", l1Contracts.L1ERC721BridgeProxy, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	finalizedCollections := make(map[common.Address]int)
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		finalizedCollections[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized erc721 bridge withdrawals", "size", len(finalizedERC721Bridges))
+		for tokenAddr, size := range finalizedCollections {
+			metrics.RecordL1FinalizedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
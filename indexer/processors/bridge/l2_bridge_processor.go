@@ -18,6 +18,7 @@ import (
 //  1. OptimismPortal
 //  2. L2CrossDomainMessenger
 //  3. L2StandardBridge
+//  4. L2ERC721Bridge
 func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2Metricer, l2Contracts config.L2Contracts, fromHeight, toHeight *big.Int) error {
 	// (1) L2ToL1MessagePasser
 	l2ToL1MPMessagesPassed, err := contracts.L2ToL1MessagePasserMessagePassedEvents(l2Contracts.L2ToL1MessagePasser, db, fromHeight, toHeight)
@@ -132,14 +133,63 @@ func L2ProcessInitiatedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		}
 	}
 
+	// (4) L2ERC721Bridge
+	initiatedERC721Bridges, err := contracts.ERC721BridgeInitiatedEvents("l2", l2Contracts.L2ERC721Bridge, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(initiatedERC721Bridges) > 0 {
+		log.Info("detected erc721 bridge withdrawals", "size", len(initiatedERC721Bridges))
+	}
+
+	bridgedCollections := make(map[common.Address]int)
+	erc721BridgeWithdrawals := make([]database.L2ERC721BridgeWithdrawal, len(initiatedERC721Bridges))
+	for i := range initiatedERC721Bridges {
+		initiatedBridge := initiatedERC721Bridges[i]
+
+		// Unlike the StandardBridge, the ERC721Bridge emits the initiated event after sending the message. The
+		// cross domain message hash & withdrawal hash are extracted from the preceding events, accounting for
+		// the SentMessageExtension1 event emitted by the messenger
+		sentMessage, ok := sentMessages[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 2}]
+		if !ok {
+			return fmt.Errorf("expected SentMessage preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if sentMessage.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, message_tx_hash = %s", initiatedBridge.Event.TransactionHash, sentMessage.Event.TransactionHash)
+		}
+
+		messagePassed, ok := messagesPassed[logKey{initiatedBridge.Event.BlockHash, initiatedBridge.Event.LogIndex - 3}]
+		if !ok {
+			return fmt.Errorf("expected MessagePassed preceding ERC721BridgeInitiated event. tx_hash = %s", initiatedBridge.Event.TransactionHash)
+		} else if messagePassed.Event.TransactionHash != initiatedBridge.Event.TransactionHash {
+			return fmt.Errorf("correlated events tx hash mismatch. bridge_tx_hash = %s, withdraw_tx_hash = %s", initiatedBridge.Event.TransactionHash, messagePassed.Event.TransactionHash)
+		}
+
+		bridgedCollections[initiatedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+
+		initiatedBridge.BridgeTransfer.CrossDomainMessageHash = sentMessage.BridgeMessage.MessageHash
+		erc721BridgeWithdrawals[i] = database.L2ERC721BridgeWithdrawal{
+			TransactionWithdrawalHash: messagePassed.WithdrawalHash,
+			ERC721BridgeTransfer:      initiatedBridge.BridgeTransfer,
+		}
+	}
+	if len(erc721BridgeWithdrawals) > 0 {
+		if err := db.BridgeTransfers.StoreL2ERC721BridgeWithdrawals(erc721BridgeWithdrawals); err != nil {
+			return err
+		}
+		for tokenAddr, size := range bridgedCollections {
+			metrics.RecordL2InitiatedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
 
 // L2ProcessFinalizedBridgeEvent will query the database for all the finalization markers for all initiated
 // bridge events. This covers every part of the multi-layered stack:
-//  1. L2CrossDomainMessenger (relayMessage marker)
+//  1. L2CrossDomainMessenger (relayMessage & failed relay markers)
 //  2. L2StandardBridge (no-op, since this is simply a wrapper over the L2CrossDomainMEssenger)
+//  3. L2ERC721Bridge (no-op, since this is simply a wrapper over the L2CrossDomainMEssenger)
 //
 // NOTE: Unlike L1, there's no L2ToL1MessagePasser stage since transaction deposits are apart of the block derivation process.
 func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2Metricer, l2Contracts config.L2Contracts, fromHeight, toHeight *big.Int) error {
@@ -169,6 +219,32 @@ func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		metrics.RecordL2CrossDomainRelayedMessages(len(crossDomainRelayedMessages))
 	}
 
+	// Failed relays leave the message stuck until successfully replayed
+	crossDomainFailedRelayedMessages, err := contracts.CrossDomainMessengerFailedRelayedMessageEvents("l2", l2Contracts.L2CrossDomainMessenger, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+	if len(crossDomainFailedRelayedMessages) > 0 {
+		log.Warn("detected failed relayed messages", "size", len(crossDomainFailedRelayedMessages))
+	}
+
+	for i := range crossDomainFailedRelayedMessages {
+		failedRelayed := crossDomainFailedRelayedMessages[i]
+		message, err := db.BridgeMessages.L1BridgeMessage(failedRelayed.MessageHash)
+		if err != nil {
+			return err
+		} else if message == nil {
+			return fmt.Errorf("missing indexed L1CrossDomainMessager message! tx_hash = %s", failedRelayed.Event.TransactionHash)
+		}
+
+		if err := db.BridgeMessages.MarkFailedRelayedL1BridgeMessage(failedRelayed.MessageHash, failedRelayed.Event.GUID); err != nil {
+			return fmt.Errorf("failed to mark failed relay of cross domain message. tx_hash = %s: %w", failedRelayed.Event.TransactionHash, err)
+		}
+	}
+	if len(crossDomainFailedRelayedMessages) > 0 {
+		metrics.RecordL2CrossDomainFailedRelayedMessages(len(crossDomainFailedRelayedMessages))
+	}
+
 	// (2) L2StandardBridge
 	// - Nothing actionable on the database. Since the StandardBridge is layered ontop of the
 	// CrossDomainMessenger, there's no need for any sanity or invariant checks as the previous step
@@ -190,6 +266,26 @@ func L2ProcessFinalizedBridgeEvents(log log.Logger, db *database.DB, metrics L2M
 		}
 	}
 
+	// (3) L2ERC721Bridge
+	// - Nothing actionable on the database. Same as the StandardBridge, the finalization status
+	// of the transfer is tracked by the relayed cross domain message.
+	finalizedERC721Bridges, err := contracts.ERC721BridgeFinalizedEvents("l2", l2Contracts.L2ERC721Bridge, db, fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	finalizedCollections := make(map[common.Address]int)
+	for i := range finalizedERC721Bridges {
+		finalizedBridge := finalizedERC721Bridges[i]
+		finalizedCollections[finalizedBridge.BridgeTransfer.TokenPair.LocalTokenAddress]++
+	}
+	if len(finalizedERC721Bridges) > 0 {
+		log.Info("detected finalized erc721 bridge deposits", "size", len(finalizedERC721Bridges))
+		for tokenAddr, size := range finalizedCollections {
+			metrics.RecordL2FinalizedERC721BridgeTransfers(tokenAddr, size)
+		}
+	}
+
 	// a-ok!
 	return nil
 }
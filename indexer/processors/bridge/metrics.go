@@ -21,9 +21,11 @@ type L1Metricer interface {
 	RecordL1TransactionDeposits(size int, mintedETH float64)
 	RecordL1ProvenWithdrawals(size int)
 	RecordL1FinalizedWithdrawals(size int)
+	RecordL1OutputProposals(size int)
 
 	RecordL1CrossDomainSentMessages(size int)
 	RecordL1CrossDomainRelayedMessages(size int)
+	RecordL1CrossDomainFailedRelayedMessages(size int)
 
 	RecordL1SkippedOVM1ProvenWithdrawals(size int)
 	RecordL1SkippedOVM1FinalizedWithdrawals(size int)
@@ -31,6 +33,9 @@ type L1Metricer interface {
 
 	RecordL1InitiatedBridgeTransfers(token common.Address, size int)
 	RecordL1FinalizedBridgeTransfers(token common.Address, size int)
+
+	RecordL1InitiatedERC721BridgeTransfers(token common.Address, size int)
+	RecordL1FinalizedERC721BridgeTransfers(token common.Address, size int)
 }
 
 type L2Metricer interface {
@@ -42,9 +47,13 @@ type L2Metricer interface {
 
 	RecordL2CrossDomainSentMessages(size int)
 	RecordL2CrossDomainRelayedMessages(size int)
+	RecordL2CrossDomainFailedRelayedMessages(size int)
 
 	RecordL2InitiatedBridgeTransfers(token common.Address, size int)
 	RecordL2FinalizedBridgeTransfers(token common.Address, size int)
+
+	RecordL2InitiatedERC721BridgeTransfers(token common.Address, size int)
+	RecordL2FinalizedERC721BridgeTransfers(token common.Address, size int)
 }
 
 type Metricer interface {
@@ -63,6 +72,7 @@ type bridgeMetrics struct {
 	txWithdrawals        prometheus.Counter
 	provenWithdrawals    prometheus.Counter
 	finalizedWithdrawals prometheus.Counter
+	outputProposals      prometheus.Counter
 
 	txMintedETH    prometheus.Counter
 	txWithdrawnETH prometheus.Counter
@@ -70,14 +80,19 @@ type bridgeMetrics struct {
 	sentMessages    *prometheus.CounterVec
 	relayedMessages *prometheus.CounterVec
 
+	failedRelayedMessages *prometheus.CounterVec
+
 	skippedOVM1Withdrawals     *prometheus.CounterVec
 	skippedOVM1RelayedMessages prometheus.Counter
 
 	initiatedBridgeTransfers *prometheus.CounterVec
 	finalizedBridgeTransfers *prometheus.CounterVec
+
+	initiatedERC721BridgeTransfers *prometheus.CounterVec
+	finalizedERC721BridgeTransfers *prometheus.CounterVec
 }
 
-func NewMetrics(registry *prometheus.Registry) Metricer {
+func NewMetrics(registry prometheus.Registerer) Metricer {
 	factory := metrics.With(registry)
 	return &bridgeMetrics{
 		intervalTick: factory.NewCounterVec(prometheus.CounterOpts{
@@ -139,6 +154,11 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 			Name:      "finalized_withdrawals",
 			Help:      "number of finalized tx withdrawals on l1",
 		}),
+		outputProposals: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "output_proposals",
+			Help:      "number of proposed l2 outputs on l1",
+		}),
 		sentMessages: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: MetricsNamespace,
 			Name:      "sent_messages",
@@ -153,6 +173,13 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 		}, []string{
 			"chain",
 		}),
+		failedRelayedMessages: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "failed_relayed_messages",
+			Help:      "number of failed relays of messages between l1 and l2",
+		}, []string{
+			"chain",
+		}),
 		skippedOVM1Withdrawals: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: MetricsNamespace,
 			Name:      "skipped_ovm1_withdrawals",
@@ -181,6 +208,22 @@ func NewMetrics(registry *prometheus.Registry) Metricer {
 			"chain",
 			"token_address",
 		}),
+		initiatedERC721BridgeTransfers: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "initiated_erc721_transfers",
+			Help:      "number of bridged nfts between l1 and l2",
+		}, []string{
+			"chain",
+			"token_address",
+		}),
+		finalizedERC721BridgeTransfers: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: MetricsNamespace,
+			Name:      "finalized_erc721_transfers",
+			Help:      "number of finalized nft transfers between l1 and l2",
+		}, []string{
+			"chain",
+			"token_address",
+		}),
 	}
 }
 
@@ -218,6 +261,10 @@ func (m *bridgeMetrics) RecordL1FinalizedWithdrawals(size int) {
 	m.finalizedWithdrawals.Add(float64(size))
 }
 
+func (m *bridgeMetrics) RecordL1OutputProposals(size int) {
+	m.outputProposals.Add(float64(size))
+}
+
 func (m *bridgeMetrics) RecordL1CrossDomainSentMessages(size int) {
 	m.sentMessages.WithLabelValues("l1").Add(float64(size))
 }
@@ -226,6 +273,10 @@ func (m *bridgeMetrics) RecordL1CrossDomainRelayedMessages(size int) {
 	m.relayedMessages.WithLabelValues("l1").Add(float64(size))
 }
 
+func (m *bridgeMetrics) RecordL1CrossDomainFailedRelayedMessages(size int) {
+	m.failedRelayedMessages.WithLabelValues("l1").Add(float64(size))
+}
+
 func (m *bridgeMetrics) RecordL1SkippedOVM1ProvenWithdrawals(size int) {
 	m.skippedOVM1Withdrawals.WithLabelValues("stage", "proven").Add(float64(size))
 }
@@ -246,6 +297,14 @@ func (m *bridgeMetrics) RecordL1FinalizedBridgeTransfers(tokenAddr common.Addres
 	m.finalizedBridgeTransfers.WithLabelValues("l1", tokenAddr.String()).Add(float64(size))
 }
 
+func (m *bridgeMetrics) RecordL1InitiatedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.initiatedERC721BridgeTransfers.WithLabelValues("l1", tokenAddr.String()).Add(float64(size))
+}
+
+func (m *bridgeMetrics) RecordL1FinalizedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.finalizedERC721BridgeTransfers.WithLabelValues("l1", tokenAddr.String()).Add(float64(size))
+}
+
 // L2Metricer
 
 func (m *bridgeMetrics) RecordL2Interval() func(error) {
@@ -280,6 +339,10 @@ func (m *bridgeMetrics) RecordL2CrossDomainRelayedMessages(size int) {
 	m.relayedMessages.WithLabelValues("l2").Add(float64(size))
 }
 
+func (m *bridgeMetrics) RecordL2CrossDomainFailedRelayedMessages(size int) {
+	m.failedRelayedMessages.WithLabelValues("l2").Add(float64(size))
+}
+
 func (m *bridgeMetrics) RecordL2InitiatedBridgeTransfers(tokenAddr common.Address, size int) {
 	m.initiatedBridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
 }
@@ -287,3 +350,11 @@ func (m *bridgeMetrics) RecordL2InitiatedBridgeTransfers(tokenAddr common.Addres
 func (m *bridgeMetrics) RecordL2FinalizedBridgeTransfers(tokenAddr common.Address, size int) {
 	m.finalizedBridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
 }
+
+func (m *bridgeMetrics) RecordL2InitiatedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.initiatedERC721BridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
+}
+
+func (m *bridgeMetrics) RecordL2FinalizedERC721BridgeTransfers(tokenAddr common.Address, size int) {
+	m.finalizedERC721BridgeTransfers.WithLabelValues("l2", tokenAddr.String()).Add(float64(size))
+}
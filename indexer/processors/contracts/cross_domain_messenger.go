@@ -147,3 +147,33 @@ func CrossDomainMessengerRelayedMessageEvents(chainSelector string, contractAddr
 
 	return crossDomainRelayedMessages, nil
 }
+
+func CrossDomainMessengerFailedRelayedMessageEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]CrossDomainMessengerRelayedMessageEvent, error) {
+	crossDomainMessengerAbi, err := bindings.CrossDomainMessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	failedRelayedMessageEventAbi := crossDomainMessengerAbi.Events["FailedRelayedMessage"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: failedRelayedMessageEventAbi.ID}
+	failedRelayedMessageEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	crossDomainFailedRelayedMessages := make([]CrossDomainMessengerRelayedMessageEvent, len(failedRelayedMessageEvents))
+	for i := range failedRelayedMessageEvents {
+		failedRelayedMessage := bindings.CrossDomainMessengerFailedRelayedMessage{Raw: *failedRelayedMessageEvents[i].RLPLog}
+		err = UnpackLog(&failedRelayedMessage, failedRelayedMessageEvents[i].RLPLog, failedRelayedMessageEventAbi.Name, crossDomainMessengerAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		crossDomainFailedRelayedMessages[i] = CrossDomainMessengerRelayedMessageEvent{
+			Event:       &failedRelayedMessageEvents[i],
+			MessageHash: failedRelayedMessage.MsgHash,
+		}
+	}
+
+	return crossDomainFailedRelayedMessages, nil
+}
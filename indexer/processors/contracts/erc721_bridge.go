@@ -0,0 +1,97 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ERC721BridgeInitiatedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+type ERC721BridgeFinalizedEvent struct {
+	Event          *database.ContractEvent
+	BridgeTransfer database.ERC721BridgeTransfer
+}
+
+// ERC721BridgeInitiatedEvents extracts all initiated bridge events from the contracts that follow the ERC721Bridge ABI. The
+// L1ERC721Bridge & L2ERC721Bridge share the event definitions of the ERC721Bridge
+func ERC721BridgeInitiatedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeInitiatedEvent, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	initiatedBridgeEventAbi := erc721BridgeAbi.Events["ERC721BridgeInitiated"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: initiatedBridgeEventAbi.ID}
+	initiatedBridgeEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeInitiatedEvents := make([]ERC721BridgeInitiatedEvent, len(initiatedBridgeEvents))
+	for i := range initiatedBridgeEvents {
+		erc721Bridge := bindings.L1ERC721BridgeERC721BridgeInitiated{Raw: *initiatedBridgeEvents[i].RLPLog}
+		err := UnpackLog(&erc721Bridge, initiatedBridgeEvents[i].RLPLog, initiatedBridgeEventAbi.Name, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeInitiatedEvents[i] = ERC721BridgeInitiatedEvent{
+			Event: &initiatedBridgeEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				FromAddress: erc721Bridge.From,
+				ToAddress:   erc721Bridge.To,
+				TokenPair:   database.TokenPair{LocalTokenAddress: erc721Bridge.LocalToken, RemoteTokenAddress: erc721Bridge.RemoteToken},
+				TokenID:     erc721Bridge.TokenId,
+				Data:        erc721Bridge.ExtraData,
+				Timestamp:   initiatedBridgeEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeInitiatedEvents, nil
+}
+
+// ERC721BridgeFinalizedEvents extracts all finalization bridge events from the contracts that follow the ERC721Bridge ABI.
+func ERC721BridgeFinalizedEvents(chainSelector string, contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]ERC721BridgeFinalizedEvent, error) {
+	erc721BridgeAbi, err := bindings.L1ERC721BridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	finalizedBridgeEventAbi := erc721BridgeAbi.Events["ERC721BridgeFinalized"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: finalizedBridgeEventAbi.ID}
+	finalizedBridgeEvents, err := db.ContractEvents.ContractEventsWithFilter(contractEventFilter, chainSelector, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	erc721BridgeFinalizedEvents := make([]ERC721BridgeFinalizedEvent, len(finalizedBridgeEvents))
+	for i := range finalizedBridgeEvents {
+		erc721Bridge := bindings.L1ERC721BridgeERC721BridgeFinalized{Raw: *finalizedBridgeEvents[i].RLPLog}
+		err := UnpackLog(&erc721Bridge, finalizedBridgeEvents[i].RLPLog, finalizedBridgeEventAbi.Name, erc721BridgeAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		erc721BridgeFinalizedEvents[i] = ERC721BridgeFinalizedEvent{
+			Event: &finalizedBridgeEvents[i],
+			BridgeTransfer: database.ERC721BridgeTransfer{
+				FromAddress: erc721Bridge.From,
+				ToAddress:   erc721Bridge.To,
+				TokenPair:   database.TokenPair{LocalTokenAddress: erc721Bridge.LocalToken, RemoteTokenAddress: erc721Bridge.RemoteToken},
+				TokenID:     erc721Bridge.TokenId,
+				Data:        erc721Bridge.ExtraData,
+				Timestamp:   finalizedBridgeEvents[i].Timestamp,
+			},
+		}
+	}
+
+	return erc721BridgeFinalizedEvents, nil
+}
@@ -0,0 +1,106 @@
+package contracts
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/node"
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type L2OutputOracleOutputProposedEvent struct {
+	*bindings.L2OutputOracleOutputProposed
+	Event *database.ContractEvent
+}
+
+type L2OutputOracleOutputsDeletedEvent struct {
+	*bindings.L2OutputOracleOutputsDeleted
+	Event *database.ContractEvent
+}
+
+func L2OutputOracleOutputProposedEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]L2OutputOracleOutputProposedEvent, error) {
+	l2OutputOracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	outputProposedEventAbi := l2OutputOracleAbi.Events["OutputProposed"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: outputProposedEventAbi.ID}
+	outputProposedEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	outputProposals := make([]L2OutputOracleOutputProposedEvent, len(outputProposedEvents))
+	for i := range outputProposedEvents {
+		outputProposed := bindings.L2OutputOracleOutputProposed{Raw: *outputProposedEvents[i].RLPLog}
+		err := UnpackLog(&outputProposed, outputProposedEvents[i].RLPLog, outputProposedEventAbi.Name, l2OutputOracleAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		outputProposals[i] = L2OutputOracleOutputProposedEvent{
+			L2OutputOracleOutputProposed: &outputProposed,
+			Event:                        &outputProposedEvents[i].ContractEvent,
+		}
+	}
+
+	return outputProposals, nil
+}
+
+func L2OutputOracleOutputsDeletedEvents(contractAddress common.Address, db *database.DB, fromHeight, toHeight *big.Int) ([]L2OutputOracleOutputsDeletedEvent, error) {
+	l2OutputOracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	outputsDeletedEventAbi := l2OutputOracleAbi.Events["OutputsDeleted"]
+	contractEventFilter := database.ContractEvent{ContractAddress: contractAddress, EventSignature: outputsDeletedEventAbi.ID}
+	outputsDeletedEvents, err := db.ContractEvents.L1ContractEventsWithFilter(contractEventFilter, fromHeight, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	outputDeletions := make([]L2OutputOracleOutputsDeletedEvent, len(outputsDeletedEvents))
+	for i := range outputsDeletedEvents {
+		outputsDeleted := bindings.L2OutputOracleOutputsDeleted{Raw: *outputsDeletedEvents[i].RLPLog}
+		err := UnpackLog(&outputsDeleted, outputsDeletedEvents[i].RLPLog, outputsDeletedEventAbi.Name, l2OutputOracleAbi)
+		if err != nil {
+			return nil, err
+		}
+
+		outputDeletions[i] = L2OutputOracleOutputsDeletedEvent{
+			L2OutputOracleOutputsDeleted: &outputsDeleted,
+			Event:                        &outputsDeletedEvents[i].ContractEvent,
+		}
+	}
+
+	return outputDeletions, nil
+}
+
+// L2OutputOracleFinalizationPeriodSeconds returns the period a proven withdrawal must wait before it can be finalized
+func L2OutputOracleFinalizationPeriodSeconds(client node.EthClient, contractAddress common.Address) (uint64, error) {
+	l2OutputOracleAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return 0, err
+	}
+
+	calldata, err := l2OutputOracleAbi.Pack("FINALIZATION_PERIOD_SECONDS")
+	if err != nil {
+		return 0, err
+	}
+	output, err := client.CallContract(ethereum.CallMsg{To: &contractAddress, Data: calldata}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("unable to query finalization period: %w", err)
+	}
+
+	period, err := l2OutputOracleAbi.Unpack("FINALIZATION_PERIOD_SECONDS", output)
+	if err != nil {
+		return 0, fmt.Errorf("unable to decode finalization period: %w", err)
+	}
+	return period[0].(*big.Int).Uint64(), nil
+}
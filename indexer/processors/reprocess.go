@@ -0,0 +1,188 @@
+package processors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/indexer/bigint"
+	"github.com/ethereum-optimism/optimism/indexer/config"
+	"github.com/ethereum-optimism/optimism/indexer/database"
+	"github.com/ethereum-optimism/optimism/indexer/processors/bridge"
+	"github.com/ethereum-optimism/optimism/indexer/processors/contracts"
+)
+
+// ReprocessConfig configures the ranges of already indexed events re-run through the bridge processor.
+// An unset range is not reprocessed.
+type ReprocessConfig struct {
+	L1FromHeight *big.Int
+	L1ToHeight   *big.Int
+
+	L2FromHeight *big.Int
+	L2ToHeight   *big.Int
+
+	// Processors to re-run. Initiated bridge events, along with output proposals, and/or their finalization
+	Initiated bool
+	Finalized bool
+
+	// Number of blocks processed per transaction
+	BatchSize uint64
+}
+
+// ReprocessBridgeEvents re-runs the bridge processor over the already indexed events within the configured ranges,
+// i.e. to index bridge data missed by a processor bug or of a backfilled contract. Re-processing already indexed
+// bridge events is a no-op, such that this can run alongside the live bridge processor. Ranges are bounded by the
+// progress of the live processor and each batch is processed in its own short-lived transaction.
+func ReprocessBridgeEvents(ctx context.Context, log log.Logger, db *database.DB, metrics bridge.Metricer, chainConfig config.ChainConfig, cfg ReprocessConfig) error {
+	log = log.New("processor", "bridge", "mode", "reprocess")
+	if cfg.BatchSize == 0 {
+		return errors.New("batch size must be greater than zero")
+	} else if !cfg.Initiated && !cfg.Finalized {
+		return errors.New("no processors to re-run")
+	}
+
+	reprocessL1 := cfg.L1FromHeight != nil && cfg.L1ToHeight != nil
+	reprocessL2 := cfg.L2FromHeight != nil && cfg.L2ToHeight != nil
+	if !reprocessL1 && !reprocessL2 {
+		return errors.New("no ranges to reprocess")
+	}
+
+	// Finalization events are processed after all initiated events such that they can be correlated
+	if cfg.Initiated {
+		if reprocessL1 {
+			latestHeader, err := db.BridgeTransactions.L1LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			l1BridgeLog := log.New("bridge", "l1", "kind", "initiated")
+			if err := reprocessRange(ctx, l1BridgeLog, db, cfg.L1FromHeight, cfg.L1ToHeight, latestL1Height(latestHeader), cfg.BatchSize, func(tx *database.DB, from, to *big.Int) error {
+				if err := processInitiatedL1Range(l1BridgeLog, tx, metrics, chainConfig, from, to); err != nil {
+					return err
+				}
+				return tx.NotifyInitiatedL1BridgeUpdates(from, to)
+			}); err != nil {
+				return fmt.Errorf("failed to reprocess initiated L1 events: %w", err)
+			}
+
+			// Replayed output deletions remove every proposal from the deleted index, including those proposed after
+			// the range. The output events of all indexed L1 state following the range are replayed to restore them.
+			deletions, err := contracts.L2OutputOracleOutputsDeletedEvents(chainConfig.L1Contracts.L2OutputOracleProxy, db, cfg.L1FromHeight, cfg.L1ToHeight)
+			if err != nil {
+				return err
+			}
+			indexedHeader, err := db.Blocks.L1LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			if len(deletions) > 0 && indexedHeader != nil && cfg.L1ToHeight.Cmp(indexedHeader.Number) < 0 {
+				l1BridgeLog.Info("replaying output proposals following reprocessed output deletions", "deletions", len(deletions))
+				fromHeight := new(big.Int).Add(cfg.L1ToHeight, bigint.One)
+				if err := reprocessRange(ctx, l1BridgeLog, db, fromHeight, indexedHeader.Number, indexedHeader.Number, cfg.BatchSize, func(tx *database.DB, from, to *big.Int) error {
+					return bridge.L1ProcessOutputProposals(l1BridgeLog, tx, metrics, chainConfig.L1Contracts, from, to)
+				}); err != nil {
+					return fmt.Errorf("failed to replay output proposals: %w", err)
+				}
+			}
+		}
+		if reprocessL2 {
+			latestHeader, err := db.BridgeTransactions.L2LatestBlockHeader()
+			if err != nil {
+				return err
+			}
+			l2BridgeLog := log.New("bridge", "l2", "kind", "initiated")
+			if err := reprocessRange(ctx, l2BridgeLog, db, cfg.L2FromHeight, cfg.L2ToHeight, latestL2Height(latestHeader), cfg.BatchSize, func(tx *database.DB, from, to *big.Int) error {
+				if err := processInitiatedL2Range(l2BridgeLog, tx, metrics, chainConfig, from, to); err != nil {
+					return err
+				}
+				return tx.NotifyInitiatedL2BridgeUpdates(from, to)
+			}); err != nil {
+				return fmt.Errorf("failed to reprocess initiated L2 events: %w", err)
+			}
+		}
+	}
+
+	if cfg.Finalized {
+		if reprocessL1 {
+			latestHeader, err := db.BridgeTransactions.L1LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+			l1BridgeLog := log.New("bridge", "l1", "kind", "finalization")
+			if err := reprocessRange(ctx, l1BridgeLog, db, cfg.L1FromHeight, cfg.L1ToHeight, latestL1Height(latestHeader), cfg.BatchSize, func(tx *database.DB, from, to *big.Int) error {
+				if err := processFinalizedL1Range(l1BridgeLog, tx, metrics, chainConfig, from, to); err != nil {
+					return err
+				}
+				return tx.NotifyFinalizedL1BridgeUpdates(from, to)
+			}); err != nil {
+				return fmt.Errorf("failed to reprocess finalized L1 events: %w", err)
+			}
+		}
+		if reprocessL2 {
+			latestHeader, err := db.BridgeTransactions.L2LatestFinalizedBlockHeader()
+			if err != nil {
+				return err
+			}
+			l2BridgeLog := log.New("bridge", "l2", "kind", "finalization")
+			if err := reprocessRange(ctx, l2BridgeLog, db, cfg.L2FromHeight, cfg.L2ToHeight, latestL2Height(latestHeader), cfg.BatchSize, func(tx *database.DB, from, to *big.Int) error {
+				return processFinalizedL2Range(l2BridgeLog, tx, metrics, chainConfig, from, to)
+			}); err != nil {
+				return fmt.Errorf("failed to reprocess finalized L2 events: %w", err)
+			}
+		}
+	}
+
+	if err := db.NotifyBridgeUpdates(); err != nil {
+		log.Warn("failed to notify bridge updates", "err", err)
+	}
+
+	log.Info("finished reprocessing")
+	return nil
+}
+
+// reprocessRange processes the range in batches, bounded by the latest height visited by the live processor
+func reprocessRange(ctx context.Context, log log.Logger, db *database.DB, fromHeight, toHeight, latestHeight *big.Int, batchSize uint64,
+	process func(tx *database.DB, from, to *big.Int) error) error {
+	if fromHeight.Cmp(toHeight) > 0 {
+		return fmt.Errorf("from height %d is greater than to height %d", fromHeight, toHeight)
+	}
+
+	if latestHeight == nil {
+		log.Warn("no processed state to reprocess")
+		return nil
+	} else if toHeight.Cmp(latestHeight) > 0 {
+		log.Warn("bounding range to the processed state", "to_block_number", toHeight, "latest_block_number", latestHeight)
+		toHeight = latestHeight
+	}
+
+	for from := fromHeight; from.Cmp(toHeight) <= 0; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		to := bigint.Clamp(from, toHeight, batchSize)
+		if err := db.Transaction(func(tx *database.DB) error { return process(tx, from, to) }); err != nil {
+			return err
+		}
+
+		from = new(big.Int).Add(to, bigint.One)
+	}
+
+	return nil
+}
+
+func latestL1Height(header *database.L1BlockHeader) *big.Int {
+	if header == nil {
+		return nil
+	}
+	return header.Number
+}
+
+func latestL2Height(header *database.L2BlockHeader) *big.Int {
+	if header == nil {
+		return nil
+	}
+	return header.Number
+}
@@ -0,0 +1,204 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Severity is the importance of a finding of an audit
+type Severity string
+
+const (
+	// SeverityOK is a value which matches its expected value
+	SeverityOK Severity = "ok"
+	// SeverityInfo is a value which has no expected value to compare with
+	SeverityInfo Severity = "info"
+	// SeverityWarning is a mismatch which is expected to be temporary or deliberate,
+	// such as a contract behind the superchain target version
+	SeverityWarning Severity = "warning"
+	// SeverityCritical is a mismatch of the ownership, the proxies or the configuration
+	// of the chain, or a value which can't be read
+	SeverityCritical Severity = "critical"
+)
+
+var severityLevels = map[Severity]int{
+	SeverityOK:       0,
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityCritical: 3,
+}
+
+// ParseSeverity parses a severity level
+func ParseSeverity(s string) (Severity, error) {
+	if _, ok := severityLevels[Severity(s)]; !ok {
+		return "", fmt.Errorf("unknown severity %q", s)
+	}
+	return Severity(s), nil
+}
+
+// AtLeast returns whether the severity is at least as high as the other severity
+func (s Severity) AtLeast(other Severity) bool {
+	return severityLevels[s] >= severityLevels[other]
+}
+
+// Finding is the result of a check of an on-chain value
+type Finding struct {
+	Chain    string         `json:"chain"`
+	ChainID  uint64         `json:"chainId"`
+	Layer    string         `json:"layer"`
+	Check    string         `json:"check"`
+	Address  common.Address `json:"address"`
+	Severity Severity       `json:"severity"`
+	Expected string         `json:"expected,omitempty"`
+	Actual   string         `json:"actual,omitempty"`
+	Message  string         `json:"message,omitempty"`
+}
+
+// Report is the result of the audit of one or more chains
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// MaxSeverity returns the highest severity of the findings
+func (r *Report) MaxSeverity() Severity {
+	max := SeverityOK
+	for _, finding := range r.Findings {
+		if finding.Severity.AtLeast(max) {
+			max = finding.Severity
+		}
+	}
+	return max
+}
+
+// Count returns the number of findings of at least the given severity
+func (r *Report) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity.AtLeast(severity) {
+			count++
+		}
+	}
+	return count
+}
+
+// Write writes the findings of at least the given severity as text
+func (r *Report) Write(w io.Writer, severity Severity) error {
+	var b strings.Builder
+	for _, finding := range r.Findings {
+		if !finding.Severity.AtLeast(severity) {
+			continue
+		}
+		fmt.Fprintf(&b, "[%s] %s (%d) %s %s at %s", strings.ToUpper(string(finding.Severity)), finding.Chain, finding.ChainID, finding.Layer, finding.Check, finding.Address)
+		switch {
+		case finding.Message != "":
+			fmt.Fprintf(&b, ": %s\n", finding.Message)
+		case finding.Expected != "":
+			fmt.Fprintf(&b, ": expected %s, got %s\n", finding.Expected, finding.Actual)
+		default:
+			fmt.Fprintf(&b, ": %s\n", finding.Actual)
+		}
+	}
+	fmt.Fprintf(&b, "%d findings, %d warnings, %d critical\n", len(r.Findings), r.Count(SeverityWarning)-r.Count(SeverityCritical), r.Count(SeverityCritical))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Client is the access to a chain needed to audit its contracts
+type Client interface {
+	bind.ContractCaller
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// auditor records the findings of the checks of a layer of a chain
+type auditor struct {
+	ctx    context.Context
+	chain  *Expectations
+	layer  string
+	report *Report
+}
+
+func (a *auditor) opts() *bind.CallOpts {
+	return &bind.CallOpts{Context: a.ctx}
+}
+
+func (a *auditor) add(finding Finding) {
+	finding.Chain = a.chain.Name
+	finding.ChainID = a.chain.ChainID
+	finding.Layer = a.layer
+	a.report.Findings = append(a.report.Findings, finding)
+}
+
+// fail records a value which can't be read
+func (a *auditor) fail(check string, addr common.Address, err error) {
+	a.add(Finding{
+		Check:    check,
+		Address:  addr,
+		Severity: SeverityCritical,
+		Message:  err.Error(),
+	})
+}
+
+// compare records the comparison of an actual value with an expected value. The value is
+// recorded for information if there is no expected value.
+func (a *auditor) compare(check string, addr common.Address, severity Severity, expected string, actual string) {
+	finding := Finding{
+		Check:    check,
+		Address:  addr,
+		Expected: expected,
+		Actual:   actual,
+	}
+	switch expected {
+	case "":
+		finding.Severity = SeverityInfo
+	case actual:
+		finding.Severity = SeverityOK
+	default:
+		finding.Severity = severity
+	}
+	a.add(finding)
+}
+
+// compareGenesis records the comparison of a value, which is updated as the chain operates, with
+// its value at genesis. A changed value is recorded for information.
+func (a *auditor) compareGenesis(check string, addr common.Address, genesis string, actual string) {
+	finding := Finding{
+		Check:    check,
+		Address:  addr,
+		Severity: SeverityInfo,
+		Actual:   actual,
+	}
+	switch genesis {
+	case "":
+	case actual:
+		finding.Severity = SeverityOK
+		finding.Expected = genesis
+	default:
+		finding.Message = fmt.Sprintf("changed from %s at genesis to %s", genesis, actual)
+	}
+	a.add(finding)
+}
+
+// hasCode checks that a contract is deployed at the address, and returns whether it is
+func (a *auditor) hasCode(check string, client Client, addr common.Address) bool {
+	code, err := client.CodeAt(a.ctx, addr, nil)
+	if err != nil {
+		a.fail(check, addr, err)
+		return false
+	}
+	if len(code) == 0 {
+		a.add(Finding{
+			Check:    check,
+			Address:  addr,
+			Severity: SeverityCritical,
+			Message:  "no code",
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func TestSeverity(t *testing.T) {
+	sev, err := ParseSeverity("warning")
+	require.NoError(t, err)
+	require.Equal(t, SeverityWarning, sev)
+	_, err = ParseSeverity("fatal")
+	require.Error(t, err)
+
+	require.True(t, SeverityCritical.AtLeast(SeverityWarning))
+	require.True(t, SeverityWarning.AtLeast(SeverityWarning))
+	require.False(t, SeverityInfo.AtLeast(SeverityWarning))
+}
+
+func TestCompare(t *testing.T) {
+	report := &Report{}
+	a := &auditor{ctx: context.Background(), chain: &Expectations{Name: "test", ChainID: 10}, layer: "L1", report: report}
+	addr := common.Address{0x01}
+
+	a.compare("match", addr, SeverityCritical, "1", "1")
+	a.compare("unknown", addr, SeverityCritical, "", "1")
+	a.compare("drift", addr, SeverityWarning, "1", "2")
+	require.Equal(t, SeverityWarning, report.MaxSeverity())
+
+	a.compare("mismatch", addr, SeverityCritical, "1", "2")
+	require.Len(t, report.Findings, 4)
+	require.Equal(t, SeverityOK, report.Findings[0].Severity)
+	require.Equal(t, SeverityInfo, report.Findings[1].Severity)
+	require.Equal(t, SeverityWarning, report.Findings[2].Severity)
+	require.Equal(t, SeverityCritical, report.Findings[3].Severity)
+	require.Equal(t, "test", report.Findings[3].Chain)
+	require.Equal(t, uint64(10), report.Findings[3].ChainID)
+	require.Equal(t, "L1", report.Findings[3].Layer)
+
+	require.Equal(t, SeverityCritical, report.MaxSeverity())
+	require.Equal(t, 2, report.Count(SeverityWarning))
+	require.Equal(t, 1, report.Count(SeverityCritical))
+
+	var buf bytes.Buffer
+	require.NoError(t, report.Write(&buf, SeverityWarning))
+	require.Contains(t, buf.String(), "[CRITICAL] test (10) L1 mismatch")
+	require.Contains(t, buf.String(), "expected 1, got 2")
+	require.NotContains(t, buf.String(), "unknown")
+	require.Contains(t, buf.String(), "4 findings, 1 warnings, 1 critical")
+}
+
+func TestFromDeployConfig(t *testing.T) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	deployments, err := genesis.NewL1Deployments("../genesis/testdata/l1-deployments.json")
+	require.NoError(t, err)
+
+	exp := FromDeployConfig("devnet", config, deployments)
+	require.Equal(t, config.L2ChainID, exp.ChainID)
+	require.Equal(t, deployments.SystemConfigProxy, exp.L1.SystemConfigProxy)
+	require.Equal(t, config.BatchSenderAddress, exp.GenesisSystemConfig.BatcherAddr)
+	require.Equal(t, uint64(config.L2GenesisBlockGasLimit), exp.GenesisSystemConfig.GasLimit)
+	require.Equal(t, config.GasPriceOracleScalar, exp.GenesisSystemConfig.Scalar.Uint64())
+	require.Equal(t, config.FinalSystemOwner, *exp.FinalSystemOwner)
+	require.Equal(t, config.ProxyAdminOwner, *exp.L2ProxyAdminOwner)
+	require.Nil(t, exp.Implementations)
+}
@@ -0,0 +1,176 @@
+package audit
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+)
+
+// L1Contracts are the addresses of the L1 contracts of a chain
+type L1Contracts struct {
+	AddressManager                    common.Address
+	ProxyAdmin                        common.Address
+	SystemConfigProxy                 common.Address
+	L1CrossDomainMessengerProxy       common.Address
+	L1ERC721BridgeProxy               common.Address
+	L1StandardBridgeProxy             common.Address
+	L2OutputOracleProxy               common.Address
+	OptimismMintableERC20FactoryProxy common.Address
+	OptimismPortalProxy               common.Address
+	// ProtocolVersions is unset if the superchain has no ProtocolVersions contract
+	ProtocolVersions *common.Address
+}
+
+// Expectations is the expected configuration of a chain. The values which are
+// unset are not compared, the actual values are reported for information.
+type Expectations struct {
+	Name    string
+	ChainID uint64
+
+	L1 L1Contracts
+	// Implementations are the expected implementations of the L1 proxies
+	Implementations *superchain.ImplementationList
+
+	// GenesisSystemConfig are the SystemConfig values at the genesis of the chain. The owner of
+	// the SystemConfig updates them as the chain operates, changes are reported for information.
+	GenesisSystemConfig *SystemConfigValues
+	UnsafeBlockSigner   *common.Address
+	// FinalSystemOwner is the owner of the L1 ProxyAdmin and of the SystemConfig
+	FinalSystemOwner *common.Address
+	// ProtocolVersionsOwner is the owner of the ProtocolVersions, which signals protocol upgrades
+	ProtocolVersionsOwner *common.Address
+
+	RequiredProtocolVersion    *params.ProtocolVersion
+	RecommendedProtocolVersion *params.ProtocolVersion
+
+	// L2 predeploy values
+	L2ProxyAdminOwner          *common.Address
+	SequencerFeeVaultRecipient *common.Address
+	BaseFeeVaultRecipient      *common.Address
+	L1FeeVaultRecipient        *common.Address
+}
+
+// SystemConfigValues are the values of the SystemConfig which are updated by its owner
+type SystemConfigValues struct {
+	BatcherAddr common.Address
+	GasLimit    uint64
+	Overhead    *big.Int
+	Scalar      *big.Int
+}
+
+// FromSuperchain returns the expectations of a chain of the superchain registry. The
+// implementations are the ones of the superchain target version.
+func FromSuperchain(chainConfig *superchain.ChainConfig) (*Expectations, error) {
+	addresses, ok := superchain.Addresses[chainConfig.ChainID]
+	if !ok {
+		return nil, fmt.Errorf("no addresses for chain ID %d", chainConfig.ChainID)
+	}
+	sc, ok := superchain.Superchains[chainConfig.Superchain]
+	if !ok {
+		return nil, fmt.Errorf("unknown superchain %s", chainConfig.Superchain)
+	}
+
+	exp := &Expectations{
+		Name:    chainConfig.Name,
+		ChainID: chainConfig.ChainID,
+		L1: L1Contracts{
+			AddressManager:                    common.Address(addresses.AddressManager),
+			ProxyAdmin:                        common.Address(addresses.ProxyAdmin),
+			SystemConfigProxy:                 common.Address(chainConfig.SystemConfigAddr),
+			L1CrossDomainMessengerProxy:       common.Address(addresses.L1CrossDomainMessengerProxy),
+			L1ERC721BridgeProxy:               common.Address(addresses.L1ERC721BridgeProxy),
+			L1StandardBridgeProxy:             common.Address(addresses.L1StandardBridgeProxy),
+			L2OutputOracleProxy:               common.Address(addresses.L2OutputOracleProxy),
+			OptimismMintableERC20FactoryProxy: common.Address(addresses.OptimismMintableERC20FactoryProxy),
+			OptimismPortalProxy:               common.Address(addresses.OptimismPortalProxy),
+		},
+	}
+	if sc.Config.ProtocolVersionsAddr != nil {
+		addr := common.Address(*sc.Config.ProtocolVersionsAddr)
+		exp.L1.ProtocolVersions = &addr
+	}
+
+	if implementations, ok := superchain.Implementations[sc.Config.L1.ChainID]; ok {
+		list, err := implementations.Resolve(superchain.SuperchainSemver)
+		if err != nil {
+			return nil, err
+		}
+		exp.Implementations = &list
+	}
+
+	if sysCfg, ok := superchain.GenesisSystemConfigs[chainConfig.ChainID]; ok {
+		exp.GenesisSystemConfig = &SystemConfigValues{
+			BatcherAddr: common.Address(sysCfg.BatcherAddr),
+			GasLimit:    sysCfg.GasLimit,
+			Overhead:    new(big.Int).SetBytes(sysCfg.Overhead[:]),
+			Scalar:      new(big.Int).SetBytes(sysCfg.Scalar[:]),
+		}
+	}
+	return exp, nil
+}
+
+// FromDeployConfig returns the expectations of a chain deployed with the deploy config
+func FromDeployConfig(name string, config *genesis.DeployConfig, deployments *genesis.L1Deployments) *Expectations {
+	exp := &Expectations{
+		Name:    name,
+		ChainID: config.L2ChainID,
+		L1: L1Contracts{
+			AddressManager:                    deployments.AddressManager,
+			ProxyAdmin:                        deployments.ProxyAdmin,
+			SystemConfigProxy:                 deployments.SystemConfigProxy,
+			L1CrossDomainMessengerProxy:       deployments.L1CrossDomainMessengerProxy,
+			L1ERC721BridgeProxy:               deployments.L1ERC721BridgeProxy,
+			L1StandardBridgeProxy:             deployments.L1StandardBridgeProxy,
+			L2OutputOracleProxy:               deployments.L2OutputOracleProxy,
+			OptimismMintableERC20FactoryProxy: deployments.OptimismMintableERC20FactoryProxy,
+			OptimismPortalProxy:               deployments.OptimismPortalProxy,
+		},
+		GenesisSystemConfig: &SystemConfigValues{
+			BatcherAddr: config.BatchSenderAddress,
+			GasLimit:    uint64(config.L2GenesisBlockGasLimit),
+			Overhead:    new(big.Int).SetUint64(config.GasPriceOracleOverhead),
+			Scalar:      new(big.Int).SetUint64(config.GasPriceOracleScalar),
+		},
+		UnsafeBlockSigner:          &config.P2PSequencerAddress,
+		FinalSystemOwner:           &config.FinalSystemOwner,
+		L2ProxyAdminOwner:          &config.ProxyAdminOwner,
+		SequencerFeeVaultRecipient: &config.SequencerFeeVaultRecipient,
+		BaseFeeVaultRecipient:      &config.BaseFeeVaultRecipient,
+		L1FeeVaultRecipient:        &config.L1FeeVaultRecipient,
+	}
+	if deployments.ProtocolVersionsProxy != (common.Address{}) {
+		exp.L1.ProtocolVersions = &deployments.ProtocolVersionsProxy
+		// the ProtocolVersions is initialized with the final system owner
+		exp.ProtocolVersionsOwner = &config.FinalSystemOwner
+		exp.RequiredProtocolVersion = &config.RequiredProtocolVersion
+		exp.RecommendedProtocolVersion = &config.RecommendedProtocolVersion
+	}
+	return exp
+}
+
+func addressString(addr *common.Address) string {
+	if addr == nil {
+		return ""
+	}
+	return addr.Hex()
+}
+
+func bigString(n *big.Int) string {
+	if n == nil {
+		return ""
+	}
+	return n.String()
+}
+
+func protocolVersionString(v *params.ProtocolVersion) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,221 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+)
+
+// L1Values are values read from the L1 contracts, which are compared with their
+// copies on L2
+type L1Values struct {
+	BatcherHash *common.Hash
+	Overhead    *big.Int
+	Scalar      *big.Int
+}
+
+type l1Proxy struct {
+	name           string
+	addr           common.Address
+	implementation *superchain.VersionedContract
+}
+
+func (exp *Expectations) l1Proxies() []l1Proxy {
+	proxies := []l1Proxy{
+		{name: "L1CrossDomainMessenger", addr: exp.L1.L1CrossDomainMessengerProxy},
+		{name: "L1ERC721Bridge", addr: exp.L1.L1ERC721BridgeProxy},
+		{name: "L1StandardBridge", addr: exp.L1.L1StandardBridgeProxy},
+		{name: "L2OutputOracle", addr: exp.L1.L2OutputOracleProxy},
+		{name: "OptimismMintableERC20Factory", addr: exp.L1.OptimismMintableERC20FactoryProxy},
+		{name: "OptimismPortal", addr: exp.L1.OptimismPortalProxy},
+		{name: "SystemConfig", addr: exp.L1.SystemConfigProxy},
+	}
+	if list := exp.Implementations; list != nil {
+		proxies[0].implementation = &list.L1CrossDomainMessenger
+		proxies[1].implementation = &list.L1ERC721Bridge
+		proxies[2].implementation = &list.L1StandardBridge
+		proxies[3].implementation = &list.L2OutputOracle
+		proxies[4].implementation = &list.OptimismMintableERC20Factory
+		proxies[5].implementation = &list.OptimismPortal
+		proxies[6].implementation = &list.SystemConfig
+	}
+	return proxies
+}
+
+// AuditL1 checks the L1 contracts of the chain: the admins, implementations and versions
+// of the proxies, the owners, the SystemConfig values and the protocol versions.
+// It returns the SystemConfig values which are compared with L2.
+func AuditL1(ctx context.Context, exp *Expectations, client Client, report *Report) *L1Values {
+	a := &auditor{ctx: ctx, chain: exp, layer: "L1", report: report}
+
+	proxyAdmin, err := bindings.NewProxyAdminCaller(exp.L1.ProxyAdmin, client)
+	if err != nil {
+		a.fail("ProxyAdmin", exp.L1.ProxyAdmin, err)
+		return nil
+	}
+	if a.hasCode("ProxyAdmin.code", client, exp.L1.ProxyAdmin) {
+		if owner, err := proxyAdmin.Owner(a.opts()); err != nil {
+			a.fail("ProxyAdmin.owner", exp.L1.ProxyAdmin, err)
+		} else {
+			a.compare("ProxyAdmin.owner", exp.L1.ProxyAdmin, SeverityCritical, addressString(exp.FinalSystemOwner), owner.Hex())
+		}
+		if addressManager, err := proxyAdmin.AddressManager(a.opts()); err != nil {
+			a.fail("ProxyAdmin.addressManager", exp.L1.ProxyAdmin, err)
+		} else {
+			a.compare("ProxyAdmin.addressManager", exp.L1.ProxyAdmin, SeverityCritical, exp.L1.AddressManager.Hex(), addressManager.Hex())
+		}
+	}
+
+	for _, proxy := range exp.l1Proxies() {
+		if !a.hasCode(proxy.name+".code", client, proxy.addr) {
+			continue
+		}
+		// the ProxyAdmin resolves the admins and implementations of all the proxy types
+		if admin, err := proxyAdmin.GetProxyAdmin(a.opts(), proxy.addr); err != nil {
+			a.fail(proxy.name+".admin", proxy.addr, err)
+		} else {
+			a.compare(proxy.name+".admin", proxy.addr, SeverityCritical, exp.L1.ProxyAdmin.Hex(), admin.Hex())
+		}
+		var expectedImpl, expectedVersion string
+		if proxy.implementation != nil {
+			expectedImpl = common.Address(proxy.implementation.Address).Hex()
+			expectedVersion = canonicalVersion(proxy.implementation.Version)
+		}
+		if impl, err := proxyAdmin.GetProxyImplementation(a.opts(), proxy.addr); err != nil {
+			a.fail(proxy.name+".implementation", proxy.addr, err)
+		} else {
+			a.compare(proxy.name+".implementation", proxy.addr, SeverityWarning, expectedImpl, impl.Hex())
+		}
+		semver, err := bindings.NewISemverCaller(proxy.addr, client)
+		if err != nil {
+			a.fail(proxy.name+".version", proxy.addr, err)
+			continue
+		}
+		if version, err := semver.Version(a.opts()); err != nil {
+			a.fail(proxy.name+".version", proxy.addr, err)
+		} else {
+			a.compare(proxy.name+".version", proxy.addr, SeverityWarning, expectedVersion, canonicalVersion(version))
+		}
+	}
+
+	values := auditSystemConfig(a, client)
+	auditProtocolVersions(a, client)
+	return values
+}
+
+func auditSystemConfig(a *auditor, client Client) *L1Values {
+	addr := a.chain.L1.SystemConfigProxy
+	sysCfg, err := bindings.NewSystemConfigCaller(addr, client)
+	if err != nil {
+		a.fail("SystemConfig", addr, err)
+		return nil
+	}
+	values := &L1Values{}
+
+	if owner, err := sysCfg.Owner(a.opts()); err != nil {
+		a.fail("SystemConfig.owner", addr, err)
+	} else {
+		a.compare("SystemConfig.owner", addr, SeverityCritical, addressString(a.chain.FinalSystemOwner), owner.Hex())
+	}
+	// the batcher, gas limit and fee parameters are updated by the owner, they are only
+	// compared with their values at genesis
+	var genesisBatcherHash, genesisGasLimit, genesisOverhead, genesisScalar string
+	if genesis := a.chain.GenesisSystemConfig; genesis != nil {
+		genesisBatcherHash = common.BytesToHash(genesis.BatcherAddr[:]).Hex()
+		genesisGasLimit = fmt.Sprintf("%d", genesis.GasLimit)
+		genesisOverhead = bigString(genesis.Overhead)
+		genesisScalar = bigString(genesis.Scalar)
+	}
+	if batcherHash, err := sysCfg.BatcherHash(a.opts()); err != nil {
+		a.fail("SystemConfig.batcherHash", addr, err)
+	} else {
+		hash := common.Hash(batcherHash)
+		values.BatcherHash = &hash
+		a.compareGenesis("SystemConfig.batcherHash", addr, genesisBatcherHash, hash.Hex())
+	}
+	if gasLimit, err := sysCfg.GasLimit(a.opts()); err != nil {
+		a.fail("SystemConfig.gasLimit", addr, err)
+	} else {
+		a.compareGenesis("SystemConfig.gasLimit", addr, genesisGasLimit, fmt.Sprintf("%d", gasLimit))
+	}
+	if overhead, err := sysCfg.Overhead(a.opts()); err != nil {
+		a.fail("SystemConfig.overhead", addr, err)
+	} else {
+		values.Overhead = overhead
+		a.compareGenesis("SystemConfig.overhead", addr, genesisOverhead, overhead.String())
+	}
+	if scalar, err := sysCfg.Scalar(a.opts()); err != nil {
+		a.fail("SystemConfig.scalar", addr, err)
+	} else {
+		values.Scalar = scalar
+		a.compareGenesis("SystemConfig.scalar", addr, genesisScalar, scalar.String())
+	}
+	if signer, err := sysCfg.UnsafeBlockSigner(a.opts()); err != nil {
+		a.fail("SystemConfig.unsafeBlockSigner", addr, err)
+	} else {
+		a.compare("SystemConfig.unsafeBlockSigner", addr, SeverityCritical, addressString(a.chain.UnsafeBlockSigner), signer.Hex())
+	}
+	return values
+}
+
+func auditProtocolVersions(a *auditor, client Client) {
+	if a.chain.L1.ProtocolVersions == nil {
+		return
+	}
+	addr := *a.chain.L1.ProtocolVersions
+	if !a.hasCode("ProtocolVersions.code", client, addr) {
+		return
+	}
+	protocolVersions, err := bindings.NewProtocolVersionsCaller(addr, client)
+	if err != nil {
+		a.fail("ProtocolVersions", addr, err)
+		return
+	}
+	if owner, err := protocolVersions.Owner(a.opts()); err != nil {
+		a.fail("ProtocolVersions.owner", addr, err)
+	} else {
+		a.compare("ProtocolVersions.owner", addr, SeverityCritical, addressString(a.chain.ProtocolVersionsOwner), owner.Hex())
+	}
+
+	check := func(name string, expected *params.ProtocolVersion, read func() (*big.Int, error), unsupported Severity) {
+		value, err := read()
+		if err != nil {
+			a.fail("ProtocolVersions."+name, addr, err)
+			return
+		}
+		var version params.ProtocolVersion
+		value.FillBytes(version[:])
+		a.compare("ProtocolVersions."+name, addr, SeverityCritical, protocolVersionString(expected), version.String())
+		// the op-node halts, or warns, when it doesn't support the signaled version
+		if cmp := rollup.OPStackSupport.Compare(version); cmp < 0 {
+			a.add(Finding{
+				Check:    "ProtocolVersions." + name + ".supported",
+				Address:  addr,
+				Severity: unsupported,
+				Expected: rollup.OPStackSupport.String(),
+				Actual:   version.String(),
+				Message:  "the op-node supports up to " + rollup.OPStackSupport.String() + ", the " + name + " version is " + version.String(),
+			})
+		}
+	}
+	check("required", a.chain.RequiredProtocolVersion, func() (*big.Int, error) {
+		return protocolVersions.Required(a.opts())
+	}, SeverityCritical)
+	check("recommended", a.chain.RecommendedProtocolVersion, func() (*big.Int, error) {
+		return protocolVersions.Recommended(a.opts())
+	}, SeverityWarning)
+}
+
+// canonicalVersion formats a semver string without "v" prefix
+func canonicalVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
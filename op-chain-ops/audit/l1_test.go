@@ -0,0 +1,239 @@
+package audit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+)
+
+var (
+	batcherAddr       = common.Address{0xba}
+	unsafeBlockSigner = common.Address{0x5e}
+)
+
+type l1Env struct {
+	backend *backends.SimulatedBackend
+	opts    *bind.TransactOpts
+	owner   common.Address
+
+	exp          *Expectations
+	systemConfig *bindings.SystemConfig
+}
+
+// newL1Env deploys the ProxyAdmin and the L1 proxies of a chain to a simulated backend. The SystemConfig and
+// ProtocolVersions proxies are initialized, the other proxies share an implementation which only serves its
+// version. The expectations of the chain match the deployment.
+func newL1Env(t *testing.T) *l1Env {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		owner: {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))},
+	}, 30_000_000)
+	t.Cleanup(func() { _ = backend.Close() })
+	opts, err := bind.NewKeyedTransactorWithChainID(key, backend.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+	env := &l1Env{backend: backend, opts: opts, owner: owner}
+
+	addressManagerAddr, tx, _, err := bindings.DeployAddressManager(opts, backend)
+	env.mined(t, tx, err)
+	proxyAdminAddr, tx, proxyAdmin, err := bindings.DeployProxyAdmin(opts, backend, owner)
+	env.mined(t, tx, err)
+	tx, err = proxyAdmin.SetAddressManager(opts, addressManagerAddr)
+	env.mined(t, tx, err)
+	deployProxy := func() common.Address {
+		addr, tx, _, err := bindings.DeployProxy(opts, backend, proxyAdminAddr)
+		env.mined(t, tx, err)
+		return addr
+	}
+
+	resourceConfig := bindings.ResourceMeteringResourceConfig{
+		MaxResourceLimit:            20_000_000,
+		ElasticityMultiplier:        10,
+		BaseFeeMaxChangeDenominator: 8,
+		MinimumBaseFee:              params.GWei,
+		SystemTxMaxGas:              1_000_000,
+		MaximumBaseFee:              new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 128), common.Big1),
+	}
+	genesisSystemConfig := &SystemConfigValues{BatcherAddr: batcherAddr, GasLimit: 30_000_000, Overhead: big.NewInt(188), Scalar: big.NewInt(684_000)}
+	systemConfigImpl, tx, _, err := bindings.DeploySystemConfig(opts, backend, owner, genesisSystemConfig.Overhead, genesisSystemConfig.Scalar,
+		common.BytesToHash(batcherAddr[:]), genesisSystemConfig.GasLimit, unsafeBlockSigner, resourceConfig)
+	env.mined(t, tx, err)
+	systemConfigABI, err := bindings.SystemConfigMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := systemConfigABI.Pack("initialize", owner, genesisSystemConfig.Overhead, genesisSystemConfig.Scalar,
+		common.BytesToHash(batcherAddr[:]), genesisSystemConfig.GasLimit, unsafeBlockSigner, resourceConfig)
+	require.NoError(t, err)
+	systemConfigProxy := deployProxy()
+	tx, err = proxyAdmin.UpgradeAndCall(opts, systemConfigProxy, systemConfigImpl, data)
+	env.mined(t, tx, err)
+	env.systemConfig, err = bindings.NewSystemConfig(systemConfigProxy, backend)
+	require.NoError(t, err)
+
+	protocolVersionsImpl, tx, _, err := bindings.DeployProtocolVersions(opts, backend)
+	env.mined(t, tx, err)
+	protocolVersionsABI, err := bindings.ProtocolVersionsMetaData.GetAbi()
+	require.NoError(t, err)
+	required := new(big.Int).SetBytes(rollup.OPStackSupport[:])
+	data, err = protocolVersionsABI.Pack("initialize", owner, required, required)
+	require.NoError(t, err)
+	protocolVersionsProxy := deployProxy()
+	tx, err = proxyAdmin.UpgradeAndCall(opts, protocolVersionsProxy, protocolVersionsImpl, data)
+	env.mined(t, tx, err)
+
+	versioned := func(addr common.Address) superchain.VersionedContract {
+		semver, err := bindings.NewISemverCaller(addr, backend)
+		require.NoError(t, err)
+		version, err := semver.Version(&bind.CallOpts{})
+		require.NoError(t, err)
+		return superchain.VersionedContract{Version: version, Address: superchain.Address(addr)}
+	}
+	impl := versioned(protocolVersionsImpl)
+	implementations := &superchain.ImplementationList{
+		L1CrossDomainMessenger:       impl,
+		L1ERC721Bridge:               impl,
+		L1StandardBridge:             impl,
+		L2OutputOracle:               impl,
+		OptimismMintableERC20Factory: impl,
+		OptimismPortal:               impl,
+		SystemConfig:                 versioned(systemConfigImpl),
+	}
+	upgrade := func() common.Address {
+		proxy := deployProxy()
+		tx, err := proxyAdmin.Upgrade(opts, proxy, protocolVersionsImpl)
+		env.mined(t, tx, err)
+		return proxy
+	}
+
+	requiredVersion := params.ProtocolVersion(rollup.OPStackSupport)
+	env.exp = &Expectations{
+		Name:    "test",
+		ChainID: 901,
+		L1: L1Contracts{
+			AddressManager:                    addressManagerAddr,
+			ProxyAdmin:                        proxyAdminAddr,
+			SystemConfigProxy:                 systemConfigProxy,
+			L1CrossDomainMessengerProxy:       upgrade(),
+			L1ERC721BridgeProxy:               upgrade(),
+			L1StandardBridgeProxy:             upgrade(),
+			L2OutputOracleProxy:               upgrade(),
+			OptimismMintableERC20FactoryProxy: upgrade(),
+			OptimismPortalProxy:               upgrade(),
+			ProtocolVersions:                  &protocolVersionsProxy,
+		},
+		Implementations:            implementations,
+		GenesisSystemConfig:        genesisSystemConfig,
+		UnsafeBlockSigner:          &unsafeBlockSigner,
+		FinalSystemOwner:           &owner,
+		ProtocolVersionsOwner:      &owner,
+		RequiredProtocolVersion:    &requiredVersion,
+		RecommendedProtocolVersion: &requiredVersion,
+	}
+	return env
+}
+
+func (e *l1Env) mined(t *testing.T, tx *types.Transaction, err error) {
+	require.NoError(t, err)
+	e.backend.Commit()
+	receipt, err := e.backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
+
+// finding returns the finding of the check, which must be unique
+func finding(t *testing.T, report *Report, check string) Finding {
+	var found []Finding
+	for _, f := range report.Findings {
+		if f.Check == check {
+			found = append(found, f)
+		}
+	}
+	require.Len(t, found, 1, check)
+	return found[0]
+}
+
+func TestAuditL1(t *testing.T) {
+	t.Run("expected", func(t *testing.T) {
+		env := newL1Env(t)
+		report := &Report{}
+		values := AuditL1(context.Background(), env.exp, env.backend, report)
+		require.Equal(t, SeverityOK, report.MaxSeverity(), "%+v", report.Findings)
+
+		require.Equal(t, common.BytesToHash(batcherAddr[:]), *values.BatcherHash)
+		require.Equal(t, big.NewInt(188), values.Overhead)
+		require.Equal(t, big.NewInt(684_000), values.Scalar)
+		for _, check := range []string{"ProxyAdmin.owner", "SystemConfig.admin", "OptimismPortal.implementation", "OptimismPortal.version",
+			"SystemConfig.batcherHash", "SystemConfig.gasLimit", "ProtocolVersions.owner", "ProtocolVersions.required"} {
+			require.Equal(t, SeverityOK, finding(t, report, check).Severity, check)
+		}
+	})
+
+	t.Run("updated system config", func(t *testing.T) {
+		env := newL1Env(t)
+		tx, err := env.systemConfig.SetBatcherHash(env.opts, common.Hash{0x01})
+		env.mined(t, tx, err)
+		tx, err = env.systemConfig.SetGasLimit(env.opts, 40_000_000)
+		env.mined(t, tx, err)
+
+		report := &Report{}
+		values := AuditL1(context.Background(), env.exp, env.backend, report)
+		require.Equal(t, SeverityInfo, report.MaxSeverity())
+		require.Equal(t, common.Hash{0x01}, *values.BatcherHash)
+		gasLimit := finding(t, report, "SystemConfig.gasLimit")
+		require.Equal(t, SeverityInfo, gasLimit.Severity)
+		require.Equal(t, "changed from 30000000 at genesis to 40000000", gasLimit.Message)
+		require.Equal(t, SeverityInfo, finding(t, report, "SystemConfig.batcherHash").Severity)
+	})
+
+	t.Run("unknown genesis", func(t *testing.T) {
+		env := newL1Env(t)
+		env.exp.GenesisSystemConfig = nil
+		report := &Report{}
+		AuditL1(context.Background(), env.exp, env.backend, report)
+		scalar := finding(t, report, "SystemConfig.scalar")
+		require.Equal(t, SeverityInfo, scalar.Severity)
+		require.Equal(t, "684000", scalar.Actual)
+		require.Empty(t, scalar.Message)
+	})
+
+	t.Run("mismatches", func(t *testing.T) {
+		env := newL1Env(t)
+		otherOwner := common.Address{0x01}
+		env.exp.ProtocolVersionsOwner = &otherOwner
+		env.exp.Implementations.OptimismPortal.Version = "2.0.0"
+		recommended := params.ProtocolVersionV0{Major: 100}.Encode()
+		env.exp.RecommendedProtocolVersion = &recommended
+
+		report := &Report{}
+		AuditL1(context.Background(), env.exp, env.backend, report)
+		require.Equal(t, SeverityCritical, finding(t, report, "ProtocolVersions.owner").Severity)
+		require.Equal(t, SeverityWarning, finding(t, report, "OptimismPortal.version").Severity)
+		require.Equal(t, SeverityCritical, finding(t, report, "ProtocolVersions.recommended").Severity)
+		require.Equal(t, 2, report.Count(SeverityCritical))
+	})
+
+	t.Run("missing contracts", func(t *testing.T) {
+		env := newL1Env(t)
+		env.exp.L1.OptimismPortalProxy = common.Address{0x01}
+		report := &Report{}
+		AuditL1(context.Background(), env.exp, env.backend, report)
+		portal := finding(t, report, "OptimismPortal.code")
+		require.Equal(t, SeverityCritical, portal.Severity)
+		require.Equal(t, "no code", portal.Message)
+		require.Equal(t, 1, report.Count(SeverityWarning))
+	})
+}
@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// AuditL2 checks the L2 predeploys of the chain: their proxies, the owner of the
+// ProxyAdmin, the references to the L1 contracts and the fee vault recipients. The
+// L1Block values are compared with the L1 values, if available.
+func AuditL2(ctx context.Context, exp *Expectations, client Client, l1 *L1Values, report *Report) {
+	a := &auditor{ctx: ctx, chain: exp, layer: "L2", report: report}
+
+	names := make([]string, 0, len(predeploys.Predeploys))
+	for name := range predeploys.Predeploys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		auditPredeploy(a, client, name, predeploys.Predeploys[name])
+	}
+
+	proxyAdmin, err := bindings.NewProxyAdminCaller(predeploys.ProxyAdminAddr, client)
+	if err != nil {
+		a.fail("ProxyAdmin", predeploys.ProxyAdminAddr, err)
+	} else if owner, err := proxyAdmin.Owner(a.opts()); err != nil {
+		a.fail("ProxyAdmin.owner", predeploys.ProxyAdminAddr, err)
+	} else {
+		a.compare("ProxyAdmin.owner", predeploys.ProxyAdminAddr, SeverityCritical, addressString(exp.L2ProxyAdminOwner), owner.Hex())
+	}
+
+	auditAddress(a, "L2CrossDomainMessenger.OTHER_MESSENGER", predeploys.L2CrossDomainMessengerAddr, &exp.L1.L1CrossDomainMessengerProxy, func(opts *bind.CallOpts) (common.Address, error) {
+		messenger, err := bindings.NewL2CrossDomainMessengerCaller(predeploys.L2CrossDomainMessengerAddr, client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return messenger.OTHERMESSENGER(opts)
+	})
+	auditAddress(a, "L2StandardBridge.OTHER_BRIDGE", predeploys.L2StandardBridgeAddr, &exp.L1.L1StandardBridgeProxy, func(opts *bind.CallOpts) (common.Address, error) {
+		bridge, err := bindings.NewL2StandardBridgeCaller(predeploys.L2StandardBridgeAddr, client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return bridge.OTHERBRIDGE(opts)
+	})
+	auditAddress(a, "L2ERC721Bridge.OTHER_BRIDGE", predeploys.L2ERC721BridgeAddr, &exp.L1.L1ERC721BridgeProxy, func(opts *bind.CallOpts) (common.Address, error) {
+		bridge, err := bindings.NewL2ERC721BridgeCaller(predeploys.L2ERC721BridgeAddr, client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		return bridge.OTHERBRIDGE(opts)
+	})
+
+	vaults := []struct {
+		name      string
+		addr      common.Address
+		recipient *common.Address
+	}{
+		{"SequencerFeeVault", predeploys.SequencerFeeVaultAddr, exp.SequencerFeeVaultRecipient},
+		{"BaseFeeVault", predeploys.BaseFeeVaultAddr, exp.BaseFeeVaultRecipient},
+		{"L1FeeVault", predeploys.L1FeeVaultAddr, exp.L1FeeVaultRecipient},
+	}
+	for _, vault := range vaults {
+		addr := vault.addr
+		auditAddress(a, vault.name+".RECIPIENT", addr, vault.recipient, func(opts *bind.CallOpts) (common.Address, error) {
+			// the fee vaults share the FeeVault interface
+			feeVault, err := bindings.NewSequencerFeeVaultCaller(addr, client)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return feeVault.RECIPIENT(opts)
+		})
+	}
+
+	if l1 != nil {
+		auditL1Block(a, client, l1)
+	}
+}
+
+func auditPredeploy(a *auditor, client Client, name string, predeploy *predeploys.Predeploy) {
+	code, err := client.CodeAt(a.ctx, predeploy.Address, nil)
+	if err != nil {
+		a.fail(name+".code", predeploy.Address, err)
+		return
+	}
+	if len(code) == 0 {
+		finding := Finding{Check: name + ".code", Address: predeploy.Address, Severity: SeverityCritical, Message: "no code"}
+		// optional predeploys are only deployed when enabled in the deploy config
+		if predeploy.Enabled != nil {
+			finding.Severity = SeverityInfo
+			finding.Message = "not deployed"
+		}
+		a.add(finding)
+		return
+	}
+	if predeploy.ProxyDisabled {
+		return
+	}
+
+	admin, err := client.StorageAt(a.ctx, predeploy.Address, genesis.AdminSlot, nil)
+	if err != nil {
+		a.fail(name+".admin", predeploy.Address, err)
+	} else {
+		a.compare(name+".admin", predeploy.Address, SeverityCritical, predeploys.ProxyAdminAddr.Hex(), common.BytesToAddress(admin).Hex())
+	}
+
+	expectedImpl, err := genesis.AddressToCodeNamespace(predeploy.Address)
+	if err != nil {
+		a.fail(name+".implementation", predeploy.Address, err)
+		return
+	}
+	impl, err := client.StorageAt(a.ctx, predeploy.Address, genesis.ImplementationSlot, nil)
+	if err != nil {
+		a.fail(name+".implementation", predeploy.Address, err)
+		return
+	}
+	implAddr := common.BytesToAddress(impl)
+	// predeploys may be upgraded to implementations outside of the code namespace
+	a.compare(name+".implementation", predeploy.Address, SeverityWarning, expectedImpl.Hex(), implAddr.Hex())
+	if implAddr != expectedImpl {
+		a.hasCode(name+".implementation.code", client, implAddr)
+	}
+}
+
+func auditAddress(a *auditor, check string, addr common.Address, expected *common.Address, read func(opts *bind.CallOpts) (common.Address, error)) {
+	actual, err := read(a.opts())
+	if err != nil {
+		a.fail(check, addr, err)
+		return
+	}
+	a.compare(check, addr, SeverityCritical, addressString(expected), actual.Hex())
+}
+
+// auditL1Block compares the L1 values of the L1Block predeploy with the SystemConfig values.
+// The values of the L1Block are updated at the start of every epoch, so they may lag behind.
+func auditL1Block(a *auditor, client Client, l1 *L1Values) {
+	addr := predeploys.L1BlockAddr
+	l1Block, err := bindings.NewL1BlockCaller(addr, client)
+	if err != nil {
+		a.fail("L1Block", addr, err)
+		return
+	}
+	if l1.BatcherHash != nil {
+		if batcherHash, err := l1Block.BatcherHash(a.opts()); err != nil {
+			a.fail("L1Block.batcherHash", addr, err)
+		} else {
+			a.compare("L1Block.batcherHash", addr, SeverityWarning, l1.BatcherHash.Hex(), common.Hash(batcherHash).Hex())
+		}
+	}
+	if l1.Overhead != nil {
+		if overhead, err := l1Block.L1FeeOverhead(a.opts()); err != nil {
+			a.fail("L1Block.l1FeeOverhead", addr, err)
+		} else {
+			a.compare("L1Block.l1FeeOverhead", addr, SeverityWarning, l1.Overhead.String(), overhead.String())
+		}
+	}
+	if l1.Scalar != nil {
+		if scalar, err := l1Block.L1FeeScalar(a.opts()); err != nil {
+			a.fail("L1Block.l1FeeScalar", addr, err)
+		} else {
+			a.compare("L1Block.l1FeeScalar", addr, SeverityWarning, l1.Scalar.String(), scalar.String())
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package audit
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// newL2Env builds the L2 genesis of the deploy config into a simulated backend, and returns the
+// expectations of the chain
+func newL2Env(t *testing.T) (*backends.SimulatedBackend, *Expectations) {
+	config, err := genesis.NewDeployConfig("../genesis/testdata/test-deploy-config-full.json")
+	require.NoError(t, err)
+	deployments, err := genesis.NewL1Deployments("../genesis/testdata/l1-deployments.json")
+	require.NoError(t, err)
+	config.SetDeployments(deployments)
+
+	l1Block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), BaseFee: big.NewInt(7), Time: 1000})
+	l2Genesis, err := genesis.BuildL2Genesis(config, l1Block)
+	require.NoError(t, err)
+	backend := backends.NewSimulatedBackend(l2Genesis.Alloc, 30_000_000)
+	t.Cleanup(func() { _ = backend.Close() })
+	return backend, FromDeployConfig("devnet", config, deployments)
+}
+
+func TestAuditL2(t *testing.T) {
+	backend, exp := newL2Env(t)
+	l1 := &L1Values{
+		BatcherHash: new(common.Hash),
+		Overhead:    exp.GenesisSystemConfig.Overhead,
+		Scalar:      exp.GenesisSystemConfig.Scalar,
+	}
+	*l1.BatcherHash = common.BytesToHash(exp.GenesisSystemConfig.BatcherAddr[:])
+
+	t.Run("expected", func(t *testing.T) {
+		report := &Report{}
+		AuditL2(context.Background(), exp, backend, l1, report)
+		require.Zero(t, report.Count(SeverityWarning), "%+v", report.Findings)
+		for _, check := range []string{"ProxyAdmin.owner", "L2StandardBridge.admin", "L2StandardBridge.implementation", "L2StandardBridge.OTHER_BRIDGE",
+			"L2CrossDomainMessenger.OTHER_MESSENGER", "L2ERC721Bridge.OTHER_BRIDGE", "BaseFeeVault.RECIPIENT", "L1Block.batcherHash", "L1Block.l1FeeScalar"} {
+			require.Equal(t, SeverityOK, finding(t, report, check).Severity, check)
+		}
+	})
+
+	t.Run("mismatches", func(t *testing.T) {
+		mismatched := *exp
+		recipient := common.Address{0x01}
+		mismatched.SequencerFeeVaultRecipient = &recipient
+		mismatched.L1.L1StandardBridgeProxy = common.Address{0x02}
+		updated := *l1
+		updated.Scalar = big.NewInt(1)
+
+		report := &Report{}
+		AuditL2(context.Background(), &mismatched, backend, &updated, report)
+		require.Equal(t, SeverityCritical, finding(t, report, "SequencerFeeVault.RECIPIENT").Severity)
+		require.Equal(t, SeverityCritical, finding(t, report, "L2StandardBridge.OTHER_BRIDGE").Severity)
+		// the L1Block values lag behind the SystemConfig
+		require.Equal(t, SeverityWarning, finding(t, report, "L1Block.l1FeeScalar").Severity)
+		require.Equal(t, 2, report.Count(SeverityCritical))
+	})
+
+	t.Run("without L1 values", func(t *testing.T) {
+		report := &Report{}
+		AuditL2(context.Background(), exp, backend, nil, report)
+		for _, f := range report.Findings {
+			require.NotEqual(t, "L1Block.batcherHash", f.Check)
+		}
+	})
+
+	t.Run("missing predeploy", func(t *testing.T) {
+		empty := backends.NewSimulatedBackend(core.GenesisAlloc{}, 30_000_000)
+		defer empty.Close()
+		report := &Report{}
+		AuditL2(context.Background(), exp, empty, nil, report)
+		require.Equal(t, SeverityCritical, finding(t, report, "L2StandardBridge.code").Severity)
+		require.Equal(t, "no code", finding(t, report, "L2StandardBridge.code").Message)
+	})
+}
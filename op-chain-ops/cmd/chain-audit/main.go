@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/maps"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/audit"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+)
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	app := &cli.App{
+		Name:  "chain-audit",
+		Usage: "Audit the on-chain configuration of chains against their expected configuration",
+		Description: "Reads the L1 system contracts and the L2 predeploys of chains of the superchain registry, " +
+			"or of a chain deployed with a deploy config, and compares them with their expected values. " +
+			"Every check is reported with a severity: ok, info when there is no expected value, warning " +
+			"or critical.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "l1-rpc-url",
+				Usage:    "L1 RPC URL, the chain ID will be used to determine the superchain",
+				Required: true,
+				EnvVars:  []string{"L1_RPC_URL"},
+			},
+			&cli.StringFlag{
+				Name:    "l2-rpc-url",
+				Usage:   "L2 RPC URL. Defaults to the public RPC of the chains of the superchain registry, required with a deploy config",
+				EnvVars: []string{"L2_RPC_URL"},
+			},
+			&cli.Uint64SliceFlag{
+				Name:  "chain-ids",
+				Usage: "L2 Chain IDs of the chains of the superchain registry to audit. Corresponds to all chains if empty",
+			},
+			&cli.StringFlag{
+				Name:    "superchain-target",
+				Usage:   "The name of the superchain of the chains to audit",
+				EnvVars: []string{"SUPERCHAIN_TARGET"},
+			},
+			&cli.PathFlag{
+				Name:    "deploy-config",
+				Usage:   "The path to the deploy config of the chain to audit, instead of chains of the superchain registry",
+				EnvVars: []string{"DEPLOY_CONFIG"},
+			},
+			&cli.PathFlag{
+				Name:    "l1-deployments",
+				Usage:   "The path to the L1 deployments of the chain to audit, required with a deploy config",
+				EnvVars: []string{"L1_DEPLOYMENTS"},
+			},
+			&cli.BoolFlag{
+				Name:  "skip-l2",
+				Usage: "Only audit the L1 contracts",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format of the report, either json or text",
+				Value: "json",
+			},
+			&cli.StringFlag{
+				Name:  "min-severity",
+				Usage: "Minimum severity of the findings written in the text format",
+				Value: string(audit.SeverityWarning),
+			},
+			&cli.StringFlag{
+				Name:  "fail-on",
+				Usage: "Exit with an error if a finding has at least this severity",
+				Value: string(audit.SeverityCritical),
+			},
+			&cli.PathFlag{
+				Name:    "outfile",
+				Usage:   "The file to write the report to. If not specified, the report is written to stdout",
+				EnvVars: []string{"OUTFILE"},
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error auditing chains", "err", err)
+	}
+}
+
+// entrypoint audits the chains and writes the report
+func entrypoint(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "json" && format != "text" {
+		return fmt.Errorf("unknown format %s", format)
+	}
+	minSeverity, err := audit.ParseSeverity(ctx.String("min-severity"))
+	if err != nil {
+		return err
+	}
+	failOn, err := audit.ParseSeverity(ctx.String("fail-on"))
+	if err != nil {
+		return err
+	}
+
+	l1Client, err := ethclient.Dial(ctx.String("l1-rpc-url"))
+	if err != nil {
+		return err
+	}
+
+	chains, err := expectations(ctx, l1Client)
+	if err != nil {
+		return err
+	}
+
+	report := &audit.Report{}
+	for _, chain := range chains {
+		log.Info("Auditing chain", "name", chain.exp.Name, "chain-id", chain.exp.ChainID)
+		l1Values := audit.AuditL1(ctx.Context, chain.exp, l1Client, report)
+		if ctx.Bool("skip-l2") {
+			continue
+		}
+		if chain.l2RPC == "" {
+			log.Warn("No L2 RPC URL, skipping the L2 predeploys", "name", chain.exp.Name)
+			continue
+		}
+		l2Client, err := ethclient.Dial(chain.l2RPC)
+		if err != nil {
+			return fmt.Errorf("cannot dial L2 of %s: %w", chain.exp.Name, err)
+		}
+		l2ChainID, err := l2Client.ChainID(ctx.Context)
+		if err != nil {
+			return fmt.Errorf("cannot fetch L2 chain ID of %s: %w", chain.exp.Name, err)
+		}
+		if l2ChainID.Uint64() != chain.exp.ChainID {
+			return fmt.Errorf("mismatched chain IDs of %s: %d != %d", chain.exp.Name, chain.exp.ChainID, l2ChainID)
+		}
+		audit.AuditL2(ctx.Context, chain.exp, l2Client, l1Values, report)
+		l2Client.Close()
+	}
+
+	var w io.Writer = os.Stdout
+	if outfile := ctx.Path("outfile"); outfile != "" {
+		f, err := os.OpenFile(outfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	} else {
+		err = report.Write(w, minSeverity)
+	}
+	if err != nil {
+		return err
+	}
+
+	if max := report.MaxSeverity(); max.AtLeast(failOn) {
+		return fmt.Errorf("%d findings with severity of at least %s", report.Count(failOn), failOn)
+	}
+	return nil
+}
+
+type chainExpectations struct {
+	exp   *audit.Expectations
+	l2RPC string
+}
+
+// expectations returns the expectations of the chains to audit, from the deploy
+// config or from the superchain registry
+func expectations(ctx *cli.Context, l1Client *ethclient.Client) ([]chainExpectations, error) {
+	if path := ctx.Path("deploy-config"); path != "" {
+		deploymentsPath := ctx.Path("l1-deployments")
+		if deploymentsPath == "" {
+			return nil, errors.New("missing required flag: l1-deployments")
+		}
+		config, err := genesis.NewDeployConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		deployments, err := genesis.NewL1Deployments(deploymentsPath)
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		return []chainExpectations{{
+			exp:   audit.FromDeployConfig(name, config, deployments),
+			l2RPC: ctx.String("l2-rpc-url"),
+		}}, nil
+	}
+
+	l1ChainID, err := l1Client.ChainID(ctx.Context)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch L1 chain ID: %w", err)
+	}
+	superchainName := ctx.String("superchain-target")
+	if superchainName == "" {
+		for name, sc := range superchain.Superchains {
+			if sc.Config.L1.ChainID == l1ChainID.Uint64() {
+				superchainName = name
+			}
+		}
+		if superchainName == "" {
+			return nil, fmt.Errorf("no superchain for L1 chain ID %d", l1ChainID)
+		}
+	}
+
+	chainIDs := ctx.Uint64Slice("chain-ids")
+	if len(chainIDs) == 0 {
+		chainIDs = maps.Keys(superchain.OPChains)
+	}
+	slices.Sort(chainIDs)
+
+	var chains []chainExpectations
+	for _, chainID := range chainIDs {
+		chainConfig, ok := superchain.OPChains[chainID]
+		if !ok {
+			return nil, fmt.Errorf("unknown chain ID %d", chainID)
+		}
+		if chainConfig.Superchain != superchainName {
+			continue
+		}
+		exp, err := audit.FromSuperchain(chainConfig)
+		if err != nil {
+			return nil, err
+		}
+		l2RPC := chainConfig.PublicRPC
+		if len(chainIDs) == 1 && ctx.String("l2-rpc-url") != "" {
+			l2RPC = ctx.String("l2-rpc-url")
+		}
+		chains = append(chains, chainExpectations{exp: exp, l2RPC: l2RPC})
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("no chains of superchain %s to audit", superchainName)
+	}
+	return chains, nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+func main() {
+	log.Root().SetHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(isatty.IsTerminal(os.Stderr.Fd()))))
+
+	app := &cli.App{
+		Name:  "genesis-diff",
+		Usage: "Compare the accounts of two L2 genesis files or state dumps",
+		Description: "Reports the added and removed accounts, and the balance, nonce, code and storage " +
+			"changes of the other accounts. The predeploys are identified by their address, so their " +
+			"immutables and their storage are decoded. The files may be genesis files, geth state dumps " +
+			"or allocs files mapping addresses to accounts.",
+		Flags: []cli.Flag{
+			&cli.PathFlag{
+				Name:     "before",
+				Usage:    "Path to the genesis file or state dump to compare from",
+				Required: true,
+			},
+			&cli.PathFlag{
+				Name:     "after",
+				Usage:    "Path to the genesis file or state dump to compare to",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format of the diff, either text or json",
+				Value: "text",
+			},
+			&cli.PathFlag{
+				Name:  "outfile",
+				Usage: "Path to write the diff to. If not specified, the diff is written to stdout",
+			},
+			&cli.BoolFlag{
+				Name:  "exit-code",
+				Usage: "Exit with an error if the accounts differ",
+			},
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error diffing genesis", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	format := ctx.String("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %s", format)
+	}
+
+	before, err := loadAllocs(ctx.Path("before"))
+	if err != nil {
+		return err
+	}
+	after, err := loadAllocs(ctx.Path("after"))
+	if err != nil {
+		return err
+	}
+
+	diff, err := genesis.DiffAllocs(before, after)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if outfile := ctx.Path("outfile"); outfile != "" {
+		f, err := os.OpenFile(outfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(diff)
+	} else {
+		err = diff.Write(w)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("exit-code") && len(diff.Accounts) > 0 {
+		return fmt.Errorf("%d accounts differ", len(diff.Accounts))
+	}
+	return nil
+}
+
+// dumpAccount is an account of a geth state dump
+type dumpAccount struct {
+	Balance string                 `json:"balance"`
+	Nonce   uint64                 `json:"nonce"`
+	Code    hexutil.Bytes          `json:"code,omitempty"`
+	Storage map[common.Hash]string `json:"storage,omitempty"`
+}
+
+// loadAllocs reads the accounts of a genesis file, a geth state dump, or an allocs file
+func loadAllocs(path string) (core.GenesisAlloc, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("cannot decode %s: %w", path, err)
+	}
+
+	if _, ok := fields["alloc"]; ok {
+		var gen core.Genesis
+		if err := json.Unmarshal(data, &gen); err != nil {
+			return nil, fmt.Errorf("cannot decode genesis %s: %w", path, err)
+		}
+		return gen.Alloc, nil
+	}
+
+	if rawAccounts, ok := fields["accounts"]; ok {
+		var accounts map[string]dumpAccount
+		if err := json.Unmarshal(rawAccounts, &accounts); err != nil {
+			return nil, fmt.Errorf("cannot decode state dump %s: %w", path, err)
+		}
+		alloc := make(core.GenesisAlloc, len(accounts))
+		for key, account := range accounts {
+			if !common.IsHexAddress(key) {
+				return nil, fmt.Errorf("state dump %s has account %s without address preimage", path, key)
+			}
+			balance, ok := new(big.Int).SetString(account.Balance, 10)
+			if !ok {
+				return nil, fmt.Errorf("invalid balance %q of account %s", account.Balance, key)
+			}
+			storage := make(map[common.Hash]common.Hash, len(account.Storage))
+			for slot, value := range account.Storage {
+				storage[slot] = common.HexToHash(value)
+			}
+			alloc[common.HexToAddress(key)] = core.GenesisAccount{
+				Balance: balance,
+				Nonce:   account.Nonce,
+				Code:    account.Code,
+				Storage: storage,
+			}
+		}
+		return alloc, nil
+	}
+
+	var alloc core.GenesisAlloc
+	if err := json.Unmarshal(data, &alloc); err != nil {
+		return nil, fmt.Errorf("cannot decode allocs %s: %w", path, err)
+	}
+	if len(alloc) == 0 {
+		return nil, errors.New("no accounts in " + path)
+	}
+	return alloc, nil
+}
@@ -45,10 +45,9 @@ func main() {
 				EnvVars: []string{"SUPERCHAIN_TARGET"},
 			},
 			&cli.PathFlag{
-				Name:     "deploy-config",
-				Usage:    "The path to the deploy config file",
-				Required: true,
-				EnvVars:  []string{"DEPLOY_CONFIG"},
+				Name:    "deploy-config",
+				Usage:   "The path to the deploy config file. Required to build the bundle",
+				EnvVars: []string{"DEPLOY_CONFIG"},
 			},
 			&cli.PathFlag{
 				Name:    "outfile",
@@ -57,6 +56,9 @@ func main() {
 			},
 		},
 		Action: entrypoint,
+		Commands: []*cli.Command{
+			simulateCommand,
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -66,45 +68,21 @@ func main() {
 
 // entrypoint contains the main logic of the script
 func entrypoint(ctx *cli.Context) error {
+	deployConfig := ctx.Path("deploy-config")
+	if deployConfig == "" {
+		return errors.New("missing required flag: deploy-config")
+	}
+
 	client, err := ethclient.Dial(ctx.String("l1-rpc-url"))
 	if err != nil {
 		return err
 	}
 
-	// Fetch the L1 chain ID to determine the superchain name
-	l1ChainID, err := client.ChainID(ctx.Context)
+	targets, err := targetChains(ctx, client)
 	if err != nil {
 		return err
 	}
 
-	superchainName := ctx.String("superchain-target")
-	if superchainName == "" {
-		superchainName, err = toSuperchainName(l1ChainID.Uint64())
-		if err != nil {
-			return err
-		}
-	}
-
-	chainIDs := ctx.Uint64Slice("chain-ids")
-	deployConfig := ctx.Path("deploy-config")
-
-	// If no chain IDs are specified, upgrade all chains
-	if len(chainIDs) == 0 {
-		chainIDs = maps.Keys(superchain.OPChains)
-	}
-	slices.Sort(chainIDs)
-
-	targets := make([]*superchain.ChainConfig, 0)
-	for _, chainConfig := range superchain.OPChains {
-		if chainConfig.Superchain == superchainName && slices.Contains(chainIDs, chainConfig.ChainID) {
-			targets = append(targets, chainConfig)
-		}
-	}
-
-	slices.SortFunc(targets, func(i, j *superchain.ChainConfig) int {
-		return int(i.ChainID) - int(j.ChainID)
-	})
-
 	// Create a batch of transactions
 	batch := safe.Batch{}
 
@@ -215,6 +193,43 @@ func entrypoint(ctx *cli.Context) error {
 	return nil
 }
 
+// targetChains returns the chains to upgrade, sorted by chain ID
+func targetChains(ctx *cli.Context, client *ethclient.Client) ([]*superchain.ChainConfig, error) {
+	// Fetch the L1 chain ID to determine the superchain name
+	l1ChainID, err := client.ChainID(ctx.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	superchainName := ctx.String("superchain-target")
+	if superchainName == "" {
+		superchainName, err = toSuperchainName(l1ChainID.Uint64())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chainIDs := ctx.Uint64Slice("chain-ids")
+
+	// If no chain IDs are specified, upgrade all chains
+	if len(chainIDs) == 0 {
+		chainIDs = maps.Keys(superchain.OPChains)
+	}
+	slices.Sort(chainIDs)
+
+	targets := make([]*superchain.ChainConfig, 0)
+	for _, chainConfig := range superchain.OPChains {
+		if chainConfig.Superchain == superchainName && slices.Contains(chainIDs, chainConfig.ChainID) {
+			targets = append(targets, chainConfig)
+		}
+	}
+
+	slices.SortFunc(targets, func(i, j *superchain.ChainConfig) int {
+		return int(i.ChainID) - int(j.ChainID)
+	})
+	return targets, nil
+}
+
 // toDeployConfigName is a temporary function that maps the chain config names
 // to deploy config names. This should be able to be removed in the future
 // with a canonical naming scheme. If an empty string is returned, then
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/safe"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/upgrades"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+)
+
+var simulateCommand = &cli.Command{
+	Name:  "simulate",
+	Usage: "Simulate a Safe bundle on a fork of L1 and report its effects",
+	Description: "Executes the transactions of the bundle as the Safe on top of the latest L1 block, " +
+		"fetching the L1 state over RPC as it is accessed. Reports the proxy implementation changes, " +
+		"the storage changes decoded through the storage layouts of the contracts, the emitted events " +
+		"and the checks of the contract versions of each chain after the upgrade. " +
+		"The L1 RPC URL, chain IDs and superchain target are read from the global flags.",
+	Flags: []cli.Flag{
+		&cli.PathFlag{
+			Name:     "bundle",
+			Usage:    "The path to the Safe bundle to simulate",
+			Required: true,
+			EnvVars:  []string{"BUNDLE"},
+		},
+		&cli.StringFlag{
+			Name:    "safe",
+			Usage:   "The address of the Safe executing the bundle. Defaults to the Safe of the bundle metadata, or the owner of the ProxyAdmin",
+			EnvVars: []string{"SAFE"},
+		},
+		&cli.PathFlag{
+			Name:    "outfile",
+			Usage:   "The file to write the report to. If not specified, the report is written to stdout",
+			EnvVars: []string{"OUTFILE"},
+		},
+	},
+	Action: simulate,
+}
+
+// simulate executes the bundle on a fork of L1 and writes the report
+func simulate(ctx *cli.Context) error {
+	data, err := os.ReadFile(ctx.Path("bundle"))
+	if err != nil {
+		return fmt.Errorf("cannot read bundle: %w", err)
+	}
+	var batch safe.Batch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return fmt.Errorf("cannot decode bundle: %w", err)
+	}
+
+	client, err := ethclient.Dial(ctx.String("l1-rpc-url"))
+	if err != nil {
+		return err
+	}
+	l1ChainID, err := client.ChainID(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("cannot fetch L1 chain ID: %w", err)
+	}
+
+	chains, err := targetChains(ctx, client)
+	if err != nil {
+		return err
+	}
+	if len(chains) == 0 {
+		return errors.New("no chains to simulate the upgrade of")
+	}
+
+	implementations, ok := superchain.Implementations[l1ChainID.Uint64()]
+	if !ok {
+		return fmt.Errorf("no implementations for chain ID %d", l1ChainID.Uint64())
+	}
+	list, err := implementations.Resolve(superchain.SuperchainSemver)
+	if err != nil {
+		return err
+	}
+
+	targets := make([]upgrades.SimulationTarget, 0, len(chains))
+	for _, chainConfig := range chains {
+		addresses, ok := superchain.Addresses[chainConfig.ChainID]
+		if !ok {
+			return fmt.Errorf("no addresses for chain ID %d", chainConfig.ChainID)
+		}
+		targets = append(targets, upgrades.SimulationTarget{
+			ChainConfig:     chainConfig,
+			Addresses:       addresses,
+			Implementations: list,
+		})
+	}
+
+	safeAddr, err := safeAddress(ctx, &batch, client, targets)
+	if err != nil {
+		return err
+	}
+	log.Info("Simulating bundle", "transactions", len(batch.Transactions), "safe", safeAddr, "chains", len(targets))
+
+	report, err := upgrades.SimulateL1(ctx.Context, client, &batch, safeAddr, targets)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if outfile := ctx.Path("outfile"); outfile != "" {
+		f, err := os.OpenFile(outfile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := report.Write(w); err != nil {
+		return err
+	}
+
+	if !report.Succeeded() {
+		return errors.New("bundle reverts")
+	}
+	for _, check := range report.Checks {
+		if check.Err != nil {
+			return fmt.Errorf("post-upgrade checks failed for %s", check.Name)
+		}
+	}
+	return nil
+}
+
+// safeAddress returns the address of the Safe executing the bundle. If not set by flag or in the
+// bundle metadata, the Safe is the owner of the ProxyAdmin of the chains, which must be the same.
+func safeAddress(ctx *cli.Context, batch *safe.Batch, client *ethclient.Client, targets []upgrades.SimulationTarget) (common.Address, error) {
+	if addr := ctx.String("safe"); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return common.Address{}, fmt.Errorf("invalid safe address %s", addr)
+		}
+		return common.HexToAddress(addr), nil
+	}
+	if common.IsHexAddress(batch.Meta.CreatedFromSafeAddress) {
+		return common.HexToAddress(batch.Meta.CreatedFromSafeAddress), nil
+	}
+
+	var owner common.Address
+	for _, target := range targets {
+		proxyAdmin, err := bindings.NewProxyAdminCaller(common.HexToAddress(target.Addresses.ProxyAdmin.String()), client)
+		if err != nil {
+			return common.Address{}, err
+		}
+		addr, err := proxyAdmin.Owner(&bind.CallOpts{Context: ctx.Context})
+		if err != nil {
+			return common.Address{}, fmt.Errorf("cannot fetch ProxyAdmin owner of %s: %w", target.ChainConfig.Name, err)
+		}
+		if owner != (common.Address{}) && owner != addr {
+			return common.Address{}, errors.New("the ProxyAdmins of the chains have different owners, set the safe address")
+		}
+		owner = addr
+	}
+	return owner, nil
+}
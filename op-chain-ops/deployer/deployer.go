@@ -13,8 +13,12 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/srcmap"
 )
 
 // TestKey is the same test key that geth uses
@@ -57,9 +61,11 @@ func NewL2Backend() *backends.SimulatedBackend {
 	return NewBackendWithGenesisTimestamp(0, false)
 }
 
-func NewBackendWithGenesisTimestamp(ts uint64, shanghai bool) *backends.SimulatedBackend {
+// NewChainConfig returns the chain config used by the simulated backends,
+// with all hardforks up to and optionally including shanghai active at genesis.
+func NewChainConfig(chainID *big.Int, shanghai bool) *params.ChainConfig {
 	chainConfig := params.ChainConfig{
-		ChainID:             ChainID,
+		ChainID:             chainID,
 		HomesteadBlock:      big.NewInt(0),
 		DAOForkBlock:        nil,
 		DAOForkSupport:      false,
@@ -88,13 +94,18 @@ func NewBackendWithGenesisTimestamp(ts uint64, shanghai bool) *backends.Simulate
 	if shanghai {
 		chainConfig.ShanghaiTime = u64ptr(0)
 	}
+	return &chainConfig
+}
+
+func NewBackendWithGenesisTimestamp(ts uint64, shanghai bool) *backends.SimulatedBackend {
+	chainConfig := NewChainConfig(ChainID, shanghai)
 
 	return backends.NewSimulatedBackendWithOpts(
 		backends.WithCacheConfig(&core.CacheConfig{
 			Preimages: true,
 		}),
 		backends.WithGenesis(core.Genesis{
-			Config:     &chainConfig,
+			Config:     chainConfig,
 			Timestamp:  ts,
 			Difficulty: big.NewInt(0),
 			Alloc: core.GenesisAlloc{
@@ -129,6 +140,11 @@ func Deploy(backend *backends.SimulatedBackend, constructors []Constructor, cb D
 		backend.Commit()
 		addr, err := bind.WaitDeployed(ctx, backend, tx)
 		if err != nil {
+			if trace, traceErr := RevertTrace(backend, tx); traceErr != nil {
+				log.Warn("Cannot trace failed deployment", "name", deployment.Name, "err", traceErr)
+			} else if trace != nil {
+				return nil, fmt.Errorf("%s: %w\n%s", deployment.Name, err, trace)
+			}
 			return nil, fmt.Errorf("%s: %w", deployment.Name, err)
 		}
 
@@ -152,6 +168,60 @@ func Deploy(backend *backends.SimulatedBackend, constructors []Constructor, cb D
 	return results, nil
 }
 
+// RevertTrace replays a mined transaction of the backend to trace the Solidity call
+// stack of its revert. It returns nil if the transaction did not revert.
+func RevertTrace(backend *backends.SimulatedBackend, tx *types.Transaction) (*srcmap.RevertTrace, error) {
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch receipt: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		return nil, nil
+	}
+
+	chain := backend.Blockchain()
+	block := chain.GetBlockByHash(receipt.BlockHash)
+	if block == nil {
+		return nil, fmt.Errorf("unknown block %s", receipt.BlockHash)
+	}
+	parent := chain.GetHeaderByHash(block.ParentHash())
+	if parent == nil {
+		return nil, fmt.Errorf("unknown parent block %s", block.ParentHash())
+	}
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open parent state: %w", err)
+	}
+
+	config := chain.Config()
+	signer := types.MakeSigner(config, block.Number(), block.Time())
+	blockCtx := core.NewEVMBlockContext(block.Header(), chain, nil, config, statedb)
+	gasPool := new(core.GasPool).AddGas(block.GasLimit())
+	// the preceding transactions of the block are replayed without tracing
+	for i, blockTx := range block.Transactions() {
+		msg, err := core.TransactionToMessage(blockTx, signer, block.BaseFee())
+		if err != nil {
+			return nil, err
+		}
+		var tracer *srcmap.RevertTracer
+		vmConfig := vm.Config{}
+		if blockTx.Hash() == tx.Hash() {
+			tracer = srcmap.NewRevertTracer(srcmap.DefaultContracts())
+			vmConfig.Tracer = tracer
+		}
+		statedb.SetTxContext(blockTx.Hash(), i)
+		evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), statedb, config, vmConfig)
+		if _, err := core.ApplyMessage(evm, msg, gasPool); err != nil {
+			return nil, fmt.Errorf("cannot replay transaction %d: %w", i, err)
+		}
+		if tracer != nil {
+			return tracer.RevertTrace(), nil
+		}
+		statedb.Finalise(true)
+	}
+	return nil, fmt.Errorf("transaction %s not found in block %s", tx.Hash(), block.Hash())
+}
+
 func u64ptr(n uint64) *uint64 {
 	return &n
 }
@@ -0,0 +1,67 @@
+package deployer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// init code which returns a single byte contract
+	deployingInitCode = hexutil.MustDecode("0x600160005360016000f3")
+	// init code which reverts with 0xdeadbeef
+	revertingInitCode = hexutil.MustDecode("0x63deadbeef6000526004601cfd")
+)
+
+func deployInitCode(backend *backends.SimulatedBackend, opts *bind.TransactOpts, deployment Constructor) (*types.Transaction, error) {
+	initCode := deployingInitCode
+	if deployment.Name == "Reverting" {
+		initCode = revertingInitCode
+	}
+	nonce, err := backend.PendingNonceAt(context.Background(), opts.From)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := backend.SuggestGasPrice(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	tx := types.NewContractCreation(nonce, common.Big0, opts.GasLimit, gasPrice, initCode)
+	tx, err = opts.Signer(opts.From, tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, backend.SendTransaction(context.Background(), tx)
+}
+
+func TestRevertTrace(t *testing.T) {
+	backend := NewL1Backend()
+	defer backend.Close()
+
+	_, err := Deploy(backend, []Constructor{{Name: "Deploying"}, {Name: "Reverting"}}, deployInitCode)
+	require.ErrorContains(t, err, "Reverting: no contract code after deployment\nexecution reverted: 0xdeadbeef\n    at <unknown>.constructor")
+
+	block, err := backend.BlockByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 1)
+	trace, err := RevertTrace(backend, block.Transactions()[0])
+	require.NoError(t, err)
+	require.NotNil(t, trace)
+	require.Equal(t, "execution reverted: 0xdeadbeef", trace.Error)
+	require.Len(t, trace.Frames, 1)
+	require.True(t, trace.Frames[0].Create)
+	require.Equal(t, "constructor", trace.Frames[0].Function)
+
+	// successful transactions aren't traced
+	block, err = backend.BlockByNumber(context.Background(), common.Big1)
+	require.NoError(t, err)
+	trace, err = RevertTrace(backend, block.Transactions()[0])
+	require.NoError(t, err)
+	require.Nil(t, trace)
+}
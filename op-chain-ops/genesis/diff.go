@@ -0,0 +1,361 @@
+package genesis
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/immutables"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+const (
+	AccountAdded   = "added"
+	AccountRemoved = "removed"
+	AccountChanged = "changed"
+)
+
+// AllocsDiff is the difference between two sets of genesis allocations
+type AllocsDiff struct {
+	Accounts []AccountChange `json:"accounts"`
+}
+
+// AccountChange is an account which was added, removed or changed
+type AccountChange struct {
+	Address common.Address `json:"address"`
+	// Name is the name of the predeploy at the address, if any
+	Name string `json:"name,omitempty"`
+	// Implementation is set for the code namespace address of a proxied predeploy
+	Implementation bool           `json:"implementation,omitempty"`
+	Status         string         `json:"status"`
+	Balance        *BalanceChange `json:"balance,omitempty"`
+	Nonce          *NonceChange   `json:"nonce,omitempty"`
+	Code           *CodeChange    `json:"code,omitempty"`
+	Storage        []SlotChange   `json:"storage,omitempty"`
+}
+
+// BalanceChange is a change of the balance of an account
+type BalanceChange struct {
+	Before *big.Int `json:"before"`
+	After  *big.Int `json:"after"`
+}
+
+// NonceChange is a change of the nonce of an account
+type NonceChange struct {
+	Before uint64 `json:"before"`
+	After  uint64 `json:"after"`
+}
+
+// CodeChange is a change of the code of an account. The code hashes are
+// zero when the account has no code.
+type CodeChange struct {
+	Before     common.Hash       `json:"before"`
+	After      common.Hash       `json:"after"`
+	SizeBefore int               `json:"sizeBefore"`
+	SizeAfter  int               `json:"sizeAfter"`
+	Immutables []ImmutableChange `json:"immutables,omitempty"`
+}
+
+// ImmutableChange is a change of an immutable of a predeploy, read through its getter
+type ImmutableChange struct {
+	Name   string `json:"name"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// SlotChange is a change of a storage slot, with the variables it holds
+// decoded through the storage layout of the contract
+type SlotChange struct {
+	Slot    common.Hash                   `json:"slot"`
+	Before  common.Hash                   `json:"before"`
+	After   common.Hash                   `json:"after"`
+	Decoded []state.StorageVariableChange `json:"decoded,omitempty"`
+}
+
+type predeployAccount struct {
+	name           string
+	implementation bool
+}
+
+// predeployAccounts returns the predeploys by their address, and the proxied
+// predeploys by the address of their implementation
+func predeployAccounts() (map[common.Address]predeployAccount, error) {
+	accounts := make(map[common.Address]predeployAccount)
+	for name, predeploy := range predeploys.Predeploys {
+		accounts[predeploy.Address] = predeployAccount{name: name}
+		if predeploy.ProxyDisabled {
+			continue
+		}
+		codeAddr, err := AddressToCodeNamespace(predeploy.Address)
+		if err != nil {
+			return nil, fmt.Errorf("error converting to code namespace: %w", err)
+		}
+		accounts[codeAddr] = predeployAccount{name: name, implementation: true}
+	}
+	return accounts, nil
+}
+
+// DiffAllocs compares the accounts of two genesis allocations. The predeploys
+// are identified by their address, so their storage is decoded through their
+// storage layout and their immutables are read through their getters.
+func DiffAllocs(before core.GenesisAlloc, after core.GenesisAlloc) (*AllocsDiff, error) {
+	accounts, err := predeployAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := make([]common.Address, 0, len(after))
+	for addr := range after {
+		addrs = append(addrs, addr)
+	}
+	for addr := range before {
+		if _, ok := after[addr]; !ok {
+			addrs = append(addrs, addr)
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	diff := &AllocsDiff{Accounts: make([]AccountChange, 0)}
+	for _, addr := range addrs {
+		accountBefore, existedBefore := before[addr]
+		accountAfter, existsAfter := after[addr]
+		predeploy := accounts[addr]
+		change := AccountChange{
+			Address:        addr,
+			Name:           predeploy.name,
+			Implementation: predeploy.implementation,
+			Status:         AccountChanged,
+		}
+		switch {
+		case !existedBefore:
+			change.Status = AccountAdded
+		case !existsAfter:
+			change.Status = AccountRemoved
+		}
+
+		balanceBefore, balanceAfter := balanceOf(accountBefore), balanceOf(accountAfter)
+		if balanceBefore.Cmp(balanceAfter) != 0 {
+			change.Balance = &BalanceChange{Before: balanceBefore, After: balanceAfter}
+		}
+		if accountBefore.Nonce != accountAfter.Nonce {
+			change.Nonce = &NonceChange{Before: accountBefore.Nonce, After: accountAfter.Nonce}
+		}
+		if !bytes.Equal(accountBefore.Code, accountAfter.Code) {
+			change.Code = &CodeChange{
+				Before:     codeHash(accountBefore.Code),
+				After:      codeHash(accountAfter.Code),
+				SizeBefore: len(accountBefore.Code),
+				SizeAfter:  len(accountAfter.Code),
+			}
+			if predeploy.name != "" {
+				change.Code.Immutables = immutableChanges(predeploy.name, addr, accountBefore.Code, accountAfter.Code)
+			}
+		}
+		change.Storage = slotChanges(predeploy, accountBefore.Storage, accountAfter.Storage)
+
+		if change.Status == AccountChanged && change.Balance == nil && change.Nonce == nil &&
+			change.Code == nil && len(change.Storage) == 0 {
+			continue
+		}
+		diff.Accounts = append(diff.Accounts, change)
+	}
+	return diff, nil
+}
+
+func balanceOf(account core.GenesisAccount) *big.Int {
+	if account.Balance == nil {
+		return new(big.Int)
+	}
+	return account.Balance
+}
+
+func codeHash(code []byte) common.Hash {
+	if len(code) == 0 {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(code)
+}
+
+func slotChanges(predeploy predeployAccount, before map[common.Hash]common.Hash, after map[common.Hash]common.Hash) []SlotChange {
+	var layout *solc.StorageLayout
+	if predeploy.name != "" {
+		// the layout is unavailable for predeploys which aren't compiled in the bindings
+		layout, _ = bindings.GetStorageLayout(predeploy.name)
+	}
+
+	slots := make([]common.Hash, 0, len(after))
+	for slot, value := range after {
+		if before[slot] != value {
+			slots = append(slots, slot)
+		}
+	}
+	for slot, value := range before {
+		if _, ok := after[slot]; !ok && value != (common.Hash{}) {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Big().Cmp(slots[j].Big()) < 0
+	})
+
+	changes := make([]SlotChange, 0, len(slots))
+	for _, slot := range slots {
+		change := SlotChange{
+			Slot:   slot,
+			Before: before[slot],
+			After:  after[slot],
+		}
+		switch slot {
+		case ImplementationSlot:
+			change.Decoded = addressSlotChange("eip1967.proxy.implementation", change.Before, change.After)
+		case AdminSlot:
+			change.Decoded = addressSlotChange("eip1967.proxy.admin", change.Before, change.After)
+		default:
+			if layout != nil {
+				change.Decoded = state.DecodeStorageChange(layout, slot, change.Before, change.After)
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func addressSlotChange(label string, before common.Hash, after common.Hash) []state.StorageVariableChange {
+	return []state.StorageVariableChange{{
+		Label:  label,
+		Type:   "address",
+		Before: common.BytesToAddress(before[:]).Hex(),
+		After:  common.BytesToAddress(after[:]).Hex(),
+	}}
+}
+
+// immutableChanges reads the immutables of the predeploy from both versions of its code.
+// The immutables are the fields of the predeploy in the immutables config, which the
+// contracts expose through getters named in screaming snake case.
+func immutableChanges(name string, addr common.Address, before []byte, after []byte) []ImmutableChange {
+	field, ok := reflect.TypeOf(immutables.PredeploysImmutableConfig{}).FieldByName(name)
+	if !ok {
+		return nil
+	}
+	var changes []ImmutableChange
+	for i := 0; i < field.Type.NumField(); i++ {
+		immutable := field.Type.Field(i)
+		getter := getterName(immutable.Name)
+		valueBefore := readImmutable(addr, before, getter, immutable.Type)
+		valueAfter := readImmutable(addr, after, getter, immutable.Type)
+		if valueBefore == valueAfter {
+			continue
+		}
+		changes = append(changes, ImmutableChange{
+			Name:   getter,
+			Before: valueBefore,
+			After:  valueAfter,
+		})
+	}
+	return changes
+}
+
+// getterName converts the name of an immutables config field, such as OtherMessenger,
+// to the name of the getter of the immutable, such as OTHER_MESSENGER
+func getterName(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// readImmutable calls the getter of an immutable on the code, and formats the value based on the
+// type of the immutables config field. It returns an empty string if the value can't be read.
+func readImmutable(addr common.Address, code []byte, getter string, typ reflect.Type) string {
+	if len(code) == 0 {
+		return ""
+	}
+	db, err := gstate.New(types.EmptyRootHash, gstate.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return ""
+	}
+	db.SetCode(addr, code)
+	selector := crypto.Keccak256([]byte(getter + "()"))[:4]
+	out, _, err := runtime.Call(addr, selector, &runtime.Config{State: db})
+	if err != nil || len(out) != 32 {
+		return ""
+	}
+	switch typ {
+	case reflect.TypeOf(common.Address{}):
+		return common.BytesToAddress(out).Hex()
+	case reflect.TypeOf(new(big.Int)):
+		return new(big.Int).SetBytes(out).String()
+	}
+	switch typ.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetBytes(out).String()
+	}
+	return common.BytesToHash(out).Hex()
+}
+
+// Write writes the diff as text
+func (d *AllocsDiff) Write(w io.Writer) error {
+	var b strings.Builder
+	if len(d.Accounts) == 0 {
+		fmt.Fprintf(&b, "No changes\n")
+	}
+	for _, account := range d.Accounts {
+		name := account.Name
+		if account.Implementation {
+			name += " implementation"
+		}
+		if name != "" {
+			name = " (" + name + ")"
+		}
+		fmt.Fprintf(&b, "%s%s: %s\n", account.Address, name, account.Status)
+		if account.Balance != nil {
+			fmt.Fprintf(&b, "  balance: %s -> %s\n", account.Balance.Before, account.Balance.After)
+		}
+		if account.Nonce != nil {
+			fmt.Fprintf(&b, "  nonce: %d -> %d\n", account.Nonce.Before, account.Nonce.After)
+		}
+		if account.Code != nil {
+			fmt.Fprintf(&b, "  code: %s (%d bytes) -> %s (%d bytes)\n", account.Code.Before, account.Code.SizeBefore, account.Code.After, account.Code.SizeAfter)
+			for _, immutable := range account.Code.Immutables {
+				fmt.Fprintf(&b, "    immutable %s: %s -> %s\n", immutable.Name, formatValue(immutable.Before), formatValue(immutable.After))
+			}
+		}
+		for _, slot := range account.Storage {
+			fmt.Fprintf(&b, "  slot %s: %s -> %s\n", slot.Slot, slot.Before, slot.After)
+			for _, variable := range slot.Decoded {
+				fmt.Fprintf(&b, "    %s (%s): %s -> %s\n", variable.Label, variable.Type, variable.Before, variable.After)
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func formatValue(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,70 @@
+package genesis_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+func TestDiffAllocs(t *testing.T) {
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.NoError(t, err)
+	config.FundDevAccounts = false
+	config.EnableGovernance = true
+	before := testBuildL2Genesis(t, config)
+
+	messengerBefore := config.L1CrossDomainMessengerProxy
+	ownerBefore := config.GovernanceTokenOwner
+	messenger := common.HexToAddress("0xff000000000000000000000000000000000000ee")
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000444")
+	config.L1CrossDomainMessengerProxy = messenger
+	config.GovernanceTokenOwner = owner
+	after := testBuildL2Genesis(t, config)
+
+	added := common.HexToAddress("0x1234")
+	after.Alloc[added] = core.GenesisAccount{Balance: big.NewInt(1)}
+
+	diff, err := genesis.DiffAllocs(before.Alloc, after.Alloc)
+	require.NoError(t, err)
+
+	accounts := make(map[common.Address]genesis.AccountChange)
+	for _, account := range diff.Accounts {
+		accounts[account.Address] = account
+	}
+	require.Len(t, accounts, 3)
+
+	require.Equal(t, genesis.AccountAdded, accounts[added].Status)
+	require.Equal(t, big.NewInt(1), accounts[added].Balance.After)
+
+	implAddr, err := genesis.AddressToCodeNamespace(predeploys.L2CrossDomainMessengerAddr)
+	require.NoError(t, err)
+	impl := accounts[implAddr]
+	require.Equal(t, genesis.AccountChanged, impl.Status)
+	require.Equal(t, "L2CrossDomainMessenger", impl.Name)
+	require.True(t, impl.Implementation)
+	require.NotNil(t, impl.Code)
+	require.Equal(t, []genesis.ImmutableChange{{
+		Name:   "OTHER_MESSENGER",
+		Before: messengerBefore.Hex(),
+		After:  messenger.Hex(),
+	}}, impl.Code.Immutables)
+
+	token := accounts[predeploys.GovernanceTokenAddr]
+	require.Equal(t, "GovernanceToken", token.Name)
+	require.Nil(t, token.Code)
+	require.Len(t, token.Storage, 1)
+	require.Equal(t, []state.StorageVariableChange{{
+		Label:  "_owner",
+		Type:   "address",
+		Before: ownerBefore.Hex(),
+		After:  owner.Hex(),
+	}}, token.Storage[0].Decoded)
+}
@@ -101,3 +101,20 @@ func TestBuildL2MainnetNoGovernanceGenesis(t *testing.T) {
 	gen := testBuildL2Genesis(t, config)
 	require.Equal(t, 2323, len(gen.Alloc))
 }
+
+func TestBuildL2GenesisRevertTrace(t *testing.T) {
+	config, err := genesis.NewDeployConfig("./testdata/test-deploy-config-devnet-l1.json")
+	require.Nil(t, err)
+
+	// replace the init code of the L2ERC721Bridge with code which reverts with 0xdeadbeef
+	bin := bindings.L2ERC721BridgeBin
+	bindings.L2ERC721BridgeBin = "0x63deadbeef6000526004601cfd"
+	t.Cleanup(func() { bindings.L2ERC721BridgeBin = bin })
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{}, 15000000)
+	block, err := backend.BlockByNumber(context.Background(), common.Big0)
+	require.NoError(t, err)
+
+	_, err = genesis.BuildL2Genesis(config, block)
+	require.ErrorContains(t, err, "L2ERC721Bridge: no contract code after deployment\nexecution reverted: 0xdeadbeef\n    at <unknown>.constructor [0x")
+}
@@ -0,0 +1,311 @@
+package srcmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/foundry"
+)
+
+// ContractsDir is the location of the contracts package within the monorepo
+var ContractsDir = filepath.Join("packages", "contracts-bedrock")
+
+type immutableReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// Contract is a compiled contract of the forge artifacts
+type Contract struct {
+	Name string
+	ABI  *abi.ABI
+
+	bytecode          []byte
+	sourceMap         string
+	deployedBytecode  []byte
+	deployedSourceMap string
+	// immutables are the ranges of the deployed bytecode that are set by the constructor
+	immutables []immutableReference
+
+	sources []string
+
+	parseOnce         sync.Once
+	srcMap            *SourceMap
+	deployedSrcMap    *SourceMap
+	srcMapErr         error
+	deployedSrcMapErr error
+}
+
+// SourceMap returns the source map of the creation or the deployed bytecode of the contract
+func (c *Contract) SourceMap(create bool) (*SourceMap, error) {
+	c.parseOnce.Do(func() {
+		c.srcMap, c.srcMapErr = ParseSourceMap(c.sources, c.bytecode, c.sourceMap)
+		c.deployedSrcMap, c.deployedSrcMapErr = ParseSourceMap(c.sources, c.deployedBytecode, c.deployedSourceMap)
+	})
+	if create {
+		return c.srcMap, c.srcMapErr
+	}
+	return c.deployedSrcMap, c.deployedSrcMapErr
+}
+
+// matchesDeployed returns whether the code is the deployed bytecode of the contract,
+// ignoring the immutables
+func (c *Contract) matchesDeployed(code []byte) bool {
+	if len(code) != len(c.deployedBytecode) {
+		return false
+	}
+	masked := common.CopyBytes(code)
+	for _, ref := range c.immutables {
+		if ref.Start+ref.Length > len(masked) {
+			return false
+		}
+		copy(masked[ref.Start:ref.Start+ref.Length], c.deployedBytecode[ref.Start:ref.Start+ref.Length])
+	}
+	return bytes.Equal(masked, c.deployedBytecode)
+}
+
+// matchesCreation returns whether the init code is the creation bytecode of the
+// contract, followed by the constructor arguments
+func (c *Contract) matchesCreation(code []byte) bool {
+	return len(c.bytecode) > 0 && bytes.HasPrefix(code, c.bytecode)
+}
+
+// Contracts is the set of contracts of the forge artifacts, to identify
+// contracts by their code
+type Contracts struct {
+	contracts []*Contract
+	errors    map[[4]byte]abi.Error
+
+	mu     sync.Mutex
+	byCode map[codeKey]*Contract
+}
+
+type codeKey struct {
+	hash   common.Hash
+	create bool
+}
+
+// LoadForgeArtifacts loads the contracts compiled into the forge-artifacts directory of the
+// contracts package. The source files are resolved through the build info of the compilation.
+func LoadForgeArtifacts(contractsDir string) (*Contracts, error) {
+	sources, err := readSourceIDs(filepath.Join(contractsDir, "artifacts", "build-info"), contractsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactsDir := filepath.Join(contractsDir, "forge-artifacts")
+	files, err := filepath.Glob(filepath.Join(artifactsDir, "*.sol", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no forge artifacts in %s", artifactsDir)
+	}
+
+	contracts := &Contracts{
+		errors: make(map[[4]byte]abi.Error),
+		byCode: make(map[codeKey]*Contract),
+	}
+	for _, file := range files {
+		contract, err := readContract(file, sources)
+		if err != nil {
+			// artifacts with unlinked libraries can't be decoded, and aren't deployed as such
+			log.Debug("Skipping forge artifact", "file", file, "err", err)
+			continue
+		}
+		if len(contract.deployedBytecode) == 0 {
+			continue
+		}
+		contracts.contracts = append(contracts.contracts, contract)
+		for _, abiErr := range contract.ABI.Errors {
+			var id [4]byte
+			copy(id[:], abiErr.ID[:4])
+			contracts.errors[id] = abiErr
+		}
+	}
+	return contracts, nil
+}
+
+func readContract(file string, sources []string) (*Contract, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var artifact foundry.Artifact
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, err
+	}
+	contractABI, err := abi.JSON(bytes.NewReader(artifact.Abi))
+	if err != nil {
+		return nil, err
+	}
+	var immutables map[string][]immutableReference
+	if len(artifact.DeployedBytecode.ImmutableReferences) > 0 {
+		if err := json.Unmarshal(artifact.DeployedBytecode.ImmutableReferences, &immutables); err != nil {
+			return nil, err
+		}
+	}
+	// artifacts are named <Contract>.json, or <Contract>.<version>.json when compiled with multiple compilers
+	name := strings.SplitN(filepath.Base(file), ".", 2)[0]
+	contract := &Contract{
+		Name:              name,
+		ABI:               &contractABI,
+		bytecode:          artifact.Bytecode.Object,
+		sourceMap:         artifact.Bytecode.SourceMap,
+		deployedBytecode:  artifact.DeployedBytecode.Object,
+		deployedSourceMap: artifact.DeployedBytecode.SourceMap,
+		sources:           sources,
+	}
+	for _, refs := range immutables {
+		contract.immutables = append(contract.immutables, refs...)
+	}
+	return contract, nil
+}
+
+// readSourceIDs reads the source files by source ID from the build info files. Files of
+// later compilations take precedence. Unknown sources are marked as unavailable.
+func readSourceIDs(buildInfoDir string, contractsDir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(buildInfoDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no build info in %s", buildInfoDir)
+	}
+	modTimes := make(map[string]int64)
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime().UnixNano()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return modTimes[files[i]] < modTimes[files[j]]
+	})
+
+	paths := make(map[int]string)
+	maxID := -1
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var buildInfo struct {
+			SourceIDToPath map[string]string `json:"source_id_to_path"`
+		}
+		if err := json.Unmarshal(data, &buildInfo); err != nil {
+			return nil, fmt.Errorf("failed to decode build info %s: %w", file, err)
+		}
+		for idStr, path := range buildInfo.SourceIDToPath {
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid source id %q in build info %s", idStr, file)
+			}
+			paths[id] = filepath.Join(contractsDir, path)
+			if id > maxID {
+				maxID = id
+			}
+		}
+	}
+
+	sources := make([]string, maxID+1)
+	for id := range sources {
+		if path, ok := paths[id]; ok {
+			sources[id] = path
+		} else {
+			sources[id] = "~unknown"
+		}
+	}
+	return sources, nil
+}
+
+// Lookup returns the contract with the code, which is init code if create is set,
+// or nil if the code doesn't belong to any of the contracts
+func (c *Contracts) Lookup(code []byte, create bool) *Contract {
+	if c == nil || len(code) == 0 {
+		return nil
+	}
+	key := codeKey{hash: crypto.Keccak256Hash(code), create: create}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if contract, ok := c.byCode[key]; ok {
+		return contract
+	}
+	var match *Contract
+	for _, contract := range c.contracts {
+		if (create && contract.matchesCreation(code)) || (!create && contract.matchesDeployed(code)) {
+			match = contract
+			break
+		}
+	}
+	c.byCode[key] = match
+	return match
+}
+
+// Error returns the custom error with the selector
+func (c *Contracts) Error(selector [4]byte) (abi.Error, bool) {
+	if c == nil {
+		return abi.Error{}, false
+	}
+	abiErr, ok := c.errors[selector]
+	return abiErr, ok
+}
+
+var (
+	defaultContractsOnce sync.Once
+	defaultContracts     *Contracts
+)
+
+// DefaultContracts loads the forge artifacts of the contracts package of the monorepo
+// containing the working directory. It returns nil if the artifacts aren't available,
+// such that revert traces are still produced, without source locations.
+func DefaultContracts() *Contracts {
+	defaultContractsOnce.Do(func() {
+		dir, err := findContractsDir()
+		if err != nil {
+			log.Warn("Contract artifacts are unavailable for revert traces", "err", err)
+			return
+		}
+		contracts, err := LoadForgeArtifacts(dir)
+		if err != nil {
+			log.Warn("Failed to load contract artifacts for revert traces", "dir", dir, "err", err)
+			return
+		}
+		defaultContracts = contracts
+	})
+	return defaultContracts
+}
+
+// findContractsDir searches the contracts package upwards from the working directory
+func findContractsDir() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		contractsDir := filepath.Join(dir, ContractsDir)
+		if _, err := os.Stat(filepath.Join(contractsDir, "forge-artifacts")); err == nil {
+			return contractsDir, nil
+		}
+		parentDir := filepath.Dir(dir)
+		// Check if we reached the filesystem root
+		if parentDir == dir {
+			return "", errors.New("forge artifacts not found, contracts must be built")
+		}
+		dir = parentDir
+	}
+}
@@ -0,0 +1,51 @@
+package srcmap
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+// fixtureDir holds the forge artifact of the PreimageOracle, with the build info and the source it was compiled from
+var fixtureDir = filepath.Join("testdata", "contracts")
+
+func TestLoadForgeArtifacts(t *testing.T) {
+	contracts, err := LoadForgeArtifacts(fixtureDir)
+	require.NoError(t, err)
+
+	deployed := hexutil.MustDecode(bindings.PreimageOracleDeployedBin)
+	contract := contracts.Lookup(deployed, false)
+	require.NotNil(t, contract)
+	require.Equal(t, "PreimageOracle", contract.Name)
+	require.Contains(t, contract.ABI.Methods, "readPreimage")
+
+	// init code is matched with its constructor arguments
+	initCode := append(hexutil.MustDecode(bindings.PreimageOracleMetaData.Bin), make([]byte, 32)...)
+	require.Equal(t, contract, contracts.Lookup(initCode, true))
+	require.Nil(t, contracts.Lookup(initCode, false))
+	require.Nil(t, contracts.Lookup(deployed[1:], false))
+
+	abiErr, ok := contracts.Error([4]byte(hexutil.MustDecode("0xfe254987")))
+	require.True(t, ok)
+	require.Equal(t, "PartOffsetOOB", abiErr.Name)
+
+	t.Run("SourceLines", func(t *testing.T) {
+		srcMap, err := contract.SourceMap(false)
+		require.NoError(t, err)
+		require.Len(t, srcMap.Instr, len(deployed))
+		// the function dispatcher is mapped to the contract definition
+		source, line, col := srcMap.Info(0)
+		require.Equal(t, filepath.Join(fixtureDir, "src", "cannon", "PreimageOracle.sol"), source)
+		require.Equal(t, uint32(10), line)
+		require.Equal(t, uint32(1), col)
+	})
+
+	t.Run("MissingArtifacts", func(t *testing.T) {
+		_, err := LoadForgeArtifacts(t.TempDir())
+		require.ErrorContains(t, err, "no build info")
+	})
+}
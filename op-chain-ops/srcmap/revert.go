@@ -0,0 +1,225 @@
+package srcmap
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// Frame is a call frame of a revert trace
+type Frame struct {
+	Address common.Address
+	// Create is set when the frame executes init code
+	Create bool
+	// Contract is the name of the contract, or empty if the code is unknown
+	Contract string
+	// Function is the name of the called function, "constructor" for init code
+	Function string
+	Source   string
+	Line     uint32
+	Col      uint32
+}
+
+func (f Frame) String() string {
+	name := "<unknown>"
+	if f.Contract != "" {
+		name = f.Contract
+	}
+	if f.Function != "" {
+		name += "." + f.Function
+	}
+	if f.Source == "" {
+		return fmt.Sprintf("%s [%s]", name, f.Address)
+	}
+	return fmt.Sprintf("%s (%s:%d:%d) [%s]", name, f.Source, f.Line, f.Col, f.Address)
+}
+
+// RevertTrace is the call stack at the point where an execution reverted
+type RevertTrace struct {
+	// Frames are ordered from the frame that reverted to the outermost frame
+	Frames []Frame
+	// Error is the decoded revert data
+	Error string
+}
+
+func (t *RevertTrace) String() string {
+	var b strings.Builder
+	b.WriteString(t.Error)
+	for _, frame := range t.Frames {
+		fmt.Fprintf(&b, "\n    at %s", frame)
+	}
+	return b.String()
+}
+
+type tracerFrame struct {
+	address common.Address
+	create  bool
+	input   []byte
+	code    []byte
+	pc      uint64
+}
+
+// RevertTracer records the Solidity call stack of the origin of a revert. A revert is
+// attributed to the innermost frame that reverted, as long as the revert data is
+// propagated unchanged by the frames calling it. Reverts caught by a calling frame
+// are discarded.
+type RevertTracer struct {
+	contracts *Contracts
+
+	frames     []*tracerFrame
+	revertData []byte
+	trace      *RevertTrace
+}
+
+// NewRevertTracer creates a tracer which identifies the contracts with the given artifacts.
+// The contracts may be nil, the trace then only lists the addresses of the frames.
+func NewRevertTracer(contracts *Contracts) *RevertTracer {
+	return &RevertTracer{contracts: contracts}
+}
+
+// RevertTrace returns the trace of the revert of the last traced execution,
+// or nil if it did not revert
+func (t *RevertTracer) RevertTrace() *RevertTrace {
+	return t.trace
+}
+
+func (t *RevertTracer) CaptureTxStart(gasLimit uint64) {}
+
+func (t *RevertTracer) CaptureTxEnd(restGas uint64) {}
+
+func (t *RevertTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.frames = t.frames[:0]
+	t.revertData = nil
+	t.trace = nil
+	t.enter(to, create, input)
+}
+
+func (t *RevertTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exit(output, err)
+}
+
+func (t *RevertTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.enter(to, typ == vm.CREATE || typ == vm.CREATE2, input)
+}
+
+func (t *RevertTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(output, err)
+}
+
+func (t *RevertTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	frame.pc = pc
+	frame.code = scope.Contract.Code
+}
+
+func (t *RevertTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+func (t *RevertTracer) enter(to common.Address, create bool, input []byte) {
+	t.frames = append(t.frames, &tracerFrame{
+		address: to,
+		create:  create,
+		input:   common.CopyBytes(input),
+	})
+}
+
+func (t *RevertTracer) exit(output []byte, err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	switch {
+	case err == nil:
+		// a revert of a deeper frame was caught
+		t.revertData = nil
+		t.trace = nil
+	case t.trace != nil && bytes.Equal(output, t.revertData):
+		// the revert of a deeper frame is propagated
+	default:
+		t.revertData = common.CopyBytes(output)
+		t.trace = t.snapshot(output, err)
+	}
+	t.frames = t.frames[:len(t.frames)-1]
+}
+
+// snapshot resolves the frames of the call stack to their source locations
+func (t *RevertTracer) snapshot(output []byte, err error) *RevertTrace {
+	trace := &RevertTrace{
+		Frames: make([]Frame, 0, len(t.frames)),
+		Error:  t.decodeError(output, err),
+	}
+	for i := len(t.frames) - 1; i >= 0; i-- {
+		trace.Frames = append(trace.Frames, t.resolve(t.frames[i]))
+	}
+	return trace
+}
+
+func (t *RevertTracer) resolve(tf *tracerFrame) Frame {
+	frame := Frame{Address: tf.address, Create: tf.create}
+	if tf.create {
+		frame.Function = "constructor"
+	}
+	contract := t.contracts.Lookup(tf.code, tf.create)
+	if contract == nil {
+		return frame
+	}
+	frame.Contract = contract.Name
+	if !tf.create && len(tf.input) >= 4 {
+		if method, err := contract.ABI.MethodById(tf.input[:4]); err == nil {
+			frame.Function = method.Name
+		}
+	}
+	srcMap, err := contract.SourceMap(tf.create)
+	if err != nil || tf.pc >= uint64(len(srcMap.Instr)) {
+		return frame
+	}
+	frame.Source, frame.Line, frame.Col = srcMap.Info(tf.pc)
+	return frame
+}
+
+// decodeError formats the revert data as a revert reason, a panic, or a custom error
+// of the contracts
+func (t *RevertTracer) decodeError(output []byte, err error) string {
+	if len(output) == 0 {
+		return err.Error()
+	}
+	if reason, unpackErr := abi.UnpackRevert(output); unpackErr == nil {
+		return fmt.Sprintf("%v: %s", err, reason)
+	}
+	if len(output) >= 4 {
+		var selector [4]byte
+		copy(selector[:], output[:4])
+		if abiErr, ok := t.contracts.Error(selector); ok {
+			if values, unpackErr := abiErr.Unpack(output); unpackErr == nil {
+				return fmt.Sprintf("%v: %s", err, formatError(abiErr, values))
+			}
+		}
+	}
+	return fmt.Sprintf("%v: %s", err, hexutil.Encode(output))
+}
+
+func formatError(abiErr abi.Error, values interface{}) string {
+	args, _ := values.([]interface{})
+	formatted := make([]string, len(args))
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case []byte:
+			formatted[i] = hexutil.Encode(v)
+		case [32]byte:
+			formatted[i] = hexutil.Encode(v[:])
+		default:
+			formatted[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return fmt.Sprintf("%s(%s)", abiErr.Name, strings.Join(formatted, ", "))
+}
+
+var _ vm.EVMLogger = (*RevertTracer)(nil)
@@ -0,0 +1,116 @@
+package srcmap
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+var (
+	reverter   = common.HexToAddress("0x1000")
+	propagator = common.HexToAddress("0x2000")
+	catcher    = common.HexToAddress("0x3000")
+	oracle     = common.HexToAddress("0x4000")
+
+	// revert(0xdeadbeef)
+	reverterCode = hexutil.MustDecode("0x63deadbeef6000526004601cfd")
+	// call(reverter), revert with its return data
+	propagatorCode = hexutil.MustDecode("0x600060006000600060007300000000000000000000000000000000000010005af13d600060003e3d6000fd")
+	// call(reverter), stop
+	catcherCode = append(hexutil.MustDecode("0x600060006000600060007300000000000000000000000000000000000010005af1"), 0x00)
+)
+
+func traceCall(t *testing.T, to common.Address) *RevertTrace {
+	return traceCallWith(t, nil, to, []byte{0x12, 0x34, 0x56, 0x78})
+}
+
+func traceCallWith(t *testing.T, contracts *Contracts, to common.Address, input []byte) *RevertTrace {
+	db, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	db.SetCode(reverter, reverterCode)
+	db.SetCode(propagator, propagatorCode)
+	db.SetCode(catcher, catcherCode)
+	db.SetCode(oracle, hexutil.MustDecode(bindings.PreimageOracleDeployedBin))
+
+	tracer := NewRevertTracer(contracts)
+	_, _, _ = runtime.Call(to, input, &runtime.Config{
+		State:     db,
+		EVMConfig: vm.Config{Tracer: tracer},
+	})
+	return tracer.RevertTrace()
+}
+
+func TestRevertTracer(t *testing.T) {
+	t.Run("Propagated", func(t *testing.T) {
+		trace := traceCall(t, propagator)
+		require.NotNil(t, trace)
+		require.Equal(t, "execution reverted: 0xdeadbeef", trace.Error)
+		require.Equal(t, []Frame{{Address: reverter}, {Address: propagator}}, trace.Frames)
+	})
+
+	t.Run("Caught", func(t *testing.T) {
+		require.Nil(t, traceCall(t, catcher))
+	})
+
+	t.Run("Direct", func(t *testing.T) {
+		trace := traceCall(t, reverter)
+		require.NotNil(t, trace)
+		require.Equal(t, []Frame{{Address: reverter}}, trace.Frames)
+	})
+}
+
+func TestRevertTracerSourceLocations(t *testing.T) {
+	contracts, err := LoadForgeArtifacts(fixtureDir)
+	require.NoError(t, err)
+	oracleABI, err := bindings.PreimageOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	source := filepath.Join(fixtureDir, "src", "cannon", "PreimageOracle.sol")
+
+	t.Run("RevertReason", func(t *testing.T) {
+		input, err := oracleABI.Pack("readPreimage", common.Hash{0x01}, common.Big0)
+		require.NoError(t, err)
+		trace := traceCallWith(t, contracts, oracle, input)
+		require.NotNil(t, trace)
+		require.Equal(t, "execution reverted: pre-image must exist", trace.Error)
+		require.Len(t, trace.Frames, 1)
+		frame := trace.Frames[0]
+		require.Equal(t, "PreimageOracle", frame.Contract)
+		require.Equal(t, "readPreimage", frame.Function)
+		require.Equal(t, source, frame.Source)
+		require.Equal(t, uint32(20), frame.Line)
+		require.Equal(t, fmt.Sprintf("PreimageOracle.readPreimage (%s:20:%d) [%s]", source, frame.Col, oracle), frame.String())
+	})
+
+	t.Run("CustomError", func(t *testing.T) {
+		// the part offset is beyond the size of the local data
+		input, err := oracleABI.Pack("loadLocalData", common.Big1, common.Hash{}, common.Hash{}, big.NewInt(32), big.NewInt(41))
+		require.NoError(t, err)
+		trace := traceCallWith(t, contracts, oracle, input)
+		require.NotNil(t, trace)
+		require.Equal(t, "execution reverted: PartOffsetOOB()", trace.Error)
+		require.Len(t, trace.Frames, 1)
+		require.Equal(t, "loadLocalData", trace.Frames[0].Function)
+		require.Equal(t, uint32(50), trace.Frames[0].Line)
+	})
+
+	t.Run("UnknownError", func(t *testing.T) {
+		input, err := oracleABI.Pack("loadLocalData", common.Big1, common.Hash{}, common.Hash{}, big.NewInt(32), big.NewInt(41))
+		require.NoError(t, err)
+		trace := traceCallWith(t, nil, oracle, input)
+		require.NotNil(t, trace)
+		require.Equal(t, "execution reverted: 0xfe254987", trace.Error)
+		require.Equal(t, []Frame{{Address: oracle}}, trace.Frames)
+	})
+}
@@ -173,6 +173,7 @@ func ParseSourceMap(sources []string, bytecode []byte, sourceMap string) (*Sourc
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse instr element in source map: %w", err)
 		}
+		lastInstr = m
 
 		for j := 0; j < instLen; j++ {
 			srcMap.Instr = append(srcMap.Instr, m)
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/solc"
+)
+
+// DecodedStorage is a variable decoded from a storage slot
+type DecodedStorage struct {
+	Label string
+	Type  string
+	Value string
+}
+
+// StorageVariableChange is a change of a variable decoded from a storage slot
+type StorageVariableChange struct {
+	Label  string `json:"label"`
+	Type   string `json:"type"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// DecodeStorageChange decodes the variables that the storage layout places in the
+// slot, and returns the ones whose value differs between the slot values
+func DecodeStorageChange(layout *solc.StorageLayout, slot common.Hash, before common.Hash, after common.Hash) []StorageVariableChange {
+	decodedBefore := DecodeStorageSlot(layout, slot, before)
+	decodedAfter := DecodeStorageSlot(layout, slot, after)
+	var changes []StorageVariableChange
+	for i := range decodedAfter {
+		if decodedBefore[i].Value == decodedAfter[i].Value {
+			continue
+		}
+		changes = append(changes, StorageVariableChange{
+			Label:  decodedAfter[i].Label,
+			Type:   decodedAfter[i].Type,
+			Before: decodedBefore[i].Value,
+			After:  decodedAfter[i].Value,
+		})
+	}
+	return changes
+}
+
+// DecodeStorageSlot decodes the variables that the storage layout places in the
+// slot. A slot may hold multiple tightly packed variables. Only variables stored
+// inplace are decoded, so no values are returned for the slots of mappings and
+// dynamic arrays, which are located at hashed keys.
+func DecodeStorageSlot(layout *solc.StorageLayout, slot common.Hash, value common.Hash) []DecodedStorage {
+	var decoded []DecodedStorage
+	for _, entry := range layout.Storage {
+		if encodeSlotKey(entry) != slot {
+			continue
+		}
+		storageType, ok := layout.Types[entry.Type]
+		if !ok || storageType.Encoding != "inplace" {
+			continue
+		}
+		// values larger than a slot are structs and static arrays, their
+		// members aren't part of the layout
+		if storageType.NumberOfBytes == 0 || entry.Offset+storageType.NumberOfBytes > 32 {
+			continue
+		}
+		end := 32 - entry.Offset
+		data := value[end-storageType.NumberOfBytes : end]
+		decoded = append(decoded, DecodedStorage{
+			Label: entry.Label,
+			Type:  storageType.Label,
+			Value: decodeValue(storageType.Label, data),
+		})
+	}
+	return decoded
+}
+
+// decodeValue formats the bytes of a value based on its solidity type label
+func decodeValue(label string, data []byte) string {
+	switch {
+	case label == "bool":
+		return strconv.FormatBool(new(big.Int).SetBytes(data).Sign() != 0)
+	case label == "address", strings.HasPrefix(label, "contract "):
+		return common.BytesToAddress(data).Hex()
+	case strings.HasPrefix(label, "uint"), strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(data).String()
+	case strings.HasPrefix(label, "int"):
+		n := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(data))*8))
+		}
+		return n.String()
+	default:
+		return hexutil.Encode(data)
+	}
+}
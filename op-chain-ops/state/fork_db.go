@@ -0,0 +1,374 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+var _ vm.StateDB = (*ForkStateDB)(nil)
+
+// ForkSource serves the state of the forked chain
+type ForkSource interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+type forkAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+}
+
+func (a *forkAccount) empty() bool {
+	return a.balance.Sign() == 0 && a.nonce == 0 && len(a.code) == 0
+}
+
+// ForkStateDB implements geth's StateDB interface on top of a remote chain.
+// Accounts and storage slots are fetched from the ForkSource the first time
+// they are accessed and copied into an in-memory state, such that execution
+// only modifies the local copy.
+//
+// The copies are recorded in the journal of the in-memory state, so the
+// revision at which each copy happened is tracked to copy it again when
+// a revert undoes it. Errors of the ForkSource can't be returned through
+// the StateDB interface, the first one is retained and returned by Error.
+type ForkStateDB struct {
+	*gstate.StateDB
+
+	ctx         context.Context
+	source      ForkSource
+	blockNumber *big.Int
+
+	originAccounts map[common.Address]*forkAccount
+	originStorage  map[common.Address]map[common.Hash]common.Hash
+
+	// loadedAccounts and loadedSlots hold the revision at which an account or slot was
+	// copied, -1 if the copy was made outside of any revision of the current transaction
+	loadedAccounts map[common.Address]int
+	loadedSlots    map[common.Address]map[common.Hash]int
+	// pendingSlots are the slots copied in the current transaction. Their committed
+	// value is the origin value, as the copy is only committed when finalising.
+	pendingSlots map[common.Address]map[common.Hash]struct{}
+	revision     int
+
+	err error
+}
+
+// NewForkStateDB creates a ForkStateDB forking the state of the source at the block number
+func NewForkStateDB(ctx context.Context, source ForkSource, blockNumber *big.Int) (*ForkStateDB, error) {
+	db, err := gstate.New(types.EmptyRootHash, gstate.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ForkStateDB{
+		StateDB:        db,
+		ctx:            ctx,
+		source:         source,
+		blockNumber:    blockNumber,
+		originAccounts: make(map[common.Address]*forkAccount),
+		originStorage:  make(map[common.Address]map[common.Hash]common.Hash),
+		loadedAccounts: make(map[common.Address]int),
+		loadedSlots:    make(map[common.Address]map[common.Hash]int),
+		pendingSlots:   make(map[common.Address]map[common.Hash]struct{}),
+		revision:       -1,
+	}, nil
+}
+
+// Error returns the first error encountered while fetching state from the ForkSource
+func (db *ForkStateDB) Error() error {
+	if db.err != nil {
+		return db.err
+	}
+	return db.StateDB.Error()
+}
+
+func (db *ForkStateDB) setError(err error) {
+	if db.err == nil {
+		db.err = err
+	}
+}
+
+func (db *ForkStateDB) originAccount(addr common.Address) (*forkAccount, error) {
+	if acc, ok := db.originAccounts[addr]; ok {
+		return acc, nil
+	}
+	balance, err := db.source.BalanceAt(db.ctx, addr, db.blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch balance of %s: %w", addr, err)
+	}
+	nonce, err := db.source.NonceAt(db.ctx, addr, db.blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nonce of %s: %w", addr, err)
+	}
+	code, err := db.source.CodeAt(db.ctx, addr, db.blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch code of %s: %w", addr, err)
+	}
+	acc := &forkAccount{balance: balance, nonce: nonce, code: code}
+	db.originAccounts[addr] = acc
+	return acc, nil
+}
+
+func (db *ForkStateDB) originSlot(addr common.Address, key common.Hash) (common.Hash, error) {
+	if value, ok := db.originStorage[addr][key]; ok {
+		return value, nil
+	}
+	acc, err := db.originAccount(addr)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var value common.Hash
+	// empty accounts have no storage, which saves fetching the slots of created contracts
+	if !acc.empty() {
+		data, err := db.source.StorageAt(db.ctx, addr, key, db.blockNumber)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch storage slot %s of %s: %w", key, addr, err)
+		}
+		value = common.BytesToHash(data)
+	}
+	if db.originStorage[addr] == nil {
+		db.originStorage[addr] = make(map[common.Hash]common.Hash)
+	}
+	db.originStorage[addr][key] = value
+	return value, nil
+}
+
+func (db *ForkStateDB) loadAccount(addr common.Address) {
+	if _, ok := db.loadedAccounts[addr]; ok {
+		return
+	}
+	acc, err := db.originAccount(addr)
+	if err != nil {
+		db.setError(err)
+		return
+	}
+	// only copy non-empty fields, to not create accounts which don't exist on the forked chain
+	if acc.balance.Sign() != 0 {
+		db.StateDB.SetBalance(addr, acc.balance)
+	}
+	if acc.nonce != 0 {
+		db.StateDB.SetNonce(addr, acc.nonce)
+	}
+	if len(acc.code) != 0 {
+		db.StateDB.SetCode(addr, acc.code)
+	}
+	db.loadedAccounts[addr] = db.revision
+}
+
+func (db *ForkStateDB) loadSlot(addr common.Address, key common.Hash) {
+	db.loadAccount(addr)
+	if _, ok := db.loadedSlots[addr][key]; ok {
+		return
+	}
+	value, err := db.originSlot(addr, key)
+	if err != nil {
+		db.setError(err)
+		return
+	}
+	if value != (common.Hash{}) {
+		db.StateDB.SetState(addr, key, value)
+	}
+	if db.loadedSlots[addr] == nil {
+		db.loadedSlots[addr] = make(map[common.Hash]int)
+	}
+	db.loadedSlots[addr][key] = db.revision
+	if db.pendingSlots[addr] == nil {
+		db.pendingSlots[addr] = make(map[common.Hash]struct{})
+	}
+	db.pendingSlots[addr][key] = struct{}{}
+}
+
+func (db *ForkStateDB) CreateAccount(addr common.Address) {
+	db.loadAccount(addr)
+	db.StateDB.CreateAccount(addr)
+}
+
+func (db *ForkStateDB) SubBalance(addr common.Address, amount *big.Int) {
+	db.loadAccount(addr)
+	db.StateDB.SubBalance(addr, amount)
+}
+
+func (db *ForkStateDB) AddBalance(addr common.Address, amount *big.Int) {
+	db.loadAccount(addr)
+	db.StateDB.AddBalance(addr, amount)
+}
+
+func (db *ForkStateDB) GetBalance(addr common.Address) *big.Int {
+	db.loadAccount(addr)
+	return db.StateDB.GetBalance(addr)
+}
+
+func (db *ForkStateDB) GetNonce(addr common.Address) uint64 {
+	db.loadAccount(addr)
+	return db.StateDB.GetNonce(addr)
+}
+
+func (db *ForkStateDB) SetNonce(addr common.Address, nonce uint64) {
+	db.loadAccount(addr)
+	db.StateDB.SetNonce(addr, nonce)
+}
+
+func (db *ForkStateDB) GetCodeHash(addr common.Address) common.Hash {
+	db.loadAccount(addr)
+	return db.StateDB.GetCodeHash(addr)
+}
+
+func (db *ForkStateDB) GetCode(addr common.Address) []byte {
+	db.loadAccount(addr)
+	return db.StateDB.GetCode(addr)
+}
+
+func (db *ForkStateDB) SetCode(addr common.Address, code []byte) {
+	db.loadAccount(addr)
+	db.StateDB.SetCode(addr, code)
+}
+
+func (db *ForkStateDB) GetCodeSize(addr common.Address) int {
+	db.loadAccount(addr)
+	return db.StateDB.GetCodeSize(addr)
+}
+
+func (db *ForkStateDB) GetCommittedState(addr common.Address, key common.Hash) common.Hash {
+	db.loadSlot(addr, key)
+	if _, ok := db.pendingSlots[addr][key]; ok {
+		return db.originStorage[addr][key]
+	}
+	return db.StateDB.GetCommittedState(addr, key)
+}
+
+func (db *ForkStateDB) GetState(addr common.Address, key common.Hash) common.Hash {
+	db.loadSlot(addr, key)
+	return db.StateDB.GetState(addr, key)
+}
+
+func (db *ForkStateDB) SetState(addr common.Address, key, value common.Hash) {
+	db.loadSlot(addr, key)
+	db.StateDB.SetState(addr, key, value)
+}
+
+func (db *ForkStateDB) SelfDestruct(addr common.Address) {
+	db.loadAccount(addr)
+	db.StateDB.SelfDestruct(addr)
+}
+
+func (db *ForkStateDB) HasSelfDestructed(addr common.Address) bool {
+	db.loadAccount(addr)
+	return db.StateDB.HasSelfDestructed(addr)
+}
+
+func (db *ForkStateDB) Selfdestruct6780(addr common.Address) {
+	db.loadAccount(addr)
+	db.StateDB.Selfdestruct6780(addr)
+}
+
+func (db *ForkStateDB) Exist(addr common.Address) bool {
+	db.loadAccount(addr)
+	return db.StateDB.Exist(addr)
+}
+
+func (db *ForkStateDB) Empty(addr common.Address) bool {
+	db.loadAccount(addr)
+	return db.StateDB.Empty(addr)
+}
+
+func (db *ForkStateDB) Snapshot() int {
+	db.revision = db.StateDB.Snapshot()
+	return db.revision
+}
+
+func (db *ForkStateDB) RevertToSnapshot(revid int) {
+	db.StateDB.RevertToSnapshot(revid)
+	// the copies made since the snapshot are undone, forget them to copy them again on access
+	for addr, rev := range db.loadedAccounts {
+		if rev >= revid {
+			delete(db.loadedAccounts, addr)
+		}
+	}
+	for _, slots := range db.loadedSlots {
+		for key, rev := range slots {
+			if rev >= revid {
+				delete(slots, key)
+			}
+		}
+	}
+}
+
+// Finalise finalises the state at the end of a transaction, committing the copies made during it
+func (db *ForkStateDB) Finalise(deleteEmptyObjects bool) {
+	db.StateDB.Finalise(deleteEmptyObjects)
+	for addr := range db.loadedAccounts {
+		db.loadedAccounts[addr] = -1
+	}
+	for _, slots := range db.loadedSlots {
+		for key := range slots {
+			slots[key] = -1
+		}
+	}
+	db.pendingSlots = make(map[common.Address]map[common.Hash]struct{})
+	db.revision = -1
+}
+
+// StorageDiff is a change of a storage slot
+type StorageDiff struct {
+	Before common.Hash
+	After  common.Hash
+}
+
+// AccountDiff is a change of an account compared to the forked chain
+type AccountDiff struct {
+	Address       common.Address
+	BalanceBefore *big.Int
+	BalanceAfter  *big.Int
+	NonceBefore   uint64
+	NonceAfter    uint64
+	CodeBefore    []byte
+	CodeAfter     []byte
+	Storage       map[common.Hash]StorageDiff
+}
+
+// Diff returns the accounts changed compared to the forked chain, sorted by address
+func (db *ForkStateDB) Diff() []AccountDiff {
+	addrs := make([]common.Address, 0, len(db.originAccounts))
+	for addr := range db.originAccounts {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+
+	var diffs []AccountDiff
+	for _, addr := range addrs {
+		origin := db.originAccounts[addr]
+		diff := AccountDiff{
+			Address:       addr,
+			BalanceBefore: origin.balance,
+			BalanceAfter:  db.GetBalance(addr),
+			NonceBefore:   origin.nonce,
+			NonceAfter:    db.GetNonce(addr),
+			CodeBefore:    origin.code,
+			CodeAfter:     db.GetCode(addr),
+			Storage:       make(map[common.Hash]StorageDiff),
+		}
+		for key, before := range db.originStorage[addr] {
+			if after := db.GetState(addr, key); after != before {
+				diff.Storage[key] = StorageDiff{Before: before, After: after}
+			}
+		}
+		if diff.BalanceBefore.Cmp(diff.BalanceAfter) != 0 || diff.NonceBefore != diff.NonceAfter ||
+			!bytes.Equal(diff.CodeBefore, diff.CodeAfter) || len(diff.Storage) != 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,144 @@
+package state_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+)
+
+type forkAccount struct {
+	balance *big.Int
+	nonce   uint64
+	code    []byte
+	storage map[common.Hash]common.Hash
+}
+
+type testForkSource struct {
+	accounts map[common.Address]*forkAccount
+	requests int
+}
+
+func (s *testForkSource) account(addr common.Address) *forkAccount {
+	s.requests++
+	if acc, ok := s.accounts[addr]; ok {
+		return acc
+	}
+	return &forkAccount{balance: new(big.Int)}
+}
+
+func (s *testForkSource) BalanceAt(ctx context.Context, addr common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return s.account(addr).balance, nil
+}
+
+func (s *testForkSource) NonceAt(ctx context.Context, addr common.Address, blockNumber *big.Int) (uint64, error) {
+	return s.account(addr).nonce, nil
+}
+
+func (s *testForkSource) CodeAt(ctx context.Context, addr common.Address, blockNumber *big.Int) ([]byte, error) {
+	return s.account(addr).code, nil
+}
+
+func (s *testForkSource) StorageAt(ctx context.Context, addr common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return s.account(addr).storage[key].Bytes(), nil
+}
+
+var (
+	forkContract = common.HexToAddress("0x1234")
+	forkKey      = common.HexToHash("0x01")
+	forkValue    = common.HexToHash("0xaa")
+)
+
+func newTestForkStateDB(t *testing.T) (*state.ForkStateDB, *testForkSource) {
+	source := &testForkSource{
+		accounts: map[common.Address]*forkAccount{
+			forkContract: {
+				balance: big.NewInt(100),
+				nonce:   1,
+				code:    []byte{0x60, 0x00},
+				storage: map[common.Hash]common.Hash{forkKey: forkValue},
+			},
+		},
+	}
+	db, err := state.NewForkStateDB(context.Background(), source, big.NewInt(10))
+	require.NoError(t, err)
+	return db, source
+}
+
+func TestForkStateDBLoads(t *testing.T) {
+	db, source := newTestForkStateDB(t)
+
+	require.Equal(t, big.NewInt(100), db.GetBalance(forkContract))
+	require.Equal(t, uint64(1), db.GetNonce(forkContract))
+	require.Equal(t, []byte{0x60, 0x00}, db.GetCode(forkContract))
+	require.Equal(t, forkValue, db.GetState(forkContract, forkKey))
+	require.Equal(t, 4, source.requests)
+
+	// accounts and slots are fetched once
+	require.Equal(t, forkValue, db.GetState(forkContract, forkKey))
+	require.Equal(t, big.NewInt(100), db.GetBalance(forkContract))
+	require.Equal(t, 4, source.requests)
+
+	// accounts which don't exist on the forked chain aren't created
+	missing := common.HexToAddress("0x5678")
+	require.False(t, db.Exist(missing))
+	require.Equal(t, common.Hash{}, db.GetState(missing, forkKey))
+	require.False(t, db.Exist(missing))
+	require.NoError(t, db.Error())
+}
+
+func TestForkStateDBRevert(t *testing.T) {
+	db, _ := newTestForkStateDB(t)
+
+	snapshot := db.Snapshot()
+	db.SetState(forkContract, forkKey, common.HexToHash("0xbb"))
+	db.AddBalance(forkContract, big.NewInt(1))
+	db.RevertToSnapshot(snapshot)
+
+	// the copies made after the snapshot are undone, and made again
+	require.Equal(t, forkValue, db.GetState(forkContract, forkKey))
+	require.Equal(t, big.NewInt(100), db.GetBalance(forkContract))
+	require.Empty(t, db.Diff())
+}
+
+func TestForkStateDBCommittedState(t *testing.T) {
+	db, _ := newTestForkStateDB(t)
+
+	db.SetState(forkContract, forkKey, common.HexToHash("0xbb"))
+	require.Equal(t, forkValue, db.GetCommittedState(forkContract, forkKey))
+	require.Equal(t, common.HexToHash("0xbb"), db.GetState(forkContract, forkKey))
+
+	db.Finalise(true)
+	require.Equal(t, common.HexToHash("0xbb"), db.GetCommittedState(forkContract, forkKey))
+}
+
+func TestForkStateDBDiff(t *testing.T) {
+	db, _ := newTestForkStateDB(t)
+
+	created := common.HexToAddress("0x5678")
+	db.CreateAccount(created)
+	db.SetCode(created, []byte{0x01})
+	db.SetState(created, forkKey, forkValue)
+	db.SetState(forkContract, forkKey, common.HexToHash("0xbb"))
+	db.SetState(forkContract, common.HexToHash("0x02"), common.Hash{})
+	db.Finalise(true)
+
+	diff := db.Diff()
+	require.Len(t, diff, 2)
+
+	require.Equal(t, forkContract, diff[0].Address)
+	require.Equal(t, map[common.Hash]state.StorageDiff{
+		forkKey: {Before: forkValue, After: common.HexToHash("0xbb")},
+	}, diff[0].Storage)
+
+	require.Equal(t, created, diff[1].Address)
+	require.Empty(t, diff[1].CodeBefore)
+	require.Equal(t, []byte{0x01}, diff[1].CodeAfter)
+	require.Equal(t, map[common.Hash]state.StorageDiff{
+		forkKey: {Before: common.Hash{}, After: forkValue},
+	}, diff[1].Storage)
+}
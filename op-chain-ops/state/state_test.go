@@ -473,3 +473,56 @@ func TestEncodeStringValue(t *testing.T) {
 		require.Equal(t, got, test.expect)
 	}
 }
+
+func TestDecodeStorageSlot(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		slot     common.Hash
+		value    common.Hash
+		expected []state.DecodedStorage
+	}{
+		{
+			slot:  common.Hash{},
+			value: common.HexToHash("0x000000000000000000000000ff00000000000000000000000000000000000001"),
+			expected: []state.DecodedStorage{
+				{Label: "_address", Type: "address", Value: common.HexToAddress("0xff00000000000000000000000000000000000001").Hex()},
+			},
+		},
+		{
+			slot:     common.BigToHash(big.NewInt(1)),
+			value:    common.HexToHash("0x01"),
+			expected: nil,
+		},
+		{
+			slot:  common.BigToHash(big.NewInt(2)),
+			value: common.HexToHash("0x01"),
+			expected: []state.DecodedStorage{
+				{Label: "_bool", Type: "bool", Value: "true"},
+			},
+		},
+		{
+			slot:  common.BigToHash(big.NewInt(4)),
+			value: common.HexToHash("0x000000000000000000000000000000090000000000000007050607080304ff01"),
+			expected: []state.DecodedStorage{
+				{Label: "offset0", Type: "uint8", Value: "1"},
+				{Label: "offset1", Type: "uint8", Value: "255"},
+				{Label: "offset2", Type: "uint16", Value: "772"},
+				{Label: "offset3", Type: "uint32", Value: "84281096"},
+				{Label: "offset4", Type: "uint64", Value: "7"},
+				{Label: "offset5", Type: "uint128", Value: "9"},
+			},
+		},
+		{
+			slot:  common.BigToHash(big.NewInt(5)),
+			value: common.HexToHash("0xaa"),
+			expected: []state.DecodedStorage{
+				{Label: "_bytes32", Type: "bytes32", Value: common.HexToHash("0xaa").Hex()},
+			},
+		},
+	}
+
+	for _, test := range cases {
+		require.Equal(t, test.expected, state.DecodeStorageSlot(&layout, test.slot, test.value), test.slot)
+	}
+}
@@ -2,6 +2,7 @@ package upgrades
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,7 +15,7 @@ import (
 
 // CheckL1 will check that the versions of the contracts on L1 match the versions
 // in the superchain registry.
-func CheckL1(ctx context.Context, list *superchain.ImplementationList, backend bind.ContractBackend) error {
+func CheckL1(ctx context.Context, list *superchain.ImplementationList, backend bind.ContractCaller) error {
 	if err := CheckVersionedContract(ctx, list.L1CrossDomainMessenger, backend); err != nil {
 		return fmt.Errorf("L1CrossDomainMessenger: %w", err)
 	}
@@ -41,7 +42,7 @@ func CheckL1(ctx context.Context, list *superchain.ImplementationList, backend b
 
 // CheckVersionedContract will check that the version of the deployed contract matches
 // the artifact in the superchain registry.
-func CheckVersionedContract(ctx context.Context, contract superchain.VersionedContract, backend bind.ContractBackend) error {
+func CheckVersionedContract(ctx context.Context, contract superchain.VersionedContract, backend bind.ContractCaller) error {
 	addr := common.HexToAddress(contract.Address.String())
 	code, err := backend.CodeAt(ctx, addr, nil)
 	if err != nil {
@@ -60,8 +61,32 @@ func CheckVersionedContract(ctx context.Context, contract superchain.VersionedCo
 	return nil
 }
 
+// CheckL1Upgraded will check that the versions of the proxies on L1 match the versions
+// in the superchain registry, which is the case after the upgrade to the implementations
+// in the registry. All mismatches are returned.
+func CheckL1Upgraded(ctx context.Context, list *superchain.ImplementationList, addresses *superchain.AddressList, chainConfig *superchain.ChainConfig, backend bind.ContractCaller) error {
+	versions, err := GetContractVersions(ctx, addresses, chainConfig, backend)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	check := func(name string, version string, contract superchain.VersionedContract) {
+		if !cmpVersion(version, contract.Version) {
+			errs = append(errs, fmt.Errorf("%s: version mismatch: expected %s, got %s", name, contract.Version, version))
+		}
+	}
+	check("L1CrossDomainMessenger", versions.L1CrossDomainMessenger, list.L1CrossDomainMessenger)
+	check("L1ERC721Bridge", versions.L1ERC721Bridge, list.L1ERC721Bridge)
+	check("L1StandardBridge", versions.L1StandardBridge, list.L1StandardBridge)
+	check("L2OutputOracle", versions.L2OutputOracle, list.L2OutputOracle)
+	check("OptimismMintableERC20Factory", versions.OptimismMintableERC20Factory, list.OptimismMintableERC20Factory)
+	check("OptimismPortal", versions.OptimismPortal, list.OptimismPortal)
+	check("SystemConfig", versions.SystemConfig, list.SystemConfig)
+	return errors.Join(errs...)
+}
+
 // getContractVersions will fetch the versions of all of the contracts.
-func GetContractVersions(ctx context.Context, addresses *superchain.AddressList, chainConfig *superchain.ChainConfig, backend bind.ContractBackend) (superchain.ContractVersions, error) {
+func GetContractVersions(ctx context.Context, addresses *superchain.AddressList, chainConfig *superchain.ChainConfig, backend bind.ContractCaller) (superchain.ContractVersions, error) {
 	var versions superchain.ContractVersions
 	var err error
 
@@ -97,8 +122,8 @@ func GetContractVersions(ctx context.Context, addresses *superchain.AddressList,
 }
 
 // getVersion will get the version of a contract at a given address.
-func getVersion(ctx context.Context, addr common.Address, backend bind.ContractBackend) (string, error) {
-	isemver, err := bindings.NewISemver(addr, backend)
+func getVersion(ctx context.Context, addr common.Address, backend bind.ContractCaller) (string, error) {
+	isemver, err := bindings.NewISemverCaller(addr, backend)
 	if err != nil {
 		return "", fmt.Errorf("%s: %w", addr, err)
 	}
@@ -0,0 +1,642 @@
+package upgrades
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/deployer"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/safe"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/srcmap"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/state"
+
+	"github.com/ethereum-optimism/superchain-registry/superchain"
+)
+
+// eventMetaData are the contracts whose events are decoded in simulation reports
+var eventMetaData = []*bind.MetaData{
+	bindings.ProxyMetaData,
+	bindings.ProxyAdminMetaData,
+	bindings.AddressManagerMetaData,
+	bindings.L1CrossDomainMessengerMetaData,
+	bindings.L1ERC721BridgeMetaData,
+	bindings.L1StandardBridgeMetaData,
+	bindings.L2OutputOracleMetaData,
+	bindings.OptimismMintableERC20FactoryMetaData,
+	bindings.OptimismPortalMetaData,
+	bindings.SystemConfigMetaData,
+}
+
+// SimulationClient is the access to L1 needed to simulate a batch on a fork of L1
+type SimulationClient interface {
+	state.ForkSource
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// Simulator executes calls on a local fork of L1. It implements bind.ContractCaller
+// so the contracts can be read after the execution of a batch.
+type Simulator struct {
+	db          *state.ForkStateDB
+	header      *types.Header
+	chainConfig *params.ChainConfig
+	blockCtx    vm.BlockContext
+	hashes      *blockHashes
+	txIndex     int
+	tracer      *srcmap.RevertTracer
+}
+
+// NewSimulator forks L1 at its latest block. The calls are executed in the
+// block following it.
+func NewSimulator(ctx context.Context, client SimulationClient) (*Simulator, error) {
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch L1 chain ID: %w", err)
+	}
+	header, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch L1 head: %w", err)
+	}
+	db, err := state.NewForkStateDB(ctx, client, header.Number)
+	if err != nil {
+		return nil, err
+	}
+	hashes := &blockHashes{
+		ctx:    ctx,
+		client: client,
+		hashes: map[uint64]common.Hash{header.Number.Uint64(): header.Hash()},
+	}
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash:     hashes.get,
+		Coinbase:    header.Coinbase,
+		GasLimit:    header.GasLimit,
+		BlockNumber: new(big.Int).Add(header.Number, common.Big1),
+		Time:        header.Time + 12,
+		Difficulty:  common.Big0,
+		BaseFee:     header.BaseFee,
+		BlobBaseFee: common.Big0,
+		Random:      &header.MixDigest,
+	}
+	if header.ExcessBlobGas != nil && header.BlobGasUsed != nil {
+		blockCtx.BlobBaseFee = eip4844.CalcBlobFee(eip4844.CalcExcessBlobGas(*header.ExcessBlobGas, *header.BlobGasUsed))
+	}
+	return &Simulator{
+		db:          db,
+		header:      header,
+		chainConfig: forkChainConfig(chainID, header, blockCtx.BlockNumber, blockCtx.Time),
+		blockCtx:    blockCtx,
+		hashes:      hashes,
+	}, nil
+}
+
+// forkChainConfig returns the chain config of the simulated block, which follows the forked header. The forks
+// of known networks are activated by the time of the block, the forks of other networks are derived from the
+// fields of the forked header.
+func forkChainConfig(chainID *big.Int, header *types.Header, number *big.Int, time uint64) *params.ChainConfig {
+	shanghai := header.WithdrawalsHash != nil
+	cancun := header.ExcessBlobGas != nil
+	for _, known := range []*params.ChainConfig{params.MainnetChainConfig, params.SepoliaChainConfig, params.GoerliChainConfig, params.HoleskyChainConfig} {
+		if known.ChainID.Cmp(chainID) == 0 {
+			shanghai = known.IsShanghai(number, time)
+			cancun = known.IsCancun(number, time)
+		}
+	}
+	chainConfig := deployer.NewChainConfig(chainID, shanghai)
+	if cancun {
+		chainConfig.CancunTime = chainConfig.ShanghaiTime
+	}
+	return chainConfig
+}
+
+// blockHashes serves the BLOCKHASH opcode from the forked chain. The EVM can't handle errors of the lookup,
+// the first one is recorded and returned after the execution instead.
+type blockHashes struct {
+	ctx    context.Context
+	client interface {
+		HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	}
+	hashes map[uint64]common.Hash
+	err    error
+}
+
+func (b *blockHashes) get(number uint64) common.Hash {
+	if hash, ok := b.hashes[number]; ok {
+		return hash
+	}
+	header, err := b.client.HeaderByNumber(b.ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("cannot fetch hash of L1 block %d: %w", number, err)
+		}
+		return common.Hash{}
+	}
+	b.hashes[number] = header.Hash()
+	return b.hashes[number]
+}
+
+// Header returns the forked L1 block
+func (s *Simulator) Header() *types.Header {
+	return s.header
+}
+
+func (s *Simulator) apply(from common.Address, to *common.Address, value *big.Int, data []byte, vmConfig vm.Config) (*core.ExecutionResult, error) {
+	if value == nil {
+		value = common.Big0
+	}
+	msg := &core.Message{
+		From:              from,
+		To:                to,
+		Value:             value,
+		GasLimit:          s.header.GasLimit,
+		GasPrice:          common.Big0,
+		GasFeeCap:         common.Big0,
+		GasTipCap:         common.Big0,
+		Data:              data,
+		SkipAccountChecks: true,
+	}
+	vmConfig.NoBaseFee = true
+	evm := vm.NewEVM(s.blockCtx, core.NewEVMTxContext(msg), s.db, s.chainConfig, vmConfig)
+	res, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+	if dbErr := s.db.Error(); dbErr != nil {
+		return nil, dbErr
+	}
+	if hashErr := s.hashes.err; hashErr != nil {
+		return nil, hashErr
+	}
+	return res, err
+}
+
+// Execute executes a call as a transaction, such that its changes persist.
+// The Solidity call stack of a revert is available through RevertTrace.
+func (s *Simulator) Execute(from common.Address, to common.Address, value *big.Int, data []byte) (*core.ExecutionResult, error) {
+	s.db.SetTxContext(common.BigToHash(big.NewInt(int64(s.txIndex))), s.txIndex)
+	s.txIndex++
+	if s.tracer == nil {
+		s.tracer = srcmap.NewRevertTracer(srcmap.DefaultContracts())
+	}
+	res, err := s.apply(from, &to, value, data, vm.Config{Tracer: s.tracer})
+	if err != nil {
+		return nil, err
+	}
+	s.db.Finalise(true)
+	return res, nil
+}
+
+// RevertTrace returns the trace of the revert of the last executed transaction,
+// or nil if it did not revert
+func (s *Simulator) RevertTrace() *srcmap.RevertTrace {
+	if s.tracer == nil {
+		return nil
+	}
+	return s.tracer.RevertTrace()
+}
+
+// CodeAt returns the code of the account in the simulated state
+func (s *Simulator) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	code := s.db.GetCode(contract)
+	if err := s.db.Error(); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// CallContract executes a call on the simulated state without persisting its changes
+func (s *Simulator) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	snapshot := s.db.Snapshot()
+	defer s.db.RevertToSnapshot(snapshot)
+	res, err := s.apply(call.From, call.To, call.Value, call.Data, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	if res.Err != nil {
+		return nil, revertError(res)
+	}
+	return res.ReturnData, nil
+}
+
+// Logs returns the logs emitted by the executed transactions
+func (s *Simulator) Logs() []*types.Log {
+	return s.db.Logs()
+}
+
+// Diff returns the accounts changed by the executed transactions
+func (s *Simulator) Diff() []state.AccountDiff {
+	return s.db.Diff()
+}
+
+func revertError(res *core.ExecutionResult) error {
+	if reason, err := abi.UnpackRevert(res.Revert()); err == nil {
+		return fmt.Errorf("%w: %s", res.Err, reason)
+	}
+	return res.Err
+}
+
+// SimulationTarget is a chain whose contracts are upgraded by the simulated batch
+type SimulationTarget struct {
+	ChainConfig     *superchain.ChainConfig
+	Addresses       *superchain.AddressList
+	Implementations superchain.ImplementationList
+}
+
+// contract is a contract known to the simulation
+type contract struct {
+	name string
+	// layout is the name of the storage layout of the contract
+	layout string
+	// proxyAdmin is set for proxies, to read their implementation
+	proxyAdmin common.Address
+}
+
+func (t *SimulationTarget) contracts() map[common.Address]contract {
+	proxyAdmin := common.HexToAddress(t.Addresses.ProxyAdmin.String())
+	name := func(n string) string {
+		return fmt.Sprintf("%s %s", t.ChainConfig.Name, n)
+	}
+	proxy := func(n string) contract {
+		return contract{name: name(n), layout: n, proxyAdmin: proxyAdmin}
+	}
+	implementation := func(n string) contract {
+		return contract{name: n + " implementation", layout: n}
+	}
+	return map[common.Address]contract{
+		proxyAdmin: {name: name("ProxyAdmin"), layout: "ProxyAdmin"},
+		common.HexToAddress(t.Addresses.AddressManager.String()):                    {name: name("AddressManager"), layout: "AddressManager"},
+		common.HexToAddress(t.Addresses.L1CrossDomainMessengerProxy.String()):       proxy("L1CrossDomainMessenger"),
+		common.HexToAddress(t.Addresses.L1ERC721BridgeProxy.String()):               proxy("L1ERC721Bridge"),
+		common.HexToAddress(t.Addresses.L1StandardBridgeProxy.String()):             proxy("L1StandardBridge"),
+		common.HexToAddress(t.Addresses.L2OutputOracleProxy.String()):               proxy("L2OutputOracle"),
+		common.HexToAddress(t.Addresses.OptimismMintableERC20FactoryProxy.String()): proxy("OptimismMintableERC20Factory"),
+		common.HexToAddress(t.Addresses.OptimismPortalProxy.String()):               proxy("OptimismPortal"),
+		common.HexToAddress(t.ChainConfig.SystemConfigAddr.String()):                proxy("SystemConfig"),
+
+		common.HexToAddress(t.Implementations.L1CrossDomainMessenger.Address.String()):       implementation("L1CrossDomainMessenger"),
+		common.HexToAddress(t.Implementations.L1ERC721Bridge.Address.String()):               implementation("L1ERC721Bridge"),
+		common.HexToAddress(t.Implementations.L1StandardBridge.Address.String()):             implementation("L1StandardBridge"),
+		common.HexToAddress(t.Implementations.L2OutputOracle.Address.String()):               implementation("L2OutputOracle"),
+		common.HexToAddress(t.Implementations.OptimismMintableERC20Factory.Address.String()): implementation("OptimismMintableERC20Factory"),
+		common.HexToAddress(t.Implementations.OptimismPortal.Address.String()):               implementation("OptimismPortal"),
+		common.HexToAddress(t.Implementations.SystemConfig.Address.String()):                 implementation("SystemConfig"),
+	}
+}
+
+// CallResult is the outcome of a call of the batch
+type CallResult struct {
+	To      common.Address
+	Method  string
+	GasUsed uint64
+	Err     error
+	// Trace is the Solidity call stack of the revert of the call
+	Trace *srcmap.RevertTrace
+}
+
+// ImplementationChange is a change of the implementation of a proxy
+type ImplementationChange struct {
+	Proxy  common.Address
+	Name   string
+	Before common.Address
+	After  common.Address
+}
+
+// StorageChange is a change of a storage slot, with the variables it holds
+// if the slot is decoded through the storage layout of the contract
+type StorageChange struct {
+	Address common.Address
+	Name    string
+	Slot    common.Hash
+	Before  common.Hash
+	After   common.Hash
+	Decoded []state.StorageVariableChange
+}
+
+// Event is a log emitted by a call of the batch
+type Event struct {
+	CallIndex uint
+	Address   common.Address
+	Name      string
+	Event     string
+}
+
+// ChainCheck is the result of checking the contract versions of a chain after the upgrade
+type ChainCheck struct {
+	Name    string
+	ChainID uint64
+	Err     error
+}
+
+// SimulationReport describes the effects of a simulated batch
+type SimulationReport struct {
+	BlockNumber     uint64
+	Safe            common.Address
+	Calls           []CallResult
+	Implementations []ImplementationChange
+	Storage         []StorageChange
+	Events          []Event
+	Checks          []ChainCheck
+}
+
+// Succeeded returns whether all calls of the batch succeeded. The Safe executes the batch
+// atomically, so the batch reverts entirely if any of its calls fails.
+func (r *SimulationReport) Succeeded() bool {
+	for _, call := range r.Calls {
+		if call.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// SimulateL1 executes the batch as the Safe on a fork of L1 and reports its effects
+// on the contracts of the targets, including the checks of their contract versions
+// against the superchain registry after the upgrade.
+func SimulateL1(ctx context.Context, client SimulationClient, batch *safe.Batch, safeAddr common.Address, targets []SimulationTarget) (*SimulationReport, error) {
+	sim, err := NewSimulator(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(map[common.Address]contract)
+	for _, target := range targets {
+		for addr, c := range target.contracts() {
+			contracts[addr] = c
+		}
+	}
+
+	before, err := proxyImplementations(ctx, sim, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read implementations before upgrade: %w", err)
+	}
+
+	report := &SimulationReport{
+		BlockNumber: sim.Header().Number.Uint64(),
+		Safe:        safeAddr,
+	}
+	for i, tx := range batch.Transactions {
+		if len(tx.Data) == 0 && tx.Method.Name != "fallback" {
+			return nil, fmt.Errorf("transaction %d has no calldata", i)
+		}
+		res, err := sim.Execute(safeAddr, tx.To, tx.Value, tx.Data)
+		if err != nil {
+			return nil, fmt.Errorf("cannot execute transaction %d: %w", i, err)
+		}
+		call := CallResult{
+			To:      tx.To,
+			Method:  tx.Signature(),
+			GasUsed: res.UsedGas,
+		}
+		if res.Err != nil {
+			call.Err = revertError(res)
+			call.Trace = sim.RevertTrace()
+		}
+		report.Calls = append(report.Calls, call)
+		if call.Err != nil {
+			return report, nil
+		}
+	}
+
+	after, err := proxyImplementations(ctx, sim, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read implementations after upgrade: %w", err)
+	}
+	for proxy, impl := range after {
+		if before[proxy] != impl {
+			report.Implementations = append(report.Implementations, ImplementationChange{
+				Proxy:  proxy,
+				Name:   contracts[proxy].name,
+				Before: before[proxy],
+				After:  impl,
+			})
+		}
+	}
+	sort.Slice(report.Implementations, func(i, j int) bool {
+		return report.Implementations[i].Name < report.Implementations[j].Name
+	})
+
+	report.Storage = storageChanges(sim.Diff(), contracts)
+	for _, l := range sim.Logs() {
+		report.Events = append(report.Events, Event{
+			CallIndex: l.TxIndex,
+			Address:   l.Address,
+			Name:      contracts[l.Address].name,
+			Event:     decodeEvent(l),
+		})
+	}
+
+	for _, target := range targets {
+		err := CheckL1Upgraded(ctx, &target.Implementations, target.Addresses, target.ChainConfig, sim)
+		report.Checks = append(report.Checks, ChainCheck{
+			Name:    target.ChainConfig.Name,
+			ChainID: target.ChainConfig.ChainID,
+			Err:     err,
+		})
+	}
+	return report, nil
+}
+
+func proxyImplementations(ctx context.Context, sim *Simulator, contracts map[common.Address]contract) (map[common.Address]common.Address, error) {
+	implementations := make(map[common.Address]common.Address)
+	for addr, c := range contracts {
+		if c.proxyAdmin == (common.Address{}) {
+			continue
+		}
+		proxyAdmin, err := bindings.NewProxyAdminCaller(c.proxyAdmin, sim)
+		if err != nil {
+			return nil, err
+		}
+		impl, err := proxyAdmin.GetProxyImplementation(&bind.CallOpts{Context: ctx}, addr)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.name, err)
+		}
+		implementations[addr] = impl
+	}
+	return implementations, nil
+}
+
+func storageChanges(diffs []state.AccountDiff, contracts map[common.Address]contract) []StorageChange {
+	var changes []StorageChange
+	for _, diff := range diffs {
+		c, known := contracts[diff.Address]
+		layout, err := bindings.GetStorageLayout(c.layout)
+		if !known || err != nil {
+			layout = nil
+		}
+		name := c.name
+		if !known {
+			name = "unknown contract"
+		}
+
+		slots := make([]common.Hash, 0, len(diff.Storage))
+		for slot := range diff.Storage {
+			slots = append(slots, slot)
+		}
+		sort.Slice(slots, func(i, j int) bool {
+			return slots[i].Big().Cmp(slots[j].Big()) < 0
+		})
+
+		for _, slot := range slots {
+			change := StorageChange{
+				Address: diff.Address,
+				Name:    name,
+				Slot:    slot,
+				Before:  diff.Storage[slot].Before,
+				After:   diff.Storage[slot].After,
+			}
+			switch slot {
+			case genesis.ImplementationSlot:
+				change.Decoded = decodeAddressSlot("eip1967.proxy.implementation", change.Before, change.After)
+			case genesis.AdminSlot:
+				change.Decoded = decodeAddressSlot("eip1967.proxy.admin", change.Before, change.After)
+			default:
+				if layout != nil {
+					change.Decoded = state.DecodeStorageChange(layout, slot, change.Before, change.After)
+				}
+			}
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func decodeAddressSlot(label string, before common.Hash, after common.Hash) []state.StorageVariableChange {
+	return []state.StorageVariableChange{{
+		Label:  label,
+		Type:   "address",
+		Before: common.BytesToAddress(before[:]).Hex(),
+		After:  common.BytesToAddress(after[:]).Hex(),
+	}}
+}
+
+// decodeEvent formats the log as an event of the known contracts, or as raw topics and data
+func decodeEvent(l *types.Log) string {
+	if len(l.Topics) > 0 {
+		for _, md := range eventMetaData {
+			contractABI, err := md.GetAbi()
+			if err != nil {
+				continue
+			}
+			ev, err := contractABI.EventByID(l.Topics[0])
+			if err != nil {
+				continue
+			}
+			args := make(map[string]any)
+			if err := contractABI.UnpackIntoMap(args, ev.Name, l.Data); err != nil {
+				continue
+			}
+			var indexed abi.Arguments
+			for _, input := range ev.Inputs {
+				if input.Indexed {
+					indexed = append(indexed, input)
+				}
+			}
+			if err := abi.ParseTopicsIntoMap(args, indexed, l.Topics[1:]); err != nil {
+				continue
+			}
+			parts := make([]string, len(ev.Inputs))
+			for i, input := range ev.Inputs {
+				parts[i] = fmt.Sprintf("%s: %s", input.Name, formatArg(args[input.Name]))
+			}
+			return fmt.Sprintf("%s(%s)", ev.Name, strings.Join(parts, ", "))
+		}
+	}
+	topics := make([]string, len(l.Topics))
+	for i, topic := range l.Topics {
+		topics[i] = topic.Hex()
+	}
+	return fmt.Sprintf("topics: [%s], data: %s", strings.Join(topics, ", "), hexutil.Encode(l.Data))
+}
+
+func formatArg(arg any) string {
+	switch v := arg.(type) {
+	case []byte:
+		return hexutil.Encode(v)
+	case [32]byte:
+		return common.Hash(v).Hex()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Write writes the report in a human-readable format
+func (r *SimulationReport) Write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Simulated batch as Safe %s on top of L1 block %d\n", r.Safe, r.BlockNumber)
+
+	fmt.Fprintf(&b, "\nCalls:\n")
+	for i, call := range r.Calls {
+		status := "ok"
+		if call.Err != nil {
+			status = fmt.Sprintf("FAILED: %v", call.Err)
+		}
+		fmt.Fprintf(&b, "  [%d] %s %s, gas used %d: %s\n", i, call.To, call.Method, call.GasUsed, status)
+		if call.Trace != nil {
+			for _, line := range strings.Split(call.Trace.String(), "\n") {
+				fmt.Fprintf(&b, "      %s\n", line)
+			}
+		}
+	}
+	if !r.Succeeded() {
+		fmt.Fprintf(&b, "\nThe batch reverts, no changes are applied\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	fmt.Fprintf(&b, "\nImplementation changes:\n")
+	for _, change := range r.Implementations {
+		fmt.Fprintf(&b, "  %s (%s): %s -> %s\n", change.Name, change.Proxy, change.Before, change.After)
+	}
+
+	fmt.Fprintf(&b, "\nStorage changes:\n")
+	var last common.Address
+	for i, change := range r.Storage {
+		if i == 0 || change.Address != last {
+			fmt.Fprintf(&b, "  %s (%s)\n", change.Name, change.Address)
+			last = change.Address
+		}
+		fmt.Fprintf(&b, "    slot %s: %s -> %s\n", change.Slot, change.Before, change.After)
+		for _, v := range change.Decoded {
+			fmt.Fprintf(&b, "      %s (%s): %s -> %s\n", v.Label, v.Type, v.Before, v.After)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nEvents:\n")
+	for _, ev := range r.Events {
+		name := ev.Name
+		if name == "" {
+			name = ev.Address.Hex()
+		}
+		fmt.Fprintf(&b, "  [%d] %s: %s\n", ev.CallIndex, name, ev.Event)
+	}
+
+	fmt.Fprintf(&b, "\nPost-upgrade checks:\n")
+	for _, check := range r.Checks {
+		if check.Err != nil {
+			fmt.Fprintf(&b, "  %s (%d): FAILED\n", check.Name, check.ChainID)
+			for _, line := range strings.Split(check.Err.Error(), "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		} else {
+			fmt.Fprintf(&b, "  %s (%d): ok\n", check.Name, check.ChainID)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,69 @@
+package upgrades
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkChainConfig(t *testing.T) {
+	number := big.NewInt(20_000_000)
+	shanghaiTime := *params.MainnetChainConfig.ShanghaiTime
+	empty := common.Hash{}
+	excessBlobGas := uint64(0)
+
+	tests := []struct {
+		name     string
+		chainID  *big.Int
+		header   *types.Header
+		time     uint64
+		shanghai bool
+		cancun   bool
+	}{
+		{"known before shanghai", params.MainnetChainConfig.ChainID, &types.Header{}, shanghaiTime - 1, false, false},
+		{"known after shanghai", params.MainnetChainConfig.ChainID, &types.Header{}, shanghaiTime, true, false},
+		{"unknown london", big.NewInt(900), &types.Header{}, shanghaiTime, false, false},
+		{"unknown shanghai", big.NewInt(900), &types.Header{WithdrawalsHash: &empty}, 0, true, false},
+		{"unknown cancun", big.NewInt(900), &types.Header{WithdrawalsHash: &empty, ExcessBlobGas: &excessBlobGas}, 0, true, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chainConfig := forkChainConfig(test.chainID, test.header, number, test.time)
+			require.Equal(t, test.chainID, chainConfig.ChainID)
+			require.Equal(t, test.shanghai, chainConfig.IsShanghai(number, test.time))
+			require.Equal(t, test.cancun, chainConfig.IsCancun(number, test.time))
+		})
+	}
+}
+
+type stubHeaders map[uint64]*types.Header
+
+func (s stubHeaders) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if header, ok := s[number.Uint64()]; ok {
+		return header, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestBlockHashes(t *testing.T) {
+	header := &types.Header{Number: big.NewInt(5), Extra: []byte("forked")}
+	hashes := &blockHashes{
+		ctx:    context.Background(),
+		client: stubHeaders{5: header},
+		hashes: map[uint64]common.Hash{},
+	}
+	require.Equal(t, header.Hash(), hashes.get(5))
+	require.NoError(t, hashes.err)
+
+	// failed lookups are recorded
+	require.Equal(t, common.Hash{}, hashes.get(4))
+	require.ErrorContains(t, hashes.err, "cannot fetch hash of L1 block 4")
+	require.Equal(t, common.Hash{}, hashes.get(3))
+	require.ErrorContains(t, hashes.err, "block 4")
+}
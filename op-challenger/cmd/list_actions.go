@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/journal"
+)
+
+var gameAddressFlag = &cli.StringFlag{
+	Name:     "game-address",
+	Usage:    "Address of the dispute game to list actions for",
+	Required: true,
+}
+
+var ListActionsCommand = &cli.Command{
+	Name:  "list-actions",
+	Usage: "List the actions recorded in the journal for a game",
+	Flags: []cli.Flag{
+		flags.DatadirFlag,
+		gameAddressFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		datadir := ctx.String(flags.DatadirFlag.Name)
+		if datadir == "" {
+			return fmt.Errorf("flag %s is required", flags.DatadirFlag.Name)
+		}
+		addr := ctx.String(gameAddressFlag.Name)
+		if !common.IsHexAddress(addr) {
+			return fmt.Errorf("invalid game address: %v", addr)
+		}
+		entries, err := journal.Read(journal.GameDir(datadir, common.HexToAddress(addr)))
+		if err != nil {
+			return err
+		}
+		return writeActions(ctx.App.Writer, entries)
+	},
+}
+
+func writeActions(out io.Writer, entries []journal.Entry) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTime\tStatus\tKind\tParent\tAttack\tValue\tTx\tError")
+	for _, entry := range entries {
+		value := ""
+		if entry.Kind == journal.KindMove {
+			value = entry.Value.Hex()
+		}
+		tx := ""
+		if entry.TxHash != (common.Hash{}) {
+			tx = entry.TxHash.Hex()
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			entry.ID, entry.Time.Format(time.RFC3339), entry.Status, entry.Kind, entry.ParentIdx, entry.IsAttack, value, tx, entry.Error)
+	}
+	return w.Flush()
+}
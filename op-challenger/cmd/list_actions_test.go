@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/journal"
+)
+
+func TestListActions(t *testing.T) {
+	t.Run("RequiresGameAddress", func(t *testing.T) {
+		err := run(context.Background(), []string{"op-challenger", "list-actions", "--datadir", t.TempDir()}, nil)
+		require.ErrorContains(t, err, "game-address")
+	})
+
+	t.Run("RejectsInvalidGameAddress", func(t *testing.T) {
+		err := run(context.Background(), []string{"op-challenger", "list-actions", "--datadir", t.TempDir(), "--game-address", "foo"}, nil)
+		require.ErrorContains(t, err, "invalid game address")
+	})
+
+	t.Run("RequiresDatadir", func(t *testing.T) {
+		err := run(context.Background(), []string{"op-challenger", "list-actions", "--game-address", common.Address{0xaa}.Hex()}, nil)
+		require.ErrorContains(t, err, "datadir")
+	})
+
+	t.Run("NoJournal", func(t *testing.T) {
+		err := run(context.Background(), []string{"op-challenger", "list-actions", "--datadir", t.TempDir(), "--game-address", common.Address{0xaa}.Hex()}, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("ListsJournalEntries", func(t *testing.T) {
+		dir := t.TempDir()
+		addr := common.Address{0xaa}
+		j, err := journal.Open(journal.GameDir(dir, addr))
+		require.NoError(t, err)
+		move, err := j.Intend(journal.Entry{Kind: journal.KindMove, ParentIdx: 3, IsAttack: true, Value: common.Hash{0xcc}})
+		require.NoError(t, err)
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		tx, err := ethtypes.SignNewTx(key, ethtypes.LatestSignerForChainID(big.NewInt(1)),
+			&ethtypes.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 4, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)})
+		require.NoError(t, err)
+		require.NoError(t, j.Published(move, tx))
+		require.NoError(t, j.Complete(move, journal.StatusConfirmed, tx.Hash(), nil))
+
+		var out bytes.Buffer
+		app := cli.NewApp()
+		app.Writer = &out
+		app.Commands = []*cli.Command{ListActionsCommand}
+		err = app.Run([]string{"op-challenger", "list-actions", "--datadir", dir, "--game-address", addr.Hex()})
+		require.NoError(t, err)
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		require.Len(t, lines, 4)
+		require.Contains(t, string(lines[1]), "intended")
+		require.Contains(t, string(lines[1]), common.Hash{0xcc}.Hex())
+		require.Contains(t, string(lines[2]), "published")
+		require.Contains(t, string(lines[2]), tx.Hash().Hex())
+		require.Contains(t, string(lines[3]), "confirmed")
+		require.Contains(t, string(lines[3]), tx.Hash().Hex())
+	})
+}
+
+func TestWriteActions(t *testing.T) {
+	var out bytes.Buffer
+	entries := []journal.Entry{
+		{ID: 0, Status: journal.StatusIntended, Kind: journal.KindMove, ParentIdx: 3, IsAttack: true, Value: common.Hash{0xaa}},
+		{ID: 0, Status: journal.StatusConfirmed, Kind: journal.KindMove, ParentIdx: 3, IsAttack: true, Value: common.Hash{0xaa}, TxHash: common.Hash{0xbb}},
+		{ID: 1, Status: journal.StatusFailed, Kind: journal.KindResolve, Error: "boom"},
+	}
+	require.NoError(t, writeActions(&out, entries))
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 4)
+	require.Contains(t, string(lines[1]), common.Hash{0xaa}.Hex())
+	require.Contains(t, string(lines[2]), common.Hash{0xbb}.Hex())
+	require.Contains(t, string(lines[2]), "confirmed")
+	require.Contains(t, string(lines[3]), "boom")
+}
@@ -58,6 +58,9 @@ func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
 		}
 		return action(ctx.Context, logger, cfg)
 	})
+	app.Commands = []*cli.Command{
+		ListActionsCommand,
+	}
 	return app.RunContext(ctx, args)
 }
 
@@ -167,6 +167,15 @@ func TestTxManagerFlagsSupported(t *testing.T) {
 	require.Equal(t, uint64(7), cfg.TxMgrConfig.NumConfirmations)
 }
 
+func TestTxManagerPoolFlagsSupported(t *testing.T) {
+	cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+		"--"+txmgr.PoolPrivateKeysFlagName, "0x1234", "--"+txmgr.PoolStuckTimeoutFlagName, "30s",
+		"--"+txmgr.AbandonPolicyFlagName, "bump"))
+	require.Equal(t, []string{"0x1234"}, cfg.TxMgrConfig.PoolPrivateKeys)
+	require.Equal(t, 30*time.Second, cfg.TxMgrConfig.PoolStuckTimeout)
+	require.Equal(t, txmgr.AbandonPolicyBump, cfg.TxMgrConfig.AbandonPolicy)
+}
+
 func TestMaxConcurrency(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint(345)
@@ -189,6 +198,73 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestGamePriority(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.GamePriorityFIFO, cfg.GamePriority)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-priority", "expiry"))
+		require.Equal(t, config.GamePriorityExpiry, cfg.GamePriority)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"unknown game priority: \"foo\"",
+			addRequiredArgs(config.TraceTypeAlphabet, "--game-priority", "foo"))
+	})
+}
+
+func TestSkipUncontested(t *testing.T) {
+	t.Run("DefaultFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.SkipUncontested)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--skip-uncontested"))
+		require.True(t, cfg.SkipUncontested)
+	})
+}
+
+func TestSelectiveDefense(t *testing.T) {
+	t.Run("DefaultFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.SelectiveDefense)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--selective-defense"))
+		require.True(t, cfg.SelectiveDefense)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("DefaultFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.DryRun)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--dry-run"))
+		require.True(t, cfg.DryRun)
+	})
+}
+
+func TestCannonMaxExecutions(t *testing.T) {
+	t.Run("DefaultUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Zero(t, cfg.CannonMaxExecutions)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--cannon-max-executions=2"))
+		require.Equal(t, uint(2), cfg.CannonMaxExecutions)
+	})
+}
+
 func TestPollInterval(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
@@ -34,6 +34,7 @@ var (
 	ErrCannonNetworkAndL2Genesis     = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
+	ErrInvalidGamePriority           = errors.New("invalid game priority")
 )
 
 type TraceType string
@@ -86,6 +87,35 @@ func ValidTraceType(value TraceType) bool {
 	return false
 }
 
+type GamePriority string
+
+const (
+	// GamePriorityFIFO progresses games in the order they are returned by the game factory
+	GamePriorityFIFO GamePriority = "fifo"
+	// GamePriorityExpiry progresses the oldest games, which are closest to their clock expiring, first
+	GamePriorityExpiry GamePriority = "expiry"
+)
+
+var GamePriorities = []GamePriority{GamePriorityFIFO, GamePriorityExpiry}
+
+func (p GamePriority) String() string {
+	return string(p)
+}
+
+// Set implements the Set method required by the [cli.Generic] interface.
+func (p *GamePriority) Set(value string) error {
+	if !slices.Contains(GamePriorities, GamePriority(value)) {
+		return fmt.Errorf("unknown game priority: %q", value)
+	}
+	*p = GamePriority(value)
+	return nil
+}
+
+func (p *GamePriority) Clone() any {
+	cpy := *p
+	return &cpy
+}
+
 const (
 	DefaultPollInterval       = time.Second * 12
 	DefaultCannonSnapshotFreq = uint(1_000_000_000)
@@ -108,6 +138,10 @@ type Config struct {
 	Datadir            string           // Data Directory
 	MaxConcurrency     uint             // Maximum number of threads to use when progressing games
 	PollInterval       time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
+	GamePriority       GamePriority     // Order in which games are progressed when there are more games than threads
+	SkipUncontested    bool             // Skip acting on games where the root claim is agreed with and has not been countered
+	SelectiveDefense   bool             // Only counter claims that are currently defeating a claim the challenger agrees with
+	DryRun             bool             // Calculate and log actions without sending any transactions
 
 	TraceTypes []TraceType // Type of traces supported
 
@@ -127,6 +161,7 @@ type Config struct {
 	CannonL2               string // L2 RPC Url
 	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
 	CannonInfoFreq         uint   // Frequency of cannon progress log messages (in VM instructions)
+	CannonMaxExecutions    uint   // Maximum number of cannon executions to run concurrently across all games (0 for no limit)
 
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
@@ -144,6 +179,7 @@ func NewConfig(
 		GameFactoryAddress: gameFactoryAddress,
 		MaxConcurrency:     uint(runtime.NumCPU()),
 		PollInterval:       DefaultPollInterval,
+		GamePriority:       GamePriorityFIFO,
 
 		TraceTypes: supportedTraceTypes,
 
@@ -179,6 +215,9 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if !slices.Contains(GamePriorities, c.GamePriority) {
+		return fmt.Errorf("%w: %q", ErrInvalidGamePriority, c.GamePriority)
+	}
 	if c.TraceTypeEnabled(TraceTypeOutputCannon) || c.TraceTypeEnabled(TraceTypeOutputAlphabet) {
 		if c.RollupRpc == "" {
 			return ErrMissingRollupRpc
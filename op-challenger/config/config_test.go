@@ -127,6 +127,19 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestGamePriority(t *testing.T) {
+	t.Run("DefaultsToFIFO", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		require.Equal(t, GamePriorityFIFO, config.GamePriority)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.GamePriority = "foo"
+		require.ErrorIs(t, config.Check(), ErrInvalidGamePriority)
+	})
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(TraceTypeAlphabet)
@@ -62,6 +62,31 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	GamePriorityFlag = &cli.GenericFlag{
+		Name: "game-priority",
+		Usage: "Order in which to progress games when there are more games than threads. Valid options: " +
+			openum.EnumString(config.GamePriorities),
+		EnvVars: prefixEnvVars("GAME_PRIORITY"),
+		Value: func() *config.GamePriority {
+			out := config.GamePriorityFIFO
+			return &out
+		}(),
+	}
+	SkipUncontestedFlag = &cli.BoolFlag{
+		Name:    "skip-uncontested",
+		Usage:   "Do not act on games where the challenger agrees with the root claim and it has not been countered",
+		EnvVars: prefixEnvVars("SKIP_UNCONTESTED"),
+	}
+	SelectiveDefenseFlag = &cli.BoolFlag{
+		Name:    "selective-defense",
+		Usage:   "Only counter claims that are currently defeating a claim the challenger agrees with",
+		EnvVars: prefixEnvVars("SELECTIVE_DEFENSE"),
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name:    "dry-run",
+		Usage:   "Calculate and log the actions the challenger would take without sending any transactions",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
 	HTTPPollInterval = &cli.DurationFlag{
 		Name:    "http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider.",
@@ -128,6 +153,11 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	CannonMaxExecutionsFlag = &cli.UintFlag{
+		Name:    "cannon-max-executions",
+		Usage:   "Maximum number of cannon executions to run concurrently across all games. 0 for no limit (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_MAX_EXECUTIONS"),
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name:    "game-window",
 		Usage:   "The time window which the challenger will look for games to progress.",
@@ -147,6 +177,10 @@ var requiredFlags = []cli.Flag{
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
 	MaxConcurrencyFlag,
+	GamePriorityFlag,
+	SkipUncontestedFlag,
+	SelectiveDefenseFlag,
+	DryRunFlag,
 	HTTPPollInterval,
 	RollupRpcFlag,
 	AlphabetFlag,
@@ -160,12 +194,14 @@ var optionalFlags = []cli.Flag{
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
+	CannonMaxExecutionsFlag,
 	GameWindowFlag,
 }
 
 func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(envVarPrefix, txmgr.DefaultChallengerFlagValues)...)
+	optionalFlags = append(optionalFlags, txmgr.PoolCLIFlags(envVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
 
@@ -290,6 +326,10 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		GameWindow:             ctx.Duration(GameWindowFlag.Name),
 		MaxConcurrency:         maxConcurrency,
 		PollInterval:           ctx.Duration(HTTPPollInterval.Name),
+		GamePriority:           config.GamePriority(ctx.String(GamePriorityFlag.Name)),
+		SkipUncontested:        ctx.Bool(SkipUncontestedFlag.Name),
+		SelectiveDefense:       ctx.Bool(SelectiveDefenseFlag.Name),
+		DryRun:                 ctx.Bool(DryRunFlag.Name),
 		RollupRpc:              ctx.String(RollupRpcFlag.Name),
 		AlphabetTrace:          ctx.String(AlphabetFlag.Name),
 		CannonNetwork:          ctx.String(CannonNetworkFlag.Name),
@@ -302,6 +342,7 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		CannonL2:               ctx.String(CannonL2Flag.Name),
 		CannonSnapshotFreq:     ctx.Uint(CannonSnapshotFreqFlag.Name),
 		CannonInfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
+		CannonMaxExecutions:    ctx.Uint(CannonMaxExecutionsFlag.Name),
 		TxMgrConfig:            txMgrConfig,
 		MetricsConfig:          metricsConfig,
 		PprofConfig:            pprofConfig,
@@ -23,7 +23,12 @@ func newDiskManager(dir string) *diskManager {
 }
 
 func (d *diskManager) DirForGame(addr common.Address) string {
-	return filepath.Join(d.datadir, gameDirPrefix+addr.Hex())
+	return GameDir(d.datadir, addr)
+}
+
+// GameDir returns the directory within datadir used to store data for the game at addr.
+func GameDir(datadir string, addr common.Address) string {
+	return filepath.Join(datadir, gameDirPrefix+addr.Hex())
 }
 
 func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
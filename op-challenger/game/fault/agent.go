@@ -37,10 +37,10 @@ type Agent struct {
 	log       log.Logger
 }
 
-func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, trace types.TraceAccessor, responder Responder, log log.Logger) *Agent {
+func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth int, solver *solver.GameSolver, responder Responder, log log.Logger) *Agent {
 	return &Agent{
 		metrics:   m,
-		solver:    solver.NewGameSolver(maxDepth, trace),
+		solver:    solver,
 		loader:    loader,
 		responder: responder,
 		maxDepth:  maxDepth,
@@ -88,6 +88,21 @@ func (a *Agent) Act(ctx context.Context) error {
 	return nil
 }
 
+// Resolve resolves the game and its claims if they are in a resolvable state, without calculating any moves.
+func (a *Agent) Resolve(ctx context.Context) error {
+	a.tryResolve(ctx)
+	return nil
+}
+
+// AgreeWithRootClaim returns true if the agent agrees with the root claim of the game.
+func (a *Agent) AgreeWithRootClaim(ctx context.Context) (bool, error) {
+	game, err := a.newGameFromContracts(ctx)
+	if err != nil {
+		return false, fmt.Errorf("create game from contracts: %w", err)
+	}
+	return a.solver.AgreeWithRootClaim(ctx, game)
+}
+
 // tryResolve resolves the game if it is in a winning state
 // Returns true if the game is resolvable (regardless of whether it was actually resolved)
 func (a *Agent) tryResolve(ctx context.Context) bool {
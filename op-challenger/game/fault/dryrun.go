@@ -0,0 +1,82 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var errDryRunClaimResolved = errors.New("claim already resolved in dry run")
+
+type DryRunMetricer interface {
+	RecordDryRunAction(action string)
+}
+
+// DryRunResponder wraps a Responder and logs the transactions it would send instead of sending them.
+// Calls that only read contract state are delegated to the wrapped Responder.
+type DryRunResponder struct {
+	log       log.Logger
+	metrics   DryRunMetricer
+	responder Responder
+
+	// resolvedClaims tracks the claims that would have been resolved. Since the contract state is never updated
+	// they would otherwise continue to be reported as resolvable.
+	resolvedLock   sync.Mutex
+	resolvedClaims map[uint64]bool
+}
+
+func NewDryRunResponder(logger log.Logger, m DryRunMetricer, responder Responder) *DryRunResponder {
+	return &DryRunResponder{
+		log:       logger,
+		metrics:   m,
+		responder: responder,
+
+		resolvedClaims: make(map[uint64]bool),
+	}
+}
+
+func (r *DryRunResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
+	return r.responder.CallResolve(ctx)
+}
+
+func (r *DryRunResponder) Resolve(_ context.Context) error {
+	r.log.Info("Dry run: would resolve game")
+	r.metrics.RecordDryRunAction("resolve")
+	return nil
+}
+
+func (r *DryRunResponder) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
+	r.resolvedLock.Lock()
+	resolved := r.resolvedClaims[claimIdx]
+	r.resolvedLock.Unlock()
+	if resolved {
+		return errDryRunClaimResolved
+	}
+	return r.responder.CallResolveClaim(ctx, claimIdx)
+}
+
+func (r *DryRunResponder) ResolveClaim(_ context.Context, claimIdx uint64) error {
+	r.resolvedLock.Lock()
+	r.resolvedClaims[claimIdx] = true
+	r.resolvedLock.Unlock()
+	r.log.Info("Dry run: would resolve claim", "claimIdx", claimIdx)
+	r.metrics.RecordDryRunAction("resolve_claim")
+	return nil
+}
+
+func (r *DryRunResponder) PerformAction(_ context.Context, action types.Action) error {
+	actionLog := r.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx)
+	if action.Type == types.ActionTypeStep {
+		actionLog = actionLog.New("prestate", common.Bytes2Hex(action.PreState), "proof", common.Bytes2Hex(action.ProofData))
+	} else {
+		actionLog = actionLog.New("value", action.Value)
+	}
+	actionLog.Info("Dry run: would perform action")
+	r.metrics.RecordDryRunAction(action.Type.String())
+	return nil
+}
@@ -0,0 +1,86 @@
+package fault
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestDryRunResponder(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("DelegatesCallResolve", func(t *testing.T) {
+		responder, stub, _ := setupDryRunResponder(t)
+		stub.callResolveStatus = gameTypes.GameStatusDefenderWon
+		status, err := responder.CallResolve(ctx)
+		require.NoError(t, err)
+		require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+		require.Equal(t, 1, stub.callResolveCount)
+	})
+
+	t.Run("DoesNotResolve", func(t *testing.T) {
+		responder, stub, m := setupDryRunResponder(t)
+		require.NoError(t, responder.Resolve(ctx))
+		require.Zero(t, stub.resolveCount)
+		require.Equal(t, 1, m.actions["resolve"])
+	})
+
+	t.Run("DoesNotResolveClaim", func(t *testing.T) {
+		responder, stub, m := setupDryRunResponder(t)
+		require.NoError(t, responder.CallResolveClaim(ctx, 3))
+		require.NoError(t, responder.ResolveClaim(ctx, 3))
+		require.Zero(t, stub.resolveClaimCount)
+		require.Equal(t, 1, m.actions["resolve_claim"])
+
+		// Claim is no longer reported as resolvable
+		require.ErrorIs(t, responder.CallResolveClaim(ctx, 3), errDryRunClaimResolved)
+		require.Equal(t, 1, stub.callResolveClaimCount)
+		require.NoError(t, responder.CallResolveClaim(ctx, 4))
+	})
+
+	t.Run("DoesNotPerformActions", func(t *testing.T) {
+		responder, _, m := setupDryRunResponder(t)
+		require.NoError(t, responder.PerformAction(ctx, types.Action{Type: types.ActionTypeMove, Value: common.Hash{0xaa}}))
+		require.NoError(t, responder.PerformAction(ctx, types.Action{Type: types.ActionTypeStep}))
+		require.NoError(t, responder.PerformAction(ctx, types.Action{Type: types.ActionTypeStep}))
+		require.Equal(t, 1, m.actions["move"])
+		require.Equal(t, 2, m.actions["step"])
+	})
+}
+
+func TestDryRunAgentTerminatesWhenClaimsResolvable(t *testing.T) {
+	agent, claimLoader, stub := setupTestAgent(t)
+	agent.responder = NewDryRunResponder(testlog.Logger(t, log.LvlInfo), &stubDryRunMetrics{actions: make(map[string]int)}, stub)
+	claimBuilder := test.NewClaimBuilder(t, agent.maxDepth, alphabet.NewTraceProvider("abcd", uint64(agent.maxDepth)))
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(true)}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Zero(t, stub.resolveClaimCount)
+}
+
+func setupDryRunResponder(t *testing.T) (*DryRunResponder, *stubResponder, *stubDryRunMetrics) {
+	stub := &stubResponder{}
+	m := &stubDryRunMetrics{actions: make(map[string]int)}
+	return NewDryRunResponder(testlog.Logger(t, log.LvlInfo), m, stub), stub, m
+}
+
+type stubDryRunMetrics struct {
+	lock    sync.Mutex
+	actions map[string]int
+}
+
+func (s *stubDryRunMetrics) RecordDryRunAction(action string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.actions[action]++
+}
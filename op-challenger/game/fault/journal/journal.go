@@ -0,0 +1,434 @@
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	fileName = "journal.jsonl"
+	// dirName is the directory within the challenger datadir that journals are stored in. It is kept separate from
+	// the per-game directories since those are deleted once the game is no longer being played.
+	dirName = "journals"
+)
+
+// GameDir returns the directory within datadir used to store the journal for the game at addr.
+func GameDir(datadir string, addr common.Address) string {
+	return filepath.Join(datadir, dirName, addr.Hex())
+}
+
+// TxLookup retrieves the state of transactions and accounts from L1.
+type TxLookup interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*ethtypes.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (tx *ethtypes.Transaction, isPending bool, err error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+type Status string
+
+const (
+	// StatusIntended records that a transaction is about to be sent.
+	StatusIntended Status = "intended"
+	// StatusPublished records a transaction that is about to be published for an intended action.
+	// An action may have multiple published transactions if the fees are increased.
+	StatusPublished Status = "published"
+	// StatusConfirmed records that the transaction was included and succeeded.
+	StatusConfirmed Status = "confirmed"
+	// StatusReverted records that the transaction was included but reverted.
+	StatusReverted Status = "reverted"
+	// StatusFailed records that the transaction could not be sent.
+	StatusFailed Status = "failed"
+	// StatusReconciled records that an action without a recorded outcome was found on chain at startup.
+	StatusReconciled Status = "reconciled"
+	// StatusAbandoned records that an action without a recorded outcome was not found on chain at startup.
+	StatusAbandoned Status = "abandoned"
+)
+
+type Kind string
+
+const (
+	KindMove         Kind = "move"
+	KindStep         Kind = "step"
+	KindUpdateOracle Kind = "update_oracle"
+	KindResolve      Kind = "resolve"
+	KindResolveClaim Kind = "resolve_claim"
+)
+
+// Entry is a single record in the journal.
+// Each action is first recorded as intended, then each transaction published for it is recorded with its hash,
+// sender and nonce, and finally a last entry with the same ID records the outcome.
+type Entry struct {
+	ID        uint64         `json:"id"`
+	Time      time.Time      `json:"time"`
+	Status    Status         `json:"status"`
+	Kind      Kind           `json:"kind"`
+	ParentIdx uint64         `json:"parentIdx"`
+	IsAttack  bool           `json:"isAttack,omitempty"`
+	Value     common.Hash    `json:"value,omitempty"`
+	TxHash    common.Hash    `json:"txHash,omitempty"`
+	From      common.Address `json:"from,omitempty"`
+	Nonce     *uint64        `json:"nonce,omitempty"`
+	Error     string         `json:"error,omitempty"`
+}
+
+// sameAction returns true if e and other record the same action, regardless of status or transaction.
+func (e Entry) sameAction(other Entry) bool {
+	return e.Kind == other.Kind && e.ParentIdx == other.ParentIdx && e.IsAttack == other.IsAttack && e.Value == other.Value
+}
+
+// pendingAction is an intended action without a recorded outcome, along with the transactions published for it.
+type pendingAction struct {
+	intended  Entry
+	published []Entry
+}
+
+// Journal is an append-only, on-disk record of the transactions sent for a single game.
+// Every entry is synced to disk before the corresponding transaction is sent so that actions which were in flight
+// when the challenger stopped can be reconciled against the game state on restart.
+// A nil *Journal is valid and records nothing.
+type Journal struct {
+	lock    sync.Mutex
+	path    string
+	nextID  uint64
+	pending []pendingAction
+	txs     TxLookup
+	now     func() time.Time
+}
+
+// Open opens the journal in dir, creating it if it does not exist.
+func Open(dir string) (*Journal, error) {
+	entries, err := Read(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create journal dir: %w", err)
+	}
+	path := filepath.Join(dir, fileName)
+	if err := terminatePartialEntry(path); err != nil {
+		return nil, err
+	}
+	var nextID uint64
+	for _, entry := range entries {
+		if entry.ID >= nextID {
+			nextID = entry.ID + 1
+		}
+	}
+	published := make(map[uint64][]Entry)
+	for _, entry := range entries {
+		if entry.Status == StatusPublished {
+			published[entry.ID] = append(published[entry.ID], entry)
+		}
+	}
+	var pending []pendingAction
+	for _, entry := range Pending(entries) {
+		pending = append(pending, pendingAction{intended: entry, published: published[entry.ID]})
+	}
+	return &Journal{
+		path:    path,
+		nextID:  nextID,
+		pending: pending,
+		now:     time.Now,
+	}, nil
+}
+
+// Read loads all entries from the journal in dir. Returns no entries if the journal does not exist.
+// Entries that can't be decoded, such as one left partially written when the process stopped, are skipped.
+func Read(dir string) ([]Entry, error) {
+	file, err := os.Open(filepath.Join(dir, fileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// terminatePartialEntry ensures the journal at path ends with a newline so that a partially written entry doesn't
+// corrupt the next entry appended.
+func terminatePartialEntry(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && (len(data) == 0 || data[len(data)-1] == '\n')) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read journal: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write([]byte{'\n'}); err != nil {
+		return fmt.Errorf("failed to terminate partial journal entry: %w", err)
+	}
+	return nil
+}
+
+// Pending returns the intended entries that have no recorded outcome.
+func Pending(entries []Entry) []Entry {
+	outcomes := make(map[uint64]bool)
+	for _, entry := range entries {
+		if entry.Status != StatusIntended && entry.Status != StatusPublished {
+			outcomes[entry.ID] = true
+		}
+	}
+	var pending []Entry
+	for _, entry := range entries {
+		if entry.Status == StatusIntended && !outcomes[entry.ID] {
+			pending = append(pending, entry)
+		}
+	}
+	return pending
+}
+
+// Intend records that the action described by entry is about to be sent.
+// Returns the recorded entry which must be passed to Complete once the outcome is known.
+func (j *Journal) Intend(entry Entry) (Entry, error) {
+	if j == nil {
+		return entry, nil
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	entry.ID = j.nextID
+	j.nextID++
+	entry.Status = StatusIntended
+	return entry, j.write(entry)
+}
+
+// Published records a transaction that is about to be published for a previously intended action.
+// It must be recorded before the transaction is sent so that it can be found on chain if the challenger stops
+// before the outcome is known.
+func (j *Journal) Published(intended Entry, tx *ethtypes.Transaction) error {
+	if j == nil {
+		return nil
+	}
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to recover transaction sender: %w", err)
+	}
+	nonce := tx.Nonce()
+	entry := intended
+	entry.Status = StatusPublished
+	entry.TxHash = tx.Hash()
+	entry.From = from
+	entry.Nonce = &nonce
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.write(entry)
+}
+
+// Complete records the outcome of a previously intended action.
+func (j *Journal) Complete(intended Entry, status Status, txHash common.Hash, err error) error {
+	if j == nil {
+		return nil
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return j.write(outcome(intended, status, txHash, err))
+}
+
+// Reconcile records an outcome for each action that was in flight when the journal was last written to.
+// Actions found in claims are marked as reconciled. Otherwise the transactions published for the action are looked
+// up using txs: mined transactions are recorded as confirmed or reverted and actions with a transaction still
+// pending, either by hash or by its sender's nonce, remain in flight. All other actions are marked as abandoned and
+// will be recalculated from the current game state.
+// Returns the number of actions that were reconciled, remain in flight and were abandoned.
+func (j *Journal) Reconcile(ctx context.Context, claims []types.Claim, txs TxLookup) (reconciled int, inFlight int, abandoned int, err error) {
+	if j == nil {
+		return 0, 0, 0, nil
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	j.txs = txs
+	var remaining []pendingAction
+	for _, action := range j.pending {
+		status, txHash := StatusReconciled, common.Hash{}
+		if !onChain(action.intended, claims) {
+			status, txHash, err = j.lookup(ctx, action)
+			if err != nil {
+				return reconciled, inFlight, abandoned, err
+			}
+		}
+		switch status {
+		case StatusIntended:
+			inFlight++
+			remaining = append(remaining, action)
+			continue
+		case StatusAbandoned:
+			abandoned++
+		default:
+			reconciled++
+		}
+		if err := j.write(outcome(action.intended, status, txHash, nil)); err != nil {
+			return reconciled, inFlight, abandoned, err
+		}
+	}
+	j.pending = remaining
+	return reconciled, inFlight, abandoned, nil
+}
+
+// InFlight returns true if an action matching entry was in flight when the challenger last stopped and has not yet
+// been mined or dropped. Actions that were in flight are checked again and their outcome recorded once known.
+// Returns true for actions that were mined since they may not yet be visible in the game state.
+func (j *Journal) InFlight(ctx context.Context, entry Entry) (bool, error) {
+	if j == nil {
+		return false, nil
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	for i, action := range j.pending {
+		if !action.intended.sameAction(entry) {
+			continue
+		}
+		status, txHash, err := j.lookup(ctx, action)
+		if err != nil {
+			return false, err
+		}
+		if status == StatusIntended {
+			return true, nil
+		}
+		if err := j.write(outcome(action.intended, status, txHash, nil)); err != nil {
+			return false, err
+		}
+		j.pending = append(j.pending[:i:i], j.pending[i+1:]...)
+		return status != StatusAbandoned, nil
+	}
+	return false, nil
+}
+
+// lookup determines the state of the transactions published for action. Returns StatusIntended if a transaction
+// may still be mined, StatusConfirmed or StatusReverted with the transaction hash if one was mined, or
+// StatusAbandoned if no transaction was published or all have been dropped.
+func (j *Journal) lookup(ctx context.Context, action pendingAction) (Status, common.Hash, error) {
+	if j.txs == nil || len(action.published) == 0 {
+		return StatusAbandoned, common.Hash{}, nil
+	}
+	for _, published := range action.published {
+		receipt, err := j.txs.TransactionReceipt(ctx, published.TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return "", common.Hash{}, fmt.Errorf("failed to fetch receipt for tx %v: %w", published.TxHash, err)
+		}
+		if receipt.Status == ethtypes.ReceiptStatusFailed {
+			return StatusReverted, published.TxHash, nil
+		}
+		return StatusConfirmed, published.TxHash, nil
+	}
+	for _, published := range action.published {
+		_, isPending, err := j.txs.TransactionByHash(ctx, published.TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return "", common.Hash{}, fmt.Errorf("failed to fetch tx %v: %w", published.TxHash, err)
+		}
+		if isPending {
+			return StatusIntended, common.Hash{}, nil
+		}
+	}
+	// The transaction may have been replaced by one with the same nonce that wasn't recorded, such as a fee bump
+	// published as the challenger stopped. Keep waiting while the nonce is still pending.
+	last := action.published[len(action.published)-1]
+	if last.Nonce == nil {
+		return StatusAbandoned, common.Hash{}, nil
+	}
+	latest, err := j.txs.NonceAt(ctx, last.From, nil)
+	if err != nil {
+		return "", common.Hash{}, fmt.Errorf("failed to fetch nonce for %v: %w", last.From, err)
+	}
+	pending, err := j.txs.PendingNonceAt(ctx, last.From)
+	if err != nil {
+		return "", common.Hash{}, fmt.Errorf("failed to fetch pending nonce for %v: %w", last.From, err)
+	}
+	if *last.Nonce >= latest && *last.Nonce < pending {
+		return StatusIntended, common.Hash{}, nil
+	}
+	return StatusAbandoned, common.Hash{}, nil
+}
+
+// onChain returns true if the effect of the action in entry is visible in claims.
+// Resolution and oracle updates are not reflected in claims so are never considered to be on chain.
+func onChain(entry Entry, claims []types.Claim) bool {
+	if entry.ParentIdx >= uint64(len(claims)) {
+		return false
+	}
+	parent := claims[entry.ParentIdx]
+	switch entry.Kind {
+	case KindMove:
+		pos := parent.Position.Defend()
+		if entry.IsAttack {
+			pos = parent.Position.Attack()
+		}
+		for _, claim := range claims {
+			if claim.ParentContractIndex == parent.ContractIndex && claim.Value == entry.Value && claim.Position.ToGIndex().Cmp(pos.ToGIndex()) == 0 {
+				return true
+			}
+		}
+		return false
+	case KindStep:
+		return parent.Countered
+	default:
+		return false
+	}
+}
+
+func outcome(intended Entry, status Status, txHash common.Hash, err error) Entry {
+	intended.Status = status
+	intended.TxHash = txHash
+	intended.From = common.Address{}
+	intended.Nonce = nil
+	if err != nil {
+		intended.Error = err.Error()
+	}
+	return intended
+}
+
+// write appends entry to the journal and syncs it to disk.
+// The file is only held open while writing so that journals for games no longer being played don't hold resources.
+func (j *Journal) write(entry Entry) error {
+	entry.Time = j.now()
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal entry: %w", err)
+	}
+	file, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+func TestReadMissingJournal(t *testing.T) {
+	entries, err := Read(t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestRecordActions(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "game")
+	j, err := Open(dir)
+	require.NoError(t, err)
+
+	move, err := j.Intend(Entry{Kind: KindMove, ParentIdx: 1, IsAttack: true, Value: common.Hash{0xaa}})
+	require.NoError(t, err)
+	step, err := j.Intend(Entry{Kind: KindStep, ParentIdx: 2})
+	require.NoError(t, err)
+	require.NotEqual(t, move.ID, step.ID)
+	require.NoError(t, j.Complete(move, StatusConfirmed, common.Hash{0xbb}, nil))
+
+	entries, err := Read(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, StatusConfirmed, entries[2].Status)
+	require.Equal(t, common.Hash{0xbb}, entries[2].TxHash)
+	require.Equal(t, []Entry{entries[1]}, Pending(entries))
+
+	t.Run("ContinueIDsAfterReopen", func(t *testing.T) {
+		j, err := Open(dir)
+		require.NoError(t, err)
+		entry, err := j.Intend(Entry{Kind: KindResolve})
+		require.NoError(t, err)
+		require.Greater(t, entry.ID, step.ID)
+	})
+}
+
+func TestRecordFailure(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(dir)
+	require.NoError(t, err)
+	entry, err := j.Intend(Entry{Kind: KindResolve})
+	require.NoError(t, err)
+	require.NoError(t, j.Complete(entry, StatusFailed, common.Hash{}, errors.New("boom")))
+
+	entries, err := Read(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "boom", entries[1].Error)
+	require.Empty(t, Pending(entries))
+}
+
+func TestIgnorePartialEntry(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(dir)
+	require.NoError(t, err)
+	_, err = j.Intend(Entry{Kind: KindResolve})
+	require.NoError(t, err)
+
+	// Simulate a crash part way through writing an entry
+	file, err := os.OpenFile(filepath.Join(dir, fileName), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte(`{"id":1,"sta`))
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	j, err = Open(dir)
+	require.NoError(t, err)
+	_, err = j.Intend(Entry{Kind: KindResolveClaim})
+	require.NoError(t, err)
+
+	entries, err := Read(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, KindResolve, entries[0].Kind)
+	require.Equal(t, KindResolveClaim, entries[1].Kind)
+}
+
+func TestNilJournal(t *testing.T) {
+	var j *Journal
+	entry, err := j.Intend(Entry{Kind: KindMove})
+	require.NoError(t, err)
+	require.NoError(t, j.Complete(entry, StatusConfirmed, common.Hash{}, nil))
+	require.NoError(t, j.Published(entry, signedTx(t, 0)))
+	reconciled, inFlight, abandoned, err := j.Reconcile(context.Background(), nil, nil)
+	require.NoError(t, err)
+	require.Zero(t, reconciled)
+	require.Zero(t, inFlight)
+	require.Zero(t, abandoned)
+	inFlightAction, err := j.InFlight(context.Background(), entry)
+	require.NoError(t, err)
+	require.False(t, inFlightAction)
+}
+
+func TestReconcile(t *testing.T) {
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(big.NewInt(1))}}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xaa}, Position: root.Position.Attack()},
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+		Countered:           true,
+	}
+	defend := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xcc}, Position: attack.Position.Defend()},
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	claims := []types.Claim{root, attack, defend}
+
+	dir := t.TempDir()
+	j, err := Open(dir)
+	require.NoError(t, err)
+	intend := func(entry Entry) {
+		_, err := j.Intend(entry)
+		require.NoError(t, err)
+	}
+	intend(Entry{Kind: KindMove, ParentIdx: 0, IsAttack: true, Value: common.Hash{0xaa}})  // On chain
+	intend(Entry{Kind: KindMove, ParentIdx: 1, IsAttack: false, Value: common.Hash{0xcc}}) // On chain
+	intend(Entry{Kind: KindMove, ParentIdx: 1, IsAttack: true, Value: common.Hash{0xcc}})  // Wrong position
+	intend(Entry{Kind: KindMove, ParentIdx: 1, IsAttack: false, Value: common.Hash{0xdd}}) // Wrong value
+	intend(Entry{Kind: KindStep, ParentIdx: 1})                                            // Parent countered
+	intend(Entry{Kind: KindStep, ParentIdx: 0})                                            // Parent not countered
+	intend(Entry{Kind: KindMove, ParentIdx: 5, IsAttack: true})                            // Unknown parent
+	intend(Entry{Kind: KindResolveClaim, ParentIdx: 0})                                    // Not visible in claims
+
+	// Reconciliation happens when the journal is next opened
+	j, err = Open(dir)
+	require.NoError(t, err)
+	reconciled, inFlight, abandoned, err := j.Reconcile(context.Background(), claims, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, reconciled)
+	require.Zero(t, inFlight)
+	require.Equal(t, 5, abandoned)
+
+	entries, err := Read(dir)
+	require.NoError(t, err)
+	require.Empty(t, Pending(entries))
+	outcomes := entries[8:]
+	expected := []Status{StatusReconciled, StatusReconciled, StatusAbandoned, StatusAbandoned, StatusReconciled, StatusAbandoned, StatusAbandoned, StatusAbandoned}
+	for i, entry := range outcomes {
+		require.Equal(t, expected[i], entry.Status, "entry %v", i)
+		require.EqualValues(t, i, entry.ID)
+	}
+
+	// Nothing left to reconcile
+	reconciled, inFlight, abandoned, err = j.Reconcile(context.Background(), claims, nil)
+	require.NoError(t, err)
+	require.Zero(t, reconciled)
+	require.Zero(t, inFlight)
+	require.Zero(t, abandoned)
+}
+
+func TestReconcilePublishedTransactions(t *testing.T) {
+	dir := t.TempDir()
+	j, err := Open(dir)
+	require.NoError(t, err)
+	publish := func(kind Kind, nonces ...uint64) []*ethtypes.Transaction {
+		entry, err := j.Intend(Entry{Kind: kind})
+		require.NoError(t, err)
+		var txs []*ethtypes.Transaction
+		for _, nonce := range nonces {
+			tx := signedTx(t, nonce)
+			require.NoError(t, j.Published(entry, tx))
+			txs = append(txs, tx)
+		}
+		return txs
+	}
+	lookup := &stubTxLookup{
+		receipts: make(map[common.Hash]*ethtypes.Receipt),
+		pending:  make(map[common.Hash]bool),
+		latest:   3,
+		next:     5,
+	}
+	confirmed := publish(KindResolve, 0, 0)
+	lookup.receipts[confirmed[1].Hash()] = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}
+	reverted := publish(KindResolveClaim, 1)
+	lookup.receipts[reverted[0].Hash()] = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusFailed}
+	pending := publish(KindUpdateOracle, 6)
+	lookup.pending[pending[0].Hash()] = true
+	publish(KindResolveClaim, 4) // Unknown tx but the nonce is still pending
+	publish(KindResolveClaim, 2) // Dropped and the nonce has been used
+	publish(KindResolveClaim)    // Never published
+
+	j, err = Open(dir)
+	require.NoError(t, err)
+	reconciled, inFlight, abandoned, err := j.Reconcile(context.Background(), nil, lookup)
+	require.NoError(t, err)
+	require.Equal(t, 2, reconciled)
+	require.Equal(t, 2, inFlight)
+	require.Equal(t, 2, abandoned)
+
+	entries, err := Read(dir)
+	require.NoError(t, err)
+	pendingEntries := Pending(entries)
+	require.Len(t, pendingEntries, 2)
+	require.Equal(t, KindUpdateOracle, pendingEntries[0].Kind)
+	outcomes := make(map[uint64]Entry)
+	for _, entry := range entries {
+		if entry.Status != StatusIntended && entry.Status != StatusPublished {
+			outcomes[entry.ID] = entry
+		}
+	}
+	require.Equal(t, StatusConfirmed, outcomes[0].Status)
+	require.Equal(t, confirmed[1].Hash(), outcomes[0].TxHash)
+	require.Equal(t, StatusReverted, outcomes[1].Status)
+	require.Equal(t, StatusAbandoned, outcomes[4].Status)
+	require.Equal(t, StatusAbandoned, outcomes[5].Status)
+
+	t.Run("InFlight", func(t *testing.T) {
+		// Matching actions are in flight until mined
+		inFlight, err := j.InFlight(context.Background(), Entry{Kind: KindUpdateOracle})
+		require.NoError(t, err)
+		require.True(t, inFlight)
+		inFlight, err = j.InFlight(context.Background(), Entry{Kind: KindUpdateOracle, ParentIdx: 1})
+		require.NoError(t, err)
+		require.False(t, inFlight)
+
+		lookup.receipts[pending[0].Hash()] = &ethtypes.Receipt{Status: ethtypes.ReceiptStatusSuccessful}
+		inFlight, err = j.InFlight(context.Background(), Entry{Kind: KindUpdateOracle})
+		require.NoError(t, err)
+		require.True(t, inFlight, "should not resend mined action")
+		inFlight, err = j.InFlight(context.Background(), Entry{Kind: KindUpdateOracle})
+		require.NoError(t, err)
+		require.False(t, inFlight, "should only report outcome once")
+
+		// Once the nonce is used, the remaining action is abandoned
+		lookup.latest = 5
+		inFlight, err = j.InFlight(context.Background(), Entry{Kind: KindResolveClaim})
+		require.NoError(t, err)
+		require.False(t, inFlight)
+
+		entries, err := Read(dir)
+		require.NoError(t, err)
+		require.Empty(t, Pending(entries))
+	})
+}
+
+func TestGameDirNotInGameData(t *testing.T) {
+	dir := GameDir("/data", common.Address{0xaa})
+	require.Equal(t, filepath.Join("/data", dirName, common.Address{0xaa}.Hex()), dir)
+}
+
+var testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+
+func signedTx(t *testing.T, nonce uint64) *ethtypes.Transaction {
+	tx, err := ethtypes.SignNewTx(testKey, ethtypes.LatestSignerForChainID(big.NewInt(1)), &ethtypes.DynamicFeeTx{
+		ChainID: big.NewInt(1),
+		Nonce:   nonce,
+		// Vary the fee so replacements have a different hash
+		GasFeeCap: big.NewInt(int64(rand.Intn(1000))),
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+type stubTxLookup struct {
+	receipts map[common.Hash]*ethtypes.Receipt
+	pending  map[common.Hash]bool
+	latest   uint64
+	next     uint64
+}
+
+func (s *stubTxLookup) TransactionReceipt(_ context.Context, txHash common.Hash) (*ethtypes.Receipt, error) {
+	receipt, ok := s.receipts[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (s *stubTxLookup) TransactionByHash(_ context.Context, txHash common.Hash) (*ethtypes.Transaction, bool, error) {
+	if !s.pending[txHash] {
+		return nil, false, ethereum.NotFound
+	}
+	return nil, true, nil
+}
+
+func (s *stubTxLookup) NonceAt(_ context.Context, _ common.Address, _ *big.Int) (uint64, error) {
+	return s.latest, nil
+}
+
+func (s *stubTxLookup) PendingNonceAt(_ context.Context, _ common.Address) (uint64, error) {
+	return s.next, nil
+}
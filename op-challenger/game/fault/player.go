@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/journal"
+	faultResponder "github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
@@ -16,26 +18,45 @@ import (
 
 type actor func(ctx context.Context) error
 
+type rootClaimChecker func(ctx context.Context) (bool, error)
+
 type GameInfo interface {
 	GetStatus(context.Context) (gameTypes.GameStatus, error)
 	GetClaimCount(context.Context) (uint64, error)
 }
 
 type GamePlayer struct {
-	act    actor
-	loader GameInfo
-	logger log.Logger
-	status gameTypes.GameStatus
+	act actor
+	// resolve resolves the game without acting on it, used for games that are skipped
+	resolve actor
+	loader  GameInfo
+	logger  log.Logger
+	status  gameTypes.GameStatus
+
+	// agreeWithRoot checks if the root claim is correct. Only set when uncontested games should be skipped.
+	agreeWithRoot rootClaimChecker
+	// agreedRoot caches the result of agreeWithRoot since the root claim can't change.
+	agreedRoot *bool
 }
 
 type GameContract interface {
-	responder.GameContract
+	faultResponder.GameContract
 	GameInfo
 	ClaimLoader
 	GetStatus(ctx context.Context) (gameTypes.GameStatus, error)
 	GetMaxGameDepth(ctx context.Context) (uint64, error)
 }
 
+// PlayerOptions configures how a GamePlayer participates in a game.
+type PlayerOptions struct {
+	// SkipUncontested skips acting on games where the only claim is a root claim we agree with.
+	SkipUncontested bool
+	// SelectiveDefense only counters claims that are currently defeating a claim we agree with.
+	SelectiveDefense bool
+	// DryRun calculates and logs the actions to take without sending any transactions.
+	DryRun bool
+}
+
 type resourceCreator func(ctx context.Context, logger log.Logger, gameDepth uint64, dir string) (types.TraceAccessor, error)
 
 func NewGamePlayer(
@@ -43,10 +64,13 @@ func NewGamePlayer(
 	logger log.Logger,
 	m metrics.Metricer,
 	dir string,
+	journalDir string,
 	addr common.Address,
 	txMgr txmgr.TxManager,
+	txs journal.TxLookup,
 	loader GameContract,
 	creator resourceCreator,
+	opts PlayerOptions,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
 
@@ -78,18 +102,58 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to create trace accessor: %w", err)
 	}
 
-	responder, err := responder.NewFaultResponder(logger, txMgr, loader)
+	actionJournal, err := openJournal(ctx, logger, journalDir, loader, txs)
+	if err != nil {
+		return nil, err
+	}
+
+	var responder Responder
+	responder, err = faultResponder.NewFaultResponder(logger, txMgr, loader, actionJournal)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
+	if opts.DryRun {
+		responder = NewDryRunResponder(logger, m, responder)
+	}
+
+	gameSolver := solver.NewGameSolver(int(gameDepth), accessor)
+	if opts.SelectiveDefense {
+		gameSolver = solver.NewSelectiveGameSolver(int(gameDepth), accessor)
+	}
 
-	agent := NewAgent(m, loader, int(gameDepth), accessor, responder, logger)
-	return &GamePlayer{
-		act:    agent.Act,
-		loader: loader,
-		logger: logger,
-		status: status,
-	}, nil
+	agent := NewAgent(m, loader, int(gameDepth), gameSolver, responder, logger)
+	player := &GamePlayer{
+		act:     agent.Act,
+		resolve: agent.Resolve,
+		loader:  loader,
+		logger:  logger,
+		status:  status,
+	}
+	if opts.SkipUncontested {
+		player.agreeWithRoot = agent.AgreeWithRootClaim
+	}
+	return player, nil
+}
+
+// openJournal opens the action journal for the game and reconciles any actions that were in flight when the
+// challenger last stopped against the claims currently in the game and the transactions published for them.
+func openJournal(ctx context.Context, logger log.Logger, dir string, loader ClaimLoader, txs journal.TxLookup) (*journal.Journal, error) {
+	j, err := journal.Open(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open action journal: %w", err)
+	}
+	claims, err := loader.GetAllClaims(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load claims to reconcile action journal: %w", err)
+	}
+	reconciled, inFlight, abandoned, err := j.Reconcile(ctx, claims, txs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile action journal: %w", err)
+	}
+	if reconciled > 0 || inFlight > 0 || abandoned > 0 {
+		logger.Info("Reconciled in flight actions", "reconciled", reconciled, "inFlight", inFlight, "abandoned", abandoned)
+	}
+	return j, nil
 }
 
 func (g *GamePlayer) Status() gameTypes.GameStatus {
@@ -102,9 +166,17 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 		g.logger.Trace("Skipping completed game")
 		return g.status
 	}
-	g.logger.Trace("Checking if actions are required")
-	if err := g.act(ctx); err != nil {
-		g.logger.Error("Error when acting on game", "err", err)
+	if g.uncontested(ctx) {
+		// No moves are required but the game still needs to be resolved once its clock expires
+		g.logger.Debug("Skipping moves in uncontested game")
+		if err := g.resolve(ctx); err != nil {
+			g.logger.Error("Error when resolving game", "err", err)
+		}
+	} else {
+		g.logger.Trace("Checking if actions are required")
+		if err := g.act(ctx); err != nil {
+			g.logger.Error("Error when acting on game", "err", err)
+		}
 	}
 	status, err := g.loader.GetStatus(ctx)
 	if err != nil {
@@ -116,6 +188,31 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	return status
 }
 
+// uncontested returns true if skipping uncontested games is enabled, the root claim is the only claim in the game
+// and it is a claim we agree with. Returns false if any of these can't be determined.
+func (g *GamePlayer) uncontested(ctx context.Context) bool {
+	if g.agreeWithRoot == nil {
+		return false
+	}
+	claimCount, err := g.loader.GetClaimCount(ctx)
+	if err != nil {
+		g.logger.Warn("Failed to get claim count", "err", err)
+		return false
+	}
+	if claimCount != 1 {
+		return false
+	}
+	if g.agreedRoot == nil {
+		agree, err := g.agreeWithRoot(ctx)
+		if err != nil {
+			g.logger.Warn("Failed to check root claim", "err", err)
+			return false
+		}
+		g.agreedRoot = &agree
+	}
+	return *g.agreedRoot
+}
+
 func (g *GamePlayer) logGameStatus(ctx context.Context, status gameTypes.GameStatus) {
 	if status == gameTypes.GameStatusInProgress {
 		claimCount, err := g.loader.GetClaimCount(ctx)
@@ -93,6 +93,52 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestSkipUncontestedGames(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t)
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, gameState.callCount, "should act")
+	})
+
+	t.Run("SkipAgreedUncontestedRoot", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t)
+		rootChecks := 0
+		game.agreeWithRoot = func(ctx context.Context) (bool, error) {
+			rootChecks++
+			return true, nil
+		}
+		game.ProgressGame(context.Background())
+		game.ProgressGame(context.Background())
+		require.Zero(t, gameState.callCount, "should not act")
+		require.Equal(t, 2, gameState.resolveCount, "should still resolve")
+		require.Equal(t, 1, rootChecks, "should cache root claim agreement")
+
+		// Once the root claim is countered, the game is acted on as normal
+		gameState.claimCount = 2
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, gameState.callCount, "should act")
+	})
+
+	t.Run("ActWhenDisagreeWithRoot", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t)
+		game.agreeWithRoot = func(ctx context.Context) (bool, error) {
+			return false, nil
+		}
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, gameState.callCount, "should act")
+	})
+
+	t.Run("ActWhenRootCheckFails", func(t *testing.T) {
+		_, game, gameState := setupProgressGameTest(t)
+		game.agreeWithRoot = func(ctx context.Context) (bool, error) {
+			return false, errors.New("boom")
+		}
+		game.ProgressGame(context.Background())
+		require.Equal(t, 1, gameState.callCount, "should act")
+		require.Nil(t, game.agreedRoot, "should retry checking root claim")
+	})
+}
+
 // TestValidateAbsolutePrestate tests that the absolute prestate is validated
 // correctly by the service component.
 func TestValidateAbsolutePrestate(t *testing.T) {
@@ -138,19 +184,21 @@ func setupProgressGameTest(t *testing.T) (*testlog.CapturingHandler, *GamePlayer
 	logger.SetHandler(handler)
 	gameState := &stubGameState{claimCount: 1}
 	game := &GamePlayer{
-		act:    gameState.Act,
-		loader: gameState,
-		logger: logger,
+		act:     gameState.Act,
+		resolve: gameState.Resolve,
+		loader:  gameState,
+		logger:  logger,
 	}
 	return handler, game, gameState
 }
 
 type stubGameState struct {
-	status     gameTypes.GameStatus
-	claimCount uint64
-	callCount  int
-	actErr     error
-	Err        error
+	status       gameTypes.GameStatus
+	claimCount   uint64
+	callCount    int
+	resolveCount int
+	actErr       error
+	Err          error
 }
 
 func (s *stubGameState) Act(ctx context.Context) error {
@@ -158,6 +206,11 @@ func (s *stubGameState) Act(ctx context.Context) error {
 	return s.actErr
 }
 
+func (s *stubGameState) Resolve(ctx context.Context) error {
+	s.resolveCount++
+	return nil
+}
+
 func (s *stubGameState) GetStatus(ctx context.Context) (gameTypes.GameStatus, error) {
 	return s.status, nil
 }
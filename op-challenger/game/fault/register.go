@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/journal"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
@@ -40,10 +41,12 @@ func RegisterGameTypes(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
+	txs journal.TxLookup,
 	caller *batching.MultiCaller,
 ) (CloseFunc, error) {
 	var closer CloseFunc
 	var l2Client *ethclient.Client
+	cannonLimiter := cannon.NewExecutionLimiter(cfg.CannonMaxExecutions)
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) || cfg.TraceTypeEnabled(config.TraceTypeOutputCannon) {
 		l2, err := ethclient.DialContext(ctx, cfg.CannonL2)
 		if err != nil {
@@ -53,16 +56,16 @@ func RegisterGameTypes(
 		closer = l2Client.Close
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeOutputCannon) {
-		registerOutputCannon(registry, ctx, logger, m, cfg, txMgr, caller, l2Client)
+		registerOutputCannon(registry, ctx, logger, m, cfg, cannonLimiter, txMgr, txs, caller, l2Client)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeOutputAlphabet) {
-		registerOutputAlphabet(registry, ctx, logger, m, cfg, txMgr, caller)
+		registerOutputAlphabet(registry, ctx, logger, m, cfg, txMgr, txs, caller)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		registerCannon(registry, ctx, logger, m, cfg, txMgr, caller, l2Client)
+		registerCannon(registry, ctx, logger, m, cfg, cannonLimiter, txMgr, txs, caller, l2Client)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		registerAlphabet(registry, ctx, logger, m, cfg, txMgr, caller)
+		registerAlphabet(registry, ctx, logger, m, cfg, txMgr, txs, caller)
 	}
 	return closer, nil
 }
@@ -74,6 +77,7 @@ func registerOutputAlphabet(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
+	txs journal.TxLookup,
 	caller *batching.MultiCaller) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewOutputBisectionGameContract(game.Proxy, caller)
@@ -96,7 +100,7 @@ func registerOutputAlphabet(
 			}
 			return accessor, nil
 		}
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, creator)
+		return NewGamePlayer(ctx, logger, m, dir, journal.GameDir(cfg.Datadir, game.Proxy), game.Proxy, txMgr, txs, contract, creator, playerOptions(cfg))
 	}
 	registry.RegisterGameType(outputAlphabetGameType, playerCreator)
 }
@@ -107,7 +111,9 @@ func registerOutputCannon(
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
+	cannonLimiter *cannon.ExecutionLimiter,
 	txMgr txmgr.TxManager,
+	txs journal.TxLookup,
 	caller *batching.MultiCaller,
 	l2Client cannon.L2HeaderSource) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
@@ -121,13 +127,13 @@ func registerOutputCannon(
 			if err != nil {
 				return nil, err
 			}
-			accessor, err := outputs.NewOutputCannonTraceAccessor(ctx, logger, m, cfg, l2Client, contract, dir, gameDepth, agreed, disputed)
+			accessor, err := outputs.NewOutputCannonTraceAccessor(ctx, logger, m, cfg, cannonLimiter, l2Client, contract, dir, gameDepth, agreed, disputed)
 			if err != nil {
 				return nil, err
 			}
 			return accessor, nil
 		}
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, creator)
+		return NewGamePlayer(ctx, logger, m, dir, journal.GameDir(cfg.Datadir, game.Proxy), game.Proxy, txMgr, txs, contract, creator, playerOptions(cfg))
 	}
 	registry.RegisterGameType(outputCannonGameType, playerCreator)
 }
@@ -138,7 +144,9 @@ func registerCannon(
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
+	cannonLimiter *cannon.ExecutionLimiter,
 	txMgr txmgr.TxManager,
+	txs journal.TxLookup,
 	caller *batching.MultiCaller,
 	l2Client cannon.L2HeaderSource) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
@@ -151,13 +159,13 @@ func registerCannon(
 			if err != nil {
 				return nil, fmt.Errorf("failed to fetch cannon local inputs: %w", err)
 			}
-			provider := cannon.NewTraceProvider(logger, m, cfg, faultTypes.NoLocalContext, localInputs, dir, gameDepth)
+			provider := cannon.NewTraceProvider(logger, m, cfg, cannonLimiter, faultTypes.NoLocalContext, localInputs, dir, gameDepth)
 			if err := ValidateAbsolutePrestate(ctx, provider, contract); err != nil {
 				return nil, err
 			}
 			return trace.NewSimpleTraceAccessor(provider), nil
 		}
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, creator)
+		return NewGamePlayer(ctx, logger, m, dir, journal.GameDir(cfg.Datadir, game.Proxy), game.Proxy, txMgr, txs, contract, creator, playerOptions(cfg))
 	}
 	registry.RegisterGameType(cannonGameType, playerCreator)
 }
@@ -169,6 +177,7 @@ func registerAlphabet(
 	m metrics.Metricer,
 	cfg *config.Config,
 	txMgr txmgr.TxManager,
+	txs journal.TxLookup,
 	caller *batching.MultiCaller) {
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
 		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
@@ -182,7 +191,15 @@ func registerAlphabet(
 			}
 			return trace.NewSimpleTraceAccessor(provider), nil
 		}
-		return NewGamePlayer(ctx, logger, m, dir, game.Proxy, txMgr, contract, creator)
+		return NewGamePlayer(ctx, logger, m, dir, journal.GameDir(cfg.Datadir, game.Proxy), game.Proxy, txMgr, txs, contract, creator, playerOptions(cfg))
 	}
 	registry.RegisterGameType(alphabetGameType, playerCreator)
 }
+
+func playerOptions(cfg *config.Config) PlayerOptions {
+	return PlayerOptions{
+		SkipUncontested:  cfg.SkipUncontested,
+		SelectiveDefense: cfg.SelectiveDefense,
+		DryRun:           cfg.DryRun,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/journal"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -30,14 +31,17 @@ type FaultResponder struct {
 
 	txMgr    txmgr.TxManager
 	contract GameContract
+	journal  *journal.Journal
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, txMgr txmgr.TxManager, contract GameContract) (*FaultResponder, error) {
+// Transactions are recorded in the supplied journal, which may be nil to disable journaling.
+func NewFaultResponder(logger log.Logger, txMgr txmgr.TxManager, contract GameContract, journal *journal.Journal) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
 		txMgr:    txMgr,
 		contract: contract,
+		journal:  journal,
 	}, nil
 }
 
@@ -54,7 +58,7 @@ func (r *FaultResponder) Resolve(ctx context.Context) error {
 		return err
 	}
 
-	return r.sendTxAndWait(ctx, candidate)
+	return r.sendTxAndWait(ctx, candidate, journal.Entry{Kind: journal.KindResolve})
 }
 
 // CallResolveClaim determines if the resolveClaim function on the fault dispute game contract
@@ -69,7 +73,7 @@ func (r *FaultResponder) ResolveClaim(ctx context.Context, claimIdx uint64) erro
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, candidate)
+	return r.sendTxAndWait(ctx, candidate, journal.Entry{Kind: journal.KindResolveClaim, ParentIdx: claimIdx})
 }
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
@@ -79,39 +83,80 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		if err != nil {
 			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
 		}
-		if err := r.sendTxAndWait(ctx, candidate); err != nil {
+		if err := r.sendTxAndWait(ctx, candidate, journal.Entry{Kind: journal.KindUpdateOracle, ParentIdx: uint64(action.ParentIdx)}); err != nil {
 			return fmt.Errorf("failed to populate pre-image oracle: %w", err)
 		}
 	}
 	var candidate txmgr.TxCandidate
 	var err error
+	entry := journal.Entry{ParentIdx: uint64(action.ParentIdx), IsAttack: action.IsAttack}
 	switch action.Type {
 	case types.ActionTypeMove:
+		entry.Kind = journal.KindMove
+		entry.Value = action.Value
 		if action.IsAttack {
 			candidate, err = r.contract.AttackTx(uint64(action.ParentIdx), action.Value)
 		} else {
 			candidate, err = r.contract.DefendTx(uint64(action.ParentIdx), action.Value)
 		}
 	case types.ActionTypeStep:
+		entry.Kind = journal.KindStep
 		candidate, err = r.contract.StepTx(uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData)
 	}
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, candidate)
+	return r.sendTxAndWait(ctx, candidate, entry)
 }
 
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
-func (r *FaultResponder) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate) error {
+// The action is recorded in the journal before the transaction is sent, each transaction as it is published and
+// the outcome once known. The transaction is not sent if the intent can't be recorded or if the same action was
+// already in flight when the challenger last stopped.
+func (r *FaultResponder) sendTxAndWait(ctx context.Context, candidate txmgr.TxCandidate, entry journal.Entry) error {
+	inFlight, err := r.journal.InFlight(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("failed to check journal for in flight action: %w", err)
+	}
+	if inFlight {
+		r.log.Info("Not sending action already in flight", "kind", entry.Kind, "parent", entry.ParentIdx)
+		return nil
+	}
+	entry, err = r.journal.Intend(entry)
+	if err != nil {
+		return fmt.Errorf("failed to journal intended action: %w", err)
+	}
+	journaled := make(map[common.Hash]bool)
+	candidate.Published = func(tx *ethtypes.Transaction) {
+		if journaled[tx.Hash()] {
+			return
+		}
+		if err := r.journal.Published(entry, tx); err != nil {
+			r.log.Warn("Failed to journal published transaction", "id", entry.ID, "tx_hash", tx.Hash(), "err", err)
+			return
+		}
+		journaled[tx.Hash()] = true
+	}
 	receipt, err := r.txMgr.Send(ctx, candidate)
 	if err != nil {
+		r.completeJournalEntry(entry, journal.StatusFailed, common.Hash{}, err)
 		return err
 	}
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		r.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
+		r.completeJournalEntry(entry, journal.StatusReverted, receipt.TxHash, nil)
 	} else {
 		r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
+		r.completeJournalEntry(entry, journal.StatusConfirmed, receipt.TxHash, nil)
 	}
 	return nil
 }
+
+// completeJournalEntry records the outcome of a sent transaction. Failures are only logged since the transaction
+// has already been sent and the entry will be reconciled with the game state on restart.
+func (r *FaultResponder) completeJournalEntry(entry journal.Entry, status journal.Status, txHash common.Hash, txErr error) {
+	if err := r.journal.Complete(entry, status, txHash, txErr); err != nil {
+		r.log.Warn("Failed to journal action outcome", "id", entry.ID, "status", status, "err", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/journal"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -196,11 +197,60 @@ func TestPerformAction(t *testing.T) {
 	})
 }
 
+func TestJournal(t *testing.T) {
+	t.Run("RecordsSentTransactions", func(t *testing.T) {
+		responder, _, _ := newTestFaultResponder(t)
+		dir := t.TempDir()
+		j, err := journal.Open(dir)
+		require.NoError(t, err)
+		responder.journal = j
+
+		action := types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		}
+		require.NoError(t, responder.PerformAction(context.Background(), action))
+		require.NoError(t, responder.ResolveClaim(context.Background(), 5))
+
+		entries, err := journal.Read(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+		require.Equal(t, journal.StatusIntended, entries[0].Status)
+		require.Equal(t, journal.KindMove, entries[0].Kind)
+		require.EqualValues(t, 123, entries[0].ParentIdx)
+		require.True(t, entries[0].IsAttack)
+		require.Equal(t, common.Hash{0xaa}, entries[0].Value)
+		require.Equal(t, journal.StatusConfirmed, entries[1].Status)
+		require.Equal(t, entries[0].ID, entries[1].ID)
+		require.Equal(t, journal.KindResolveClaim, entries[2].Kind)
+		require.EqualValues(t, 5, entries[2].ParentIdx)
+		require.Empty(t, journal.Pending(entries))
+	})
+
+	t.Run("RecordsFailedTransactions", func(t *testing.T) {
+		responder, mockTxMgr, _ := newTestFaultResponder(t)
+		mockTxMgr.sendFails = true
+		dir := t.TempDir()
+		j, err := journal.Open(dir)
+		require.NoError(t, err)
+		responder.journal = j
+
+		require.ErrorIs(t, responder.Resolve(context.Background()), mockSendError)
+		entries, err := journal.Read(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, journal.StatusFailed, entries[1].Status)
+		require.Equal(t, mockSendError.Error(), entries[1].Error)
+	})
+}
+
 func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
 	contract := &mockContract{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract)
+	responder, err := NewFaultResponder(log, mockTxMgr, contract, nil)
 	require.NoError(t, err)
 	return responder, mockTxMgr, contract
 }
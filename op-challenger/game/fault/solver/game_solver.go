@@ -10,6 +10,8 @@ import (
 
 type GameSolver struct {
 	claimSolver *claimSolver
+	// selective restricts responses to claims that currently defeat a claim we agree with.
+	selective bool
 }
 
 func NewGameSolver(gameDepth int, trace types.TraceAccessor) *GameSolver {
@@ -18,6 +20,16 @@ func NewGameSolver(gameDepth int, trace types.TraceAccessor) *GameSolver {
 	}
 }
 
+// NewSelectiveGameSolver creates a GameSolver that only responds when a claim it agrees with is at risk.
+// Disagreed claims are only countered if they are currently uncountered and their parent, along with every other
+// claim in the path to the root, is a claim we agree with. A disagreed root claim is always countered.
+func NewSelectiveGameSolver(gameDepth int, trace types.TraceAccessor) *GameSolver {
+	return &GameSolver{
+		claimSolver: newClaimSolver(gameDepth, trace),
+		selective:   true,
+	}
+}
+
 func (s *GameSolver) AgreeWithRootClaim(ctx context.Context, game types.Game) (bool, error) {
 	return s.claimSolver.agreeWithClaim(ctx, game, game.Claims()[0])
 }
@@ -27,9 +39,23 @@ func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine if root claim is correct: %w", err)
 	}
+	var uncountered map[int]bool
+	if s.selective {
+		uncountered = types.UncounteredClaims(game.Claims())
+	}
 	var errs []error
 	var actions []types.Action
 	for _, claim := range game.Claims() {
+		if s.selective {
+			risk, err := s.atRisk(ctx, game, agreeWithRootClaim, claim, uncountered)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !risk {
+				continue
+			}
+		}
 		var action *types.Action
 		var err error
 		if uint64(claim.Depth()) == game.MaxDepth() {
@@ -91,3 +117,27 @@ func (s *GameSolver) calculateMove(ctx context.Context, game types.Game, agreeWi
 		Value:     move.Value,
 	}, nil
 }
+
+// atRisk returns true if claim is a disagreed claim that must be countered to protect a claim we agree with.
+// A disagreed root claim is always at risk. Any other disagreed claim is at risk if it is currently uncountered,
+// and so defeating its parent, and its parent is on a path of claims we agree with.
+func (s *GameSolver) atRisk(ctx context.Context, game types.Game, agreeWithRootClaim bool, claim types.Claim, uncountered map[int]bool) (bool, error) {
+	if game.AgreeWithClaimLevel(claim, agreeWithRootClaim) {
+		return false, nil
+	}
+	if claim.IsRoot() {
+		return true, nil
+	}
+	if !uncountered[claim.ContractIndex] {
+		return false, nil
+	}
+	parent, err := game.GetParent(claim)
+	if err != nil {
+		return false, fmt.Errorf("failed to get parent of claim %v: %w", claim.ContractIndex, err)
+	}
+	agree, err := s.claimSolver.agreeWithClaimPath(ctx, game, parent)
+	if err != nil {
+		return false, fmt.Errorf("failed to determine if parent of claim %v is correct: %w", claim.ContractIndex, err)
+	}
+	return agree, nil
+}
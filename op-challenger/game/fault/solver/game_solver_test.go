@@ -18,6 +18,7 @@ func TestCalculateNextActions(t *testing.T) {
 	tests := []struct {
 		name             string
 		rootClaimCorrect bool
+		selective        bool
 		setupGame        func(builder *faulttest.GameBuilder)
 	}{
 		{
@@ -81,6 +82,56 @@ func TestCalculateNextActions(t *testing.T) {
 					Attack(maliciousStateHash)
 			},
 		},
+		{
+			name:      "Selective_AttackRootClaim",
+			selective: true,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				builder.Seq().ExpectAttack()
+			},
+		},
+		{
+			name:             "Selective_DoNotAttackCorrectRootClaim",
+			rootClaimCorrect: true,
+			selective:        true,
+			setupGame:        func(builder *faulttest.GameBuilder) {},
+		},
+		{
+			name:      "Selective_IgnoreClaimDefeatingDishonestClaim",
+			selective: true,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				// The root claim is defended by the uncountered claim so must be countered
+				builder.Seq().ExpectAttack()
+				// The claim at our level is incorrect so isn't protected
+				builder.Seq().Attack(common.Hash{0xaa}).Attack(common.Hash{0xbb})
+			},
+		},
+		{
+			name:      "Selective_CounterClaimDefeatingHonestClaim",
+			selective: true,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				honestClaim := builder.Seq().AttackCorrect()
+				honestClaim.Attack(common.Hash{0xaa}).ExpectAttack()
+			},
+		},
+		{
+			name:      "Selective_IgnoreAlreadyCounteredClaims",
+			selective: true,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				honestClaim := builder.Seq().AttackCorrect()
+				// Already countered by a different honest move so our own response isn't required
+				honestClaim.Attack(common.Hash{0xaa}).DefendCorrect()
+			},
+		},
+		{
+			name:      "Selective_RespondWhenCounterIsDefeated",
+			selective: true,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				honestClaim := builder.Seq().AttackCorrect()
+				dishonestClaim := honestClaim.Attack(common.Hash{0xaa})
+				dishonestClaim.ExpectAttack()
+				dishonestClaim.DefendCorrect().Attack(common.Hash{0xbb}).ExpectStepAttack()
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -94,7 +145,11 @@ func TestCalculateNextActions(t *testing.T) {
 					i, claim.Position.ToGIndex(), claim.Position.TraceIndex(maxDepth), claim.ParentContractIndex, claim.Countered, claim.Value)
 			}
 
-			solver := NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()))
+			accessor := trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider())
+			solver := NewGameSolver(maxDepth, accessor)
+			if test.selective {
+				solver = NewSelectiveGameSolver(maxDepth, accessor)
+			}
 			actions, err := solver.CalculateNextActions(context.Background(), game)
 			require.NoError(t, err)
 			for i, action := range actions {
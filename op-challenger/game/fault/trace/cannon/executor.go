@@ -32,6 +32,7 @@ type cmdExecutor func(ctx context.Context, l log.Logger, binary string, args ...
 type Executor struct {
 	logger           log.Logger
 	metrics          CannonMetricer
+	limiter          *ExecutionLimiter
 	l1               string
 	l2               string
 	inputs           LocalGameInputs
@@ -47,10 +48,11 @@ type Executor struct {
 	cmdExecutor      cmdExecutor
 }
 
-func NewExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, inputs LocalGameInputs) *Executor {
+func NewExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, limiter *ExecutionLimiter, inputs LocalGameInputs) *Executor {
 	return &Executor{
 		logger:           logger,
 		metrics:          m,
+		limiter:          limiter,
 		l1:               cfg.L1EthRpc,
 		l2:               cfg.CannonL2,
 		inputs:           inputs,
@@ -121,6 +123,10 @@ func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) erro
 	if err := os.MkdirAll(proofDir, 0755); err != nil {
 		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
 	}
+	if err := e.limiter.Acquire(ctx); err != nil {
+		return fmt.Errorf("waiting to execute cannon: %w", err)
+	}
+	defer e.limiter.Release()
 	e.logger.Info("Generating trace", "proof", i, "cmd", e.cannon, "args", strings.Join(args, ", "))
 	execStart := time.Now()
 	err = e.cmdExecutor(ctx, e.logger.New("proof", i), e.cannon, args...)
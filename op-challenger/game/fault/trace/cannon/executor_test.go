@@ -41,7 +41,7 @@ func TestGenerateProof(t *testing.T) {
 	}
 	captureExec := func(t *testing.T, cfg config.Config, proofAt uint64) (string, string, map[string]string) {
 		m := &cannonDurationMetrics{}
-		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), m, &cfg, inputs)
+		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), m, &cfg, nil, inputs)
 		executor.selectSnapshot = func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error) {
 			return input, nil
 		}
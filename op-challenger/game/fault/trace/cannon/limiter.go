@@ -0,0 +1,43 @@
+package cannon
+
+import (
+	"context"
+)
+
+// ExecutionLimiter restricts the number of cannon executions that can run concurrently across all games.
+// Executing cannon is far more resource intensive than other game actions, so it is limited separately to the
+// number of games being progressed. A nil *ExecutionLimiter applies no limit.
+type ExecutionLimiter struct {
+	slots chan struct{}
+}
+
+// NewExecutionLimiter creates a limiter allowing at most maxExecutions concurrent cannon executions.
+// Returns nil, which applies no limit, if maxExecutions is 0.
+func NewExecutionLimiter(maxExecutions uint) *ExecutionLimiter {
+	if maxExecutions == 0 {
+		return nil
+	}
+	return &ExecutionLimiter{slots: make(chan struct{}, maxExecutions)}
+}
+
+// Acquire blocks until an execution slot is available or ctx is done.
+// Release must be called after the execution completes if no error is returned.
+func (l *ExecutionLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release returns an execution slot acquired by Acquire.
+func (l *ExecutionLimiter) Release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+}
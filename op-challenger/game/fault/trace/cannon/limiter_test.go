@@ -0,0 +1,33 @@
+package cannon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionLimiter(t *testing.T) {
+	t.Run("NilIsUnlimited", func(t *testing.T) {
+		limiter := NewExecutionLimiter(0)
+		require.Nil(t, limiter)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, limiter.Acquire(context.Background()))
+		}
+		limiter.Release()
+	})
+
+	t.Run("BlocksWhenFull", func(t *testing.T) {
+		limiter := NewExecutionLimiter(2)
+		require.NoError(t, limiter.Acquire(context.Background()))
+		require.NoError(t, limiter.Acquire(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, limiter.Acquire(ctx), context.DeadlineExceeded)
+
+		limiter.Release()
+		require.NoError(t, limiter.Acquire(context.Background()))
+	})
+}
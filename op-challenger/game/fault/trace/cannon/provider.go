@@ -54,12 +54,12 @@ type CannonTraceProvider struct {
 	lastStep uint64
 }
 
-func NewTraceProvider(logger log.Logger, m CannonMetricer, cfg *config.Config, localContext common.Hash, localInputs LocalGameInputs, dir string, gameDepth uint64) *CannonTraceProvider {
+func NewTraceProvider(logger log.Logger, m CannonMetricer, cfg *config.Config, limiter *ExecutionLimiter, localContext common.Hash, localInputs LocalGameInputs, dir string, gameDepth uint64) *CannonTraceProvider {
 	return &CannonTraceProvider{
 		logger:       logger,
 		dir:          dir,
 		prestate:     cfg.CannonAbsolutePreState,
-		generator:    NewExecutor(logger, m, cfg, localInputs),
+		generator:    NewExecutor(logger, m, cfg, limiter, localInputs),
 		gameDepth:    gameDepth,
 		localContext: localContext,
 	}
@@ -21,6 +21,7 @@ func NewOutputCannonTraceAccessor(
 	logger log.Logger,
 	m metrics.Metricer,
 	cfg *config.Config,
+	limiter *cannon.ExecutionLimiter,
 	l2Client cannon.L2HeaderSource,
 	contract cannon.L1HeadSource,
 	dir string,
@@ -43,7 +44,7 @@ func NewOutputCannonTraceAccessor(
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch cannon local inputs: %w", err)
 		}
-		provider := cannon.NewTraceProvider(logger, m, cfg, localContext, localInputs, subdir, bottomDepth)
+		provider := cannon.NewTraceProvider(logger, m, cfg, limiter, localContext, localInputs, subdir, bottomDepth)
 		return provider, nil
 	}
 
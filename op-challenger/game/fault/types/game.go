@@ -119,3 +119,27 @@ func (g *gameState) getParent(claim Claim) *Claim {
 	parent := g.claims[claim.ParentContractIndex]
 	return &parent
 }
+
+// UncounteredClaims determines which claims would be uncountered if the game were resolved in its current state,
+// keyed by contract index. A claim is countered if it was stepped on or if any of its children are uncountered, so
+// claims without children that have not been stepped on are always uncountered. The defender wins the game if the
+// root claim is uncountered.
+func UncounteredClaims(claims []Claim) map[int]bool {
+	countered := make(map[int]bool, len(claims))
+	uncountered := make(map[int]bool, len(claims))
+	// Children are always added after their parent so process claims in reverse order.
+	for i := len(claims) - 1; i >= 0; i-- {
+		claim := claims[i]
+		if claim.Countered {
+			// Claim has been countered by a step.
+			countered[claim.ContractIndex] = true
+		}
+		if !countered[claim.ContractIndex] {
+			uncountered[claim.ContractIndex] = true
+			if !claim.IsRoot() {
+				countered[claim.ParentContractIndex] = true
+			}
+		}
+	}
+	return uncountered
+}
@@ -147,3 +147,15 @@ func buildGameWithClaim(claimGIndex *big.Int, parentGIndex *big.Int) *gameState
 	}
 	return NewGameState([]Claim{parentClaim, claim}, testMaxDepth)
 }
+
+func TestUncounteredClaims(t *testing.T) {
+	root, top, middle, bottom := createTestClaims()
+	require.Equal(t, map[int]bool{0: true}, UncounteredClaims([]Claim{root}))
+	require.Equal(t, map[int]bool{1: true}, UncounteredClaims([]Claim{root, top}))
+	require.Equal(t, map[int]bool{0: true, 2: true}, UncounteredClaims([]Claim{root, top, middle}))
+	require.Equal(t, map[int]bool{1: true, 3: true}, UncounteredClaims([]Claim{root, top, middle, bottom}))
+
+	// A claim that was stepped on no longer counters its parent
+	bottom.Countered = true
+	require.Equal(t, map[int]bool{0: true, 2: true}, UncounteredClaims([]Claim{root, top, middle, bottom}))
+}
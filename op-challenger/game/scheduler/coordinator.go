@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -37,6 +40,7 @@ type coordinator struct {
 	createPlayer PlayerCreator
 	states       map[common.Address]*gameState
 	disk         DiskManager
+	priority     config.GamePriority
 }
 
 // schedule takes the current list of games to attempt to progress, filters out games that have previous
@@ -85,7 +89,8 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata)
 	}
 	c.m.RecordGamesStatus(gamesInProgress, gamesDefenderWon, gamesChallengerWon)
 
-	// Finally, enqueue the jobs
+	// Finally, enqueue the jobs in priority order
+	c.prioritize(jobs)
 	for _, j := range jobs {
 		if err := c.enqueueJob(ctx, j); err != nil {
 			errs = append(errs, fmt.Errorf("failed to enqueue job for game %v: %w", j.addr, err))
@@ -120,10 +125,22 @@ func (c *coordinator) createJob(game types.GameMetadata) (*job, error) {
 		c.logger.Debug("Not rescheduling resolved game", "game", game.Proxy, "status", state.status)
 		return nil, nil
 	}
-	return &job{addr: game.Proxy, player: state.player, status: state.status}, nil
+	return &job{addr: game.Proxy, timestamp: game.Timestamp, player: state.player, status: state.status}, nil
+}
+
+// prioritize sorts jobs into the order they should be progressed in according to the configured priority.
+// Games all have the same maximum duration so the oldest games are the closest to their clocks expiring.
+func (c *coordinator) prioritize(jobs []job) {
+	if c.priority != config.GamePriorityExpiry {
+		return
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].timestamp < jobs[j].timestamp
+	})
 }
 
 func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
+	j.queuedAt = time.Now()
 	for {
 		select {
 		case c.jobQueue <- j:
@@ -162,7 +179,7 @@ func (c *coordinator) deleteResolvedGameFiles() {
 	}
 }
 
-func newCoordinator(logger log.Logger, m SchedulerMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager) *coordinator {
+func newCoordinator(logger log.Logger, m SchedulerMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, priority config.GamePriority) *coordinator {
 	return &coordinator{
 		logger:       logger,
 		m:            m,
@@ -170,6 +187,7 @@ func newCoordinator(logger log.Logger, m SchedulerMetricer, jobQueue chan<- job,
 		resultQueue:  resultQueue,
 		createPlayer: createPlayer,
 		disk:         disk,
+		priority:     priority,
 		states:       make(map[common.Address]*gameState),
 	}
 }
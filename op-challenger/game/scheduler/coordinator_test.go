@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
@@ -203,6 +204,35 @@ func TestDoNotDeleteDataForGameThatFailedToCreatePlayer(t *testing.T) {
 	require.NotNil(t, j.player, "should have created player for game 1")
 }
 
+func TestScheduleOldestGamesFirst(t *testing.T) {
+	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	c.priority = config.GamePriorityExpiry
+	games := []types.GameMetadata{
+		{Proxy: common.Address{0xaa}, Timestamp: 300},
+		{Proxy: common.Address{0xbb}, Timestamp: 100},
+		{Proxy: common.Address{0xcc}, Timestamp: 200},
+		{Proxy: common.Address{0xdd}, Timestamp: 100},
+	}
+	require.NoError(t, c.schedule(context.Background(), games))
+	require.Len(t, workQueue, len(games))
+	var order []common.Address
+	for i := 0; i < len(games); i++ {
+		order = append(order, (<-workQueue).addr)
+	}
+	require.Equal(t, []common.Address{{0xbb}, {0xdd}, {0xcc}, {0xaa}}, order)
+}
+
+func TestScheduleInFactoryOrderWithFIFOPriority(t *testing.T) {
+	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	games := []types.GameMetadata{
+		{Proxy: common.Address{0xaa}, Timestamp: 300},
+		{Proxy: common.Address{0xbb}, Timestamp: 100},
+	}
+	require.NoError(t, c.schedule(context.Background(), games))
+	require.Equal(t, common.Address{0xaa}, (<-workQueue).addr)
+	require.Equal(t, common.Address{0xbb}, (<-workQueue).addr)
+}
+
 func TestDropOldGameStates(t *testing.T) {
 	c, workQueue, _, _, _ := setupCoordinatorTest(t, 10)
 	gameAddr1 := common.Address{0xaa}
@@ -237,7 +267,7 @@ func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan jo
 		created: make(map[common.Address]*test.StubGamePlayer),
 	}
 	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
-	c := newCoordinator(logger, metrics.NoopMetrics, workQueue, resultQueue, games.CreateGame, disk)
+	c := newCoordinator(logger, metrics.NoopMetrics, workQueue, resultQueue, games.CreateGame, disk, config.GamePriorityFIFO)
 	return c, workQueue, resultQueue, games, disk
 }
 
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -19,7 +20,9 @@ type DiskManager interface {
 }
 
 type job struct {
-	addr   common.Address
-	player GamePlayer
-	status types.GameStatus
+	addr      common.Address
+	timestamp uint64
+	player    GamePlayer
+	status    types.GameStatus
+	queuedAt  time.Time
 }
@@ -3,12 +3,14 @@ package scheduler
 import (
 	"context"
 	"sync"
+	"time"
 )
 
 // progressGames accepts jobs from in channel, calls ProgressGame on the job.player and returns the job
 // with updated job.resolved via the out channel.
+// The time each job spent waiting in the queue is reported to queueLatency before it is progressed.
 // The loop exits when the ctx is done.  wg.Done() is called when the function returns.
-func progressGames(ctx context.Context, in <-chan job, out chan<- job, wg *sync.WaitGroup, threadActive, threadIdle func()) {
+func progressGames(ctx context.Context, in <-chan job, out chan<- job, wg *sync.WaitGroup, threadActive, threadIdle func(), queueLatency func(job, time.Duration)) {
 	defer wg.Done()
 	for {
 		select {
@@ -16,6 +18,7 @@ func progressGames(ctx context.Context, in <-chan job, out chan<- job, wg *sync.
 			return
 		case j := <-in:
 			threadActive()
+			queueLatency(j, time.Since(j.queuedAt))
 			j.status = j.player.ProgressGame(ctx)
 			out <- j
 			threadIdle()
@@ -22,10 +22,11 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 	defer cancel()
 	var wg sync.WaitGroup
 	wg.Add(1)
-	go progressGames(ctx, in, out, &wg, ms.ThreadActive, ms.ThreadIdle)
+	go progressGames(ctx, in, out, &wg, ms.ThreadActive, ms.ThreadIdle, ms.QueueLatency)
 
 	in <- job{
-		player: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress},
+		player:   &test.StubGamePlayer{StatusValue: types.GameStatusInProgress},
+		queuedAt: time.Now().Add(-time.Minute),
 	}
 	waitErr := wait.For(context.Background(), 100*time.Millisecond, func() (bool, error) {
 		return ms.activeCalls >= 1, nil
@@ -33,6 +34,7 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 	require.NoError(t, waitErr)
 	require.Equal(t, ms.activeCalls, 1)
 	require.Equal(t, ms.idleCalls, 1)
+	require.GreaterOrEqual(t, ms.latencies[0], time.Minute)
 
 	in <- job{
 		player: &test.StubGamePlayer{StatusValue: types.GameStatusDefenderWon},
@@ -58,6 +60,7 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 type metricSink struct {
 	activeCalls int
 	idleCalls   int
+	latencies   []time.Duration
 }
 
 func (m *metricSink) ThreadActive() {
@@ -68,6 +71,10 @@ func (m *metricSink) ThreadIdle() {
 	m.idleCalls++
 }
 
+func (m *metricSink) QueueLatency(_ job, latency time.Duration) {
+	m.latencies = append(m.latencies, latency)
+}
+
 func readWithTimeout[T any](t *testing.T, ch <-chan T) T {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
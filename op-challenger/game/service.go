@@ -7,6 +7,7 @@ import (
 	"io"
 	"sync/atomic"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -36,7 +37,7 @@ type Service struct {
 
 	faultGamesCloser fault.CloseFunc
 
-	txMgr *txmgr.SimpleTxManager
+	txMgr txmgr.TxManager
 
 	loader *loader.GameLoader
 
@@ -97,7 +98,7 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 }
 
 func (s *Service) initTxManager(cfg *config.Config) error {
-	txMgr, err := txmgr.NewSimpleTxManager("challenger", s.logger, s.metrics, cfg.TxMgrConfig)
+	txMgr, err := txmgr.NewTxManager("challenger", s.logger, s.metrics, cfg.TxMgrConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create the transaction manager: %w", err)
 	}
@@ -152,7 +153,11 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	}
 	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
 	s.metricsSrv = metricsSrv
-	s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txMgr.From())
+	senders := []common.Address{s.txMgr.From()}
+	if pool, ok := s.txMgr.(*txmgr.PoolTxManager); ok {
+		senders = pool.Senders()
+	}
+	s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, senders)
 	return nil
 }
 
@@ -169,14 +174,14 @@ func (s *Service) initGameLoader(cfg *config.Config) error {
 func (s *Service) initScheduler(ctx context.Context, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.logger, s.metrics, cfg, s.txMgr, caller)
+	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.logger, s.metrics, cfg, s.txMgr, s.l1Client, caller)
 	if err != nil {
 		return err
 	}
 	s.faultGamesCloser = closer
 
 	disk := newDiskManager(cfg.Datadir)
-	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, gameTypeRegistry.CreatePlayer)
+	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, cfg.GamePriority, gameTypeRegistry.CreatePlayer)
 	return nil
 }
 
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -20,7 +21,7 @@ type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
 
-	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
+	StartBalanceMetrics(l log.Logger, client *ethclient.Client, accounts []common.Address) io.Closer
 
 	// Record Tx metrics
 	txmetrics.TxMetricer
@@ -30,12 +31,14 @@ type Metricer interface {
 
 	RecordGameStep()
 	RecordGameMove()
+	RecordDryRunAction(action string)
 	RecordCannonExecutionTime(t float64)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameUpdateQueueLatency(latency time.Duration)
 
 	IncActiveExecutors()
 	DecActiveExecutors()
@@ -60,10 +63,14 @@ type Metrics struct {
 	moves prometheus.Counter
 	steps prometheus.Counter
 
+	dryRunActions prometheus.CounterVec
+
 	cannonExecutionTime prometheus.Histogram
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	gameUpdateQueueLatency prometheus.Histogram
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -110,6 +117,13 @@ func NewMetrics() *Metrics {
 			Name:      "steps",
 			Help:      "Number of game steps made by the challenge agent",
 		}),
+		dryRunActions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "dry_run_actions",
+			Help:      "Number of actions the challenge agent would have taken if not running in dry-run mode",
+		}, []string{
+			"action",
+		}),
 		cannonExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "cannon_execution_time",
@@ -130,6 +144,12 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		gameUpdateQueueLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "game_update_queue_latency",
+			Help:      "Time (in seconds) a scheduled game update waited for an executor",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2.0, 14),
+		}),
 	}
 }
 
@@ -140,9 +160,13 @@ func (m *Metrics) Start(host string, port int) (*httputil.HTTPServer, error) {
 func (m *Metrics) StartBalanceMetrics(
 	l log.Logger,
 	client *ethclient.Client,
-	account common.Address,
+	accounts []common.Address,
 ) io.Closer {
-	return opmetrics.LaunchBalanceMetrics(l, m.registry, m.ns, client, account)
+	// A single account keeps the unlabelled balance metric
+	if len(accounts) == 1 {
+		return opmetrics.LaunchBalanceMetrics(l, m.registry, m.ns, client, accounts[0])
+	}
+	return opmetrics.LaunchBalancesMetrics(l, m.registry, m.ns, client, accounts)
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
@@ -169,6 +193,10 @@ func (m *Metrics) RecordGameStep() {
 	m.steps.Add(1)
 }
 
+func (m *Metrics) RecordDryRunAction(action string) {
+	m.dryRunActions.WithLabelValues(action).Inc()
+}
+
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
@@ -195,6 +223,10 @@ func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
 }
 
+func (m *Metrics) RecordGameUpdateQueueLatency(latency time.Duration) {
+	m.gameUpdateQueueLatency.Observe(latency.Seconds())
+}
+
 func (m *Metrics) RecordGameUpdateScheduled() {
 	m.inflightGames.Add(1)
 }
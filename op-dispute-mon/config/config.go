@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+)
+
+var (
+	ErrMissingL1EthRPC           = errors.New("missing l1 eth rpc url")
+	ErrMissingGameFactoryAddress = errors.New("missing game factory address")
+	ErrMissingRollupRpc          = errors.New("missing rollup rpc url")
+	ErrInvalidMonitorInterval    = errors.New("monitor interval must be greater than 0")
+)
+
+const (
+	// DefaultGameWindow is the default maximum time duration in the past
+	// that the monitor will look for games to monitor.
+	DefaultGameWindow = time.Duration(11 * 24 * time.Hour)
+	// DefaultMonitorInterval is the default time between checks of all games.
+	DefaultMonitorInterval = 30 * time.Second
+	// DefaultExpiryWarning is the default time before a game expires at which it is reported
+	// if it is heading to resolve incorrectly.
+	DefaultExpiryWarning = 24 * time.Hour
+)
+
+// Config is a well typed config that is parsed from the CLI params.
+// It also contains config options for auxiliary services.
+type Config struct {
+	L1EthRpc           string         // L1 RPC Url
+	GameFactoryAddress common.Address // Address of the dispute game factory
+	RollupRpc          string         // The trusted op-node RPC used to determine the correct output roots
+
+	MonitorInterval time.Duration // Frequency to check all games
+	GameWindow      time.Duration // Maximum window to look for games to monitor
+	ExpiryWarning   time.Duration // Time before expiry to report games heading to resolve incorrectly
+
+	MetricsConfig opmetrics.CLIConfig
+	PprofConfig   oppprof.CLIConfig
+}
+
+func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc string) Config {
+	return Config{
+		L1EthRpc:           l1EthRpc,
+		GameFactoryAddress: gameFactoryAddress,
+		RollupRpc:          rollupRpc,
+
+		MonitorInterval: DefaultMonitorInterval,
+		GameWindow:      DefaultGameWindow,
+		ExpiryWarning:   DefaultExpiryWarning,
+
+		MetricsConfig: opmetrics.DefaultCLIConfig(),
+		PprofConfig:   oppprof.DefaultCLIConfig(),
+	}
+}
+
+func (c Config) Check() error {
+	if c.L1EthRpc == "" {
+		return ErrMissingL1EthRPC
+	}
+	if c.GameFactoryAddress == (common.Address{}) {
+		return ErrMissingGameFactoryAddress
+	}
+	if c.RollupRpc == "" {
+		return ErrMissingRollupRpc
+	}
+	if c.MonitorInterval <= 0 {
+		return ErrInvalidMonitorInterval
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return fmt.Errorf("metrics config: %w", err)
+	}
+	if err := c.PprofConfig.Check(); err != nil {
+		return fmt.Errorf("pprof config: %w", err)
+	}
+	return nil
+}
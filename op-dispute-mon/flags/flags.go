@@ -0,0 +1,120 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+)
+
+const (
+	envVarPrefix = "OP_DISPUTE_MON"
+)
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(envVarPrefix, name)
+}
+
+var (
+	// Required Flags
+	L1EthRpcFlag = &cli.StringFlag{
+		Name:    "l1-eth-rpc",
+		Usage:   "HTTP provider URL for L1.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC"),
+	}
+	FactoryAddressFlag = &cli.StringFlag{
+		Name:    "game-factory-address",
+		Usage:   "Address of the fault game factory contract.",
+		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
+	}
+	RollupRpcFlag = &cli.StringFlag{
+		Name:    "rollup-rpc",
+		Usage:   "HTTP provider URL for the trusted rollup node used to determine the correct output roots.",
+		EnvVars: prefixEnvVars("ROLLUP_RPC"),
+	}
+	// Optional Flags
+	MonitorIntervalFlag = &cli.DurationFlag{
+		Name:    "monitor-interval",
+		Usage:   "The time between checks of all games.",
+		EnvVars: prefixEnvVars("MONITOR_INTERVAL"),
+		Value:   config.DefaultMonitorInterval,
+	}
+	GameWindowFlag = &cli.DurationFlag{
+		Name:    "game-window",
+		Usage:   "The time window which the monitor will look for games to monitor.",
+		EnvVars: prefixEnvVars("GAME_WINDOW"),
+		Value:   config.DefaultGameWindow,
+	}
+	ExpiryWarningFlag = &cli.DurationFlag{
+		Name:    "expiry-warning",
+		Usage:   "Report games heading to resolve incorrectly when they are within this duration of expiring.",
+		EnvVars: prefixEnvVars("EXPIRY_WARNING"),
+		Value:   config.DefaultExpiryWarning,
+	}
+)
+
+// requiredFlags are checked by [CheckRequired]
+var requiredFlags = []cli.Flag{
+	L1EthRpcFlag,
+	FactoryAddressFlag,
+	RollupRpcFlag,
+}
+
+// optionalFlags is a list of unchecked cli flags
+var optionalFlags = []cli.Flag{
+	MonitorIntervalFlag,
+	GameWindowFlag,
+	ExpiryWarningFlag,
+}
+
+func init() {
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
+
+// NewConfigFromCLI parses the Config from the provided flags or environment variables.
+func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
+	if err := CheckRequired(ctx); err != nil {
+		return nil, err
+	}
+	gameFactoryAddress, err := opservice.ParseAddress(ctx.String(FactoryAddressFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	metricsConfig := opmetrics.ReadCLIConfig(ctx)
+	pprofConfig := oppprof.ReadCLIConfig(ctx)
+
+	return &config.Config{
+		L1EthRpc:           ctx.String(L1EthRpcFlag.Name),
+		GameFactoryAddress: gameFactoryAddress,
+		RollupRpc:          ctx.String(RollupRpcFlag.Name),
+
+		MonitorInterval: ctx.Duration(MonitorIntervalFlag.Name),
+		GameWindow:      ctx.Duration(GameWindowFlag.Name),
+		ExpiryWarning:   ctx.Duration(ExpiryWarningFlag.Name),
+
+		MetricsConfig: metricsConfig,
+		PprofConfig:   pprofConfig,
+	}, nil
+}
@@ -0,0 +1,217 @@
+package metrics
+
+import (
+	"math/big"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_dispute_mon"
+
+// GameAgreementStatus describes whether the root claim of a game is correct and how the game is resolving.
+type GameAgreementStatus uint8
+
+const (
+	// AgreeDefenderAhead means the root claim is correct and the game is currently resolving in its favour.
+	AgreeDefenderAhead GameAgreementStatus = iota
+	// DisagreeDefenderAhead means the root claim is incorrect but the game is currently resolving in its favour.
+	DisagreeDefenderAhead
+	// AgreeChallengerAhead means the root claim is correct but the game is currently resolving against it.
+	AgreeChallengerAhead
+	// DisagreeChallengerAhead means the root claim is incorrect and the game is currently resolving against it.
+	DisagreeChallengerAhead
+
+	// AgreeDefenderWins means the root claim is correct and the game resolved in its favour.
+	AgreeDefenderWins
+	// DisagreeDefenderWins means the root claim is incorrect but the game resolved in its favour.
+	DisagreeDefenderWins
+	// AgreeChallengerWins means the root claim is correct but the game resolved against it.
+	AgreeChallengerWins
+	// DisagreeChallengerWins means the root claim is incorrect and the game resolved against it.
+	DisagreeChallengerWins
+)
+
+var gameAgreementStatuses = map[GameAgreementStatus][2]string{
+	AgreeDefenderAhead:      {"agree", "defender_ahead"},
+	DisagreeDefenderAhead:   {"disagree", "defender_ahead"},
+	AgreeChallengerAhead:    {"agree", "challenger_ahead"},
+	DisagreeChallengerAhead: {"disagree", "challenger_ahead"},
+	AgreeDefenderWins:       {"agree", "defender_won"},
+	DisagreeDefenderWins:    {"disagree", "defender_won"},
+	AgreeChallengerWins:     {"agree", "challenger_won"},
+	DisagreeChallengerWins:  {"disagree", "challenger_won"},
+}
+
+func (s GameAgreementStatus) String() string {
+	labels, ok := gameAgreementStatuses[s]
+	if !ok {
+		return "unknown"
+	}
+	return labels[0] + "_" + labels[1]
+}
+
+// Incorrect returns true if the game is resolving, or has resolved, contrary to the correct output root.
+func (s GameAgreementStatus) Incorrect() bool {
+	switch s {
+	case DisagreeDefenderAhead, AgreeChallengerAhead, DisagreeDefenderWins, AgreeChallengerWins:
+		return true
+	default:
+		return false
+	}
+}
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	RecordGameAgreement(status GameAgreementStatus, count int)
+	RecordExpiringIncorrectGames(count int)
+	RecordUnsupportedGames(count int)
+	RecordUnclaimedBonds(games int, total *big.Int)
+	RecordMonitorFailure()
+	RecordMonitorDuration(seconds float64)
+	RecordLastMonitorTimestamp(timestamp uint64)
+}
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	gamesAgreement    prometheus.GaugeVec
+	expiringIncorrect prometheus.Gauge
+	unsupportedGames  prometheus.Gauge
+
+	unclaimedBondGames prometheus.Gauge
+	unclaimedBonds     prometheus.Gauge
+
+	monitorFailures      prometheus.Counter
+	monitorDuration      prometheus.Histogram
+	lastMonitorTimestamp prometheus.Gauge
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       Namespace,
+		registry: registry,
+		factory:  factory,
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "up",
+			Help:      "1 if the op-dispute-mon has finished starting up",
+		}),
+		gamesAgreement: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "games_agreement",
+			Help:      "Number of games broken down by whether the root claim is correct and the game's expected or actual result",
+		}, []string{
+			"root_agreement",
+			"result",
+		}),
+		expiringIncorrect: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "expiring_incorrect_games",
+			Help:      "Number of in progress games that are heading to resolve incorrectly and are close to expiring",
+		}),
+		unsupportedGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "unsupported_games",
+			Help:      "Number of games with a game type that can't be monitored",
+		}),
+		unclaimedBondGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "unclaimed_bond_games",
+			Help:      "Number of resolved games that still hold bonds",
+		}),
+		unclaimedBonds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "unclaimed_bonds",
+			Help:      "Total value (in wei) of bonds held by resolved games",
+		}),
+		monitorFailures: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "monitor_failures",
+			Help:      "Number of times checking games failed",
+		}),
+		monitorDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "monitor_duration",
+			Help:      "Time (in seconds) to check all games",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2.0, 12),
+		}),
+		lastMonitorTimestamp: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "last_monitor_timestamp",
+			Help:      "Unix timestamp of the last successful check of all games",
+		}),
+	}
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and
+// config info for the op-dispute-mon.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	prometheus.MustRegister()
+	m.up.Set(1)
+}
+
+func (m *Metrics) Document() []opmetrics.DocumentedMetric {
+	return m.factory.Document()
+}
+
+func (m *Metrics) RecordGameAgreement(status GameAgreementStatus, count int) {
+	labels := gameAgreementStatuses[status]
+	m.gamesAgreement.WithLabelValues(labels[0], labels[1]).Set(float64(count))
+}
+
+func (m *Metrics) RecordExpiringIncorrectGames(count int) {
+	m.expiringIncorrect.Set(float64(count))
+}
+
+func (m *Metrics) RecordUnsupportedGames(count int) {
+	m.unsupportedGames.Set(float64(count))
+}
+
+func (m *Metrics) RecordUnclaimedBonds(games int, total *big.Int) {
+	m.unclaimedBondGames.Set(float64(games))
+	wei, _ := new(big.Float).SetInt(total).Float64()
+	m.unclaimedBonds.Set(wei)
+}
+
+func (m *Metrics) RecordMonitorFailure() {
+	m.monitorFailures.Inc()
+}
+
+func (m *Metrics) RecordMonitorDuration(seconds float64) {
+	m.monitorDuration.Observe(seconds)
+}
+
+func (m *Metrics) RecordLastMonitorTimestamp(timestamp uint64) {
+	m.lastMonitorTimestamp.Set(float64(timestamp))
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import "math/big"
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordInfo(version string) {}
+func (*NoopMetricsImpl) RecordUp()                 {}
+
+func (*NoopMetricsImpl) RecordGameAgreement(status GameAgreementStatus, count int) {}
+func (*NoopMetricsImpl) RecordExpiringIncorrectGames(count int)                    {}
+func (*NoopMetricsImpl) RecordUnsupportedGames(count int)                          {}
+func (*NoopMetricsImpl) RecordUnclaimedBonds(games int, total *big.Int)            {}
+
+func (*NoopMetricsImpl) RecordMonitorFailure()                       {}
+func (*NoopMetricsImpl) RecordMonitorDuration(seconds float64)       {}
+func (*NoopMetricsImpl) RecordLastMonitorTimestamp(timestamp uint64) {}
@@ -0,0 +1,46 @@
+package mon
+
+import (
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+)
+
+// forecast returns the status the game would have if it were resolved with its current claims.
+// A claim is countered if it was stepped on or if any of its children are uncountered. The defender wins if the root
+// claim is uncountered.
+func forecast(claims []faultTypes.Claim) gameTypes.GameStatus {
+	if len(claims) == 0 {
+		return gameTypes.GameStatusInProgress
+	}
+	if !faultTypes.UncounteredClaims(claims)[claims[0].ContractIndex] {
+		return gameTypes.GameStatusChallengerWon
+	}
+	return gameTypes.GameStatusDefenderWon
+}
+
+// agreementStatus classifies a game based on whether the root claim is correct and the actual or expected result.
+func agreementStatus(agreeWithRoot bool, status gameTypes.GameStatus, expected gameTypes.GameStatus) metrics.GameAgreementStatus {
+	if status == gameTypes.GameStatusInProgress {
+		switch {
+		case agreeWithRoot && expected == gameTypes.GameStatusDefenderWon:
+			return metrics.AgreeDefenderAhead
+		case agreeWithRoot:
+			return metrics.AgreeChallengerAhead
+		case expected == gameTypes.GameStatusDefenderWon:
+			return metrics.DisagreeDefenderAhead
+		default:
+			return metrics.DisagreeChallengerAhead
+		}
+	}
+	switch {
+	case agreeWithRoot && status == gameTypes.GameStatusDefenderWon:
+		return metrics.AgreeDefenderWins
+	case agreeWithRoot:
+		return metrics.AgreeChallengerWins
+	case status == gameTypes.GameStatusDefenderWon:
+		return metrics.DisagreeDefenderWins
+	default:
+		return metrics.DisagreeChallengerWins
+	}
+}
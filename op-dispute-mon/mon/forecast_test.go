@@ -0,0 +1,102 @@
+package mon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+)
+
+func TestForecast(t *testing.T) {
+	root := faultTypes.NewPositionFromGIndex(big.NewInt(1))
+	claim := func(idx int, parent int, pos faultTypes.Position, countered bool) faultTypes.Claim {
+		return faultTypes.Claim{
+			ClaimData:           faultTypes.ClaimData{Position: pos},
+			ContractIndex:       idx,
+			ParentContractIndex: parent,
+			Countered:           countered,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		claims   []faultTypes.Claim
+		expected gameTypes.GameStatus
+	}{
+		{
+			name:     "NoClaims",
+			expected: gameTypes.GameStatusInProgress,
+		},
+		{
+			name:     "UncontestedRoot",
+			claims:   []faultTypes.Claim{claim(0, 0, root, false)},
+			expected: gameTypes.GameStatusDefenderWon,
+		},
+		{
+			name:     "RootCountered",
+			claims:   []faultTypes.Claim{claim(0, 0, root, false), claim(1, 0, root.Attack(), false)},
+			expected: gameTypes.GameStatusChallengerWon,
+		},
+		{
+			name: "CounterCountered",
+			claims: []faultTypes.Claim{
+				claim(0, 0, root, false),
+				claim(1, 0, root.Attack(), false),
+				claim(2, 1, root.Attack().Attack(), false),
+			},
+			expected: gameTypes.GameStatusDefenderWon,
+		},
+		{
+			name: "OneUncounteredChildCountersParent",
+			claims: []faultTypes.Claim{
+				claim(0, 0, root, false),
+				claim(1, 0, root.Attack(), false),
+				claim(2, 1, root.Attack().Attack(), false),
+				claim(3, 0, root.Attack(), false),
+			},
+			expected: gameTypes.GameStatusChallengerWon,
+		},
+		{
+			name: "SteppedOnLeaf",
+			claims: []faultTypes.Claim{
+				claim(0, 0, root, false),
+				claim(1, 0, root.Attack(), true),
+			},
+			expected: gameTypes.GameStatusDefenderWon,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, forecast(test.claims))
+		})
+	}
+}
+
+func TestAgreementStatus(t *testing.T) {
+	tests := []struct {
+		agree    bool
+		status   gameTypes.GameStatus
+		expected gameTypes.GameStatus
+		result   metrics.GameAgreementStatus
+	}{
+		{true, gameTypes.GameStatusInProgress, gameTypes.GameStatusDefenderWon, metrics.AgreeDefenderAhead},
+		{false, gameTypes.GameStatusInProgress, gameTypes.GameStatusDefenderWon, metrics.DisagreeDefenderAhead},
+		{true, gameTypes.GameStatusInProgress, gameTypes.GameStatusChallengerWon, metrics.AgreeChallengerAhead},
+		{false, gameTypes.GameStatusInProgress, gameTypes.GameStatusChallengerWon, metrics.DisagreeChallengerAhead},
+		{true, gameTypes.GameStatusDefenderWon, gameTypes.GameStatusChallengerWon, metrics.AgreeDefenderWins},
+		{false, gameTypes.GameStatusDefenderWon, gameTypes.GameStatusChallengerWon, metrics.DisagreeDefenderWins},
+		{true, gameTypes.GameStatusChallengerWon, gameTypes.GameStatusDefenderWon, metrics.AgreeChallengerWins},
+		{false, gameTypes.GameStatusChallengerWon, gameTypes.GameStatusDefenderWon, metrics.DisagreeChallengerWins},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.result.String(), func(t *testing.T) {
+			require.Equal(t, test.result, agreementStatus(test.agree, test.status, test.expected))
+		})
+	}
+}
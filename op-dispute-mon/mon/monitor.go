@@ -0,0 +1,221 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrUnsupportedGameType = errors.New("unsupported game type")
+
+// gameSource loads information about the games available to monitor
+type gameSource interface {
+	FetchAllGamesAtBlock(ctx context.Context, earliest uint64, blockHash common.Hash) ([]gameTypes.GameMetadata, error)
+}
+
+type OutputRollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// BalanceSource loads the ETH balance of an account.
+type BalanceSource interface {
+	BalanceAtHash(ctx context.Context, account common.Address, blockHash common.Hash) (*big.Int, error)
+}
+
+// GameContract provides the read-only view of a dispute game required to monitor it.
+type GameContract interface {
+	GetStatus(ctx context.Context) (gameTypes.GameStatus, error)
+	GetAllClaims(ctx context.Context) ([]faultTypes.Claim, error)
+	GetGameDuration(ctx context.Context) (uint64, error)
+	GetBlockRange(ctx context.Context) (prestateBlock uint64, poststateBlock uint64, retErr error)
+}
+
+// contractCreator binds to the game contract. Returns ErrUnsupportedGameType if the game can't be monitored.
+type contractCreator func(game gameTypes.GameMetadata) (GameContract, error)
+
+type blockHashFetcher func(ctx context.Context) (common.Hash, error)
+
+type gameMonitor struct {
+	logger         log.Logger
+	clock          clock.Clock
+	metrics        metrics.Metricer
+	source         gameSource
+	rollupClient   OutputRollupClient
+	balances       BalanceSource
+	createContract contractCreator
+	fetchBlockHash blockHashFetcher
+	gameWindow     time.Duration
+	expiryWarning  time.Duration
+	interval       time.Duration
+	loop           *clock.LoopFn
+}
+
+func newGameMonitor(
+	logger log.Logger,
+	cl clock.Clock,
+	m metrics.Metricer,
+	source gameSource,
+	rollupClient OutputRollupClient,
+	balances BalanceSource,
+	createContract contractCreator,
+	fetchBlockHash blockHashFetcher,
+	gameWindow time.Duration,
+	expiryWarning time.Duration,
+	interval time.Duration,
+) *gameMonitor {
+	return &gameMonitor{
+		logger:         logger,
+		clock:          cl,
+		metrics:        m,
+		source:         source,
+		rollupClient:   rollupClient,
+		balances:       balances,
+		createContract: createContract,
+		fetchBlockHash: fetchBlockHash,
+		gameWindow:     gameWindow,
+		expiryWarning:  expiryWarning,
+		interval:       interval,
+	}
+}
+
+func (m *gameMonitor) minGameTimestamp() uint64 {
+	if m.gameWindow.Seconds() == 0 {
+		return 0
+	}
+	if m.clock.Now().Unix() > int64(m.gameWindow.Seconds()) {
+		return uint64(m.clock.Now().Add(-m.gameWindow).Unix())
+	}
+	return 0
+}
+
+// monitorGames checks every game within the game window and updates the metrics.
+// Games that can't be checked are logged and excluded from the metrics.
+func (m *gameMonitor) monitorGames(ctx context.Context) error {
+	start := m.clock.Now()
+	blockHash, err := m.fetchBlockHash(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block hash: %w", err)
+	}
+	games, err := m.source.FetchAllGamesAtBlock(ctx, m.minGameTimestamp(), blockHash)
+	if err != nil {
+		return fmt.Errorf("failed to load games: %w", err)
+	}
+	counts := make(map[metrics.GameAgreementStatus]int)
+	unsupported := 0
+	expiring := 0
+	unclaimedGames := 0
+	unclaimedBonds := new(big.Int)
+	for _, game := range games {
+		state, err := m.checkGame(ctx, game, blockHash)
+		if errors.Is(err, ErrUnsupportedGameType) {
+			unsupported++
+			continue
+		} else if err != nil {
+			m.logger.Error("Failed to check game", "game", game.Proxy, "err", err)
+			continue
+		}
+		counts[state.agreement]++
+		if state.agreement.Incorrect() {
+			m.logger.Warn("Game is resolving incorrectly", "game", game.Proxy, "result", state.agreement, "expiry", state.expiry)
+		}
+		if state.status == gameTypes.GameStatusInProgress && state.agreement.Incorrect() && !m.clock.Now().Before(state.expiry.Add(-m.expiryWarning)) {
+			expiring++
+		}
+		if state.status != gameTypes.GameStatusInProgress && state.balance.Sign() > 0 {
+			unclaimedGames++
+			unclaimedBonds.Add(unclaimedBonds, state.balance)
+		}
+	}
+	for status := metrics.AgreeDefenderAhead; status <= metrics.DisagreeChallengerWins; status++ {
+		m.metrics.RecordGameAgreement(status, counts[status])
+	}
+	m.metrics.RecordExpiringIncorrectGames(expiring)
+	m.metrics.RecordUnsupportedGames(unsupported)
+	m.metrics.RecordUnclaimedBonds(unclaimedGames, unclaimedBonds)
+	m.metrics.RecordMonitorDuration(m.clock.Now().Sub(start).Seconds())
+	m.metrics.RecordLastMonitorTimestamp(uint64(m.clock.Now().Unix()))
+	return nil
+}
+
+type gameState struct {
+	status    gameTypes.GameStatus
+	agreement metrics.GameAgreementStatus
+	expiry    time.Time
+	// balance is the value of the bonds held by the game. The game contracts don't yet pay out bonds or track
+	// credits per claimant so any balance left in a resolved game is unclaimed.
+	balance *big.Int
+}
+
+func (m *gameMonitor) checkGame(ctx context.Context, game gameTypes.GameMetadata, blockHash common.Hash) (gameState, error) {
+	contract, err := m.createContract(game)
+	if err != nil {
+		return gameState{}, err
+	}
+	status, err := contract.GetStatus(ctx)
+	if err != nil {
+		return gameState{}, fmt.Errorf("failed to load game status: %w", err)
+	}
+	claims, err := contract.GetAllClaims(ctx)
+	if err != nil {
+		return gameState{}, fmt.Errorf("failed to load claims: %w", err)
+	}
+	if len(claims) == 0 {
+		return gameState{}, errors.New("no claims")
+	}
+	duration, err := contract.GetGameDuration(ctx)
+	if err != nil {
+		return gameState{}, fmt.Errorf("failed to load game duration: %w", err)
+	}
+	_, l2BlockNum, err := contract.GetBlockRange(ctx)
+	if err != nil {
+		return gameState{}, fmt.Errorf("failed to load block range: %w", err)
+	}
+	output, err := m.rollupClient.OutputAtBlock(ctx, l2BlockNum)
+	if err != nil {
+		return gameState{}, fmt.Errorf("failed to get output at block %v: %w", l2BlockNum, err)
+	}
+	balance, err := m.balances.BalanceAtHash(ctx, game.Proxy, blockHash)
+	if err != nil {
+		return gameState{}, fmt.Errorf("failed to load game balance: %w", err)
+	}
+	agreeWithRoot := common.Hash(output.OutputRoot) == claims[0].Value
+	return gameState{
+		status:    status,
+		agreement: agreementStatus(agreeWithRoot, status, forecast(claims)),
+		expiry:    time.Unix(int64(game.Timestamp+duration), 0),
+		balance:   balance,
+	}, nil
+}
+
+func (m *gameMonitor) onTick(ctx context.Context) {
+	if err := m.monitorGames(ctx); err != nil {
+		m.metrics.RecordMonitorFailure()
+		m.logger.Error("Failed to monitor games", "err", err)
+	}
+}
+
+func (m *gameMonitor) StartMonitoring() {
+	if m.loop != nil {
+		return // already started
+	}
+	m.loop = clock.NewLoopFn(m.clock, m.onTick, nil, m.interval)
+}
+
+func (m *gameMonitor) StopMonitoring() {
+	if m.loop == nil {
+		return // already stopped
+	}
+	_ = m.loop.Close()
+	m.loop = nil
+}
@@ -0,0 +1,277 @@
+package mon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	correctOutput   = common.Hash{0xaa}
+	incorrectOutput = common.Hash{0xbb}
+	gameDuration    = uint64(1000)
+)
+
+func TestMonitorGames(t *testing.T) {
+	t.Run("ClassifiesGames", func(t *testing.T) {
+		monitor, source, contracts, m, _ := setupMonitorTest(t)
+		source.games = []gameTypes.GameMetadata{
+			newGame(contracts, 1, gameTypes.GameStatusInProgress, correctOutput, false),
+			newGame(contracts, 2, gameTypes.GameStatusInProgress, correctOutput, true),
+			newGame(contracts, 3, gameTypes.GameStatusInProgress, incorrectOutput, false),
+			newGame(contracts, 4, gameTypes.GameStatusInProgress, incorrectOutput, true),
+			newGame(contracts, 5, gameTypes.GameStatusDefenderWon, correctOutput, false),
+			newGame(contracts, 6, gameTypes.GameStatusChallengerWon, incorrectOutput, true),
+			newGame(contracts, 7, gameTypes.GameStatusDefenderWon, incorrectOutput, false),
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, map[metrics.GameAgreementStatus]int{
+			metrics.AgreeDefenderAhead:      1,
+			metrics.AgreeChallengerAhead:    1,
+			metrics.DisagreeDefenderAhead:   1,
+			metrics.DisagreeChallengerAhead: 1,
+			metrics.AgreeDefenderWins:       1,
+			metrics.DisagreeChallengerWins:  1,
+			metrics.DisagreeDefenderWins:    1,
+			metrics.AgreeChallengerWins:     0,
+		}, m.agreement)
+		require.Zero(t, m.expiring)
+		require.NotZero(t, m.lastTimestamp)
+	})
+
+	t.Run("ReportsIncorrectGamesCloseToExpiry", func(t *testing.T) {
+		monitor, source, contracts, m, cl := setupMonitorTest(t)
+		source.games = []gameTypes.GameMetadata{
+			newGame(contracts, 1, gameTypes.GameStatusInProgress, correctOutput, false),
+			newGame(contracts, 2, gameTypes.GameStatusInProgress, incorrectOutput, false),
+			newGame(contracts, 3, gameTypes.GameStatusInProgress, incorrectOutput, true),
+			newGame(contracts, 4, gameTypes.GameStatusDefenderWon, incorrectOutput, false),
+		}
+		// Games are created at the current time so are not yet close to expiry
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Zero(t, m.expiring)
+
+		cl.AdvanceTime(time.Duration(gameDuration)*time.Second - monitor.expiryWarning)
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, 1, m.expiring)
+	})
+
+	t.Run("CountsUnsupportedGames", func(t *testing.T) {
+		monitor, source, contracts, m, _ := setupMonitorTest(t)
+		source.games = []gameTypes.GameMetadata{
+			newGame(contracts, 1, gameTypes.GameStatusInProgress, correctOutput, false),
+			{GameType: 0, Proxy: common.Address{0xff}},
+		}
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, 1, m.unsupported)
+		require.Equal(t, 1, m.agreement[metrics.AgreeDefenderAhead])
+	})
+
+	t.Run("ReportsUnclaimedBonds", func(t *testing.T) {
+		monitor, source, contracts, m, _ := setupMonitorTest(t)
+		balances := monitor.balances.(*stubBalanceSource)
+		source.games = []gameTypes.GameMetadata{
+			newGame(contracts, 1, gameTypes.GameStatusInProgress, correctOutput, false),
+			newGame(contracts, 2, gameTypes.GameStatusDefenderWon, correctOutput, false),
+			newGame(contracts, 3, gameTypes.GameStatusChallengerWon, incorrectOutput, true),
+			newGame(contracts, 4, gameTypes.GameStatusDefenderWon, correctOutput, false),
+		}
+		// Bonds in in-progress games are still at stake so aren't unclaimed
+		balances.balances[common.Address{1}] = big.NewInt(100)
+		balances.balances[common.Address{2}] = big.NewInt(20)
+		balances.balances[common.Address{3}] = big.NewInt(3)
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, 2, m.unclaimedGames)
+		require.Equal(t, big.NewInt(23), m.unclaimedBonds)
+	})
+
+	t.Run("SkipGamesThatFail", func(t *testing.T) {
+		monitor, source, contracts, m, _ := setupMonitorTest(t)
+		source.games = []gameTypes.GameMetadata{
+			newGame(contracts, 1, gameTypes.GameStatusInProgress, correctOutput, false),
+			newGame(contracts, 2, gameTypes.GameStatusInProgress, correctOutput, false),
+		}
+		contracts[common.Address{2}].claimsErr = errors.New("boom")
+		require.NoError(t, monitor.monitorGames(context.Background()))
+		require.Equal(t, 1, m.agreement[metrics.AgreeDefenderAhead])
+	})
+
+	t.Run("FailWhenGamesCannotBeLoaded", func(t *testing.T) {
+		monitor, source, _, _, _ := setupMonitorTest(t)
+		source.err = errors.New("boom")
+		require.ErrorIs(t, monitor.monitorGames(context.Background()), source.err)
+	})
+}
+
+func TestMonitorLoop(t *testing.T) {
+	monitor, source, contracts, m, cl := setupMonitorTest(t)
+	source.games = []gameTypes.GameMetadata{
+		newGame(contracts, 1, gameTypes.GameStatusInProgress, correctOutput, false),
+	}
+	monitor.StartMonitoring()
+	defer monitor.StopMonitoring()
+	require.Eventually(t, func() bool {
+		cl.AdvanceTime(monitor.interval)
+		return m.monitorCount() > 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func setupMonitorTest(t *testing.T) (*gameMonitor, *stubGameSource, map[common.Address]*stubGameContract, *stubMetrics, *clock.DeterministicClock) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	cl := clock.NewDeterministicClock(time.Unix(10_000, 0))
+	source := &stubGameSource{}
+	contracts := make(map[common.Address]*stubGameContract)
+	m := &stubMetrics{}
+	createContract := func(game gameTypes.GameMetadata) (GameContract, error) {
+		contract, ok := contracts[game.Proxy]
+		if !ok {
+			return nil, ErrUnsupportedGameType
+		}
+		return contract, nil
+	}
+	fetchBlockHash := func(ctx context.Context) (common.Hash, error) {
+		return common.Hash{0x01}, nil
+	}
+	rollup := &stubRollupClient{outputs: map[uint64]common.Hash{}}
+	balances := &stubBalanceSource{balances: make(map[common.Address]*big.Int)}
+	monitor := newGameMonitor(logger, cl, m, source, rollup, balances, createContract, fetchBlockHash,
+		time.Hour, 100*time.Second, time.Second)
+	// All games use the same L2 block
+	rollup.outputs[l2BlockNum] = correctOutput
+	return monitor, source, contracts, m, cl
+}
+
+const l2BlockNum = uint64(42)
+
+// newGame creates a game with the specified status and root claim.
+// If countered is true, the root claim is attacked by an uncountered claim.
+func newGame(contracts map[common.Address]*stubGameContract, id byte, status gameTypes.GameStatus, rootClaim common.Hash, countered bool) gameTypes.GameMetadata {
+	addr := common.Address{id}
+	root := faultTypes.NewPositionFromGIndex(big.NewInt(1))
+	claims := []faultTypes.Claim{{ClaimData: faultTypes.ClaimData{Value: rootClaim, Position: root}}}
+	if countered {
+		claims = append(claims, faultTypes.Claim{
+			ClaimData:     faultTypes.ClaimData{Value: common.Hash{0xcc}, Position: root.Attack()},
+			ContractIndex: 1,
+		})
+	}
+	contracts[addr] = &stubGameContract{status: status, claims: claims}
+	return gameTypes.GameMetadata{GameType: outputCannonGameType, Timestamp: 10_000, Proxy: addr}
+}
+
+type stubGameSource struct {
+	games []gameTypes.GameMetadata
+	err   error
+}
+
+func (s *stubGameSource) FetchAllGamesAtBlock(_ context.Context, _ uint64, _ common.Hash) ([]gameTypes.GameMetadata, error) {
+	return s.games, s.err
+}
+
+type stubRollupClient struct {
+	outputs map[uint64]common.Hash
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	output, ok := s.outputs[blockNum]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &eth.OutputResponse{OutputRoot: eth.Bytes32(output)}, nil
+}
+
+type stubBalanceSource struct {
+	balances map[common.Address]*big.Int
+}
+
+func (s *stubBalanceSource) BalanceAtHash(_ context.Context, account common.Address, _ common.Hash) (*big.Int, error) {
+	balance, ok := s.balances[account]
+	if !ok {
+		return new(big.Int), nil
+	}
+	return balance, nil
+}
+
+type stubGameContract struct {
+	status    gameTypes.GameStatus
+	claims    []faultTypes.Claim
+	claimsErr error
+}
+
+func (s *stubGameContract) GetStatus(_ context.Context) (gameTypes.GameStatus, error) {
+	return s.status, nil
+}
+
+func (s *stubGameContract) GetAllClaims(_ context.Context) ([]faultTypes.Claim, error) {
+	return s.claims, s.claimsErr
+}
+
+func (s *stubGameContract) GetGameDuration(_ context.Context) (uint64, error) {
+	return gameDuration, nil
+}
+
+func (s *stubGameContract) GetBlockRange(_ context.Context) (uint64, uint64, error) {
+	return l2BlockNum - 1, l2BlockNum, nil
+}
+
+type stubMetrics struct {
+	metrics.NoopMetricsImpl
+	lock          sync.Mutex
+	agreement     map[metrics.GameAgreementStatus]int
+	expiring      int
+	unsupported   int
+	lastTimestamp uint64
+
+	unclaimedGames int
+	unclaimedBonds *big.Int
+	monitors       int
+}
+
+func (s *stubMetrics) RecordGameAgreement(status metrics.GameAgreementStatus, count int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.agreement == nil {
+		s.agreement = make(map[metrics.GameAgreementStatus]int)
+	}
+	s.agreement[status] = count
+}
+
+func (s *stubMetrics) RecordExpiringIncorrectGames(count int) {
+	s.expiring = count
+}
+
+func (s *stubMetrics) RecordUnsupportedGames(count int) {
+	s.unsupported = count
+}
+
+func (s *stubMetrics) RecordUnclaimedBonds(games int, total *big.Int) {
+	s.unclaimedGames = games
+	s.unclaimedBonds = total
+}
+
+func (s *stubMetrics) RecordLastMonitorTimestamp(timestamp uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastTimestamp = timestamp
+	s.monitors++
+}
+
+func (s *stubMetrics) monitorCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.monitors
+}
@@ -52,7 +52,7 @@ func (g *CannonGameHelper) CreateHonestActor(ctx context.Context, rollupCfg *rol
 	defer l2Client.Close() // Not needed after fetching the inputs
 	localInputs, err := cannon.FetchLocalInputs(ctx, gameContract, l2Client)
 	g.require.NoError(err, "fetch cannon local inputs")
-	provider := cannon.NewTraceProvider(logger, metrics.NoopMetrics, cfg, types.NoLocalContext, localInputs, filepath.Join(cfg.Datadir, "honest"), uint64(maxDepth))
+	provider := cannon.NewTraceProvider(logger, metrics.NoopMetrics, cfg, nil, types.NoLocalContext, localInputs, filepath.Join(cfg.Datadir, "honest"), uint64(maxDepth))
 
 	return &HonestHelper{
 		t:            g.t,
@@ -226,6 +226,7 @@ func (h *FactoryHelper) StartCannonGameWithCorrectRoot(ctx context.Context, roll
 		testlog.Logger(h.t, log.LvlInfo).New("role", "CorrectTrace"),
 		metrics.NoopMetrics,
 		cfg,
+		nil,
 		faultTypes.NoLocalContext,
 		inputs,
 		cfg.Datadir,
@@ -0,0 +1,171 @@
+package withdrawals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+const EnvVarPrefix = "OP_NODE_WITHDRAWALS"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
+}
+
+var (
+	l1RPCFlag = &cli.StringFlag{
+		Name:     txmgr.L1RPCFlagName,
+		Usage:    "HTTP provider URL for L1",
+		EnvVars:  prefixEnvVars("L1_ETH_RPC"),
+		Required: true,
+	}
+	l2RPCFlag = &cli.StringFlag{
+		Name:     "l2-eth-rpc",
+		Usage:    "HTTP provider URL for L2. Must serve eth_getProof",
+		EnvVars:  prefixEnvVars("L2_ETH_RPC"),
+		Required: true,
+	}
+	portalFlag = &cli.StringFlag{
+		Name:     "optimism-portal",
+		Usage:    "Address of the OptimismPortal on L1",
+		EnvVars:  prefixEnvVars("OPTIMISM_PORTAL"),
+		Required: true,
+	}
+	txHashFlag = &cli.StringFlag{
+		Name:     "tx-hash",
+		Usage:    "Hash of the L2 transaction initiating the withdrawal",
+		Required: true,
+	}
+	pollIntervalFlag = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "How often to check the withdrawal status while waiting for an output or the finalization period",
+		EnvVars: prefixEnvVars("POLL_INTERVAL"),
+		Value:   12 * time.Second,
+	}
+
+	statusFlags = append([]cli.Flag{
+		l1RPCFlag,
+		l2RPCFlag,
+		portalFlag,
+		txHashFlag,
+	}, oplog.CLIFlags(EnvVarPrefix)...)
+
+	finalizeFlags = append(append([]cli.Flag{
+		l1RPCFlag,
+		l2RPCFlag,
+		portalFlag,
+		txHashFlag,
+		pollIntervalFlag,
+	}, txmgr.CLIFlags(EnvVarPrefix)...), oplog.CLIFlags(EnvVarPrefix)...)
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "status",
+		Usage: "Shows the status of a withdrawal on L1",
+		Flags: statusFlags,
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+			w, txHash, closeFn, err := newWithdrawer(ctx, logger, nil)
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+
+			wd, err := w.Status(ctx.Context, txHash)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(ctx.App.Writer, "Withdrawal hash: %s\n", wd.WithdrawalHash)
+			fmt.Fprintf(ctx.App.Writer, "L2 block:        %d\n", wd.L2BlockNumber)
+			fmt.Fprintf(ctx.App.Writer, "Status:          %s\n", wd.Status)
+			if wd.ProvenAt != 0 {
+				fmt.Fprintf(ctx.App.Writer, "Proven at:       %s\n", time.Unix(int64(wd.ProvenAt), 0).UTC())
+				fmt.Fprintf(ctx.App.Writer, "Finalizable at:  %s\n", time.Unix(int64(wd.FinalizableAt), 0).UTC())
+			}
+			return nil
+		},
+	},
+	{
+		Name:  "finalize",
+		Usage: "Proves and finalizes a withdrawal on L1",
+		Description: "Waits for an output covering the withdrawal to be proposed, proves the withdrawal, waits for the " +
+			"finalization period and finalizes the withdrawal. The progress is read from L1, so an interrupted " +
+			"withdrawal is resumed by running the command again.",
+		Flags: finalizeFlags,
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+			txMgr, err := txmgr.NewSimpleTxManager("withdrawals", logger, &metrics.NoopTxMetrics{}, txmgr.ReadCLIConfig(ctx))
+			if err != nil {
+				return fmt.Errorf("failed to create tx manager: %w", err)
+			}
+			w, txHash, closeFn, err := newWithdrawer(ctx, logger, txMgr)
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+
+			if err := w.Complete(ctx.Context, txHash); err != nil {
+				return err
+			}
+			logger.Info("Withdrawal finalized", "tx", txHash)
+			return nil
+		},
+	},
+}
+
+// l2Client serves the receipts, headers and proofs of the withdrawals from a single L2 RPC connection
+type l2Client struct {
+	*ethclient.Client
+	geth *gethclient.Client
+}
+
+func (c *l2Client) GetProof(ctx context.Context, account common.Address, keys []string, blockNumber *big.Int) (*gethclient.AccountResult, error) {
+	return c.geth.GetProof(ctx, account, keys, blockNumber)
+}
+
+func newWithdrawer(ctx *cli.Context, logger log.Logger, txMgr txmgr.TxManager) (*withdrawals.Withdrawer, common.Hash, func(), error) {
+	if !common.IsHexAddress(ctx.String(portalFlag.Name)) {
+		return nil, common.Hash{}, nil, errors.New("invalid --optimism-portal address")
+	}
+	portalAddr := common.HexToAddress(ctx.String(portalFlag.Name))
+	txHash := common.HexToHash(ctx.String(txHashFlag.Name))
+
+	l1, err := ethclient.DialContext(ctx.Context, ctx.String(l1RPCFlag.Name))
+	if err != nil {
+		return nil, common.Hash{}, nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	l2RPC, err := rpc.DialContext(ctx.Context, ctx.String(l2RPCFlag.Name))
+	if err != nil {
+		l1.Close()
+		return nil, common.Hash{}, nil, fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	closeFn := func() {
+		l1.Close()
+		l2RPC.Close()
+	}
+
+	l2 := &l2Client{Client: ethclient.NewClient(l2RPC), geth: gethclient.New(l2RPC)}
+	w, err := withdrawals.NewWithdrawer(ctx.Context, logger, l1, l2, portalAddr, txMgr, ctx.Duration(pollIntervalFlag.Name))
+	if err != nil {
+		closeFn()
+		return nil, common.Hash{}, nil, err
+	}
+	return w, txHash, closeFn, nil
+}
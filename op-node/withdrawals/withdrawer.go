@@ -0,0 +1,326 @@
+package withdrawals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// Status is the progress of a withdrawal on L1
+type Status string
+
+const (
+	// StatusWaitingForOutput is the status of withdrawals whose L2 block isn't covered by a proposed output yet
+	StatusWaitingForOutput Status = "waiting_for_output"
+	// StatusReadyToProve is the status of withdrawals which are not proven, or proven against a deleted output
+	StatusReadyToProve Status = "ready_to_prove"
+	// StatusWaitingForFinalization is the status of proven withdrawals within the finalization period
+	StatusWaitingForFinalization Status = "waiting_for_finalization"
+	StatusReadyToFinalize        Status = "ready_to_finalize"
+	StatusFinalized              Status = "finalized"
+)
+
+// L1Client is the L1 access of the Withdrawer
+type L1Client interface {
+	bind.ContractCaller
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// L2Client is the L2 access of the Withdrawer
+type L2Client interface {
+	ProofClient
+	ReceiptClient
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Withdrawal is the state of a withdrawal initiated on L2
+type Withdrawal struct {
+	TxHash         common.Hash
+	WithdrawalHash common.Hash
+	L2BlockNumber  uint64
+	Transaction    bindings.TypesWithdrawalTransaction
+
+	Status Status
+	// ProvenAt is the L1 timestamp of the proof of the withdrawal, zero if not proven
+	ProvenAt uint64
+	// FinalizableAt is the L1 timestamp after which the proven withdrawal can be finalized
+	FinalizableAt uint64
+	// L1BlockNumber is the L1 block the status was read at
+	L1BlockNumber uint64
+}
+
+// Withdrawer proves and finalizes withdrawals on L1. All of its progress is read from the chains, such that
+// an interrupted withdrawal resumes from its current status.
+type Withdrawer struct {
+	log          log.Logger
+	l1           L1Client
+	l2           L2Client
+	portalAddr   common.Address
+	portal       *bindings.OptimismPortalCaller
+	portalABI    *abi.ABI
+	oracle       *bindings.L2OutputOracleCaller
+	txMgr        txmgr.TxManager
+	pollInterval time.Duration
+}
+
+// NewWithdrawer creates a Withdrawer for the OptimismPortal. The tx manager may be nil to only read the
+// status of withdrawals.
+func NewWithdrawer(ctx context.Context, logger log.Logger, l1 L1Client, l2 L2Client, portalAddr common.Address, txMgr txmgr.TxManager, pollInterval time.Duration) (*Withdrawer, error) {
+	portal, err := bindings.NewOptimismPortalCaller(portalAddr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind OptimismPortal: %w", err)
+	}
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	oracleAddr, err := portal.L2ORACLE(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2OutputOracle address: %w", err)
+	}
+	oracle, err := bindings.NewL2OutputOracleCaller(oracleAddr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	return &Withdrawer{
+		log:          logger,
+		l1:           l1,
+		l2:           l2,
+		portalAddr:   portalAddr,
+		portal:       portal,
+		portalABI:    portalABI,
+		oracle:       oracle,
+		txMgr:        txMgr,
+		pollInterval: pollInterval,
+	}, nil
+}
+
+// Status finds the withdrawal initiated by the L2 transaction and reads its progress on L1
+func (w *Withdrawer) Status(ctx context.Context, txHash common.Hash) (*Withdrawal, error) {
+	receipt, err := w.l2.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal receipt: %w", err)
+	}
+	ev, err := ParseMessagePassed(receipt)
+	if err != nil {
+		return nil, err
+	}
+	withdrawalHash, err := WithdrawalHash(ev)
+	if err != nil {
+		return nil, err
+	}
+	wd := &Withdrawal{
+		TxHash:         txHash,
+		WithdrawalHash: withdrawalHash,
+		L2BlockNumber:  receipt.BlockNumber.Uint64(),
+		Transaction: bindings.TypesWithdrawalTransaction{
+			Nonce:    ev.Nonce,
+			Sender:   ev.Sender,
+			Target:   ev.Target,
+			Value:    ev.Value,
+			GasLimit: ev.GasLimit,
+			Data:     ev.Data,
+		},
+	}
+
+	// read all of the L1 state at the same block, such that a new head doesn't mix up the status
+	l1Head, err := w.l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	wd.L1BlockNumber = l1Head.Number.Uint64()
+
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: l1Head.Number}
+	finalized, err := w.portal.FinalizedWithdrawals(opts, withdrawalHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finalized withdrawal: %w", err)
+	}
+	latestOutputBlock, err := w.oracle.LatestBlockNumber(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest output: %w", err)
+	}
+	proven, err := w.portal.ProvenWithdrawals(opts, withdrawalHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proven withdrawal: %w", err)
+	}
+	if proven.Timestamp.Sign() > 0 {
+		valid, err := w.isProofValid(opts, proven.OutputRoot, proven.L2OutputIndex)
+		if err != nil {
+			return nil, err
+		}
+		if valid {
+			finalizationPeriod, err := w.oracle.FINALIZATIONPERIODSECONDS(opts)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get finalization period: %w", err)
+			}
+			wd.ProvenAt = proven.Timestamp.Uint64()
+			wd.FinalizableAt = wd.ProvenAt + finalizationPeriod.Uint64()
+		}
+	}
+	wd.Status = withdrawalStatus(finalized, latestOutputBlock.Uint64() >= wd.L2BlockNumber, wd.ProvenAt, wd.FinalizableAt, l1Head.Time)
+	return wd, nil
+}
+
+// isProofValid returns false if the output the withdrawal was proven against was deleted, in which case
+// the withdrawal must be proven again
+func (w *Withdrawer) isProofValid(opts *bind.CallOpts, outputRoot [32]byte, outputIndex *big.Int) (bool, error) {
+	nextOutputIndex, err := w.oracle.NextOutputIndex(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to get next output index: %w", err)
+	}
+	if outputIndex.Cmp(nextOutputIndex) >= 0 {
+		return false, nil
+	}
+	output, err := w.oracle.GetL2Output(opts, outputIndex)
+	if err != nil {
+		return false, fmt.Errorf("failed to get proven output: %w", err)
+	}
+	return output.OutputRoot == outputRoot, nil
+}
+
+func withdrawalStatus(finalized bool, outputProposed bool, provenAt uint64, finalizableAt uint64, l1Time uint64) Status {
+	switch {
+	case finalized:
+		return StatusFinalized
+	case provenAt > 0 && l1Time > finalizableAt:
+		return StatusReadyToFinalize
+	case provenAt > 0:
+		return StatusWaitingForFinalization
+	case outputProposed:
+		return StatusReadyToProve
+	default:
+		return StatusWaitingForOutput
+	}
+}
+
+// Complete proves and finalizes the withdrawal, waiting for an output to be proposed and for the finalization
+// period to elapse as needed. It returns once the withdrawal is finalized.
+func (w *Withdrawer) Complete(ctx context.Context, txHash common.Hash) error {
+	if w.txMgr == nil {
+		return errors.New("a tx manager is required to complete withdrawals")
+	}
+	// the L1 block of the last confirmed transaction. A status read at an older block, e.g. from a lagging
+	// node behind a load balancer, doesn't include the transaction and must not trigger it again.
+	var minL1Block uint64
+	for {
+		wd, err := w.Status(ctx, txHash)
+		if err != nil {
+			return err
+		}
+		wait := w.pollInterval
+		if wd.L1BlockNumber < minL1Block {
+			w.log.Info("Waiting for the L1 node to sync", "l1_block", wd.L1BlockNumber, "min_l1_block", minL1Block)
+			wd.Status = ""
+		} else {
+			w.log.Info("Withdrawal status", "tx", txHash, "withdrawal", wd.WithdrawalHash, "l2_block", wd.L2BlockNumber, "status", wd.Status)
+		}
+
+		switch wd.Status {
+		case StatusFinalized:
+			return nil
+		case StatusReadyToProve:
+			if minL1Block, err = w.prove(ctx, wd); err != nil {
+				return err
+			}
+			continue
+		case StatusReadyToFinalize:
+			if minL1Block, err = w.finalize(ctx, wd); err != nil {
+				return err
+			}
+			continue
+		case StatusWaitingForFinalization:
+			// the finalization period lasts days, sleep until it is over rather than polling
+			if untilFinalizable := time.Until(time.Unix(int64(wd.FinalizableAt), 0)); untilFinalizable > wait {
+				w.log.Info("Waiting for the finalization period", "finalizable_at", time.Unix(int64(wd.FinalizableAt), 0))
+				wait = untilFinalizable
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// prove proves the withdrawal and returns the L1 block which includes the proof
+func (w *Withdrawer) prove(ctx context.Context, wd *Withdrawal) (uint64, error) {
+	output, err := w.oracle.GetL2OutputAfter(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(wd.L2BlockNumber))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get output covering the withdrawal: %w", err)
+	}
+	header, err := w.l2.HeaderByNumber(ctx, output.L2BlockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get header of output block: %w", err)
+	}
+	params, err := ProveWithdrawalParameters(ctx, w.l2, w.l2, wd.TxHash, header, w.oracle)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build withdrawal proof: %w", err)
+	}
+
+	data, err := w.portalABI.Pack("proveWithdrawalTransaction", wd.Transaction, params.L2OutputIndex, params.OutputRootProof, params.WithdrawalProof)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack proveWithdrawalTransaction: %w", err)
+	}
+	w.log.Info("Proving withdrawal", "withdrawal", wd.WithdrawalHash, "output_index", params.L2OutputIndex, "output_block", output.L2BlockNumber)
+	receipt, err := w.send(ctx, data)
+	if err != nil {
+		return 0, err
+	}
+	proven, err := w.portal.ProvenWithdrawals(&bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}, wd.WithdrawalHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get proven withdrawal: %w", err)
+	}
+	if proven.Timestamp.Sign() == 0 {
+		return 0, fmt.Errorf("withdrawal %s not proven after transaction %s", wd.WithdrawalHash, receipt.TxHash)
+	}
+	return receipt.BlockNumber.Uint64(), nil
+}
+
+// finalize finalizes the withdrawal and returns the L1 block which includes the finalization
+func (w *Withdrawer) finalize(ctx context.Context, wd *Withdrawal) (uint64, error) {
+	data, err := w.portalABI.Pack("finalizeWithdrawalTransaction", wd.Transaction)
+	if err != nil {
+		return 0, fmt.Errorf("failed to pack finalizeWithdrawalTransaction: %w", err)
+	}
+	w.log.Info("Finalizing withdrawal", "withdrawal", wd.WithdrawalHash)
+	receipt, err := w.send(ctx, data)
+	if err != nil {
+		return 0, err
+	}
+	finalized, err := w.portal.FinalizedWithdrawals(&bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}, wd.WithdrawalHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get finalized withdrawal: %w", err)
+	}
+	if !finalized {
+		return 0, fmt.Errorf("withdrawal %s not finalized after transaction %s", wd.WithdrawalHash, receipt.TxHash)
+	}
+	return receipt.BlockNumber.Uint64(), nil
+}
+
+func (w *Withdrawer) send(ctx context.Context, data []byte) (*types.Receipt, error) {
+	receipt, err := w.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData: data,
+		To:     &w.portalAddr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %s reverted", receipt.TxHash)
+	}
+	w.log.Info("Transaction confirmed", "tx", receipt.TxHash, "block", receipt.BlockNumber)
+	return receipt, nil
+}
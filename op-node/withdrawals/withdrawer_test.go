@@ -0,0 +1,357 @@
+package withdrawals
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+func TestWithdrawalStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		finalized      bool
+		outputProposed bool
+		provenAt       uint64
+		finalizableAt  uint64
+		l1Time         uint64
+		expected       Status
+	}{
+		{"no output", false, false, 0, 0, 100, StatusWaitingForOutput},
+		{"output proposed", false, true, 0, 0, 100, StatusReadyToProve},
+		{"within finalization period", false, true, 100, 200, 150, StatusWaitingForFinalization},
+		{"at end of finalization period", false, true, 100, 200, 200, StatusWaitingForFinalization},
+		{"after finalization period", false, true, 100, 200, 201, StatusReadyToFinalize},
+		{"finalized", true, true, 100, 200, 300, StatusFinalized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, withdrawalStatus(tt.finalized, tt.outputProposed, tt.provenAt, tt.finalizableAt, tt.l1Time))
+		})
+	}
+}
+
+// finalizationPeriod is shorter than two L1 blocks of the simulated backend, which are 10 seconds apart
+const finalizationPeriod = 15
+
+func TestWithdrawer(t *testing.T) {
+	t.Run("status", func(t *testing.T) {
+		env := newWithdrawerEnv(t)
+		require.Equal(t, StatusWaitingForOutput, env.status(t).Status)
+
+		env.proposeOutput(t)
+		wd := env.status(t)
+		require.Equal(t, StatusReadyToProve, wd.Status)
+		require.Equal(t, env.withdrawalHash, wd.WithdrawalHash)
+		require.Equal(t, uint64(1), wd.L2BlockNumber)
+		require.Zero(t, wd.ProvenAt)
+
+		l1Block, err := env.withdrawer.prove(context.Background(), wd)
+		require.NoError(t, err)
+		wd = env.status(t)
+		require.Equal(t, StatusWaitingForFinalization, wd.Status)
+		require.Greater(t, wd.L1BlockNumber, l1Block)
+		require.NotZero(t, wd.ProvenAt)
+		require.Equal(t, wd.ProvenAt+finalizationPeriod, wd.FinalizableAt)
+
+		// every status read mines a block, which is 10 seconds later
+		require.Equal(t, StatusReadyToFinalize, env.status(t).Status)
+		_, err = env.withdrawer.finalize(context.Background(), wd)
+		require.NoError(t, err)
+		require.Equal(t, StatusFinalized, env.status(t).Status)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		env := newWithdrawerEnv(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// the withdrawal waits for the output to be proposed
+		var headReads int
+		env.l1.onHead = func() {
+			if headReads++; headReads == 3 {
+				env.proposeOutput(t)
+			}
+		}
+		require.NoError(t, env.withdrawer.Complete(ctx, env.txHash))
+		require.Greater(t, headReads, 3)
+		require.Equal(t, 2, env.txMgr.sent)
+		require.Equal(t, StatusFinalized, env.status(t).Status)
+	})
+
+	t.Run("unconfirmed proof", func(t *testing.T) {
+		env := newWithdrawerEnv(t)
+		env.proposeOutput(t)
+		wd := env.status(t)
+
+		// the transaction is reported as successful, but the proof isn't on chain
+		env.txMgr.dropTxs = true
+		_, err := env.withdrawer.prove(context.Background(), wd)
+		require.ErrorContains(t, err, "not proven after transaction")
+		_, err = env.withdrawer.finalize(context.Background(), wd)
+		require.ErrorContains(t, err, "not finalized after transaction")
+	})
+}
+
+type withdrawerEnv struct {
+	l1     *simulatedL1
+	l2     *stubL2
+	txMgr  *simulatedTxManager
+	oracle *bindings.L2OutputOracle
+
+	withdrawer     *Withdrawer
+	txHash         common.Hash
+	withdrawalHash common.Hash
+}
+
+// newWithdrawerEnv deploys the OptimismPortal and L2OutputOracle to a simulated L1, and stubs an L2 with a
+// single withdrawal in block 1
+func newWithdrawerEnv(t *testing.T) *withdrawerEnv {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		from: {Balance: new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(1000))},
+	}, 30_000_000)
+	t.Cleanup(func() { _ = backend.Close() })
+	l1 := &simulatedL1{SimulatedBackend: backend}
+	txMgr := &simulatedTxManager{backend: backend, key: key, from: from}
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, backend.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+	deployed := func(addr common.Address, tx *types.Transaction, err error) common.Address {
+		require.NoError(t, err)
+		backend.Commit()
+		receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		return addr
+	}
+
+	oracleAddr, tx, oracle, err := bindings.DeployL2OutputOracle(opts, backend, big.NewInt(1), big.NewInt(1), common.Big0, common.Big0, from, from, big.NewInt(finalizationPeriod))
+	deployed(oracleAddr, tx, err)
+	superchainConfigAddr, tx, _, err := bindings.DeploySuperchainConfig(opts, backend)
+	deployed(superchainConfigAddr, tx, err)
+	portalImplAddr, tx, _, err := bindings.DeployOptimismPortal(opts, backend, oracleAddr, common.Address{})
+	deployed(portalImplAddr, tx, err)
+	portalAddr, tx, proxy, err := bindings.DeployProxy(opts, backend, from)
+	deployed(portalAddr, tx, err)
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	require.NoError(t, err)
+	initialize, err := portalABI.Pack("initialize", superchainConfigAddr)
+	require.NoError(t, err)
+	tx, err = proxy.UpgradeToAndCall(opts, portalImplAddr, initialize)
+	deployed(portalAddr, tx, err)
+
+	l2 := newStubL2(t)
+	withdrawer, err := NewWithdrawer(context.Background(), testlog.Logger(t, log.LvlInfo), l1, l2, portalAddr, txMgr, 10*time.Millisecond)
+	require.NoError(t, err)
+	return &withdrawerEnv{
+		l1:             l1,
+		l2:             l2,
+		txMgr:          txMgr,
+		oracle:         oracle,
+		withdrawer:     withdrawer,
+		txHash:         l2.receipt.TxHash,
+		withdrawalHash: l2.withdrawalHash,
+	}
+}
+
+func (e *withdrawerEnv) status(t *testing.T) *Withdrawal {
+	wd, err := e.withdrawer.Status(context.Background(), e.txHash)
+	require.NoError(t, err)
+	return wd
+}
+
+// proposeOutput proposes the output of the L2 block which includes the withdrawal
+func (e *withdrawerEnv) proposeOutput(t *testing.T) {
+	opts, err := bind.NewKeyedTransactorWithChainID(e.txMgr.key, e.l1.Blockchain().Config().ChainID)
+	require.NoError(t, err)
+	header := e.l2.header
+	outputRoot := crypto.Keccak256Hash(make([]byte, 32), header.Root[:], e.l2.proof.StorageHash[:], header.Hash().Bytes())
+	_, err = e.oracle.ProposeL2Output(opts, outputRoot, header.Number, [32]byte{}, common.Big0)
+	require.NoError(t, err)
+	e.l1.Commit()
+}
+
+// simulatedL1 mines a block on every read of the head, such that time passes while the Withdrawer polls
+type simulatedL1 struct {
+	*backends.SimulatedBackend
+	// onHead is called before the block is mined
+	onHead func()
+}
+
+func (s *simulatedL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		if s.onHead != nil {
+			s.onHead()
+		}
+		s.Commit()
+	}
+	return s.SimulatedBackend.HeaderByNumber(ctx, number)
+}
+
+// simulatedTxManager sends transactions to the simulated L1 and mines them right away
+type simulatedTxManager struct {
+	backend *backends.SimulatedBackend
+	key     *ecdsa.PrivateKey
+	from    common.Address
+	sent    int
+	// dropTxs reports transactions as successful without sending them
+	dropTxs bool
+}
+
+func (m *simulatedTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	if m.dropTxs {
+		head, err := m.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: head.Number}, nil
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(m.key, m.backend.Blockchain().Config().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	opts.GasLimit = 1_000_000
+	tx, err := bind.NewBoundContract(*candidate.To, abi.ABI{}, m.backend, m.backend, m.backend).RawTransact(opts, candidate.TxData)
+	if err != nil {
+		return nil, err
+	}
+	m.sent++
+	m.backend.Commit()
+	return m.backend.TransactionReceipt(ctx, tx.Hash())
+}
+
+func (m *simulatedTxManager) From() common.Address {
+	return m.from
+}
+
+func (m *simulatedTxManager) BlockNumber(ctx context.Context) (uint64, error) {
+	return m.backend.Blockchain().CurrentBlock().Number.Uint64(), nil
+}
+
+func (m *simulatedTxManager) Close() {}
+
+// stubL2 serves a withdrawal receipt, and the header and proofs of an L2 state which only holds the withdrawal
+type stubL2 struct {
+	receipt        *types.Receipt
+	header         *types.Header
+	proof          *gethclient.AccountResult
+	withdrawalHash common.Hash
+}
+
+func newStubL2(t *testing.T) *stubL2 {
+	ev := &bindings.L2ToL1MessagePasserMessagePassed{
+		Nonce:    big.NewInt(7),
+		Sender:   common.Address{0xaa},
+		Target:   common.Address{0xbb},
+		Value:    common.Big0,
+		GasLimit: big.NewInt(100_000),
+		Data:     []byte{},
+	}
+	withdrawalHash, err := WithdrawalHash(ev)
+	require.NoError(t, err)
+	passerABI, err := bindings.L2ToL1MessagePasserMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := passerABI.Events["MessagePassed"].Inputs.NonIndexed().Pack(ev.Value, ev.GasLimit, ev.Data, withdrawalHash)
+	require.NoError(t, err)
+	receipt := &types.Receipt{
+		TxHash:      common.Hash{0x01},
+		BlockNumber: big.NewInt(1),
+		Logs: []*types.Log{{
+			Address: predeploys.L2ToL1MessagePasserAddr,
+			Topics:  []common.Hash{MessagePassedTopic, common.BigToHash(ev.Nonce), common.BytesToHash(ev.Sender[:]), common.BytesToHash(ev.Target[:])},
+			Data:    data,
+		}},
+	}
+
+	// the message passer storage marks the withdrawal as sent
+	slot := StorageSlotOfWithdrawalHash(withdrawalHash)
+	storageTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	storageValue, err := rlp.EncodeToBytes(common.Big1)
+	require.NoError(t, err)
+	storageTrie.MustUpdate(crypto.Keccak256(slot[:]), storageValue)
+	var storageProof proofList
+	require.NoError(t, storageTrie.Prove(crypto.Keccak256(slot[:]), &storageProof))
+
+	account := types.StateAccount{Nonce: 0, Balance: new(big.Int), Root: storageTrie.Hash(), CodeHash: types.EmptyCodeHash[:]}
+	accountTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	accountValue, err := rlp.EncodeToBytes(&account)
+	require.NoError(t, err)
+	accountTrie.MustUpdate(crypto.Keccak256(predeploys.L2ToL1MessagePasserAddr[:]), accountValue)
+	var accountProof proofList
+	require.NoError(t, accountTrie.Prove(crypto.Keccak256(predeploys.L2ToL1MessagePasserAddr[:]), &accountProof))
+
+	return &stubL2{
+		receipt: receipt,
+		header:  &types.Header{Number: big.NewInt(1), Root: accountTrie.Hash(), Difficulty: common.Big0},
+		proof: &gethclient.AccountResult{
+			Address:      predeploys.L2ToL1MessagePasserAddr,
+			AccountProof: accountProof,
+			Balance:      account.Balance,
+			CodeHash:     types.EmptyCodeHash,
+			Nonce:        account.Nonce,
+			StorageHash:  account.Root,
+			StorageProof: []gethclient.StorageResult{{Key: slot.String(), Value: common.Big1, Proof: storageProof}},
+		},
+		withdrawalHash: withdrawalHash,
+	}
+}
+
+func (s *stubL2) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if txHash != s.receipt.TxHash {
+		return nil, ethereum.NotFound
+	}
+	return s.receipt, nil
+}
+
+func (s *stubL2) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil || number.Cmp(s.header.Number) != 0 {
+		return nil, ethereum.NotFound
+	}
+	return s.header, nil
+}
+
+func (s *stubL2) GetProof(_ context.Context, addr common.Address, keys []string, number *big.Int) (*gethclient.AccountResult, error) {
+	if addr != s.proof.Address || len(keys) != 1 || keys[0] != s.proof.StorageProof[0].Key || number.Cmp(s.header.Number) != 0 {
+		return nil, ethereum.NotFound
+	}
+	return s.proof, nil
+}
+
+// proofList collects the trie nodes of a proof, from the root to the leaf
+type proofList []string
+
+func (l *proofList) Put(_ []byte, value []byte) error {
+	*l = append(*l, hexutil.Encode(value))
+	return nil
+}
+
+func (l *proofList) Delete([]byte) error {
+	panic("not supported")
+}
@@ -290,6 +290,29 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestLookaheadWorkers(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, 0, cfg.LookaheadWorkers)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--lookahead.workers", "16"))
+		require.Equal(t, 16, cfg.LookaheadWorkers)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.False(t, cfg.MetricsConfig.Enabled)
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--metrics.enabled", "--metrics.port", "7301"))
+		require.True(t, cfg.MetricsConfig.Enabled)
+		require.Equal(t, 7301, cfg.MetricsConfig.ListenPort)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -11,6 +11,7 @@ import (
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -30,6 +31,7 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrInvalidLookahead    = errors.New("lookahead workers must not be negative")
 )
 
 type Config struct {
@@ -67,6 +69,12 @@ type Config struct {
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// LookaheadWorkers is the number of workers used to speculatively prefetch pre-images.
+	// Lookahead is disabled if set to 0.
+	LookaheadWorkers int
+
+	MetricsConfig opmetrics.CLIConfig
 }
 
 func (c *Config) Check() error {
@@ -103,6 +111,12 @@ func (c *Config) Check() error {
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.LookaheadWorkers < 0 {
+		return ErrInvalidLookahead
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return fmt.Errorf("invalid metrics config: %w", err)
+	}
 	return nil
 }
 
@@ -132,6 +146,8 @@ func NewConfig(
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1RPCKind:           sources.RPCKindStandard,
 		IsCustomChainConfig: isCustomConfig,
+		LookaheadWorkers:    flags.LookaheadWorkers.Value,
+		MetricsConfig:       opmetrics.DefaultCLIConfig(),
 	}
 }
 
@@ -197,6 +213,8 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		ExecCmd:             ctx.String(flags.Exec.Name),
 		ServerMode:          ctx.Bool(flags.Server.Name),
 		IsCustomChainConfig: isCustomConfig,
+		LookaheadWorkers:    ctx.Int(flags.LookaheadWorkers.Name),
+		MetricsConfig:       opmetrics.ReadCLIConfig(ctx),
 	}, nil
 }
 
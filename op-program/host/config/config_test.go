@@ -160,6 +160,21 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestRejectNegativeLookaheadWorkers(t *testing.T) {
+	cfg := validConfig()
+	cfg.LookaheadWorkers = -1
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrInvalidLookahead)
+}
+
+func TestRejectInvalidMetricsConfig(t *testing.T) {
+	cfg := validConfig()
+	cfg.MetricsConfig.Enabled = true
+	cfg.MetricsConfig.ListenPort = 65536
+	err := cfg.Check()
+	require.ErrorContains(t, err, "invalid metrics config")
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
@@ -10,6 +10,7 @@ import (
 	service "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -100,6 +101,12 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	LookaheadWorkers = &cli.IntFlag{
+		Name:    "lookahead.workers",
+		Usage:   "Number of workers used to speculatively prefetch pre-images the client program is likely to request. 0 disables lookahead.",
+		EnvVars: prefixEnvVars("LOOKAHEAD_WORKERS"),
+		Value:   0,
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -123,12 +130,14 @@ var programFlags = []cli.Flag{
 	L1RPCProviderKind,
 	Exec,
 	Server,
+	LookaheadWorkers,
 }
 
 func init() {
 	Flags = append(Flags, oplog.CLIFlags(EnvVarPrefix)...)
 	Flags = append(Flags, requiredFlags...)
 	Flags = append(Flags, programFlags...)
+	Flags = append(Flags, opmetrics.CLIFlags(EnvVarPrefix)...)
 }
 
 func CheckRequired(ctx *cli.Context) error {
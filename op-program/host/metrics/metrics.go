@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const Namespace = "op_program_host"
+
+type Metricer interface {
+	RecordPrefetch(hintType string, seconds float64)
+	RecordPrefetchFailure(hintType string)
+
+	RecordLookaheadScheduled()
+	RecordLookaheadDropped()
+	RecordLookaheadFetched(nodes int)
+	RecordLookaheadFailed(nodes int)
+}
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	prefetchDuration prometheus.HistogramVec
+	prefetchFailures prometheus.CounterVec
+
+	lookaheadScheduled prometheus.Counter
+	lookaheadDropped   prometheus.Counter
+	lookaheadFetched   prometheus.Counter
+	lookaheadFailed    prometheus.Counter
+}
+
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       Namespace,
+		registry: registry,
+		factory:  factory,
+
+		prefetchDuration: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "prefetch_duration_seconds",
+			Help:      "Time (in seconds) to fetch the pre-images of a hint, by hint type",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2.0, 12),
+		}, []string{
+			"hint_type",
+		}),
+		prefetchFailures: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "prefetch_failures_total",
+			Help:      "Number of hints for which the pre-images could not be fetched, by hint type",
+		}, []string{
+			"hint_type",
+		}),
+		lookaheadScheduled: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "lookahead_scheduled_total",
+			Help:      "Number of state nodes queued for their descendants to be prefetched",
+		}),
+		lookaheadDropped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "lookahead_dropped_total",
+			Help:      "Number of state nodes not queued for lookahead because the queue was full",
+		}),
+		lookaheadFetched: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "lookahead_fetched_nodes_total",
+			Help:      "Number of state nodes speculatively fetched by the lookahead workers",
+		}),
+		lookaheadFailed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "lookahead_failed_nodes_total",
+			Help:      "Number of state nodes the lookahead workers failed to fetch",
+		}),
+	}
+}
+
+func (m *Metrics) Document() []opmetrics.DocumentedMetric {
+	return m.factory.Document()
+}
+
+func (m *Metrics) RecordPrefetch(hintType string, seconds float64) {
+	m.prefetchDuration.WithLabelValues(hintType).Observe(seconds)
+}
+
+func (m *Metrics) RecordPrefetchFailure(hintType string) {
+	m.prefetchFailures.WithLabelValues(hintType).Inc()
+}
+
+func (m *Metrics) RecordLookaheadScheduled() {
+	m.lookaheadScheduled.Inc()
+}
+
+func (m *Metrics) RecordLookaheadDropped() {
+	m.lookaheadDropped.Inc()
+}
+
+func (m *Metrics) RecordLookaheadFetched(nodes int) {
+	m.lookaheadFetched.Add(float64(nodes))
+}
+
+func (m *Metrics) RecordLookaheadFailed(nodes int) {
+	m.lookaheadFailed.Add(float64(nodes))
+}
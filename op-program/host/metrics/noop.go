@@ -0,0 +1,13 @@
+package metrics
+
+type NoopMetricsImpl struct{}
+
+var NoopMetrics Metricer = new(NoopMetricsImpl)
+
+func (*NoopMetricsImpl) RecordPrefetch(hintType string, seconds float64) {}
+func (*NoopMetricsImpl) RecordPrefetchFailure(hintType string)           {}
+
+func (*NoopMetricsImpl) RecordLookaheadScheduled()        {}
+func (*NoopMetricsImpl) RecordLookaheadDropped()          {}
+func (*NoopMetricsImpl) RecordLookaheadFetched(nodes int) {}
+func (*NoopMetricsImpl) RecordLookaheadFailed(nodes int)  {}
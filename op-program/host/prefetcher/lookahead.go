@@ -0,0 +1,223 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// lookaheadDepth is the number of trie levels below a requested node that are speculatively fetched.
+const lookaheadDepth = 2
+
+// lookaheadQueueSize is the number of pending nodes that may be queued for lookahead before new ones are dropped.
+const lookaheadQueueSize = 64
+
+// L2BatchSource is an optional extension of L2Source that can retrieve many trie nodes in a single request.
+type L2BatchSource interface {
+	NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error)
+}
+
+// LookaheadStats records the work performed by the lookahead workers.
+type LookaheadStats struct {
+	Scheduled uint64
+	Dropped   uint64
+	Fetched   uint64
+	Failed    uint64
+}
+
+// lookahead speculatively fetches the descendants of state trie nodes using a pool of workers.
+// The client program walks the trie from the root one node at a time, so fetching the nodes below the one just
+// requested while the client processes it hides most of the RPC latency of the following requests.
+// Lookahead is best-effort: failures are logged and the node will be fetched on demand instead.
+type lookahead struct {
+	logger  log.Logger
+	metrics metrics.Metricer
+	source  L2BatchSource
+	kvStore kvstore.KV
+
+	queue  chan []byte
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	scheduled atomic.Uint64
+	dropped   atomic.Uint64
+	fetched   atomic.Uint64
+	failed    atomic.Uint64
+}
+
+func newLookahead(logger log.Logger, m metrics.Metricer, source L2BatchSource, kvStore kvstore.KV, workers int) *lookahead {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &lookahead{
+		logger:  logger,
+		metrics: m,
+		source:  source,
+		kvStore: kvStore,
+		queue:   make(chan []byte, lookaheadQueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for i := 0; i < workers; i++ {
+		l.wg.Add(1)
+		go l.worker()
+	}
+	return l
+}
+
+// schedule queues the children of node for retrieval. It never blocks; if the queue is full the node is dropped.
+func (l *lookahead) schedule(node []byte) {
+	select {
+	case l.queue <- node:
+		l.scheduled.Add(1)
+		l.metrics.RecordLookaheadScheduled()
+	default:
+		l.dropped.Add(1)
+		l.metrics.RecordLookaheadDropped()
+	}
+}
+
+func (l *lookahead) worker() {
+	defer l.wg.Done()
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case node := <-l.queue:
+			l.expand(node)
+		}
+	}
+}
+
+// expand fetches the unknown descendants of node, one trie level per batch request, up to lookaheadDepth levels.
+func (l *lookahead) expand(node []byte) {
+	frontier := [][]byte{node}
+	for depth := 0; depth < lookaheadDepth && len(frontier) > 0; depth++ {
+		var missing []common.Hash
+		for _, n := range frontier {
+			for _, child := range childHashes(n) {
+				if _, err := l.kvStore.Get(preimage.Keccak256Key(child).PreimageKey()); errors.Is(err, kvstore.ErrNotFound) {
+					missing = append(missing, child)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			return
+		}
+		nodes, err := l.source.NodesByHash(l.ctx, missing)
+		if err != nil {
+			l.failed.Add(uint64(len(missing)))
+			l.metrics.RecordLookaheadFailed(len(missing))
+			l.logger.Debug("Lookahead fetch failed", "nodes", len(missing), "err", err)
+			return
+		}
+		for i, n := range nodes {
+			// Never store a node under a key it does not hash to, the client would read it as a valid pre-image
+			if actual := crypto.Keccak256Hash(n); actual != missing[i] {
+				l.failed.Add(uint64(len(missing)))
+				l.metrics.RecordLookaheadFailed(len(missing))
+				l.logger.Warn("Lookahead node does not match its hash", "hash", missing[i], "actual", actual)
+				return
+			}
+		}
+		for i, n := range nodes {
+			if err := l.kvStore.Put(preimage.Keccak256Key(missing[i]).PreimageKey(), n); err != nil {
+				l.logger.Warn("Failed to store lookahead node", "hash", missing[i], "err", err)
+				return
+			}
+		}
+		l.fetched.Add(uint64(len(nodes)))
+		l.metrics.RecordLookaheadFetched(len(nodes))
+		frontier = nodes
+	}
+}
+
+func (l *lookahead) stats() LookaheadStats {
+	return LookaheadStats{
+		Scheduled: l.scheduled.Load(),
+		Dropped:   l.dropped.Load(),
+		Fetched:   l.fetched.Load(),
+		Failed:    l.failed.Load(),
+	}
+}
+
+func (l *lookahead) close() {
+	l.cancel()
+	l.wg.Wait()
+}
+
+// childHashes returns the hashes of the nodes referenced by the supplied MPT node.
+// Children small enough to be embedded in their parent are searched recursively.
+// Values stored in leaf nodes are not followed.
+func childHashes(node []byte) []common.Hash {
+	elems, _, err := rlp.SplitList(node)
+	if err != nil {
+		return nil
+	}
+	count, err := rlp.CountValues(elems)
+	if err != nil {
+		return nil
+	}
+	switch count {
+	case 2:
+		// Short node: the first element is the compact encoded key. If the key has the terminator flag set,
+		// the second element is a value rather than a reference to a child.
+		key, rest, err := rlp.SplitString(elems)
+		if err != nil || len(key) == 0 || key[0]&0x20 != 0 {
+			return nil
+		}
+		return childRef(rest)
+	case 17:
+		var children []common.Hash
+		rest := elems
+		for i := 0; i < 16; i++ {
+			var raw []byte
+			raw, rest, err = splitRaw(rest)
+			if err != nil {
+				return children
+			}
+			children = append(children, childRef(raw)...)
+		}
+		return children
+	default:
+		return nil
+	}
+}
+
+// childRef decodes the child reference at the start of data.
+func childRef(data []byte) []common.Hash {
+	kind, content, _, err := rlp.Split(data)
+	if err != nil {
+		return nil
+	}
+	switch {
+	case kind == rlp.String && len(content) == common.HashLength:
+		return []common.Hash{common.BytesToHash(content)}
+	case kind == rlp.List:
+		raw, _, err := splitRaw(data)
+		if err != nil {
+			return nil
+		}
+		return childHashes(raw)
+	default:
+		return nil
+	}
+}
+
+// splitRaw splits off the first RLP value, including its header, from data.
+func splitRaw(data []byte) ([]byte, []byte, error) {
+	_, _, rest, err := rlp.Split(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid rlp: %w", err)
+	}
+	return data[:len(data)-len(rest)], rest, nil
+}
@@ -0,0 +1,242 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestChildHashes(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	values := make([]hexutil.Bytes, 200)
+	for i := range values {
+		values[i] = testutils.RandomData(rng, 40)
+	}
+	root, nodes := mpt.WriteTrie(values)
+	known := make(map[common.Hash]bool)
+	for _, node := range nodes {
+		known[crypto.Keccak256Hash(node)] = true
+	}
+
+	// Every hash referenced by a node must be another node in the trie and every node other than the root
+	// must be referenced by exactly one parent.
+	referenced := make(map[common.Hash]int)
+	for _, node := range nodes {
+		for _, child := range childHashes(node) {
+			require.True(t, known[child], "unknown child %v", child)
+			referenced[child]++
+		}
+	}
+	for hash := range known {
+		if hash == root {
+			require.Zero(t, referenced[hash])
+			continue
+		}
+		require.Equal(t, 1, referenced[hash], "node %v", hash)
+	}
+}
+
+func TestChildHashesInvalidNode(t *testing.T) {
+	require.Empty(t, childHashes(nil))
+	require.Empty(t, childHashes([]byte{0x01, 0x02}))
+}
+
+func TestLookaheadFetchesDescendants(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	values := make([]hexutil.Bytes, 500)
+	for i := range values {
+		values[i] = testutils.RandomData(rng, 40)
+	}
+	root, nodes := mpt.WriteTrie(values)
+	source := newBatchL2Client(nodes)
+	prefetcher, kv := createLookaheadPrefetcher(t, source)
+
+	source.ExpectNodeByHash(root, source.nodes[root], nil)
+	oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	require.EqualValues(t, source.nodes[root], oracle.NodeByHash(root))
+
+	// Both levels below the root are fetched in the background without further hints.
+	var expected []common.Hash
+	for _, child := range childHashes(source.nodes[root]) {
+		expected = append(expected, child)
+		expected = append(expected, childHashes(source.nodes[child])...)
+	}
+	require.NotEmpty(t, expected)
+	require.Eventually(t, func() bool {
+		for _, hash := range expected {
+			if _, err := kv.Get(preimage.Keccak256Key(hash).PreimageKey()); err != nil {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Requesting a prefetched node does not require any further individual fetches.
+	for _, hash := range expected {
+		require.EqualValues(t, source.nodes[hash], oracle.NodeByHash(hash))
+	}
+	source.MockDebugClient.AssertExpectations(t)
+
+	prefetcher.Close()
+	stats, ok := prefetcher.LookaheadStats()
+	require.True(t, ok)
+	require.EqualValues(t, len(expected), stats.Fetched)
+	require.Zero(t, stats.Failed)
+}
+
+func TestLookaheadFailureIsNotFatal(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	values := make([]hexutil.Bytes, 100)
+	for i := range values {
+		values[i] = testutils.RandomData(rng, 40)
+	}
+	root, nodes := mpt.WriteTrie(values)
+	source := newBatchL2Client(nodes)
+	source.batchErr = errors.New("boom")
+	prefetcher, _ := createLookaheadPrefetcher(t, source)
+
+	source.ExpectNodeByHash(root, source.nodes[root], nil)
+	oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	require.EqualValues(t, source.nodes[root], oracle.NodeByHash(root))
+	require.Eventually(t, func() bool {
+		stats, _ := prefetcher.LookaheadStats()
+		return stats.Failed > 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Children are still available on demand
+	child := childHashes(source.nodes[root])[0]
+	source.ExpectNodeByHash(child, source.nodes[child], nil)
+	require.EqualValues(t, source.nodes[child], oracle.NodeByHash(child))
+	source.MockDebugClient.AssertExpectations(t)
+}
+
+func TestLookaheadRejectsMismatchedNodes(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	values := make([]hexutil.Bytes, 100)
+	for i := range values {
+		values[i] = testutils.RandomData(rng, 40)
+	}
+	root, nodes := mpt.WriteTrie(values)
+	source := newBatchL2Client(nodes)
+	source.tamper = true
+	prefetcher, kv := createLookaheadPrefetcher(t, source)
+
+	source.ExpectNodeByHash(root, source.nodes[root], nil)
+	oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	require.EqualValues(t, source.nodes[root], oracle.NodeByHash(root))
+	require.Eventually(t, func() bool {
+		stats, _ := prefetcher.LookaheadStats()
+		return stats.Failed > 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// Nodes that don't match their hash are never stored
+	for _, child := range childHashes(source.nodes[root]) {
+		_, err := kv.Get(preimage.Keccak256Key(child).PreimageKey())
+		require.ErrorIs(t, err, kvstore.ErrNotFound)
+	}
+	stats, _ := prefetcher.LookaheadStats()
+	require.Zero(t, stats.Fetched)
+}
+
+func TestLookaheadRequiresBatchSource(t *testing.T) {
+	prefetcher, _, _, _ := createPrefetcher(t)
+	prefetcher.StartLookahead(4)
+	_, ok := prefetcher.LookaheadStats()
+	require.False(t, ok)
+	prefetcher.Close()
+}
+
+func TestLookaheadL1BlockHeader(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, rcpts := testutils.RandomBlock(rng, 10)
+	hash := block.Hash()
+
+	prefetcher, l1Cl, _, _ := createPrefetcher(t)
+	prefetcher.StartLookahead(4)
+	defer prefetcher.Close()
+	l1Cl.ExpectInfoByHash(hash, eth.BlockToInfo(block), nil)
+	l1Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
+	l1Cl.ExpectFetchReceipts(hash, eth.BlockToInfo(block), rcpts, nil)
+
+	// Header, transactions and receipts are all fetched by the header hint
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	require.Equal(t, eth.HeaderBlockInfo(block.Header()), oracle.HeaderByBlockHash(hash))
+	l1Cl.AssertExpectations(t)
+
+	_, txs := oracle.TransactionsByBlockHash(hash)
+	assertTransactionsEqual(t, block.Transactions(), txs)
+	_, receipts := oracle.ReceiptsByBlockHash(hash)
+	assertReceiptsEqual(t, rcpts, receipts)
+	l1Cl.AssertExpectations(t)
+}
+
+type batchL2Client struct {
+	l2Client
+	lock     sync.Mutex
+	nodes    map[common.Hash][]byte
+	batchErr error
+	// tamper causes batch requests to return nodes that don't match the requested hashes
+	tamper bool
+}
+
+func newBatchL2Client(nodes []hexutil.Bytes) *batchL2Client {
+	byHash := make(map[common.Hash][]byte)
+	for _, node := range nodes {
+		byHash[crypto.Keccak256Hash(node)] = node
+	}
+	return &batchL2Client{
+		l2Client: l2Client{
+			MockL2Client:    new(testutils.MockL2Client),
+			MockDebugClient: new(testutils.MockDebugClient),
+		},
+		nodes: byHash,
+	}
+}
+
+func (c *batchL2Client) NodesByHash(_ context.Context, hashes []common.Hash) ([][]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.batchErr != nil {
+		return nil, c.batchErr
+	}
+	result := make([][]byte, len(hashes))
+	for i, hash := range hashes {
+		node, ok := c.nodes[hash]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+		if c.tamper {
+			node = append([]byte{0x00}, node...)
+		}
+		result[i] = node
+	}
+	return result, nil
+}
+
+func createLookaheadPrefetcher(t *testing.T, source *batchL2Client) (*Prefetcher, kvstore.KV) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	kv := kvstore.NewMemKV()
+	prefetcher := NewPrefetcher(logger, metrics.NoopMetrics, new(testutils.MockL1Source), source, kv)
+	prefetcher.StartLookahead(2)
+	t.Cleanup(prefetcher.Close)
+	return prefetcher, kv
+}
@@ -5,18 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
 )
 
 type L1Source interface {
@@ -34,19 +37,66 @@ type L2Source interface {
 
 type Prefetcher struct {
 	logger    log.Logger
+	metrics   metrics.Metricer
 	l1Fetcher L1Source
 	l2Fetcher L2Source
 	lastHint  string
 	kvStore   kvstore.KV
+
+	// l2BatchFetcher is the unwrapped L2 source, if it supports batched node retrieval
+	l2BatchFetcher L2BatchSource
+	// lookaheadEnabled indicates that block hints also fetch the data the client will request next
+	lookaheadEnabled bool
+	// lookahead prefetches state trie nodes in the background, nil if not enabled
+	lookahead *lookahead
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+func NewPrefetcher(logger log.Logger, m metrics.Metricer, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+	batchFetcher, _ := l2Fetcher.(L2BatchSource)
 	return &Prefetcher{
-		logger:    logger,
-		l1Fetcher: NewRetryingL1Source(logger, l1Fetcher),
-		l2Fetcher: NewRetryingL2Source(logger, l2Fetcher),
-		kvStore:   kvStore,
+		logger:         logger,
+		metrics:        m,
+		l1Fetcher:      NewRetryingL1Source(logger, l1Fetcher),
+		l2Fetcher:      NewRetryingL2Source(logger, l2Fetcher),
+		kvStore:        kvStore,
+		l2BatchFetcher: batchFetcher,
+	}
+}
+
+// StartLookahead enables speculative fetching of data the client program is likely to request next.
+// L1 block header hints additionally fetch the block's transactions and receipts in parallel and L2 state node
+// hints schedule the descendants of the node to be fetched in the background by the specified number of workers.
+// State node lookahead requires the L2 source to support batched node retrieval.
+func (p *Prefetcher) StartLookahead(workers int) {
+	if workers <= 0 || p.lookaheadEnabled {
+		return
+	}
+	p.lookaheadEnabled = true
+	if p.l2BatchFetcher == nil {
+		p.logger.Warn("L2 source does not support batched node retrieval, state node lookahead disabled")
+		return
+	}
+	p.logger.Info("Starting prefetch lookahead", "workers", workers)
+	p.lookahead = newLookahead(p.logger, p.metrics, p.l2BatchFetcher, p.kvStore, workers)
+}
+
+// LookaheadStats returns statistics for the lookahead workers, or false if lookahead is not enabled.
+func (p *Prefetcher) LookaheadStats() (LookaheadStats, bool) {
+	if p.lookahead == nil {
+		return LookaheadStats{}, false
+	}
+	return p.lookahead.stats(), true
+}
+
+// Close stops any background lookahead workers.
+func (p *Prefetcher) Close() {
+	if p.lookahead == nil {
+		return
 	}
+	p.lookahead.close()
+	stats := p.lookahead.stats()
+	p.logger.Info("Prefetch lookahead stopped",
+		"scheduled", stats.Scheduled, "dropped", stats.Dropped, "fetched", stats.Fetched, "failed", stats.Failed)
 }
 
 func (p *Prefetcher) Hint(hint string) error {
@@ -80,8 +130,21 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		return err
 	}
 	p.logger.Debug("Prefetching", "type", hintType, "hash", hash)
+	start := time.Now()
+	if err := p.prefetchHint(ctx, hintType, hash); err != nil {
+		p.metrics.RecordPrefetchFailure(hintType)
+		return err
+	}
+	p.metrics.RecordPrefetch(hintType, time.Since(start).Seconds())
+	return nil
+}
+
+func (p *Prefetcher) prefetchHint(ctx context.Context, hintType string, hash common.Hash) error {
 	switch hintType {
 	case l1.HintL1BlockHeader:
+		if p.lookaheadEnabled {
+			return p.prefetchL1Block(ctx, hash)
+		}
 		header, err := p.l1Fetcher.InfoByHash(ctx, hash)
 		if err != nil {
 			return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
@@ -122,7 +185,13 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L2 state node %s: %w", hash, err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), node)
+		if err := p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), node); err != nil {
+			return err
+		}
+		if p.lookahead != nil {
+			p.lookahead.schedule(node)
+		}
+		return nil
 	case l2.HintL2Code:
 		code, err := p.l2Fetcher.CodeByHash(ctx, hash)
 		if err != nil {
@@ -139,6 +208,38 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
+// prefetchL1Block fetches the header, transactions and receipts of an L1 block in parallel.
+// The client program retrieves the transactions and receipts of almost every L1 block it reads the header of.
+func (p *Prefetcher) prefetchL1Block(ctx context.Context, hash common.Hash) error {
+	var g errgroup.Group
+	g.Go(func() error {
+		header, err := p.l1Fetcher.InfoByHash(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 block %s header: %w", hash, err)
+		}
+		data, err := header.HeaderRLP()
+		if err != nil {
+			return fmt.Errorf("marshall header: %w", err)
+		}
+		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+	})
+	g.Go(func() error {
+		_, txs, err := p.l1Fetcher.InfoAndTxsByHash(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 block %s txs: %w", hash, err)
+		}
+		return p.storeTransactions(txs)
+	})
+	g.Go(func() error {
+		_, receipts, err := p.l1Fetcher.FetchReceipts(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
+		}
+		return p.storeReceipts(receipts)
+	})
+	return g.Wait()
+}
+
 func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
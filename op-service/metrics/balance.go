@@ -49,3 +49,29 @@ func LaunchBalanceMetrics(log log.Logger, r *prometheus.Registry, ns string, cli
 		return nil
 	}, 10*time.Second)
 }
+
+// LaunchBalancesMetrics starts a periodic query of the balances of the supplied accounts and records
+// them to the "balance" metric of the namespace, labelled by the address of the account.
+// The balances are recorded in Ether (not Wei).
+func LaunchBalancesMetrics(log log.Logger, r *prometheus.Registry, ns string, client *ethclient.Client, accounts []common.Address) *clock.LoopFn {
+	balanceGuage := promauto.With(r).NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: ns,
+		Name:      "balance",
+		Help:      "balance (in ether) of the accounts",
+	}, []string{"address"})
+	return clock.NewLoopFn(clock.SystemClock, func(ctx context.Context) {
+		ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+		for _, account := range accounts {
+			bigBal, err := client.BalanceAt(ctx, account, nil)
+			if err != nil {
+				log.Warn("failed to get balance of account", "err", err, "address", account)
+				continue
+			}
+			balanceGuage.WithLabelValues(account.Hex()).Set(weiToEther(bigBal))
+		}
+	}, func() error {
+		log.Info("balance metrics shutting down")
+		return nil
+	}, 10*time.Second)
+}
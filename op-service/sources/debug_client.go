@@ -8,14 +8,20 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// maxNodeBatchSize is the maximum number of debug_dbGet requests sent in a single batch request.
+const maxNodeBatchSize = 100
+
 type DebugClient struct {
-	callContext batching.CallContextFn
+	callContext      batching.CallContextFn
+	batchCallContext batching.BatchCallContextFn
 }
 
-func NewDebugClient(callContext batching.CallContextFn) *DebugClient {
-	return &DebugClient{callContext}
+func NewDebugClient(callContext batching.CallContextFn, batchCallContext batching.BatchCallContextFn) *DebugClient {
+	return &DebugClient{callContext, batchCallContext}
 }
 
 func (o *DebugClient) NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
@@ -27,6 +33,43 @@ func (o *DebugClient) NodeByHash(ctx context.Context, hash common.Hash) ([]byte,
 	return node, nil
 }
 
+// NodesByHash retrieves multiple MPT nodes using batched debug_dbGet requests.
+// The returned nodes are in the same order as the requested hashes.
+// An error is returned if any of the nodes could not be retrieved or does not match its hash.
+func (o *DebugClient) NodesByHash(ctx context.Context, hashes []common.Hash) ([][]byte, error) {
+	results := make([]hexutil.Bytes, len(hashes))
+	for start := 0; start < len(hashes); start += maxNodeBatchSize {
+		end := start + maxNodeBatchSize
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		batch := make([]rpc.BatchElem, 0, end-start)
+		for i := start; i < end; i++ {
+			batch = append(batch, rpc.BatchElem{
+				Method: "debug_dbGet",
+				Args:   []any{hexutil.Encode(hashes[i][:])},
+				Result: &results[i],
+			})
+		}
+		if err := o.batchCallContext(ctx, batch); err != nil {
+			return nil, fmt.Errorf("failed to retrieve state MPT nodes: %w", err)
+		}
+		for i, elem := range batch {
+			if elem.Error != nil {
+				return nil, fmt.Errorf("failed to retrieve state MPT node %s: %w", hashes[start+i], elem.Error)
+			}
+		}
+	}
+	nodes := make([][]byte, len(results))
+	for i, node := range results {
+		if actual := crypto.Keccak256Hash(node); actual != hashes[i] {
+			return nil, fmt.Errorf("state MPT node %s does not match its hash %s", hashes[i], actual)
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
 func (o *DebugClient) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
 	// First try retrieving with the new code prefix
 	code, err := o.dbGet(ctx, append(append(make([]byte, 0), rawdb.CodePrefix...), hash[:]...))
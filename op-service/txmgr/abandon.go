@@ -0,0 +1,275 @@
+package txmgr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// AbandonPolicy is what the transaction manager does with the published transaction of a
+// send which it gives up on, because the send timed out, was cancelled or was aborted.
+type AbandonPolicy string
+
+const (
+	// AbandonPolicyNone leaves the transaction in the mempool. The next transaction reuses
+	// its nonce if it is not mined, and conflicts with it. The empty policy is none.
+	AbandonPolicyNone AbandonPolicy = "none"
+	// AbandonPolicyBump keeps bumping the fees of the transaction until it is mined.
+	AbandonPolicyBump AbandonPolicy = "bump"
+	// AbandonPolicyCancel replaces the transaction with a zero-value transfer to the sender,
+	// at a higher fee, until the replacement is mined.
+	AbandonPolicyCancel AbandonPolicy = "cancel"
+)
+
+// Check returns an error if the policy is unknown
+func (p AbandonPolicy) Check() error {
+	switch p {
+	case "", AbandonPolicyNone, AbandonPolicyBump, AbandonPolicyCancel:
+		return nil
+	default:
+		return fmt.Errorf("unknown abandon policy %q", p)
+	}
+}
+
+// TxPoolBackend is implemented by backends which can report the transactions of an account
+// queued in the mempool behind a nonce gap. Without it, only the nonce gaps below the
+// transactions tracked by the transaction manager are filled, so the queued transactions of
+// an earlier instance stay stuck.
+type TxPoolBackend interface {
+	QueuedNonces(ctx context.Context, account common.Address) ([]uint64, error)
+}
+
+// txPoolClient is an ethclient.Client which reports the queued transactions with the txpool
+// namespace of the node
+type txPoolClient struct {
+	*ethclient.Client
+}
+
+// QueuedNonces returns the nonces of the queued transactions of the account, with txpool_contentFrom
+func (c *txPoolClient) QueuedNonces(ctx context.Context, account common.Address) ([]uint64, error) {
+	var content map[string]map[string]json.RawMessage
+	if err := c.Client.Client().CallContext(ctx, &content, "txpool_contentFrom", account); err != nil {
+		return nil, err
+	}
+	nonces := make([]uint64, 0, len(content["queued"]))
+	for key := range content["queued"] {
+		nonce, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nonce %q of queued transaction: %w", key, err)
+		}
+		nonces = append(nonces, nonce)
+	}
+	return nonces, nil
+}
+
+// Cancellation reasons of the metrics
+const (
+	cancelReasonAbandoned = "abandoned"
+	cancelReasonGap       = "nonce_gap"
+)
+
+// tracksNonces returns whether the published nonces are tracked, to resolve abandoned
+// transactions and to fill nonce gaps.
+func (m *SimpleTxManager) tracksNonces() bool {
+	return m.cfg.AbandonPolicy == AbandonPolicyBump || m.cfg.AbandonPolicy == AbandonPolicyCancel
+}
+
+// nextNonce returns the nonce of the next transaction after a reset. It must be called
+// with the nonce lock held.
+//
+// The nonce is fetched from the latest known block. When nonces are tracked, the next nonce
+// also follows the pending transactions of the mempool, the tracked transactions and, with a
+// [TxPoolBackend], the queued transactions of the mempool. The pending and queued transactions,
+// which may be those of an earlier instance, are kept. The nonces above the pending ones and
+// below a tracked or queued nonce which are neither tracked nor queued themselves are nonce
+// gaps, which would block the later transactions, and are filled with zero-value transfers.
+// This also fills the gaps left in the mempool by an earlier instance at startup.
+//
+// With the none policy, the nonce of the latest block is used as is, so neither the pending
+// transactions nor the nonce gaps of an earlier instance are handled.
+func (m *SimpleTxManager) nextNonce(ctx context.Context) (uint64, error) {
+	// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	latest, err := m.backend.NonceAt(cCtx, m.cfg.From, nil)
+	if err != nil {
+		m.metr.RPCError()
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	if !m.tracksNonces() {
+		return latest, nil
+	}
+
+	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	pending, err := m.backend.PendingNonceAt(cCtx, m.cfg.From)
+	if err != nil {
+		m.metr.RPCError()
+		return 0, fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	fillFrom := max(latest, pending)
+	next := fillFrom
+	for nonce := range m.published {
+		next = max(next, nonce+1)
+	}
+	queued := m.queuedNonces(ctx, fillFrom)
+	for nonce := range queued {
+		next = max(next, nonce+1)
+	}
+	for nonce := fillFrom; nonce < next; nonce++ {
+		if _, ok := m.published[nonce]; !ok && !queued[nonce] {
+			m.l.Warn("Filling nonce gap", "nonce", nonce, "latest", latest, "pending", pending, "next", next)
+			m.resolve(nonce, m.unpublished[nonce], AbandonPolicyCancel, cancelReasonGap)
+		}
+	}
+	for nonce := range m.unpublished {
+		if nonce < next {
+			delete(m.unpublished, nonce)
+		}
+	}
+	return next, nil
+}
+
+// queuedNonces returns the nonces from the given one of the transactions queued in the
+// mempool, if the backend reports them. Nodes which don't serve the txpool namespace report
+// no queued transactions.
+func (m *SimpleTxManager) queuedNonces(ctx context.Context, from uint64) map[uint64]bool {
+	backend, ok := m.backend.(TxPoolBackend)
+	if !ok {
+		return nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	nonces, err := backend.QueuedNonces(cCtx, m.cfg.From)
+	if err != nil {
+		m.l.Warn("Failed to get queued transactions, nonce gaps below them are not filled", "err", err)
+		return nil
+	}
+	queued := make(map[uint64]bool)
+	for _, nonce := range nonces {
+		if nonce >= from {
+			queued[nonce] = true
+		}
+	}
+	return queued
+}
+
+// abandon resolves the transaction of a failed send according to the abandon policy. A
+// nonce which was never published becomes a nonce gap, which is filled after the reset of
+// the nonce with fees above those of the signed transaction.
+func (m *SimpleTxManager) abandon(signed *types.Transaction) {
+	if !m.tracksNonces() {
+		return
+	}
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	nonce := signed.Nonce()
+	tx := m.published[nonce]
+	if tx == nil {
+		delete(m.published, nonce)
+		m.unpublished[nonce] = signed
+		return
+	}
+	m.l.Warn("Abandoned transaction", "hash", tx.Hash(), "nonce", nonce, "policy", m.cfg.AbandonPolicy)
+	m.metr.TxAbandoned()
+	m.resolve(nonce, tx, m.cfg.AbandonPolicy, cancelReasonAbandoned)
+}
+
+// resolve sends the transaction at the nonce in the background until it is mined, either
+// bumping the previous transaction or replacing it with a zero-value transfer. The nonce is
+// tracked until then. It must be called with the nonce lock held.
+func (m *SimpleTxManager) resolve(nonce uint64, prev *types.Transaction, policy AbandonPolicy, reason string) {
+	m.published[nonce] = prev
+	if policy == AbandonPolicyCancel {
+		m.metr.TxCancelled(reason)
+	}
+	m.resolving.Add(1)
+	go func() {
+		defer m.resolving.Done()
+		defer m.untrack(nonce)
+		ctx := m.resolveCtx
+		var tx *types.Transaction
+		var err error
+		if policy == AbandonPolicyCancel {
+			tx, err = m.craftCancelTx(ctx, nonce, prev)
+			if err != nil {
+				m.l.Error("Failed to create cancellation transaction", "nonce", nonce, "err", err)
+				return
+			}
+		} else if tx, err = m.increaseGasPrice(ctx, prev); err != nil {
+			// the abandoned transaction is resubmitted, and bumped at the next resubmission
+			m.l.Warn("Failed to bump abandoned transaction", "nonce", nonce, "err", err)
+			tx = prev
+		}
+		l := m.l.New("nonce", nonce, "policy", policy, "reason", reason)
+		l.Info("Resolving transaction", "hash", tx.Hash())
+		receipt, err := m.sendTx(ctx, tx, nil)
+		if err != nil {
+			l.Warn("Failed to resolve transaction", "err", err)
+			return
+		}
+		l.Info("Resolved transaction", "hash", receipt.TxHash, "block", receipt.BlockNumber)
+	}()
+}
+
+// craftCancelTx creates a signed zero-value transfer to the sender at the nonce. If there
+// is a previous transaction at the nonce, the fees are bumped enough to replace it.
+func (m *SimpleTxManager) craftCancelTx(ctx context.Context, nonce uint64, prev *types.Transaction) (*types.Transaction, error) {
+	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
+	if prev != nil {
+		gasTipCap, gasFeeCap = updateFees(prev.GasTipCap(), prev.GasFeeCap(), gasTipCap, basefee, m.l)
+	}
+	rawTx := &types.DynamicFeeTx{
+		ChainID:   m.chainID,
+		Nonce:     nonce,
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		Gas:       params.TxGas,
+		To:        &m.cfg.From,
+		Value:     common.Big0,
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+}
+
+// trackPublished records the latest published transaction at its nonce
+func (m *SimpleTxManager) trackPublished(tx *types.Transaction) {
+	if !m.tracksNonces() {
+		return
+	}
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	m.published[tx.Nonce()] = tx
+}
+
+// untrack stops tracking a nonce, once its transaction is mined or resolved
+func (m *SimpleTxManager) untrack(nonce uint64) {
+	if !m.tracksNonces() {
+		return
+	}
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	delete(m.published, nonce)
+}
+
+// stopResolving stops the resolution of the abandoned transactions, which are left in the
+// mempool, and waits for the resolutions to return.
+func (m *SimpleTxManager) stopResolving() {
+	if m.resolveCancel == nil {
+		return
+	}
+	m.resolveCancel()
+	m.resolving.Wait()
+}
@@ -0,0 +1,312 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var abandonTestFrom = common.Address{0xaa}
+
+// mockNonceBackend is a mockBackend with configurable latest and pending nonces, which
+// records the published transactions.
+type mockNonceBackend struct {
+	mockBackend
+	latest  uint64
+	pending uint64
+
+	sentLock sync.Mutex
+	sent     []*types.Transaction
+}
+
+func (b *mockNonceBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return b.latest, nil
+}
+
+func (b *mockNonceBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.pending, nil
+}
+
+// onSend records the published transactions, and mines those matching the filter
+func (b *mockNonceBackend) onSend(mine func(tx *types.Transaction) bool) {
+	b.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		b.sentLock.Lock()
+		b.sent = append(b.sent, tx)
+		b.sentLock.Unlock()
+		if mine(tx) {
+			txHash := tx.Hash()
+			b.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	})
+}
+
+// cancelled returns the nonces of the zero-value transfers to the sender
+func (b *mockNonceBackend) cancelled() []uint64 {
+	b.sentLock.Lock()
+	defer b.sentLock.Unlock()
+	var nonces []uint64
+	for _, tx := range b.sent {
+		if *tx.To() == abandonTestFrom && tx.Value().Sign() == 0 {
+			nonces = append(nonces, tx.Nonce())
+		}
+	}
+	return nonces
+}
+
+func newAbandonTestMgr(t *testing.T, policy AbandonPolicy) (*SimpleTxManager, *mockNonceBackend) {
+	backend := &mockNonceBackend{mockBackend: *newMockBackend(newGasPricer(3))}
+	cfg := configWithNumConfs(1)
+	cfg.Backend = backend
+	cfg.ChainID = big.NewInt(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.From = abandonTestFrom
+	cfg.AbandonPolicy = policy
+	require.NoError(t, cfg.Check())
+	mgr := newSimpleTxManager("TEST", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, cfg)
+	t.Cleanup(mgr.Close)
+	return mgr, backend
+}
+
+func (m *SimpleTxManager) trackedNonces() int {
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	return len(m.published)
+}
+
+// TestAbandonedTxIsCancelled asserts that the transaction of a send which times out is
+// replaced with a zero-value transfer at a higher fee.
+func TestAbandonedTxIsCancelled(t *testing.T) {
+	mgr, backend := newAbandonTestMgr(t, AbandonPolicyCancel)
+	backend.onSend(func(tx *types.Transaction) bool {
+		return *tx.To() == abandonTestFrom
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	to := common.Address{0xff}
+	_, err := mgr.Send(ctx, TxCandidate{To: &to, Value: big.NewInt(1), GasLimit: 50_000})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.Eventually(t, func() bool {
+		return mgr.trackedNonces() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{0}, backend.cancelled())
+	abandoned, cancellation := backend.sent[0], backend.sent[len(backend.sent)-1]
+	require.Equal(t, to, *abandoned.To())
+	require.Equal(t, abandoned.Nonce(), cancellation.Nonce())
+	require.Equal(t, uint64(21_000), cancellation.Gas())
+	require.True(t, cancellation.GasFeeCap().Cmp(abandoned.GasFeeCap()) > 0)
+	require.True(t, cancellation.GasTipCap().Cmp(abandoned.GasTipCap()) > 0)
+
+	// the next transaction follows the cancelled nonce
+	backend.latest = 1
+	backend.pending = 1
+	backend.onSend(func(tx *types.Transaction) bool { return true })
+	receipt, err := mgr.Send(context.Background(), TxCandidate{To: &to, GasLimit: 50_000})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), backend.sent[len(backend.sent)-1].Nonce())
+	require.Equal(t, backend.sent[len(backend.sent)-1].Hash(), receipt.TxHash)
+}
+
+// TestAbandonedTxIsBumped asserts that the transaction of a send which times out keeps
+// being bumped until it is mined.
+func TestAbandonedTxIsBumped(t *testing.T) {
+	mgr, backend := newAbandonTestMgr(t, AbandonPolicyBump)
+	var firstFeeCap *big.Int
+	backend.onSend(func(tx *types.Transaction) bool {
+		if firstFeeCap == nil {
+			firstFeeCap = tx.GasFeeCap()
+			return false
+		}
+		return tx.GasFeeCap().Cmp(firstFeeCap) > 0
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	to := common.Address{0xff}
+	_, err := mgr.Send(ctx, TxCandidate{To: &to, GasLimit: 50_000})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.Eventually(t, func() bool {
+		return mgr.trackedNonces() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, backend.cancelled())
+	require.Greater(t, len(backend.sent), 1)
+	for _, tx := range backend.sent {
+		require.Equal(t, to, *tx.To())
+		require.Equal(t, uint64(0), tx.Nonce())
+	}
+}
+
+// TestNextNonceFillsGaps asserts that the untracked nonces between the pending nonce and a
+// tracked nonce are filled with zero-value transfers.
+func TestNextNonceFillsGaps(t *testing.T) {
+	tracked := types.NewTx(&types.DynamicFeeTx{Nonce: 5, To: &common.Address{0xff}})
+	tests := []struct {
+		name      string
+		policy    AbandonPolicy
+		latest    uint64
+		pending   uint64
+		published map[uint64]*types.Transaction
+		next      uint64
+		filled    []uint64
+	}{
+		{name: "NoneIgnoresPending", policy: AbandonPolicyNone, latest: 2, pending: 4, next: 2},
+		{name: "CancelKeepsPending", policy: AbandonPolicyCancel, latest: 2, pending: 4, next: 4},
+		{name: "BumpKeepsPending", policy: AbandonPolicyBump, latest: 2, pending: 4, next: 4},
+		{
+			name: "BumpFillsGaps", policy: AbandonPolicyBump, latest: 3, pending: 3,
+			published: map[uint64]*types.Transaction{5: tracked}, next: 6, filled: []uint64{3, 4},
+		},
+		{
+			name: "CancelFillsGapsAbovePending", policy: AbandonPolicyCancel, latest: 2, pending: 4,
+			published: map[uint64]*types.Transaction{5: tracked}, next: 6, filled: []uint64{4},
+		},
+		{
+			name: "CancelSkipsSignedNonces", policy: AbandonPolicyCancel, latest: 1, pending: 1,
+			published: map[uint64]*types.Transaction{1: nil, 3: tracked}, next: 4, filled: []uint64{2},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			mgr, backend := newAbandonTestMgr(t, test.policy)
+			backend.latest = test.latest
+			backend.pending = test.pending
+			backend.onSend(func(tx *types.Transaction) bool { return true })
+			for nonce, tx := range test.published {
+				mgr.published[nonce] = tx
+			}
+
+			mgr.nonceLock.Lock()
+			next, err := mgr.nextNonce(context.Background())
+			mgr.nonceLock.Unlock()
+			require.NoError(t, err)
+			require.Equal(t, test.next, next)
+
+			require.Eventually(t, func() bool {
+				return mgr.trackedNonces() == len(test.published)
+			}, 5*time.Second, 10*time.Millisecond)
+			require.ElementsMatch(t, test.filled, backend.cancelled())
+		})
+	}
+}
+
+// TestNextNonceGapOutbidsUnpublishedTx asserts that the nonce gap of a send which was never
+// confirmed to be published is filled with fees above those of its signed transaction.
+func TestNextNonceGapOutbidsUnpublishedTx(t *testing.T) {
+	mgr, backend := newAbandonTestMgr(t, AbandonPolicyCancel)
+	backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		backend.sentLock.Lock()
+		defer backend.sentLock.Unlock()
+		backend.sent = append(backend.sent, tx)
+		return errors.New("connection reset")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	to := common.Address{0xff}
+	_, err := mgr.Send(ctx, TxCandidate{To: &to, GasLimit: 50_000})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Zero(t, mgr.trackedNonces())
+	signed := backend.sent[0]
+
+	backend.onSend(func(tx *types.Transaction) bool { return true })
+	mgr.published[1] = types.NewTx(&types.DynamicFeeTx{Nonce: 1, To: &to})
+	mgr.nonceLock.Lock()
+	next, err := mgr.nextNonce(context.Background())
+	mgr.nonceLock.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), next)
+
+	require.Eventually(t, func() bool {
+		return mgr.trackedNonces() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{0}, backend.cancelled())
+	cancellation := backend.sent[len(backend.sent)-1]
+	require.True(t, cancellation.GasFeeCap().Cmp(signed.GasFeeCap()) > 0)
+	require.True(t, cancellation.GasTipCap().Cmp(signed.GasTipCap()) > 0)
+	require.Empty(t, mgr.unpublished)
+}
+
+// mockTxPoolBackend is a mockNonceBackend which reports queued transactions
+type mockTxPoolBackend struct {
+	mockNonceBackend
+	queued []uint64
+}
+
+func (b *mockTxPoolBackend) QueuedNonces(ctx context.Context, account common.Address) ([]uint64, error) {
+	return b.queued, nil
+}
+
+// cancelMetrics records the reasons of the cancelled transactions
+type cancelMetrics struct {
+	metrics.NoopTxMetrics
+	lock    sync.Mutex
+	reasons []string
+}
+
+func (m *cancelMetrics) TxCancelled(reason string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.reasons = append(m.reasons, reason)
+}
+
+func (m *cancelMetrics) cancelled() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]string(nil), m.reasons...)
+}
+
+// TestStartupFillsGapsBelowQueuedTxs asserts that a new transaction manager fills the nonce
+// gaps an earlier instance left below its queued transactions, and sends after them.
+func TestStartupFillsGapsBelowQueuedTxs(t *testing.T) {
+	backend := &mockTxPoolBackend{
+		mockNonceBackend: mockNonceBackend{mockBackend: *newMockBackend(newGasPricer(3)), latest: 2, pending: 3},
+		queued:           []uint64{5, 7, 1},
+	}
+	cfg := configWithNumConfs(1)
+	cfg.Backend = backend
+	cfg.ChainID = big.NewInt(1)
+	cfg.NetworkTimeout = time.Second
+	cfg.From = abandonTestFrom
+	cfg.AbandonPolicy = AbandonPolicyCancel
+	require.NoError(t, cfg.Check())
+	metr := &cancelMetrics{}
+	mgr := newSimpleTxManager("TEST", testlog.Logger(t, log.LvlCrit), metr, cfg)
+	t.Cleanup(mgr.Close)
+
+	backend.onSend(func(tx *types.Transaction) bool { return true })
+	to := common.Address{0xff}
+	receipt, err := mgr.Send(context.Background(), TxCandidate{To: &to, GasLimit: 50_000})
+	require.NoError(t, err)
+	backend.sentLock.Lock()
+	var mined *types.Transaction
+	for _, tx := range backend.sent {
+		if tx.Hash() == receipt.TxHash {
+			mined = tx
+		}
+	}
+	backend.sentLock.Unlock()
+	require.NotNil(t, mined)
+	require.Equal(t, uint64(8), mined.Nonce())
+
+	require.Eventually(t, func() bool {
+		return mgr.trackedNonces() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.ElementsMatch(t, []uint64{3, 4, 6}, backend.cancelled())
+	require.Equal(t, []string{cancelReasonGap, cancelReasonGap, cancelReasonGap}, metr.cancelled())
+}
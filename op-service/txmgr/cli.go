@@ -32,6 +32,10 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	PoolPrivateKeysFlagName           = "txmgr.pool-private-keys"
+	PoolMinBalanceGweiFlagName        = "txmgr.pool-min-balance-gwei"
+	PoolStuckTimeoutFlagName          = "txmgr.pool-stuck-timeout"
+	AbandonPolicyFlagName             = "txmgr.abandon-policy"
 )
 
 var (
@@ -58,6 +62,8 @@ type DefaultFlagValues struct {
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
 	ReceiptQueryInterval      time.Duration
+	PoolStuckTimeout          time.Duration
+	AbandonPolicy             AbandonPolicy
 }
 
 var (
@@ -70,6 +76,7 @@ var (
 		TxSendTimeout:             0 * time.Second,
 		TxNotInMempoolTimeout:     2 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		AbandonPolicy:             AbandonPolicyNone,
 	}
 	DefaultChallengerFlagValues = DefaultFlagValues{
 		NumConfirmations:          uint64(3),
@@ -80,6 +87,8 @@ var (
 		TxSendTimeout:             2 * time.Minute,
 		TxNotInMempoolTimeout:     1 * time.Minute,
 		ReceiptQueryInterval:      12 * time.Second,
+		PoolStuckTimeout:          1 * time.Minute,
+		AbandonPolicy:             AbandonPolicyNone,
 	}
 )
 
@@ -155,9 +164,46 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.ReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.StringFlag{
+			Name: AbandonPolicyFlagName,
+			Usage: "What to do with the published transaction of a send which times out or is cancelled: " +
+				"'cancel' replaces it with a zero-value transfer to the sender, 'bump' keeps bumping its fees until it is mined, " +
+				"'none' leaves it in the mempool. Unless it is 'none', nonce gaps are filled with zero-value transfers, " +
+				"including those left in the mempool by an earlier instance if the node serves txpool_contentFrom. " +
+				"With 'none', the pending transactions and nonce gaps of an earlier instance are not handled.",
+			Value:   string(defaults.AbandonPolicy),
+			EnvVars: prefixEnvVars("TXMGR_ABANDON_POLICY"),
+		},
 	}, opsigner.CLIFlags(envPrefix)...)
 }
 
+// PoolCLIFlags returns the flags of the sender pool of a PoolTxManager. They are only
+// registered by services which create their transaction manager with NewTxManager.
+func PoolCLIFlags(envPrefix string, defaults DefaultFlagValues) []cli.Flag {
+	prefixEnvVars := func(name string) []string {
+		return opservice.PrefixEnvVar(envPrefix, name)
+	}
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: PoolPrivateKeysFlagName,
+			Usage: "Additional private keys to send transactions from, each with its own nonce. The primary key is the private key, mnemonic or remote signer. " +
+				"Requires an abandon policy other than 'none', to recover the nonce of a sender whose send failed.",
+			EnvVars: prefixEnvVars("TXMGR_POOL_PRIVATE_KEYS"),
+		},
+		&cli.Uint64Flag{
+			Name:    PoolMinBalanceGweiFlagName,
+			Usage:   "Balance in GWEI below which a key of the pool is not used for new transactions. If 0 it is disabled.",
+			EnvVars: prefixEnvVars("TXMGR_POOL_MIN_BALANCE_GWEI"),
+		},
+		&cli.DurationFlag{
+			Name:    PoolStuckTimeoutFlagName,
+			Usage:   "Duration a transaction of a key of the pool may be pending before new transactions are sent from the other keys",
+			Value:   defaults.PoolStuckTimeout,
+			EnvVars: prefixEnvVars("TXMGR_POOL_STUCK_TIMEOUT"),
+		},
+	}
+}
+
 type CLIConfig struct {
 	L1RPCURL                  string
 	Mnemonic                  string
@@ -174,6 +220,10 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	PoolPrivateKeys           []string
+	PoolMinBalanceGwei        uint64
+	PoolStuckTimeout          time.Duration
+	AbandonPolicy             AbandonPolicy
 }
 
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
@@ -187,6 +237,8 @@ func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
 		TxSendTimeout:             defaults.TxSendTimeout,
 		TxNotInMempoolTimeout:     defaults.TxNotInMempoolTimeout,
 		ReceiptQueryInterval:      defaults.ReceiptQueryInterval,
+		PoolStuckTimeout:          defaults.PoolStuckTimeout,
+		AbandonPolicy:             defaults.AbandonPolicy,
 		SignerCLIConfig:           opsigner.NewCLIConfig(),
 	}
 }
@@ -216,6 +268,15 @@ func (m CLIConfig) Check() error {
 	if m.SafeAbortNonceTooLowCount == 0 {
 		return errors.New("SafeAbortNonceTooLowCount must not be 0")
 	}
+	if len(m.PoolPrivateKeys) > 0 && m.PoolStuckTimeout == 0 {
+		return errors.New("must provide PoolStuckTimeout with PoolPrivateKeys")
+	}
+	if len(m.PoolPrivateKeys) > 0 && (m.AbandonPolicy == "" || m.AbandonPolicy == AbandonPolicyNone) {
+		return errors.New("must provide an AbandonPolicy other than none with PoolPrivateKeys")
+	}
+	if err := m.AbandonPolicy.Check(); err != nil {
+		return err
+	}
 	if err := m.SignerCLIConfig.Check(); err != nil {
 		return err
 	}
@@ -239,6 +300,10 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		PoolPrivateKeys:           ctx.StringSlice(PoolPrivateKeysFlagName),
+		PoolMinBalanceGwei:        ctx.Uint64(PoolMinBalanceGweiFlagName),
+		PoolStuckTimeout:          ctx.Duration(PoolStuckTimeoutFlagName),
+		AbandonPolicy:             AbandonPolicy(ctx.String(AbandonPolicyFlagName)),
 	}
 }
 
@@ -275,7 +340,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 	}
 
 	return Config{
-		Backend:                   l1,
+		Backend:                   &txPoolClient{Client: l1},
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
 		FeeLimitMultiplier:        cfg.FeeLimitMultiplier,
 		ChainID:                   chainID,
@@ -285,6 +350,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
 		NumConfirmations:          cfg.NumConfirmations,
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+		AbandonPolicy:             cfg.AbandonPolicy,
 		Signer:                    signerFactory(chainID),
 		From:                      from,
 	}, nil
@@ -331,6 +397,10 @@ type Config struct {
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
 
+	// AbandonPolicy is what to do with the published transaction of a send which is given
+	// up on. Unless it is none, the published nonces are tracked and nonce gaps are filled.
+	AbandonPolicy AbandonPolicy
+
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
@@ -367,5 +437,8 @@ func (m Config) Check() error {
 	if m.ChainID == nil {
 		return errors.New("must provide the ChainID")
 	}
+	if err := m.AbandonPolicy.Check(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -22,6 +22,15 @@ func TestDefaultConfigIsValid(t *testing.T) {
 	require.NoError(t, cfg.Check())
 }
 
+func TestPoolRequiresAbandonPolicy(t *testing.T) {
+	cfg := NewCLIConfig(l1EthRpcValue, DefaultChallengerFlagValues)
+	cfg.PoolPrivateKeys = []string{"0x1234"}
+	require.ErrorContains(t, cfg.Check(), "AbandonPolicy")
+
+	cfg.AbandonPolicy = AbandonPolicyBump
+	require.NoError(t, cfg.Check())
+}
+
 func configForArgs(args ...string) CLIConfig {
 	app := cli.NewApp()
 	// txmgr expects the --l1-eth-rpc option to be declared externally
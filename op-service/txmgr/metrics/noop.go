@@ -10,4 +10,6 @@ func (*NoopTxMetrics) RecordGasBumpCount(int)            {}
 func (*NoopTxMetrics) RecordTxConfirmationLatency(int64) {}
 func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
 func (*NoopTxMetrics) TxPublished(string)                {}
+func (*NoopTxMetrics) TxAbandoned()                      {}
+func (*NoopTxMetrics) TxCancelled(string)                {}
 func (*NoopTxMetrics) RPCError()                         {}
@@ -15,6 +15,8 @@ type TxMetricer interface {
 	RecordPendingTx(pending int64)
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
+	TxAbandoned()
+	TxCancelled(reason string)
 	RPCError()
 }
 
@@ -27,6 +29,8 @@ type TxMetrics struct {
 	currentNonce       prometheus.Gauge
 	pendingTxs         prometheus.Gauge
 	txPublishError     *prometheus.CounterVec
+	txAbandoned        prometheus.Counter
+	txCancelled        *prometheus.CounterVec
 	publishEvent       *metrics.Event
 	confirmEvent       metrics.EventVec
 	rpcError           prometheus.Counter
@@ -96,6 +100,18 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Count of publish errors. Labels are sanitized error strings",
 			Subsystem: "txmgr",
 		}, []string{"error"}),
+		txAbandoned: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_abandoned_count",
+			Help:      "Count of published transactions of sends which were given up on",
+			Subsystem: "txmgr",
+		}),
+		txCancelled: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tx_cancelled_count",
+			Help:      "Count of nonces replaced with zero-value transfers. Labels are the reasons, abandoned or nonce_gap",
+			Subsystem: "txmgr",
+		}, []string{"reason"}),
 		confirmEvent: metrics.NewEventVec(factory, ns, "txmgr", "confirm", "tx confirm", []string{"status"}),
 		publishEvent: metrics.NewEvent(factory, ns, "txmgr", "publish", "tx publish"),
 		rpcError: factory.NewCounter(prometheus.CounterOpts{
@@ -141,6 +157,14 @@ func (t *TxMetrics) TxPublished(errString string) {
 	}
 }
 
+func (t *TxMetrics) TxAbandoned() {
+	t.txAbandoned.Inc()
+}
+
+func (t *TxMetrics) TxCancelled(reason string) {
+	t.txCancelled.WithLabelValues(reason).Inc()
+}
+
 func (t *TxMetrics) RPCError() {
 	t.rpcError.Inc()
 }
@@ -0,0 +1,340 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+// ErrNoFundedSender is returned when no sender of the pool has the minimum balance
+var ErrNoFundedSender = errors.New("no sender with the minimum balance")
+
+// BalanceBackend is implemented by backends which can report the balance of the senders
+// of a [PoolTxManager]. Without it, the balances of the senders are not tracked.
+type BalanceBackend interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// PoolConfig houses parameters for altering the behavior of a PoolTxManager.
+type PoolConfig struct {
+	// MinBalance is the balance below which a sender is not used for new transactions.
+	// If nil, the balances of the senders are not checked.
+	MinBalance *big.Int
+
+	// StuckTimeout is how long a transaction of a sender may be pending before the
+	// sender is considered stuck. New transactions are sent from the other senders
+	// while a sender is stuck, or after one of its sends failed.
+	StuckTimeout time.Duration
+
+	// BalanceRefreshInterval is how long the balance of a sender is cached.
+	BalanceRefreshInterval time.Duration
+}
+
+func (c PoolConfig) Check() error {
+	if c.StuckTimeout == 0 {
+		return errors.New("must provide StuckTimeout")
+	}
+	if c.BalanceRefreshInterval == 0 {
+		return errors.New("must provide BalanceRefreshInterval")
+	}
+	return nil
+}
+
+// poolSender is a sender of the pool, with its own nonce stream.
+type poolSender struct {
+	txMgr *SimpleTxManager
+
+	// sends are the start times of the in-flight sends, by send ID
+	sends map[uint64]time.Time
+	// failedAt is the time of the last failed send, after which the nonce was reset
+	failedAt time.Time
+
+	balance   *big.Int
+	balanceAt time.Time
+}
+
+func (s *poolSender) from() common.Address {
+	return s.txMgr.From()
+}
+
+// stuck returns whether a send of the sender has been pending for longer than the timeout
+func (s *poolSender) stuck(now time.Time, timeout time.Duration) bool {
+	for _, start := range s.sends {
+		if now.Sub(start) > timeout {
+			return true
+		}
+	}
+	return false
+}
+
+// coolingDown returns whether the sender recently failed to send a transaction
+func (s *poolSender) coolingDown(now time.Time, timeout time.Duration) bool {
+	return !s.failedAt.IsZero() && now.Sub(s.failedAt) < timeout
+}
+
+// funded returns whether the sender has the minimum balance. Unknown balances are
+// considered funded.
+func (s *poolSender) funded(minBalance *big.Int) bool {
+	return minBalance == nil || s.balance == nil || s.balance.Cmp(minBalance) >= 0
+}
+
+// PoolTxManager is an implementation of TxManager that spreads transactions over a pool
+// of senders. Every sender is a SimpleTxManager with its own nonce, so a transaction
+// stuck in the mempool only blocks the later transactions of its own sender.
+//
+// Transactions are sent from the funded sender with the fewest pending transactions,
+// preferring senders which are neither stuck nor recovering from a failed send. The
+// transaction of a failed send is resolved by its sender according to the [AbandonPolicy].
+// With the none policy, the next transaction of the sender conflicts with it, so the
+// pool is only created from a [CLIConfig] with another policy.
+type PoolTxManager struct {
+	name    string
+	cfg     PoolConfig
+	backend ETHBackend
+	l       log.Logger
+	metr    metrics.TxMetricer
+
+	senders []*poolSender
+	lock    sync.Mutex
+	nextID  uint64
+
+	pending atomic.Int64
+}
+
+// NewPoolTxManager initializes a new PoolTxManager with the passed Config. The key of the
+// Config is the primary sender, and the pool private keys are the additional senders.
+func NewPoolTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLIConfig) (*PoolTxManager, error) {
+	conf, err := NewConfig(cfg, l)
+	if err != nil {
+		return nil, err
+	}
+	confs := []Config{conf}
+	for i, key := range cfg.PoolPrivateKeys {
+		signerFactory, from, err := opcrypto.SignerFactoryFromConfig(l, key, "", "", opsigner.CLIConfig{})
+		if err != nil {
+			return nil, fmt.Errorf("could not init signer of pool key %d: %w", i, err)
+		}
+		senderConf := conf
+		senderConf.Signer = signerFactory(conf.ChainID)
+		senderConf.From = from
+		confs = append(confs, senderConf)
+	}
+	poolConf := PoolConfig{
+		StuckTimeout:           cfg.PoolStuckTimeout,
+		BalanceRefreshInterval: cfg.ReceiptQueryInterval,
+	}
+	if cfg.PoolMinBalanceGwei != 0 {
+		poolConf.MinBalance = new(big.Int).Mul(new(big.Int).SetUint64(cfg.PoolMinBalanceGwei), big.NewInt(1e9))
+	}
+	return NewPoolTxManagerFromConfig(name, l, m, confs, poolConf)
+}
+
+// NewPoolTxManagerFromConfig initializes a new PoolTxManager with a Config per sender. The
+// first Config is the primary sender. All senders must share the same Backend.
+func NewPoolTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetricer, confs []Config, poolConf PoolConfig) (*PoolTxManager, error) {
+	if len(confs) == 0 {
+		return nil, errors.New("must provide at least one sender")
+	}
+	if err := poolConf.Check(); err != nil {
+		return nil, fmt.Errorf("invalid pool config: %w", err)
+	}
+	l = l.New("service", name)
+	senders := make([]*poolSender, 0, len(confs))
+	seen := make(map[common.Address]bool)
+	for i, conf := range confs {
+		if err := conf.Check(); err != nil {
+			return nil, fmt.Errorf("invalid config of sender %d: %w", i, err)
+		}
+		if conf.Backend != confs[0].Backend {
+			return nil, fmt.Errorf("sender %d does not share the backend of the primary sender", i)
+		}
+		if seen[conf.From] {
+			return nil, fmt.Errorf("duplicate sender %s", conf.From)
+		}
+		seen[conf.From] = true
+		// the pool records the pending transactions of all senders, and only the
+		// nonce of the primary sender
+		senderMetr := &poolSenderMetrics{TxMetricer: m, recordNonce: i == 0}
+		senders = append(senders, &poolSender{
+			txMgr: newSimpleTxManager(name, l.New("from", conf.From), senderMetr, conf),
+			sends: make(map[uint64]time.Time),
+		})
+	}
+	if _, ok := confs[0].Backend.(BalanceBackend); !ok && poolConf.MinBalance != nil {
+		l.Warn("Backend does not report balances, the balances of the senders are not tracked")
+	}
+	return &PoolTxManager{
+		name:    name,
+		cfg:     poolConf,
+		backend: confs[0].Backend,
+		l:       l,
+		metr:    m,
+		senders: senders,
+	}, nil
+}
+
+// From returns the address of the primary sender. Transactions may be sent from any of
+// the [PoolTxManager.Senders].
+func (m *PoolTxManager) From() common.Address {
+	return m.senders[0].from()
+}
+
+// Senders returns the addresses of all the senders of the pool
+func (m *PoolTxManager) Senders() []common.Address {
+	addrs := make([]common.Address, len(m.senders))
+	for i, sender := range m.senders {
+		addrs[i] = sender.from()
+	}
+	return addrs
+}
+
+func (m *PoolTxManager) BlockNumber(ctx context.Context) (uint64, error) {
+	return m.backend.BlockNumber(ctx)
+}
+
+func (m *PoolTxManager) Close() {
+	for _, sender := range m.senders {
+		sender.txMgr.stopResolving()
+	}
+	// the senders share the backend
+	m.backend.Close()
+}
+
+// Send sends the transaction from one of the senders of the pool. It blocks until the
+// transaction is confirmed, like [SimpleTxManager.Send].
+//
+// NOTE: Send can be called concurrently, the nonces of the senders are managed internally.
+func (m *PoolTxManager) Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	m.refreshBalances(ctx)
+	sender, id, err := m.selectSender()
+	if err != nil {
+		return nil, err
+	}
+	m.metr.RecordPendingTx(m.pending.Add(1))
+	defer func() {
+		m.metr.RecordPendingTx(m.pending.Add(-1))
+	}()
+	receipt, err := sender.txMgr.Send(ctx, candidate)
+	m.release(sender, id, err)
+	return receipt, err
+}
+
+// selectSender selects the sender of the next transaction and records the send
+func (m *PoolTxManager) selectSender() (*poolSender, uint64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	var healthy, funded *poolSender
+	for _, sender := range m.senders {
+		if !sender.funded(m.cfg.MinBalance) {
+			continue
+		}
+		if funded == nil || len(sender.sends) < len(funded.sends) {
+			funded = sender
+		}
+		if sender.stuck(now, m.cfg.StuckTimeout) || sender.coolingDown(now, m.cfg.StuckTimeout) {
+			continue
+		}
+		if healthy == nil || len(sender.sends) < len(healthy.sends) {
+			healthy = sender
+		}
+	}
+	sender := healthy
+	if sender == nil {
+		if funded == nil {
+			return nil, 0, fmt.Errorf("%w of %v wei", ErrNoFundedSender, m.cfg.MinBalance)
+		}
+		// queue the transaction behind the pending ones rather than fail it
+		m.l.Warn("All senders are stuck or recovering, queueing transaction", "from", funded.from(), "pending", len(funded.sends))
+		sender = funded
+	}
+
+	id := m.nextID
+	m.nextID++
+	sender.sends[id] = now
+	return sender, id, nil
+}
+
+// release records the end of a send. The balance of the sender is refreshed before its
+// next transaction.
+func (m *PoolTxManager) release(sender *poolSender, id uint64, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(sender.sends, id)
+	sender.balanceAt = time.Time{}
+	if err != nil {
+		m.l.Warn("Send failed, pausing sender", "from", sender.from(), "err", err)
+		sender.failedAt = time.Now()
+	}
+}
+
+// refreshBalances fetches the balances of the senders which are older than the refresh
+// interval. A balance which can't be fetched is unknown.
+func (m *PoolTxManager) refreshBalances(ctx context.Context) {
+	backend, ok := m.backend.(BalanceBackend)
+	if !ok || m.cfg.MinBalance == nil {
+		return
+	}
+	m.lock.Lock()
+	now := time.Now()
+	var stale []*poolSender
+	for _, sender := range m.senders {
+		if now.Sub(sender.balanceAt) > m.cfg.BalanceRefreshInterval {
+			stale = append(stale, sender)
+		}
+	}
+	m.lock.Unlock()
+
+	for _, sender := range stale {
+		cCtx, cancel := context.WithTimeout(ctx, sender.txMgr.cfg.NetworkTimeout)
+		balance, err := backend.BalanceAt(cCtx, sender.from(), nil)
+		cancel()
+		if err != nil {
+			m.metr.RPCError()
+			m.l.Warn("Failed to fetch sender balance", "from", sender.from(), "err", err)
+		} else if balance.Cmp(m.cfg.MinBalance) < 0 {
+			m.l.Warn("Sender balance below minimum", "from", sender.from(), "balance", balance, "min", m.cfg.MinBalance)
+		}
+		m.lock.Lock()
+		sender.balance = balance
+		sender.balanceAt = time.Now()
+		m.lock.Unlock()
+	}
+}
+
+// poolSenderMetrics are the metrics of a sender of the pool. The pending transactions
+// are recorded for the whole pool.
+type poolSenderMetrics struct {
+	metrics.TxMetricer
+	recordNonce bool
+}
+
+func (m *poolSenderMetrics) RecordPendingTx(int64) {}
+
+func (m *poolSenderMetrics) RecordNonce(nonce uint64) {
+	if m.recordNonce {
+		m.TxMetricer.RecordNonce(nonce)
+	}
+}
+
+// NewTxManager initializes a PoolTxManager if pool private keys are configured, and a
+// SimpleTxManager otherwise.
+func NewTxManager(name string, l log.Logger, m metrics.TxMetricer, cfg CLIConfig) (TxManager, error) {
+	if len(cfg.PoolPrivateKeys) == 0 {
+		return NewSimpleTxManager(name, l, m, cfg)
+	}
+	return NewPoolTxManager(name, l, m, cfg)
+}
@@ -0,0 +1,232 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type mockBalanceBackend struct {
+	mockBackend
+	balances map[common.Address]*big.Int
+}
+
+func (b *mockBalanceBackend) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	balance, ok := b.balances[account]
+	if !ok {
+		return nil, errors.New("unknown account")
+	}
+	return balance, nil
+}
+
+func newTestPool(t *testing.T, backend ETHBackend, senders int, poolConf PoolConfig) *PoolTxManager {
+	var confs []Config
+	for i := 0; i < senders; i++ {
+		conf := configWithNumConfs(1)
+		conf.Backend = backend
+		conf.NetworkTimeout = time.Second
+		conf.ChainID = big.NewInt(1)
+		conf.From = common.Address{byte(i + 1)}
+		confs = append(confs, conf)
+	}
+	if poolConf.StuckTimeout == 0 {
+		poolConf.StuckTimeout = time.Minute
+	}
+	if poolConf.BalanceRefreshInterval == 0 {
+		poolConf.BalanceRefreshInterval = time.Minute
+	}
+	pool, err := NewPoolTxManagerFromConfig("TEST", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, confs, poolConf)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestPoolSelectsLeastPendingSender(t *testing.T) {
+	pool := newTestPool(t, newMockBackend(newGasPricer(1)), 3, PoolConfig{})
+	require.Equal(t, common.Address{1}, pool.From())
+	require.Equal(t, []common.Address{{1}, {2}, {3}}, pool.Senders())
+
+	seen := make(map[common.Address]uint64)
+	for i := 0; i < 3; i++ {
+		sender, id, err := pool.selectSender()
+		require.NoError(t, err)
+		seen[sender.from()] = id
+	}
+	require.Len(t, seen, 3)
+
+	// the released sender is the only one without pending transactions
+	pool.release(pool.senders[1], seen[common.Address{2}], nil)
+	sender, _, err := pool.selectSender()
+	require.NoError(t, err)
+	require.Equal(t, common.Address{2}, sender.from())
+}
+
+func TestPoolAvoidsStuckAndFailedSenders(t *testing.T) {
+	pool := newTestPool(t, newMockBackend(newGasPricer(1)), 3, PoolConfig{StuckTimeout: time.Minute})
+
+	// the first sender has a transaction pending for longer than the stuck timeout
+	pool.senders[0].sends[100] = time.Now().Add(-2 * time.Minute)
+	sender, id, err := pool.selectSender()
+	require.NoError(t, err)
+	require.Equal(t, common.Address{2}, sender.from())
+	// the second sender fails to send, and recovers for the stuck timeout
+	pool.release(sender, id, errors.New("boom"))
+
+	for i := 0; i < 3; i++ {
+		sender, _, err = pool.selectSender()
+		require.NoError(t, err)
+		require.Equal(t, common.Address{3}, sender.from())
+	}
+
+	// once every sender is stuck or recovering, the transaction is queued on the funded
+	// sender with the fewest pending transactions
+	pool.senders[2].failedAt = time.Now()
+	sender, _, err = pool.selectSender()
+	require.NoError(t, err)
+	require.Equal(t, common.Address{2}, sender.from())
+}
+
+func TestPoolSkipsUnfundedSenders(t *testing.T) {
+	backend := &mockBalanceBackend{
+		mockBackend: *newMockBackend(newGasPricer(1)),
+		balances: map[common.Address]*big.Int{
+			{1}: big.NewInt(10),
+			{2}: big.NewInt(1000),
+		},
+	}
+	pool := newTestPool(t, backend, 2, PoolConfig{MinBalance: big.NewInt(100)})
+	ctx := context.Background()
+
+	pool.refreshBalances(ctx)
+	for i := 0; i < 2; i++ {
+		sender, _, err := pool.selectSender()
+		require.NoError(t, err)
+		require.Equal(t, common.Address{2}, sender.from())
+	}
+
+	// the balance is refreshed once the send of the sender completes
+	backend.balances[common.Address{2}] = big.NewInt(99)
+	pool.refreshBalances(ctx)
+	require.Equal(t, big.NewInt(1000), pool.senders[1].balance)
+	for id := range pool.senders[1].sends {
+		pool.release(pool.senders[1], id, nil)
+	}
+	pool.refreshBalances(ctx)
+	_, _, err := pool.selectSender()
+	require.ErrorIs(t, err, ErrNoFundedSender)
+}
+
+func TestPoolSendsConcurrentlyFromAllSenders(t *testing.T) {
+	backend := newMockBackend(newGasPricer(1))
+	var mu sync.Mutex
+	froms := make(map[common.Address]int)
+	pool := newTestPool(t, backend, 3, PoolConfig{})
+	for _, sender := range pool.senders {
+		from := sender.from()
+		sender.txMgr.cfg.Signer = func(ctx context.Context, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			require.Equal(t, from, addr)
+			mu.Lock()
+			froms[addr]++
+			mu.Unlock()
+			return tx, nil
+		}
+	}
+	// only mine the transactions once every sender is in use, so the sends overlap
+	var sent sync.WaitGroup
+	sent.Add(3)
+	backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		go func() {
+			sent.Done()
+			sent.Wait()
+			txHash := tx.Hash()
+			backend.mine(&txHash, tx.GasFeeCap())
+		}()
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			to := common.Address{0xff, byte(i)}
+			receipt, err := pool.Send(context.Background(), TxCandidate{To: &to, GasLimit: 21000})
+			require.NoError(t, err)
+			require.NotNil(t, receipt)
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, map[common.Address]int{{1}: 1, {2}: 1, {3}: 1}, froms)
+}
+
+func TestPoolRejectsDuplicateSenders(t *testing.T) {
+	backend := newMockBackend(newGasPricer(1))
+	conf := configWithNumConfs(1)
+	conf.Backend = backend
+	conf.NetworkTimeout = time.Second
+	conf.ChainID = big.NewInt(1)
+	poolConf := PoolConfig{StuckTimeout: time.Minute, BalanceRefreshInterval: time.Minute}
+	_, err := NewPoolTxManagerFromConfig("TEST", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{}, []Config{conf, conf}, poolConf)
+	require.ErrorContains(t, err, "duplicate sender")
+}
+
+// TestPoolRecoversNonceOfFailedSend asserts that the nonce of a sender whose send timed
+// out is recovered by cancelling the abandoned transaction, without conflicting with the
+// next transaction of the sender.
+func TestPoolRecoversNonceOfFailedSend(t *testing.T) {
+	backend := &mockNonceBackend{mockBackend: *newMockBackend(newGasPricer(3))}
+	conf := configWithNumConfs(1)
+	conf.Backend = backend
+	conf.NetworkTimeout = time.Second
+	conf.ChainID = big.NewInt(1)
+	conf.From = abandonTestFrom
+	conf.AbandonPolicy = AbandonPolicyCancel
+	pool, err := NewPoolTxManagerFromConfig("TEST", testlog.Logger(t, log.LvlCrit), &metrics.NoopTxMetrics{},
+		[]Config{conf}, PoolConfig{StuckTimeout: time.Minute, BalanceRefreshInterval: time.Minute})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	// only the cancellation and the transactions following the abandoned nonce are mined
+	backend.onSend(func(tx *types.Transaction) bool {
+		return *tx.To() == abandonTestFrom || tx.Nonce() > 0
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	to := common.Address{0xff}
+	_, err = pool.Send(ctx, TxCandidate{To: &to, GasLimit: 50_000})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// the abandoned transaction is still in the mempool when the sender is used again
+	backend.pending = 1
+	receipt, err := pool.Send(context.Background(), TxCandidate{To: &to, GasLimit: 50_000})
+	require.NoError(t, err)
+	var mined *types.Transaction
+	backend.sentLock.Lock()
+	for _, tx := range backend.sent {
+		if tx.Hash() == receipt.TxHash {
+			mined = tx
+		}
+	}
+	backend.sentLock.Unlock()
+	require.NotNil(t, mined)
+	require.Equal(t, to, *mined.To())
+	require.Equal(t, uint64(1), mined.Nonce())
+
+	require.Eventually(t, func() bool {
+		return pool.senders[0].txMgr.trackedNonces() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []uint64{0}, backend.cancelled())
+}
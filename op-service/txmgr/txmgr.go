@@ -100,8 +100,21 @@ type SimpleTxManager struct {
 
 	nonce     *uint64
 	nonceLock sync.RWMutex
+	// published are the latest published transactions by nonce, of the sends in flight and
+	// of the abandoned sends being resolved. A nil transaction is a signed nonce which is
+	// not yet published. Nonces are only tracked with an [AbandonPolicy] other than none.
+	published map[uint64]*types.Transaction
+	// unpublished are the signed transactions of abandoned sends which were never confirmed
+	// to be published. They may still be in the mempool, so they seed the fees of the
+	// cancellation which fills their nonce gap.
+	unpublished map[uint64]*types.Transaction
 
 	pending atomic.Int64
+
+	// resolveCtx is cancelled on Close, to stop the resolution of abandoned transactions
+	resolveCtx    context.Context
+	resolveCancel context.CancelFunc
+	resolving     sync.WaitGroup
 }
 
 // NewSimpleTxManager initializes a new SimpleTxManager with the passed Config.
@@ -118,14 +131,23 @@ func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetrice
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
+	return newSimpleTxManager(name, l.New("service", name), m, conf), nil
+}
+
+func newSimpleTxManager(name string, l log.Logger, m metrics.TxMetricer, conf Config) *SimpleTxManager {
+	resolveCtx, resolveCancel := context.WithCancel(context.Background())
 	return &SimpleTxManager{
-		chainID: conf.ChainID,
-		name:    name,
-		cfg:     conf,
-		backend: conf.Backend,
-		l:       l.New("service", name),
-		metr:    m,
-	}, nil
+		chainID:       conf.ChainID,
+		name:          name,
+		cfg:           conf,
+		backend:       conf.Backend,
+		l:             l,
+		metr:          m,
+		published:     make(map[uint64]*types.Transaction),
+		unpublished:   make(map[uint64]*types.Transaction),
+		resolveCtx:    resolveCtx,
+		resolveCancel: resolveCancel,
+	}
 }
 
 func (m *SimpleTxManager) From() common.Address {
@@ -137,6 +159,7 @@ func (m *SimpleTxManager) BlockNumber(ctx context.Context) (uint64, error) {
 }
 
 func (m *SimpleTxManager) Close() {
+	m.stopResolving()
 	m.backend.Close()
 }
 
@@ -151,6 +174,10 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// Published is called, if set, with each signed tx before it is published, including replacements with
+	// increased fees. It allows the tx to be recorded so that it can be found if the sender stops before the
+	// receipt is received.
+	Published func(tx *types.Transaction)
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -191,7 +218,13 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.sendTx(ctx, tx)
+	receipt, err := m.sendTx(ctx, tx, candidate.Published)
+	if err != nil {
+		m.abandon(tx)
+	} else {
+		m.untrack(tx.Nonce())
+	}
+	return receipt, err
 }
 
 // craftTx creates the signed transaction
@@ -250,13 +283,9 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.Dy
 	defer m.nonceLock.Unlock()
 
 	if m.nonce == nil {
-		// Fetch the sender's nonce from the latest known block (nil `blockNumber`)
-		childCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
-		defer cancel()
-		nonce, err := m.backend.NonceAt(childCtx, m.cfg.From, nil)
+		nonce, err := m.nextNonce(ctx)
 		if err != nil {
-			m.metr.RPCError()
-			return nil, fmt.Errorf("failed to get nonce: %w", err)
+			return nil, err
 		}
 		m.nonce = &nonce
 	} else {
@@ -273,6 +302,10 @@ func (m *SimpleTxManager) signWithNextNonce(ctx context.Context, rawTx *types.Dy
 		*m.nonce--
 	} else {
 		m.metr.RecordNonce(*m.nonce)
+		if m.tracksNonces() {
+			m.published[*m.nonce] = nil
+			delete(m.unpublished, *m.nonce)
+		}
 	}
 	return tx, err
 }
@@ -286,8 +319,9 @@ func (m *SimpleTxManager) resetNonce() {
 }
 
 // send submits the same transaction several times with increasing gas prices as necessary.
-// It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// It waits for the transaction to be confirmed on chain. If published is not nil, it is called with each
+// transaction before it is published.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, published func(tx *types.Transaction)) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -297,8 +331,8 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	receiptChan := make(chan *types.Receipt, 1)
 	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
 		wg.Add(1)
-		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
-		if published {
+		tx, sent := m.publishTx(ctx, tx, sendState, bumpFees, published)
+		if sent {
 			go func() {
 				defer wg.Done()
 				m.waitForTx(ctx, tx, sendState, receiptChan)
@@ -343,7 +377,7 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 // publishTx publishes the transaction to the transaction pool. If it receives any underpriced errors
 // it will bump the fees and retry.
 // Returns the latest fee bumped tx, and a boolean indicating whether the tx was sent or not
-func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool) (*types.Transaction, bool) {
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool, published func(tx *types.Transaction)) (*types.Transaction, bool) {
 	updateLogFields := func(tx *types.Transaction) log.Logger {
 		return m.l.New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 	}
@@ -371,11 +405,17 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			return tx, false
 		}
 
+		if published != nil {
+			published(tx)
+		}
 		cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 		err := m.backend.SendTransaction(cCtx, tx)
 		cancel()
 		sendState.ProcessSendError(err)
 
+		if err == nil || errStringMatch(err, txpool.ErrAlreadyKnown) {
+			m.trackPublished(tx)
+		}
 		if err == nil {
 			m.metr.TxPublished("")
 			log.Info("Transaction successfully published")
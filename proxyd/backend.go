@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -111,6 +112,18 @@ var (
 		HTTPErrorCode: 500,
 	}
 
+	ErrOverComputeUnitQuota = &RPCErr{
+		Code:          JSONRPCErrorInternal - 22,
+		Message:       "over daily compute unit quota",
+		HTTPErrorCode: 429,
+	}
+
+	ErrTenantWSNotSupported = &RPCErr{
+		Code:          JSONRPCErrorInternal - 24,
+		Message:       "websocket connections are not supported for tenants",
+		HTTPErrorCode: 403,
+	}
+
 	ErrBackendUnexpectedJSONRPC = errors.New("backend returned an unexpected JSON-RPC response")
 
 	ErrConsensusGetReceiptsCantBeBatched = errors.New("consensus_getReceipts cannot be batched")
@@ -133,6 +146,14 @@ func ErrInvalidParams(msg string) *RPCErr {
 	}
 }
 
+func ErrTransactionRejected(reason string) *RPCErr {
+	return &RPCErr{
+		Code:          JSONRPCErrorInternal - 23,
+		Message:       "transaction rejected: " + reason,
+		HTTPErrorCode: 400,
+	}
+}
+
 type Backend struct {
 	Name                 string
 	rpcURL               string
@@ -162,7 +183,8 @@ type Backend struct {
 	networkRequestsSlidingWindow *sw.AvgSlidingWindow
 	networkErrorsSlidingWindow   *sw.AvgSlidingWindow
 
-	weight int
+	// weight is updated by config reloads
+	weight atomic.Int64
 }
 
 type BackendOpt func(b *Backend)
@@ -251,7 +273,7 @@ func WithConsensusForcedCandidate(forcedCandidate bool) BackendOpt {
 
 func WithWeight(weight int) BackendOpt {
 	return func(b *Backend) {
-		b.weight = weight
+		b.weight.Store(int64(weight))
 	}
 }
 
@@ -707,6 +729,38 @@ type BackendGroup struct {
 	Backends        []*Backend
 	WeightedRouting bool
 	Consensus       *ConsensusPoller
+
+	// mtx guards the backends, weighted routing and consensus of the group, which are replaced
+	// by config reloads
+	mtx sync.RWMutex
+}
+
+// state returns the backends, weighted routing and consensus poller of the group
+func (bg *BackendGroup) state() ([]*Backend, bool, *ConsensusPoller) {
+	bg.mtx.RLock()
+	defer bg.mtx.RUnlock()
+	return bg.Backends, bg.WeightedRouting, bg.Consensus
+}
+
+func (bg *BackendGroup) backends() []*Backend {
+	backends, _, _ := bg.state()
+	return backends
+}
+
+func (bg *BackendGroup) consensus() *ConsensusPoller {
+	_, _, consensus := bg.state()
+	return consensus
+}
+
+// update replaces the state of the group, returning the replaced consensus poller
+func (bg *BackendGroup) update(backends []*Backend, weightedRouting bool, consensus *ConsensusPoller) *ConsensusPoller {
+	bg.mtx.Lock()
+	defer bg.mtx.Unlock()
+	prev := bg.Consensus
+	bg.Backends = backends
+	bg.WeightedRouting = weightedRouting
+	bg.Consensus = consensus
+	return prev
 }
 
 func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch bool) ([]*RPCRes, string, error) {
@@ -714,21 +768,22 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 		return nil, "", nil
 	}
 
-	backends := bg.orderedBackendsForRequest()
+	backends, weightedRouting, consensus := bg.state()
+	backends = orderedBackendsForRequest(backends, weightedRouting, consensus)
 
 	overriddenResponses := make([]*indexedReqRes, 0)
 	rewrittenReqs := make([]*RPCReq, 0, len(rpcReqs))
 
-	if bg.Consensus != nil {
+	if consensus != nil {
 		// When `consensus_aware` is set to `true`, the backend group acts as a load balancer
 		// serving traffic from any backend that agrees in the consensus group
 
 		// We also rewrite block tags to enforce compliance with consensus
 		rctx := RewriteContext{
-			latest:        bg.Consensus.GetLatestBlockNumber(),
-			safe:          bg.Consensus.GetSafeBlockNumber(),
-			finalized:     bg.Consensus.GetFinalizedBlockNumber(),
-			maxBlockRange: bg.Consensus.maxBlockRange,
+			latest:        consensus.GetLatestBlockNumber(),
+			safe:          consensus.GetSafeBlockNumber(),
+			finalized:     consensus.GetFinalizedBlockNumber(),
+			maxBlockRange: consensus.maxBlockRange,
 		}
 
 		for i, req := range rpcReqs {
@@ -829,7 +884,7 @@ func (bg *BackendGroup) Forward(ctx context.Context, rpcReqs []*RPCReq, isBatch
 }
 
 func (bg *BackendGroup) ProxyWS(ctx context.Context, clientConn *websocket.Conn, methodWhitelist *StringSet) (*WSProxier, error) {
-	for _, back := range bg.Backends {
+	for _, back := range bg.backends() {
 		proxier, err := back.ProxyWS(clientConn, methodWhitelist)
 		if errors.Is(err, ErrBackendOffline) {
 			log.Warn(
@@ -867,27 +922,27 @@ func (bg *BackendGroup) ProxyWS(ctx context.Context, clientConn *websocket.Conn,
 
 func weightedShuffle(backends []*Backend) {
 	weight := func(i int) float64 {
-		return float64(backends[i].weight)
+		return float64(backends[i].weight.Load())
 	}
 
 	weightedshuffle.ShuffleInplace(backends, weight, nil)
 }
 
-func (bg *BackendGroup) orderedBackendsForRequest() []*Backend {
-	if bg.Consensus != nil {
-		return bg.loadBalancedConsensusGroup()
-	} else if bg.WeightedRouting {
-		result := make([]*Backend, len(bg.Backends))
-		copy(result, bg.Backends)
+func orderedBackendsForRequest(backends []*Backend, weightedRouting bool, consensus *ConsensusPoller) []*Backend {
+	if consensus != nil {
+		return loadBalancedConsensusGroup(consensus, weightedRouting)
+	} else if weightedRouting {
+		result := make([]*Backend, len(backends))
+		copy(result, backends)
 		weightedShuffle(result)
 		return result
 	} else {
-		return bg.Backends
+		return backends
 	}
 }
 
-func (bg *BackendGroup) loadBalancedConsensusGroup() []*Backend {
-	cg := bg.Consensus.GetConsensusGroup()
+func loadBalancedConsensusGroup(consensus *ConsensusPoller, weightedRouting bool) []*Backend {
+	cg := consensus.GetConsensusGroup()
 
 	backendsHealthy := make([]*Backend, 0, len(cg))
 	backendsDegraded := make([]*Backend, 0, len(cg))
@@ -913,7 +968,7 @@ func (bg *BackendGroup) loadBalancedConsensusGroup() []*Backend {
 		backendsDegraded[i], backendsDegraded[j] = backendsDegraded[j], backendsDegraded[i]
 	})
 
-	if bg.WeightedRouting {
+	if weightedRouting {
 		weightedShuffle(backendsHealthy)
 	}
 
@@ -925,8 +980,8 @@ func (bg *BackendGroup) loadBalancedConsensusGroup() []*Backend {
 }
 
 func (bg *BackendGroup) Shutdown() {
-	if bg.Consensus != nil {
-		bg.Consensus.Shutdown()
+	if consensus := bg.consensus(); consensus != nil {
+		consensus.Shutdown()
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/redis/go-redis/v9"
 
@@ -16,6 +17,8 @@ import (
 type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Put(ctx context.Context, key string, value string) error
+	// PutWithTTL puts a value that expires after the ttl
+	PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error
 }
 
 const (
@@ -29,6 +32,12 @@ type cache struct {
 	lru *lru.Cache
 }
 
+type cacheEntry struct {
+	value string
+	// expiresAt is zero if the entry does not expire
+	expiresAt time.Time
+}
+
 func newMemoryCache() *cache {
 	rep, _ := lru.New(memoryCacheLimit)
 	return &cache{rep}
@@ -36,13 +45,23 @@ func newMemoryCache() *cache {
 
 func (c *cache) Get(ctx context.Context, key string) (string, error) {
 	if val, ok := c.lru.Get(key); ok {
-		return val.(string), nil
+		entry := val.(cacheEntry)
+		if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+			c.lru.Remove(key)
+			return "", nil
+		}
+		return entry.value, nil
 	}
 	return "", nil
 }
 
 func (c *cache) Put(ctx context.Context, key string, value string) error {
-	c.lru.Add(key, value)
+	c.lru.Add(key, cacheEntry{value: value})
+	return nil
+}
+
+func (c *cache) PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	c.lru.Add(key, cacheEntry{value: value, expiresAt: time.Now().Add(ttl)})
 	return nil
 }
 
@@ -77,8 +96,12 @@ func (c *redisCache) Get(ctx context.Context, key string) (string, error) {
 }
 
 func (c *redisCache) Put(ctx context.Context, key string, value string) error {
+	return c.PutWithTTL(ctx, key, value, redisTTL)
+}
+
+func (c *redisCache) PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
 	start := time.Now()
-	err := c.rdb.SetEx(ctx, c.namespaced(key), value, redisTTL).Err()
+	err := c.rdb.SetEx(ctx, c.namespaced(key), value, ttl).Err()
 	redisCacheDurationSumm.WithLabelValues("SETEX").Observe(float64(time.Since(start).Milliseconds()))
 
 	if err != nil {
@@ -115,17 +138,80 @@ func (c *cacheWithCompression) Put(ctx context.Context, key string, value string
 	return c.cache.Put(ctx, key, string(encodedVal))
 }
 
+func (c *cacheWithCompression) PutWithTTL(ctx context.Context, key string, value string, ttl time.Duration) error {
+	encodedVal := snappy.Encode(nil, []byte(value))
+	return c.cache.PutWithTTL(ctx, key, string(encodedVal), ttl)
+}
+
+// RPCCache caches the responses of requests forwarded to the backend group
 type RPCCache interface {
-	GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error)
-	PutRPC(ctx context.Context, req *RPCReq, res *RPCRes) error
+	GetRPC(ctx context.Context, group *BackendGroup, req *RPCReq) (*RPCRes, error)
+	PutRPC(ctx context.Context, group *BackendGroup, req *RPCReq, res *RPCRes) error
 }
 
 type rpcCache struct {
 	cache    Cache
 	handlers map[string]RPCMethodHandler
+
+	// finalityHandlers cache number-addressed requests by the finality of the backend group serving them
+	finalityHandlers map[string]*FinalityMethodHandler
+}
+
+type RPCCacheOpt func(c *rpcCache)
+
+// backendGroupFinality tracks the finality of a backend group. Groups that are not consensus aware,
+// or whose consensus poller has yet to be created, have no known finality
+type backendGroupFinality struct {
+	bg *BackendGroup
+}
+
+func (f *backendGroupFinality) GetSafeBlockNumber() hexutil.Uint64 {
+	consensus := f.bg.consensus()
+	if consensus == nil {
+		return 0
+	}
+	return consensus.GetSafeBlockNumber()
+}
+
+func (f *backendGroupFinality) GetFinalizedBlockNumber() hexutil.Uint64 {
+	consensus := f.bg.consensus()
+	if consensus == nil {
+		return 0
+	}
+	return consensus.GetFinalizedBlockNumber()
 }
 
-func newRPCCache(cache Cache) RPCCache {
+// WithFinalityCache caches the number-addressed requests of blocks at or below the finalized block agreed
+// by the consensus aware backend group serving them, and optionally of safe blocks when the safe ttl is
+// greater than zero
+func WithFinalityCache(finalizedTTL time.Duration, safeTTL time.Duration) RPCCacheOpt {
+	return func(c *rpcCache) {
+		policy := &finalityPolicy{finalizedTTL: finalizedTTL, safeTTL: safeTTL}
+		blockHandler := &FinalityMethodHandler{cache: c.cache, policy: policy, requestBlock: blockNumberParam(0)}
+		stateHandler := &FinalityMethodHandler{cache: c.cache, policy: policy, requestBlock: blockNumberParam(1)}
+		storageHandler := &FinalityMethodHandler{cache: c.cache, policy: policy, requestBlock: blockNumberParam(2)}
+		logsHandler := &FinalityMethodHandler{cache: c.cache, policy: policy, requestBlock: blockRangeParam}
+		receiptHandler := &FinalityMethodHandler{cache: c.cache, policy: policy, resultBlock: receiptBlockNumber}
+
+		c.finalityHandlers = map[string]*FinalityMethodHandler{
+			"eth_getBlockByNumber":                    blockHandler,
+			"eth_getBlockTransactionCountByNumber":    blockHandler,
+			"eth_getUncleCountByBlockNumber":          blockHandler,
+			"eth_getTransactionByBlockNumberAndIndex": blockHandler,
+			"eth_getUncleByBlockNumberAndIndex":       blockHandler,
+			"eth_getBalance":                          stateHandler,
+			"eth_getCode":                             stateHandler,
+			"eth_getTransactionCount":                 stateHandler,
+			"eth_call":                                stateHandler,
+			"eth_getStorageAt":                        storageHandler,
+			"eth_getProof":                            storageHandler,
+			"eth_getLogs":                             logsHandler,
+			"eth_getTransactionReceipt":               receiptHandler,
+		}
+	}
+}
+
+func newRPCCache(cache Cache, opts ...RPCCacheOpt) RPCCache {
 	staticHandler := &StaticMethodHandler{cache: cache}
 	debugGetRawReceiptsHandler := &StaticMethodHandler{cache: cache,
 		filterGet: func(req *RPCReq) bool {
@@ -160,14 +246,29 @@ func newRPCCache(cache Cache) RPCCache {
 		"eth_getUncleByBlockHashAndIndex":       staticHandler,
 		"debug_getRawReceipts":                  debugGetRawReceiptsHandler,
 	}
-	return &rpcCache{
+	c := &rpcCache{
 		cache:    cache,
 		handlers: handlers,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// handler returns the handler of the request forwarded to the group, if cached
+func (c *rpcCache) handler(group *BackendGroup, req *RPCReq) RPCMethodHandler {
+	if handler := c.handlers[req.Method]; handler != nil {
+		return handler
+	}
+	if handler := c.finalityHandlers[req.Method]; handler != nil && group != nil {
+		return &groupFinalityHandler{handler: handler, group: group.Name, tracker: &backendGroupFinality{group}}
+	}
+	return nil
 }
 
-func (c *rpcCache) GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error) {
-	handler := c.handlers[req.Method]
+func (c *rpcCache) GetRPC(ctx context.Context, group *BackendGroup, req *RPCReq) (*RPCRes, error) {
+	handler := c.handler(group, req)
 	if handler == nil {
 		return nil, nil
 	}
@@ -184,8 +285,8 @@ func (c *rpcCache) GetRPC(ctx context.Context, req *RPCReq) (*RPCRes, error) {
 	return res, nil
 }
 
-func (c *rpcCache) PutRPC(ctx context.Context, req *RPCReq, res *RPCRes) error {
-	handler := c.handlers[req.Method]
+func (c *rpcCache) PutRPC(ctx context.Context, group *BackendGroup, req *RPCReq, res *RPCRes) error {
+	handler := c.handler(group, req)
 	if handler == nil {
 		return nil
 	}
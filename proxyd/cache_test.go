@@ -4,7 +4,9 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -119,10 +121,10 @@ func TestRPCCacheImmutableRPCs(t *testing.T) {
 
 	for _, rpc := range rpcs {
 		t.Run(rpc.name, func(t *testing.T) {
-			err := cache.PutRPC(ctx, rpc.req, rpc.res)
+			err := cache.PutRPC(ctx, nil, rpc.req, rpc.res)
 			require.NoError(t, err)
 
-			cachedRes, err := cache.GetRPC(ctx, rpc.req)
+			cachedRes, err := cache.GetRPC(ctx, nil, rpc.req)
 			require.NoError(t, err)
 			require.Equal(t, rpc.res, cachedRes)
 		})
@@ -201,13 +203,239 @@ func TestRPCCacheUnsupportedMethod(t *testing.T) {
 	for _, rpc := range rpcs {
 		t.Run(rpc.name, func(t *testing.T) {
 			fakeval := mustMarshalJSON([]string{rpc.name})
-			err := cache.PutRPC(ctx, rpc.req, &RPCRes{Result: fakeval})
+			err := cache.PutRPC(ctx, nil, rpc.req, &RPCRes{Result: fakeval})
 			require.NoError(t, err)
 
-			cachedRes, err := cache.GetRPC(ctx, rpc.req)
+			cachedRes, err := cache.GetRPC(ctx, nil, rpc.req)
 			require.NoError(t, err)
 			require.Nil(t, cachedRes)
 		})
 	}
 
 }
+
+// newFinalityBackendGroup returns a consensus aware backend group that agreed on the safe & finalized blocks
+func newFinalityBackendGroup(name string, safe hexutil.Uint64, finalized hexutil.Uint64) (*BackendGroup, ConsensusTracker) {
+	tracker := NewInMemoryConsensusTracker()
+	tracker.SetSafeBlockNumber(safe)
+	tracker.SetFinalizedBlockNumber(finalized)
+	return &BackendGroup{Name: name, Consensus: &ConsensusPoller{tracker: tracker}}, tracker
+}
+
+func TestRPCCacheFinalizedRPCs(t *testing.T) {
+	ctx := context.Background()
+
+	group, _ := newFinalityBackendGroup("main", 0x20, 0x10)
+	cache := newRPCCache(newMemoryCache(), WithFinalityCache(time.Hour, 0))
+	ID := []byte(strconv.Itoa(1))
+
+	rpcs := []struct {
+		name string
+		// putReq is the request as forwarded to the backend, with the finalized tag addressed by number
+		putReq *RPCReq
+		getReq *RPCReq
+		res    *RPCRes
+		cached bool
+	}{
+		{
+			name:   "eth_getBlockByNumber finalized",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x10", false}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"finalized", false}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x10"}, ID: ID},
+			cached: true,
+		},
+		{
+			name:   "eth_getBlockByNumber historical",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x1", true}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x1", true}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x1"}, ID: ID},
+			cached: true,
+		},
+		{
+			name:   "eth_getBlockByNumber unfinalized",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x11", false}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x11", false}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x11"}, ID: ID},
+		},
+		{
+			name:   "eth_getBlockTransactionCountByNumber unresolved finalized tag",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockTransactionCountByNumber", Params: mustMarshalJSON([]interface{}{"finalized"}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockTransactionCountByNumber", Params: mustMarshalJSON([]interface{}{"0x10"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: "0x2", ID: ID},
+		},
+		{
+			name:   "eth_getBlockByNumber latest",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"latest", false}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"latest", false}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x30"}, ID: ID},
+		},
+		{
+			name:   "eth_call finalized",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_call", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"to": "0x4200000000000000000000000000000000000016"}, "0x10"}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_call", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"to": "0x4200000000000000000000000000000000000016"}, "finalized"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: "0x01", ID: ID},
+			cached: true,
+		},
+		{
+			name:   "eth_getBalance eip-1898 block number",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBalance", Params: mustMarshalJSON([]interface{}{"0x4200000000000000000000000000000000000016", map[string]interface{}{"blockNumber": "0x5"}}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBalance", Params: mustMarshalJSON([]interface{}{"0x4200000000000000000000000000000000000016", "0x5"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: "0x100", ID: ID},
+			cached: true,
+		},
+		{
+			name:   "eth_getBalance missing block defaults to latest",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBalance", Params: mustMarshalJSON([]interface{}{"0x4200000000000000000000000000000000000016"}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getBalance", Params: mustMarshalJSON([]interface{}{"0x4200000000000000000000000000000000000016"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: "0x100", ID: ID},
+		},
+		{
+			name:   "eth_getLogs finalized range",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x10"}}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "finalized"}}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: []interface{}{}, ID: ID},
+			cached: true,
+		},
+		{
+			name:   "eth_getLogs unfinalized range",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x11"}}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x11"}}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: []interface{}{}, ID: ID},
+		},
+		{
+			name:   "eth_getLogs open range",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"fromBlock": "0x1"}}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getLogs", Params: mustMarshalJSON([]interface{}{map[string]interface{}{"fromBlock": "0x1"}}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: []interface{}{}, ID: ID},
+		},
+		{
+			name:   "eth_getTransactionReceipt finalized",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"blockNumber": "0xf"}, ID: ID},
+			cached: true,
+		},
+		{
+			name:   "eth_getTransactionReceipt unfinalized",
+			putReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0xb903239f8543d04b5dc1ba6579132b143087c68db1b2168786408fcbce568238"}), ID: ID},
+			getReq: &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0xb903239f8543d04b5dc1ba6579132b143087c68db1b2168786408fcbce568238"}), ID: ID},
+			res:    &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"blockNumber": "0x11"}, ID: ID},
+		},
+	}
+
+	for _, rpc := range rpcs {
+		t.Run(rpc.name, func(t *testing.T) {
+			err := cache.PutRPC(ctx, group, rpc.putReq, rpc.res)
+			require.NoError(t, err)
+
+			cachedRes, err := cache.GetRPC(ctx, group, rpc.getReq)
+			require.NoError(t, err)
+			if rpc.cached {
+				require.Equal(t, rpc.res, cachedRes)
+			} else {
+				require.Nil(t, cachedRes)
+			}
+		})
+	}
+}
+
+func TestRPCCacheFinalityRegression(t *testing.T) {
+	ctx := context.Background()
+
+	group, tracker := newFinalityBackendGroup("main", 0x20, 0x10)
+	shared := newMemoryCache()
+	cache := newRPCCache(shared, WithFinalityCache(time.Hour, time.Hour))
+	ID := []byte(strconv.Itoa(1))
+
+	finalizedReq := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x10", false}), ID: ID}
+	safeReq := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x20", false}), ID: ID}
+	receiptReq := &RPCReq{JSONRPC: "2.0", Method: "eth_getTransactionReceipt", Params: mustMarshalJSON([]string{"0xc6ef2fc5426d6ad6fd9e2a26abeab0aa2411b7ab17f30a99d3cb96aed1d1055b"}), ID: ID}
+	res := &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"hash": "0x1", "blockNumber": "0x10"}, ID: ID}
+
+	for _, req := range []*RPCReq{finalizedReq, safeReq, receiptReq} {
+		require.NoError(t, cache.PutRPC(ctx, group, req, res))
+		cachedRes, err := cache.GetRPC(ctx, group, req)
+		require.NoError(t, err)
+		require.Equal(t, res, cachedRes)
+	}
+
+	requireCached := func(cache RPCCache, req *RPCReq, cached bool) {
+		cachedRes, err := cache.GetRPC(ctx, group, req)
+		require.NoError(t, err)
+		if cached {
+			require.Equal(t, res, cachedRes)
+		} else {
+			require.Nil(t, cachedRes)
+		}
+	}
+
+	// unknown block numbers, while the consensus is being established, serve no entries
+	tracker.SetSafeBlockNumber(0)
+	tracker.SetFinalizedBlockNumber(0)
+	requireCached(cache, finalizedReq, false)
+	requireCached(cache, receiptReq, false)
+
+	tracker.SetSafeBlockNumber(0x20)
+	tracker.SetFinalizedBlockNumber(0x10)
+	requireCached(cache, finalizedReq, true)
+
+	// entries of blocks above a regressed safe or finalized block are no longer served, by any instance
+	// sharing the cache
+	other := newRPCCache(shared, WithFinalityCache(time.Hour, time.Hour))
+	tracker.SetSafeBlockNumber(0x1f)
+	for _, c := range []RPCCache{cache, other} {
+		requireCached(c, safeReq, false)
+		requireCached(c, finalizedReq, true)
+	}
+
+	tracker.SetFinalizedBlockNumber(0xf)
+	tracker.SetSafeBlockNumber(0xf)
+	for _, c := range []RPCCache{cache, other} {
+		requireCached(c, finalizedReq, false)
+		requireCached(c, receiptReq, false)
+	}
+}
+
+func TestRPCCacheFinalityPerBackendGroup(t *testing.T) {
+	ctx := context.Background()
+
+	main, _ := newFinalityBackendGroup("main", 0x10, 0x10)
+	lagging, _ := newFinalityBackendGroup("lagging", 0x5, 0x5)
+	unaware := &BackendGroup{Name: "unaware"}
+	cache := newRPCCache(newMemoryCache(), WithFinalityCache(time.Hour, 0))
+	ID := []byte(strconv.Itoa(1))
+
+	req := &RPCReq{JSONRPC: "2.0", Method: "eth_getBlockByNumber", Params: mustMarshalJSON([]interface{}{"0x10", false}), ID: ID}
+	res := &RPCRes{JSONRPC: "2.0", Result: map[string]interface{}{"number": "0x10"}, ID: ID}
+
+	for _, group := range []*BackendGroup{main, lagging, unaware, nil} {
+		require.NoError(t, cache.PutRPC(ctx, group, req, res))
+	}
+
+	// only the group whose agreed finalized block includes the block serves it
+	cachedRes, err := cache.GetRPC(ctx, main, req)
+	require.NoError(t, err)
+	require.Equal(t, res, cachedRes)
+
+	for _, group := range []*BackendGroup{lagging, unaware, nil} {
+		cachedRes, err := cache.GetRPC(ctx, group, req)
+		require.NoError(t, err)
+		require.Nil(t, cachedRes)
+	}
+}
+
+func TestMemoryCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	cache := newMemoryCache()
+
+	require.NoError(t, cache.PutWithTTL(ctx, "expired", "value", -time.Second))
+	require.NoError(t, cache.PutWithTTL(ctx, "live", "value", time.Hour))
+
+	val, err := cache.Get(ctx, "expired")
+	require.NoError(t, err)
+	require.Empty(t, val)
+
+	val, err = cache.Get(ctx, "live")
+	require.NoError(t, err)
+	require.Equal(t, "value", val)
+}
@@ -0,0 +1,269 @@
+package proxyd
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	captureFileName     = "proxyd-capture.jsonl"
+	captureFilePattern  = "proxyd-capture-*.jsonl"
+	captureQueueSize    = 1024
+	defaultCaptureFiles = 10
+
+	defaultCaptureFileSize = 100 * 1024 * 1024
+)
+
+// CaptureRecord is a captured request, along with the response served by proxyd
+type CaptureRecord struct {
+	Time     time.Time       `json:"time"`
+	ReqID    string          `json:"req_id"`
+	Auth     string          `json:"auth,omitempty"`
+	RemoteIP string          `json:"remote_ip,omitempty"`
+	ServedBy string          `json:"served_by,omitempty"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// Capturer samples requests & responses into a local file, rotated once over the maximum size. Records are
+// written in the background and dropped when the writer can't keep up
+type Capturer struct {
+	sampleRate  float64
+	dir         string
+	maxFileSize int64
+	maxFiles    int
+	redactAuth  bool
+	redactIP    bool
+	// redactKey keys the digests of the redacted values. Redacted values are dropped without a key
+	redactKey []byte
+
+	records chan *CaptureRecord
+	file    *os.File
+	writer  *bufio.Writer
+	size    int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewCapturer(cfg CaptureConfig) (*Capturer, error) {
+	if cfg.SampleRate <= 0 || cfg.SampleRate > 1 {
+		return nil, fmt.Errorf("capture sample rate must be in (0, 1]")
+	}
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("capture dir must be set")
+	}
+	redactSecret, err := ReadFromEnvOrConfig(cfg.RedactSecret)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating capture dir: %w", err)
+	}
+
+	c := &Capturer{
+		sampleRate:  cfg.SampleRate,
+		dir:         cfg.Dir,
+		maxFileSize: cfg.MaxFileSizeBytes,
+		maxFiles:    cfg.MaxFiles,
+		redactAuth:  cfg.RedactAuth,
+		redactIP:    cfg.RedactIP,
+		redactKey:   []byte(redactSecret),
+		records:     make(chan *CaptureRecord, captureQueueSize),
+		done:        make(chan struct{}),
+	}
+	if c.maxFileSize == 0 {
+		c.maxFileSize = defaultCaptureFileSize
+	}
+	if c.maxFiles == 0 {
+		c.maxFiles = defaultCaptureFiles
+	}
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+
+	c.wg.Add(1)
+	go c.writeLoop()
+	return c, nil
+}
+
+// Sample returns true if the request should be captured
+func (c *Capturer) Sample() bool {
+	return rand.Float64() < c.sampleRate
+}
+
+// Capture queues the request & its response to be written, redacting the client's identity if configured
+func (c *Capturer) Capture(ctx context.Context, remoteIP string, servedBy string, body []byte, res interface{}) {
+	request := json.RawMessage(body)
+	if !json.Valid(body) {
+		request = mustMarshalJSON(string(body))
+	}
+	response, err := json.Marshal(res)
+	if err != nil {
+		log.Warn("error marshaling captured response", "req_id", GetReqID(ctx), "err", err)
+		return
+	}
+
+	record := &CaptureRecord{
+		Time:     time.Now(),
+		ReqID:    GetReqID(ctx),
+		Auth:     GetAuthCtx(ctx),
+		RemoteIP: remoteIP,
+		ServedBy: servedBy,
+		Request:  request,
+		Response: response,
+	}
+	if c.redactAuth {
+		record.Auth = c.redact(record.Auth)
+	}
+	if c.redactIP {
+		record.RemoteIP = c.redact(record.RemoteIP)
+	}
+
+	select {
+	case c.records <- record:
+		RecordCapture("captured")
+	default:
+		RecordCapture("dropped")
+	}
+}
+
+func (c *Capturer) Shutdown() {
+	close(c.done)
+	c.wg.Wait()
+}
+
+func (c *Capturer) writeLoop() {
+	defer c.wg.Done()
+	flush := time.NewTicker(time.Second)
+	defer flush.Stop()
+	for {
+		select {
+		case record := <-c.records:
+			if err := c.write(record); err != nil {
+				log.Error("error writing capture record", "err", err)
+				RecordCapture("error")
+			}
+		case <-flush.C:
+			if err := c.writer.Flush(); err != nil {
+				log.Error("error flushing capture file", "err", err)
+			}
+		case <-c.done:
+			for len(c.records) > 0 {
+				if err := c.write(<-c.records); err != nil {
+					log.Error("error writing capture record", "err", err)
+				}
+			}
+			if err := c.writer.Flush(); err != nil {
+				log.Error("error flushing capture file", "err", err)
+			}
+			c.file.Close()
+			return
+		}
+	}
+}
+
+func (c *Capturer) write(record *CaptureRecord) error {
+	line := append(mustMarshalJSON(record), '\n')
+	if c.size > 0 && c.size+int64(len(line)) > c.maxFileSize {
+		if err := c.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := c.writer.Write(line)
+	c.size += int64(n)
+	return err
+}
+
+func (c *Capturer) open() error {
+	path := filepath.Join(c.dir, captureFileName)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening capture file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	c.file = f
+	c.writer = bufio.NewWriter(f)
+	c.size = info.Size()
+	return nil
+}
+
+// rotate renames the current file after the time of rotation, removing the oldest rotated files above maxFiles
+func (c *Capturer) rotate() error {
+	if err := c.writer.Flush(); err != nil {
+		return err
+	}
+	if err := c.file.Close(); err != nil {
+		return err
+	}
+	rotated := filepath.Join(c.dir, fmt.Sprintf("proxyd-capture-%d.jsonl", time.Now().UnixNano()))
+	if err := os.Rename(filepath.Join(c.dir, captureFileName), rotated); err != nil {
+		return err
+	}
+
+	files, err := filepath.Glob(filepath.Join(c.dir, captureFilePattern))
+	if err != nil {
+		return err
+	}
+	// rotated files sort by the time of rotation
+	sort.Strings(files)
+	for len(files) > c.maxFiles {
+		if err := os.Remove(files[0]); err != nil {
+			log.Warn("error removing rotated capture file", "file", files[0], "err", err)
+		}
+		files = files[1:]
+	}
+	return c.open()
+}
+
+// redact replaces the value with a keyed digest, such that records of the same client can still be correlated
+// without the digests of low entropy values, such as IPs, being reversible. The value is dropped without a key
+func (c *Capturer) redact(value string) string {
+	if len(c.redactKey) == 0 {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.redactKey)
+	mac.Write([]byte(value))
+	return "redacted:" + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// ReadCaptureFile reads the records of a capture file
+func ReadCaptureFile(path string) ([]*CaptureRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []*CaptureRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := new(CaptureRecord)
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, fmt.Errorf("error decoding capture record: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
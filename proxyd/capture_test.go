@@ -0,0 +1,75 @@
+package proxyd
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturer(t *testing.T) {
+	dir := t.TempDir()
+	capturer, err := NewCapturer(CaptureConfig{
+		SampleRate:       1,
+		Dir:              dir,
+		MaxFileSizeBytes: 400,
+		MaxFiles:         2,
+		RedactAuth:       true,
+		RedactIP:         true,
+		RedactSecret:     "secret",
+	})
+	require.NoError(t, err)
+	require.True(t, capturer.Sample())
+
+	ctx := context.WithValue(context.Background(), ContextKeyAuth, "alias") // nolint:staticcheck
+	ctx = context.WithValue(ctx, ContextKeyReqID, "req")                    // nolint:staticcheck
+	req := []byte(`{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}`)
+	for i := 0; i < 10; i++ {
+		capturer.Capture(ctx, "1.2.3.4", "main/node1", req, NewRPCRes([]byte("1"), "0x1"))
+	}
+	// malformed requests are captured as strings
+	capturer.Capture(ctx, "1.2.3.4", "", []byte("barf"), NewRPCErrorRes(nil, ErrParseErr))
+	capturer.Shutdown()
+
+	rotated, err := filepath.Glob(filepath.Join(dir, captureFilePattern))
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+
+	records, err := ReadCaptureFile(filepath.Join(dir, captureFileName))
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	last := records[len(records)-1]
+	require.Equal(t, `"barf"`, string(last.Request))
+	require.JSONEq(t, `{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error"},"id":null}`, string(last.Response))
+
+	records, err = ReadCaptureFile(rotated[1])
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+	record := records[0]
+	require.Equal(t, "req", record.ReqID)
+	require.Equal(t, "main/node1", record.ServedBy)
+	require.JSONEq(t, string(req), string(record.Request))
+	require.JSONEq(t, `{"jsonrpc":"2.0","result":"0x1","id":1}`, string(record.Response))
+	require.Equal(t, capturer.redact("alias"), record.Auth)
+	require.Equal(t, capturer.redact("1.2.3.4"), record.RemoteIP)
+	require.NotContains(t, record.RemoteIP, "1.2.3.4")
+}
+
+func TestCapturerRedaction(t *testing.T) {
+	newCapturer := func(secret string) *Capturer {
+		capturer, err := NewCapturer(CaptureConfig{SampleRate: 1, Dir: t.TempDir(), RedactAuth: true, RedactIP: true, RedactSecret: secret})
+		require.NoError(t, err)
+		t.Cleanup(capturer.Shutdown)
+		return capturer
+	}
+
+	// digests are keyed, so can't be reversed by hashing the candidate values
+	capturer, other := newCapturer("secret"), newCapturer("other")
+	require.Equal(t, capturer.redact("1.2.3.4"), capturer.redact("1.2.3.4"))
+	require.NotEqual(t, capturer.redact("1.2.3.4"), capturer.redact("1.2.3.5"))
+	require.NotEqual(t, capturer.redact("1.2.3.4"), other.redact("1.2.3.4"))
+
+	// without a secret, the redacted values are dropped
+	require.Empty(t, newCapturer("").redact("1.2.3.4"))
+}
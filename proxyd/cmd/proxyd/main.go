@@ -67,11 +67,28 @@ func main() {
 		}()
 	}
 
-	_, shutdown, err := proxyd.Start(config)
+	srv, shutdown, err := proxyd.Start(config)
 	if err != nil {
 		log.Crit("error starting proxyd", "err", err)
 	}
 
+	// SIGHUP reloads the config file
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			log.Info("caught SIGHUP, reloading config", "path", os.Args[1])
+			config := new(proxyd.Config)
+			if _, err := toml.DecodeFile(os.Args[1], config); err != nil {
+				log.Error("error reading config file", "err", err)
+				continue
+			}
+			if err := srv.Reload(config); err != nil {
+				log.Error("error reloading config", "err", err)
+			}
+		}
+	}()
+
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	recvSig := <-sig
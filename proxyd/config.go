@@ -26,10 +26,20 @@ type ServerConfig struct {
 	MaxRequestBodyLogLen  int  `toml:"max_request_body_log_len"`
 	EnablePprof           bool `toml:"enable_pprof"`
 	EnableXServedByHeader bool `toml:"enable_served_by_header"`
+
+	// AdminToken enables the POST /admin/reload endpoint of the RPC server, authenticated by the bearer token
+	AdminToken string `toml:"admin_token"`
 }
 
 type CacheConfig struct {
 	Enabled bool `toml:"enabled"`
+
+	// Finality caches number-addressed requests forwarded to consensus aware backend groups, by the safe &
+	// finalized block numbers agreed by each group. Only hash-addressed requests are cached if unset
+	Finality     bool         `toml:"finality"`
+	FinalizedTTL TOMLDuration `toml:"finalized_ttl"`
+	// SafeTTL enables caching requests addressing safe, but unfinalized, blocks. Disabled if 0
+	SafeTTL TOMLDuration `toml:"safe_ttl"`
 }
 
 type RedisConfig struct {
@@ -142,6 +152,74 @@ type SenderRateLimitConfig struct {
 	AllowedChainIds []*big.Int `toml:"allowed_chain_ids"`
 }
 
+// CaptureConfig configures the sampling of requests & responses to local files, for replay
+type CaptureConfig struct {
+	Enabled bool `toml:"enabled"`
+	// SampleRate is the fraction of the requests captured
+	SampleRate       float64 `toml:"sample_rate"`
+	Dir              string  `toml:"dir"`
+	MaxFileSizeBytes int64   `toml:"max_file_size_bytes"`
+	// MaxFiles is the number of rotated files kept
+	MaxFiles int `toml:"max_files"`
+	// RedactAuth & RedactIP replace the auth alias and remote IP of the clients with a digest keyed by the
+	// RedactSecret, or drop them if no secret is set
+	RedactAuth   bool   `toml:"redact_auth"`
+	RedactIP     bool   `toml:"redact_ip"`
+	RedactSecret string `toml:"redact_secret"`
+}
+
+// TxForwardingConfig configures the validation and routing of eth_sendRawTransaction requests
+type TxForwardingConfig struct {
+	Enabled bool `toml:"enabled"`
+
+	AllowedChainIds  []*big.Int `toml:"allowed_chain_ids"`
+	MaxGasLimit      uint64     `toml:"max_gas_limit"`
+	BlockedAddresses []string   `toml:"blocked_addresses"`
+	// NonceBackendGroup serves the pending nonce of the senders, rejecting transactions more than
+	// MaxNonceGap nonces ahead
+	NonceBackendGroup string `toml:"nonce_backend_group"`
+	MaxNonceGap       uint64 `toml:"max_nonce_gap"`
+
+	// BroadcastBackends each receive the transactions in parallel
+	BroadcastBackends []string `toml:"broadcast_backends"`
+	// PrivateBackends receive the transactions of the tenants opted into private transactions, or every
+	// transaction if PrivateOnly is set, in place of the broadcast backends
+	PrivateBackends []string `toml:"private_backends"`
+	PrivateOnly     bool     `toml:"private_only"`
+}
+
+// WSMultiplexingConfig configures the sharing of the newHeads & logs subscriptions of WS clients
+type WSMultiplexingConfig struct {
+	Enabled bool `toml:"enabled"`
+	// MaxBackfillBlocks bounds the missed heads replayed to the clients after a failover
+	MaxBackfillBlocks uint64 `toml:"max_backfill_blocks"`
+	// ClientQueueSize bounds the messages buffered to each client before dropping it
+	ClientQueueSize int `toml:"client_queue_size"`
+}
+
+// TenantConfig configures the policies of the requests authenticated by a tenant's secret
+type TenantConfig struct {
+	// Secret authenticating the tenant through the request path, i.e /{secret}
+	Secret string `toml:"secret"`
+	// BackendGroup serving the tenant's requests in place of the rpc method mappings
+	BackendGroup string `toml:"backend_group"`
+	// AllowedMethods restricts the methods the tenant can call. All mapped methods if empty
+	AllowedMethods []string `toml:"allowed_methods"`
+
+	MaxRPS int `toml:"max_rps"`
+	// DailyComputeUnits is the quota of compute units, weighted per method, consumed per day
+	DailyComputeUnits int64 `toml:"daily_compute_units"`
+
+	// SenderRateLimit replaces the global sender rate limit for the tenant's eth_sendRawTransaction requests
+	SenderRateLimit         int          `toml:"sender_rate_limit"`
+	SenderRateLimitInterval TOMLDuration `toml:"sender_rate_limit_interval"`
+
+	// PrivateTransactions routes the tenant's transactions to the private backends of the tx forwarding
+	PrivateTransactions bool `toml:"private_transactions"`
+}
+
+type TenantsConfig map[string]*TenantConfig
+
 type Config struct {
 	WSBackendGroup        string                `toml:"ws_backend_group"`
 	Server                ServerConfig          `toml:"server"`
@@ -156,8 +234,14 @@ type Config struct {
 	BackendGroups         BackendGroupsConfig   `toml:"backend_groups"`
 	RPCMethodMappings     map[string]string     `toml:"rpc_method_mappings"`
 	WSMethodWhitelist     []string              `toml:"ws_method_whitelist"`
+	WSMultiplexing        WSMultiplexingConfig  `toml:"ws_multiplexing"`
 	WhitelistErrorMessage string                `toml:"whitelist_error_message"`
 	SenderRateLimit       SenderRateLimitConfig `toml:"sender_rate_limit"`
+	TxForwarding          TxForwardingConfig    `toml:"tx_forwarding"`
+	Capture               CaptureConfig         `toml:"capture"`
+	Tenants               TenantsConfig         `toml:"tenants"`
+	// ComputeUnits weighs each method towards the tenants' daily quotas. Unlisted methods weigh 1
+	ComputeUnits map[string]int `toml:"compute_units"`
 }
 
 func ReadFromEnvOrConfig(value string) (string, error) {
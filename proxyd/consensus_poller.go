@@ -27,13 +27,17 @@ type ConsensusPoller struct {
 	cancelFunc context.CancelFunc
 	listeners  []OnConsensusBroken
 
-	backendGroup      *BackendGroup
+	backendGroup *BackendGroup
+	// backends are the members of the group when the poller was created, as the poller is replaced
+	// when a config reload changes them
+	backends          []*Backend
 	backendState      map[*Backend]*backendState
 	consensusGroupMux sync.Mutex
 	consensusGroup    []*Backend
 
 	tracker      ConsensusTracker
 	asyncHandler ConsensusAsyncHandler
+	prev         *ConsensusPoller
 
 	minPeerCount       uint64
 	banPeriod          time.Duration
@@ -122,7 +126,7 @@ func NewPollerAsyncHandler(ctx context.Context, cp *ConsensusPoller) ConsensusAs
 }
 func (ah *PollerAsyncHandler) Init() {
 	// create the individual backend pollers
-	for _, be := range ah.cp.backendGroup.Backends {
+	for _, be := range ah.cp.backends {
 		go func(be *Backend) {
 			for {
 				timer := time.NewTimer(PollerInterval)
@@ -215,16 +219,29 @@ func WithMinPeerCount(minPeerCount uint64) ConsensusOpt {
 	}
 }
 
+// WithBackends polls the given backends rather than the current members of the group
+func WithBackends(backends []*Backend) ConsensusOpt {
+	return func(cp *ConsensusPoller) {
+		cp.backends = backends
+	}
+}
+
+// WithPreviousState carries over the tracker, listeners and state of the backends of the replaced poller,
+// such that the consensus of the group isn't lost when the poller is replaced
+func WithPreviousState(prev *ConsensusPoller) ConsensusOpt {
+	return func(cp *ConsensusPoller) {
+		cp.prev = prev
+	}
+}
+
 func NewConsensusPoller(bg *BackendGroup, opts ...ConsensusOpt) *ConsensusPoller {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 
-	state := make(map[*Backend]*backendState, len(bg.Backends))
-
 	cp := &ConsensusPoller{
 		ctx:          ctx,
 		cancelFunc:   cancelFunc,
 		backendGroup: bg,
-		backendState: state,
+		backends:     bg.backends(),
 
 		banPeriod:          5 * time.Minute,
 		maxUpdateThreshold: 30 * time.Second,
@@ -236,6 +253,9 @@ func NewConsensusPoller(bg *BackendGroup, opts ...ConsensusOpt) *ConsensusPoller
 		opt(cp)
 	}
 
+	if cp.tracker == nil && cp.prev != nil {
+		cp.tracker = cp.prev.tracker
+	}
 	if cp.tracker == nil {
 		cp.tracker = NewInMemoryConsensusTracker()
 	}
@@ -244,7 +264,12 @@ func NewConsensusPoller(bg *BackendGroup, opts ...ConsensusOpt) *ConsensusPoller
 		cp.asyncHandler = NewPollerAsyncHandler(ctx, cp)
 	}
 
+	cp.backendState = make(map[*Backend]*backendState, len(cp.backends))
 	cp.Reset()
+	if cp.prev != nil {
+		cp.inheritState(cp.prev)
+		cp.prev = nil
+	}
 	cp.asyncHandler.Init()
 
 	return cp
@@ -451,8 +476,8 @@ func (cp *ConsensusPoller) UpdateBackendGroupConsensus(ctx context.Context) {
 	// update consensus group
 	group := make([]*Backend, 0, len(candidates))
 	consensusBackendsNames := make([]string, 0, len(candidates))
-	filteredBackendsNames := make([]string, 0, len(cp.backendGroup.Backends))
-	for _, be := range cp.backendGroup.Backends {
+	filteredBackendsNames := make([]string, 0, len(cp.backends))
+	for _, be := range cp.backends {
 		_, exist := candidates[be]
 		if exist {
 			group = append(group, be)
@@ -472,7 +497,7 @@ func (cp *ConsensusPoller) UpdateBackendGroupConsensus(ctx context.Context) {
 
 	RecordGroupConsensusCount(cp.backendGroup, len(group))
 	RecordGroupConsensusFilteredCount(cp.backendGroup, len(filteredBackendsNames))
-	RecordGroupTotalCount(cp.backendGroup, len(cp.backendGroup.Backends))
+	RecordGroupTotalCount(cp.backendGroup, len(cp.backends))
 
 	log.Debug("group state",
 		"proposedBlock", proposedBlock,
@@ -515,11 +540,33 @@ func (cp *ConsensusPoller) Unban(be *Backend) {
 
 // Reset reset all backend states
 func (cp *ConsensusPoller) Reset() {
-	for _, be := range cp.backendGroup.Backends {
+	for _, be := range cp.backends {
 		cp.backendState[be] = &backendState{}
 	}
 }
 
+// inheritState copies the state of the backends polled by both pollers, along with the consensus group
+// restricted to the backends of this poller
+func (cp *ConsensusPoller) inheritState(prev *ConsensusPoller) {
+	for _, be := range cp.backends {
+		if _, ok := prev.backendState[be]; ok {
+			cp.backendState[be] = prev.getBackendState(be)
+		}
+	}
+
+	group := make([]*Backend, 0)
+	for _, be := range prev.GetConsensusGroup() {
+		if _, ok := cp.backendState[be]; ok {
+			group = append(group, be)
+		}
+	}
+	cp.consensusGroupMux.Lock()
+	cp.consensusGroup = group
+	cp.consensusGroupMux.Unlock()
+
+	cp.listeners = append(append([]OnConsensusBroken{}, prev.listeners...), cp.listeners...)
+}
+
 // fetchBlock is a convenient wrapper to make a request to get a block directly from the backend
 func (cp *ConsensusPoller) fetchBlock(ctx context.Context, be *Backend, block string) (blockNumber hexutil.Uint64, blockHash string, err error) {
 	var rpcRes RPCRes
@@ -630,9 +677,9 @@ func (cp *ConsensusPoller) setBackendState(be *Backend, peerCount uint64, inSync
 //   - updated recently
 //   - not lagging latest block
 func (cp *ConsensusPoller) getConsensusCandidates() map[*Backend]*backendState {
-	candidates := make(map[*Backend]*backendState, len(cp.backendGroup.Backends))
+	candidates := make(map[*Backend]*backendState, len(cp.backends))
 
-	for _, be := range cp.backendGroup.Backends {
+	for _, be := range cp.backends {
 		bs := cp.getBackendState(be)
 		if be.forcedCandidate {
 			candidates[be] = bs
@@ -0,0 +1,173 @@
+package integration_tests
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	ms "github.com/ethereum-optimism/optimism/proxyd/tools/mockserver/handler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	goodBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer goodBackend.Close()
+	spareBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer spareBackend.Close()
+
+	require.NoError(t, os.Setenv("GOOD_BACKEND_RPC_URL", goodBackend.URL()))
+	require.NoError(t, os.Setenv("SPARE_BACKEND_RPC_URL", spareBackend.URL()))
+
+	config := ReadConfig("reload")
+	srv, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	client := NewProxydClient("http://127.0.0.1:8545")
+
+	t.Run("adds backends and method mappings", func(t *testing.T) {
+		res, code, err := client.SendRPC("eth_blockNumber", nil)
+		require.NoError(t, err)
+		require.Equal(t, 403, code)
+		require.Contains(t, string(res), "-32001")
+
+		reloaded := ReadConfig("reload")
+		reloaded.BackendGroups["main"].Backends = []string{"spare"}
+		reloaded.RPCMethodMappings["eth_blockNumber"] = "main"
+		require.NoError(t, srv.Reload(reloaded))
+
+		goodBackend.Reset()
+		spareBackend.Reset()
+		res, code, err = client.SendRPC("eth_blockNumber", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Len(t, goodBackend.Requests(), 0)
+		require.Len(t, spareBackend.Requests(), 1)
+	})
+
+	t.Run("rejects invalid config", func(t *testing.T) {
+		invalid := ReadConfig("reload")
+		invalid.RPCMethodMappings["eth_blockNumber"] = "missing"
+		require.ErrorContains(t, srv.Reload(invalid), "undefined backend group missing")
+
+		// the previous config keeps being served
+		spareBackend.Reset()
+		_, code, err := client.SendRPC("eth_blockNumber", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		require.Len(t, spareBackend.Requests(), 1)
+	})
+
+	t.Run("rejects changes requiring a restart", func(t *testing.T) {
+		restart := ReadConfig("reload")
+		restart.BackendGroups["main"].Backends = []string{"spare"}
+		restart.RPCMethodMappings["eth_blockNumber"] = "main"
+		restart.Server.MaxBodySizeBytes = 1
+		require.ErrorContains(t, srv.Reload(restart), "changes to server require a restart")
+	})
+
+	t.Run("swaps rate limiters", func(t *testing.T) {
+		limited := ReadConfig("reload")
+		limited.BackendGroups["main"].Backends = []string{"spare"}
+		limited.RPCMethodMappings["eth_blockNumber"] = "main"
+		limited.RateLimit.BaseRate = 1
+		limited.RateLimit.BaseInterval = proxyd.TOMLDuration(time.Minute)
+		require.NoError(t, srv.Reload(limited))
+
+		_, code, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		res, code, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 429, code)
+		require.Contains(t, string(res), "-32016")
+	})
+
+	t.Run("admin endpoint", func(t *testing.T) {
+		raw, err := os.ReadFile("testdata/reload.toml")
+		require.NoError(t, err)
+		body := []byte(strings.Replace(string(raw), `eth_chainId = "main"`, "eth_chainId = \"main\"\neth_call = \"main\"", 1))
+
+		unauthorized := NewProxydClient("http://127.0.0.1:8545/admin/reload")
+		_, code, err := unauthorized.SendRequest(body)
+		require.NoError(t, err)
+		require.Equal(t, 401, code)
+
+		headers := make(http.Header)
+		headers.Set("Authorization", "Bearer admin-token")
+		admin := NewProxydClientWithHeaders("http://127.0.0.1:8545/admin/reload", headers)
+		res, code, err := admin.SendRequest(body)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		require.Equal(t, "OK", string(res))
+
+		goodBackend.Reset()
+		_, code, err = client.SendRPC("eth_call", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		require.Len(t, goodBackend.Requests(), 1)
+
+		res, code, err = admin.SendRequest([]byte("[server"))
+		require.NoError(t, err)
+		require.Equal(t, 400, code)
+		require.Contains(t, string(res), "error decoding config")
+	})
+}
+
+func TestReloadConsensus(t *testing.T) {
+	dir, err := os.Getwd()
+	require.NoError(t, err)
+	responses := path.Join(dir, "testdata/consensus_responses.yml")
+
+	node1 := NewMockBackend(nil)
+	defer node1.Close()
+	node2 := NewMockBackend(nil)
+	defer node2.Close()
+	h1 := ms.MockedHandler{Autoload: true, AutoloadFile: responses}
+	h2 := ms.MockedHandler{Autoload: true, AutoloadFile: responses}
+	node1.SetHandler(http.HandlerFunc(h1.Handler))
+	node2.SetHandler(http.HandlerFunc(h2.Handler))
+
+	require.NoError(t, os.Setenv("NODE1_URL", node1.URL()))
+	require.NoError(t, os.Setenv("NODE2_URL", node2.URL()))
+
+	config := ReadConfig("consensus")
+	srv, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	ctx := context.Background()
+	bg := srv.CurrentBackendGroups()["node"]
+	for _, be := range bg.Backends {
+		bg.Consensus.UpdateBackend(ctx, be)
+	}
+	bg.Consensus.UpdateBackendGroupConsensus(ctx)
+	require.Len(t, bg.Consensus.GetConsensusGroup(), 2)
+	latest := bg.Consensus.GetLatestBlockNumber()
+	node1Backend := bg.Backends[0]
+	prevConsensus := bg.Consensus
+
+	reloaded := ReadConfig("consensus")
+	reloaded.BackendGroups["node"].Backends = []string{"node1"}
+	reloaded.BackendGroups["node"].ConsensusMaxBlockLag = 16
+	require.NoError(t, srv.Reload(reloaded))
+
+	// the group is updated in place, with a poller carrying over the state of the kept backend
+	require.Same(t, bg, srv.CurrentBackendGroups()["node"])
+	require.NotSame(t, prevConsensus, bg.Consensus)
+	require.Equal(t, []*proxyd.Backend{node1Backend}, bg.Backends)
+	require.Equal(t, []*proxyd.Backend{node1Backend}, bg.Consensus.GetConsensusGroup())
+	require.Equal(t, latest, bg.Consensus.GetLatestBlockNumber())
+
+	node1.Reset()
+	_, code, err := NewProxydClient("http://127.0.0.1:8545").SendRPC("eth_getBlockByNumber", []interface{}{"0x101", false})
+	require.NoError(t, err)
+	require.Equal(t, 200, code)
+	require.Len(t, node1.Requests(), 1)
+}
@@ -0,0 +1,106 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tenantNotWhitelistedResponse = `{"jsonrpc":"2.0","error":{"code":-32001,"message":"rpc method is not whitelisted"},"id":999}`
+	tenantOverQuotaResponse      = `{"jsonrpc":"2.0","error":{"code":-32022,"message":"over daily compute unit quota"},"id":999}`
+	tenantWSResponse             = `{"jsonrpc":"2.0","error":{"code":-32024,"message":"websocket connections are not supported for tenants"},"id":null}`
+)
+
+func TestTenants(t *testing.T) {
+	goodBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer goodBackend.Close()
+	partnerBackend := NewMockBackend(BatchedResponseHandler(200, goodResponse))
+	defer partnerBackend.Close()
+
+	require.NoError(t, os.Setenv("GOOD_BACKEND_RPC_URL", goodBackend.URL()))
+	require.NoError(t, os.Setenv("PARTNER_BACKEND_RPC_URL", partnerBackend.URL()))
+
+	config := ReadConfig("tenants")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545")
+		_, code, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 401, code)
+	})
+
+	t.Run("backend group override", func(t *testing.T) {
+		goodBackend.Reset()
+		partnerBackend.Reset()
+		client := NewProxydClient("http://127.0.0.1:8545/partner-secret")
+		res, code, err := client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		RequireEqualJSON(t, []byte(goodResponse), res)
+		require.Len(t, partnerBackend.Requests(), 1)
+		require.Len(t, goodBackend.Requests(), 0)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/partner-secret")
+		res, code, err := client.SendRPC("eth_blockNumber", nil)
+		require.NoError(t, err)
+		require.Equal(t, 403, code)
+		RequireEqualJSON(t, []byte(tenantNotWhitelistedResponse), res)
+	})
+
+	t.Run("over compute unit quota", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/partner-secret")
+		// 1 unit was consumed by eth_chainId above, eth_call weighs 5 units
+		_, code, err := client.SendRPC("eth_call", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+
+		res, code, err := client.SendRPC("eth_call", nil)
+		require.NoError(t, err)
+		require.Equal(t, 429, code)
+		RequireEqualJSON(t, []byte(tenantOverQuotaResponse), res)
+
+		// the units of the rejected request are not consumed
+		_, code, err = client.SendRPC("eth_chainId", nil)
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+	})
+
+	t.Run("over rate limit", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/limited-secret")
+		limitedRes, codes := spamReqs(t, client, ethChainID, 429, 3)
+		require.Equal(t, 1, codes[429])
+		require.Equal(t, 2, codes[200])
+		// the message of the rate limit error is configurable
+		var res proxyd.RPCRes
+		require.NoError(t, json.Unmarshal(limitedRes, &res))
+		require.Equal(t, proxyd.ErrOverRateLimit.Code, res.Error.Code)
+	})
+
+	t.Run("global rate limit without max_rps", func(t *testing.T) {
+		client := NewProxydClient("http://127.0.0.1:8545/default-secret")
+		_, codes := spamReqs(t, client, ethChainID, 429, 3)
+		require.Equal(t, 1, codes[429])
+		require.Equal(t, 2, codes[200])
+	})
+
+	t.Run("websocket rejected", func(t *testing.T) {
+		_, res, err := websocket.DefaultDialer.Dial("ws://127.0.0.1:8546/partner-secret", nil)
+		require.ErrorIs(t, err, websocket.ErrBadHandshake)
+		defer res.Body.Close()
+		require.Equal(t, 403, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		RequireEqualJSON(t, []byte(tenantWSResponse), body)
+	})
+}
@@ -0,0 +1,191 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	txAcceptedRes   = `{"id":1,"jsonrpc":"2.0","result":"0x1234"}`
+	txNonceTooLow   = `{"id":1,"jsonrpc":"2.0","error":{"code":-32000,"message":"nonce too low"}}`
+	txBlockedRes    = `{"id":1,"jsonrpc":"2.0","error":{"code":-32023,"message":"transaction rejected: address is blocked"}}`
+	txNonceGapRes   = `{"id":1,"jsonrpc":"2.0","error":{"code":-32023,"message":"transaction rejected: nonce gap above 5"}}`
+	txGasLimitRes   = `{"id":1,"jsonrpc":"2.0","error":{"code":-32023,"message":"transaction rejected: gas limit above 50000"}}`
+	txChainIdRes    = `{"id":1,"jsonrpc":"2.0","error":{"code":-32023,"message":"transaction rejected: chain id is not allowed"}}`
+	pendingNonceRes = `{"id":1,"jsonrpc":"2.0","result":"0x3"}`
+)
+
+func TestTxForwarding(t *testing.T) {
+	seq1 := NewMockBackend(SingleResponseHandler(503, "unavailable"))
+	defer seq1.Close()
+	seq2 := NewMockBackend(SingleResponseHandler(200, txAcceptedRes))
+	defer seq2.Close()
+	private := NewMockBackend(SingleResponseHandler(200, txAcceptedRes))
+	defer private.Close()
+	nonce := NewMockBackend(SingleResponseHandler(200, pendingNonceRes))
+	defer nonce.Close()
+
+	require.NoError(t, os.Setenv("SEQ1_BACKEND_RPC_URL", seq1.URL()))
+	require.NoError(t, os.Setenv("SEQ2_BACKEND_RPC_URL", seq2.URL()))
+	require.NoError(t, os.Setenv("PRIVATE_BACKEND_RPC_URL", private.URL()))
+	require.NoError(t, os.Setenv("NONCE_BACKEND_RPC_URL", nonce.URL()))
+
+	reset := func() {
+		seq1.Reset()
+		seq2.Reset()
+		private.Reset()
+		nonce.Reset()
+	}
+
+	config := ReadConfig("tx_forwarding")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	client := NewProxydClient("http://127.0.0.1:8545/public-secret")
+
+	t.Run("broadcast returns the first success", func(t *testing.T) {
+		reset()
+		res, code, err := client.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		RequireEqualJSON(t, []byte(txAcceptedRes), res)
+		require.Len(t, seq1.Requests(), 1)
+		require.Len(t, seq2.Requests(), 1)
+		require.Len(t, private.Requests(), 0)
+		require.Len(t, nonce.Requests(), 1)
+	})
+
+	t.Run("backend errors are relayed", func(t *testing.T) {
+		reset()
+		seq2.SetHandler(SingleResponseHandler(200, txNonceTooLow))
+		defer seq2.SetHandler(SingleResponseHandler(200, txAcceptedRes))
+		res, _, err := client.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		RequireEqualJSON(t, []byte(txNonceTooLow), res)
+	})
+
+	t.Run("private transactions", func(t *testing.T) {
+		reset()
+		searcher := NewProxydClient("http://127.0.0.1:8545/searcher-secret")
+		res, code, err := searcher.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		RequireEqualJSON(t, []byte(txAcceptedRes), res)
+		require.Len(t, private.Requests(), 1)
+		require.Len(t, seq1.Requests(), 0)
+		require.Len(t, seq2.Requests(), 0)
+	})
+
+	t.Run("blocked recipient", func(t *testing.T) {
+		reset()
+		res, code, err := client.SendRequest(makeSendRawTransaction(txHex2))
+		require.NoError(t, err)
+		require.Equal(t, 400, code)
+		RequireEqualJSON(t, []byte(txBlockedRes), res)
+		require.Len(t, seq2.Requests(), 0)
+	})
+
+	t.Run("nonce gap", func(t *testing.T) {
+		reset()
+		nonce.SetHandler(SingleResponseHandler(200, `{"id":1,"jsonrpc":"2.0","result":"0x0"}`))
+		defer nonce.SetHandler(SingleResponseHandler(200, pendingNonceRes))
+		res, _, err := client.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		RequireEqualJSON(t, []byte(txNonceGapRes), res)
+		require.Len(t, seq2.Requests(), 0)
+	})
+
+	t.Run("unhealthy backends are skipped", func(t *testing.T) {
+		// seq1 is unavailable, raising its error rate past the threshold
+		for i := 0; i < 10; i++ {
+			_, _, err := client.SendRequest(makeSendRawTransaction(txHex1))
+			require.NoError(t, err)
+		}
+		reset()
+		res, code, err := client.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		require.Equal(t, 200, code)
+		RequireEqualJSON(t, []byte(txAcceptedRes), res)
+		require.Len(t, seq1.Requests(), 0)
+		require.Len(t, seq2.Requests(), 1)
+	})
+}
+
+func TestTxForwardingBatch(t *testing.T) {
+	// the mock backends serve their requests sequentially
+	seq := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req proxyd.RPCReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		time.Sleep(500 * time.Millisecond)
+		_, _ = w.Write(mustMarshal(proxyd.NewRPCRes(req.ID, "0x1234")))
+	}))
+	defer seq.Close()
+	nonce := NewMockBackend(SingleResponseHandler(200, pendingNonceRes))
+	defer nonce.Close()
+
+	require.NoError(t, os.Setenv("SEQ1_BACKEND_RPC_URL", seq.URL))
+	require.NoError(t, os.Setenv("SEQ2_BACKEND_RPC_URL", seq.URL))
+	require.NoError(t, os.Setenv("PRIVATE_BACKEND_RPC_URL", seq.URL))
+	require.NoError(t, os.Setenv("NONCE_BACKEND_RPC_URL", nonce.URL()))
+
+	config := ReadConfig("tx_forwarding")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	client := NewProxydClient("http://127.0.0.1:8545/public-secret")
+	start := time.Now()
+	res, code, err := client.SendBatchRPC(
+		NewRPCReq("1", "eth_sendRawTransaction", []interface{}{txHex1}),
+		NewRPCReq("2", "eth_sendRawTransaction", []interface{}{txHex1}),
+		NewRPCReq("3", "eth_sendRawTransaction", []interface{}{txHex1}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 200, code)
+	// the transactions of the batch are forwarded concurrently
+	require.Less(t, time.Since(start), time.Second)
+	RequireEqualJSON(t, []byte(`[{"id":1,"jsonrpc":"2.0","result":"0x1234"},{"id":2,"jsonrpc":"2.0","result":"0x1234"},{"id":3,"jsonrpc":"2.0","result":"0x1234"}]`), res)
+}
+
+func TestTxForwardingPolicies(t *testing.T) {
+	goodBackend := NewMockBackend(SingleResponseHandler(200, txAcceptedRes))
+	defer goodBackend.Close()
+
+	for _, env := range []string{"SEQ1_BACKEND_RPC_URL", "SEQ2_BACKEND_RPC_URL", "PRIVATE_BACKEND_RPC_URL", "NONCE_BACKEND_RPC_URL"} {
+		require.NoError(t, os.Setenv(env, goodBackend.URL()))
+	}
+
+	client := NewProxydClient("http://127.0.0.1:8545/public-secret")
+
+	t.Run("gas limit", func(t *testing.T) {
+		config := ReadConfig("tx_forwarding")
+		config.TxForwarding.MaxGasLimit = 50000
+		_, shutdown, err := proxyd.Start(config)
+		require.NoError(t, err)
+		defer shutdown()
+
+		res, _, err := client.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		RequireEqualJSON(t, []byte(txGasLimitRes), res)
+	})
+
+	t.Run("chain id", func(t *testing.T) {
+		config := ReadConfig("tx_forwarding")
+		config.TxForwarding.AllowedChainIds[0].SetInt64(10)
+		_, shutdown, err := proxyd.Start(config)
+		require.NoError(t, err)
+		defer shutdown()
+
+		res, _, err := client.SendRequest(makeSendRawTransaction(txHex1))
+		require.NoError(t, err)
+		RequireEqualJSON(t, []byte(txChainIdRes), res)
+	})
+}
@@ -0,0 +1,296 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSubscriptionNode serves newHeads subscriptions over WS and blocks over HTTP
+type mockSubscriptionNode struct {
+	server   *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu             sync.Mutex
+	conns          []*websocket.Conn
+	subscribes     int
+	unsubscribes   int
+	subscribeDelay time.Duration
+}
+
+func newMockSubscriptionNode() *mockSubscriptionNode {
+	n := &mockSubscriptionNode{}
+	n.server = httptest.NewServer(n)
+	return n
+}
+
+func (n *mockSubscriptionNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if websocket.IsWebSocketUpgrade(r) {
+		conn, err := n.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			panic(err)
+		}
+		n.mu.Lock()
+		n.conns = append(n.conns, conn)
+		n.mu.Unlock()
+		go n.readPump(conn)
+		return
+	}
+
+	var req proxyd.RPCReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		panic(err)
+	}
+	var result interface{}
+	switch req.Method {
+	case "eth_getBlockByNumber":
+		var params []interface{}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			panic(err)
+		}
+		number, err := hexutil.DecodeUint64(params[0].(string))
+		if err != nil {
+			panic(err)
+		}
+		block := mockHead(number)
+		block["transactions"] = []string{}
+		result = block
+	case "eth_chainId":
+		result = "0x1"
+	}
+	_, _ = w.Write(mustMarshal(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result}))
+}
+
+func (n *mockSubscriptionNode) readPump(conn *websocket.Conn) {
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req proxyd.RPCReq
+		if err := json.Unmarshal(msg, &req); err != nil {
+			panic(err)
+		}
+		res := mustMarshal(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0xsub"})
+		n.mu.Lock()
+		delay := n.subscribeDelay
+		switch req.Method {
+		case "eth_subscribe":
+			n.subscribes++
+		case "eth_unsubscribe":
+			n.unsubscribes++
+		}
+		n.mu.Unlock()
+		if req.Method == "eth_subscribe" && delay > 0 {
+			time.AfterFunc(delay, func() { n.write(res) })
+			continue
+		}
+		n.write(res)
+	}
+}
+
+func (n *mockSubscriptionNode) write(msg []byte) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, conn := range n.conns {
+		_ = conn.WriteMessage(websocket.TextMessage, msg)
+	}
+}
+
+func (n *mockSubscriptionNode) EmitHead(number uint64) {
+	n.write(mustMarshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_subscription",
+		"params":  map[string]interface{}{"subscription": "0xsub", "result": mockHead(number)},
+	}))
+}
+
+func (n *mockSubscriptionNode) Subscribes() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.subscribes
+}
+
+func (n *mockSubscriptionNode) Unsubscribes() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.unsubscribes
+}
+
+func (n *mockSubscriptionNode) SetSubscribeDelay(delay time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.subscribeDelay = delay
+}
+
+func (n *mockSubscriptionNode) URL() string {
+	return n.server.URL
+}
+
+func (n *mockSubscriptionNode) WSURL() string {
+	return strings.Replace(n.server.URL, "http://", "ws://", 1)
+}
+
+func (n *mockSubscriptionNode) Close() {
+	n.server.Close()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, conn := range n.conns {
+		conn.Close()
+	}
+}
+
+func mockHead(number uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"number": hexutil.Uint64(number),
+		"hash":   common.BigToHash(new(big.Int).SetUint64(number)),
+	}
+}
+
+func mustMarshal(v interface{}) []byte {
+	out, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+type wsNotification struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *proxyd.RPCErr  `json:"error"`
+	Params struct {
+		Subscription string `json:"subscription"`
+		Result       struct {
+			Number hexutil.Uint64 `json:"number"`
+		} `json:"result"`
+	} `json:"params"`
+}
+
+func readWSMessage(t *testing.T, ch chan []byte) wsNotification {
+	select {
+	case msg := <-ch:
+		var n wsNotification
+		require.NoError(t, json.Unmarshal(msg, &n))
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for ws message")
+		return wsNotification{}
+	}
+}
+
+func TestWSMultiplexing(t *testing.T) {
+	node1 := newMockSubscriptionNode()
+	defer node1.Close()
+	node2 := newMockSubscriptionNode()
+	defer node2.Close()
+
+	require.NoError(t, os.Setenv("NODE1_URL", node1.URL()))
+	require.NoError(t, os.Setenv("NODE1_WS_URL", node1.WSURL()))
+	require.NoError(t, os.Setenv("NODE2_URL", node2.URL()))
+	require.NoError(t, os.Setenv("NODE2_WS_URL", node2.WSURL()))
+
+	config := ReadConfig("ws_mux")
+	_, shutdown, err := proxyd.Start(config)
+	require.NoError(t, err)
+	defer shutdown()
+
+	clientChs := make([]chan []byte, 2)
+	subIDs := make([]string, 2)
+	for i := range clientChs {
+		ch := make(chan []byte, 16)
+		clientChs[i] = ch
+		client, err := NewProxydWSClient("ws://127.0.0.1:8546", func(msgType int, data []byte) {
+			ch <- data
+		}, nil)
+		require.NoError(t, err)
+		defer client.HardClose()
+
+		require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`)))
+		res := readWSMessage(t, ch)
+		require.NoError(t, json.Unmarshal(res.Result, &subIDs[i]))
+	}
+	require.NotEqual(t, subIDs[0], subIDs[1])
+
+	t.Run("subscriptions are shared", func(t *testing.T) {
+		require.Equal(t, 1, node1.Subscribes())
+		require.Equal(t, 0, node2.Subscribes())
+
+		node1.EmitHead(1)
+		for i, ch := range clientChs {
+			n := readWSMessage(t, ch)
+			require.Equal(t, subIDs[i], n.Params.Subscription)
+			require.Equal(t, hexutil.Uint64(1), n.Params.Result.Number)
+		}
+	})
+
+	t.Run("failover without duplicate or missing heads", func(t *testing.T) {
+		node1.Close()
+		require.Eventually(t, func() bool {
+			return node2.Subscribes() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		// head 1 was already delivered, heads 2 & 3 are backfilled
+		node2.EmitHead(1)
+		node2.EmitHead(4)
+		for i, ch := range clientChs {
+			for number := uint64(2); number <= 4; number++ {
+				n := readWSMessage(t, ch)
+				require.Equal(t, subIDs[i], n.Params.Subscription)
+				require.Equal(t, hexutil.Uint64(number), n.Params.Result.Number, fmt.Sprintf("client %d", i))
+			}
+		}
+	})
+
+	t.Run("requests are forwarded", func(t *testing.T) {
+		client, err := NewProxydWSClient("ws://127.0.0.1:8546", func(msgType int, data []byte) {
+			clientChs[0] <- data
+		}, nil)
+		require.NoError(t, err)
+		defer client.HardClose()
+
+		require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)))
+		res := readWSMessage(t, clientChs[0])
+		require.Equal(t, `"0x1"`, string(res.Result))
+	})
+
+	t.Run("subscription timeout", func(t *testing.T) {
+		ch := make(chan []byte, 16)
+		client, err := NewProxydWSClient("ws://127.0.0.1:8546", func(msgType int, data []byte) {
+			ch <- data
+		}, nil)
+		require.NoError(t, err)
+		defer client.HardClose()
+
+		node2.SetSubscribeDelay(6 * time.Second)
+		defer node2.SetSubscribeDelay(0)
+		require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["logs",{}]}`)))
+		var res wsNotification
+		select {
+		case msg := <-ch:
+			require.NoError(t, json.Unmarshal(msg, &res))
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the subscription error")
+		}
+		require.NotNil(t, res.Error)
+		require.Equal(t, proxyd.ErrGatewayTimeout.Code, res.Error.Code)
+
+		// the upstream subscription responded late is unused
+		require.Eventually(t, func() bool {
+			return node2.Unsubscribes() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 type RPCMethodHandler interface {
@@ -90,3 +93,246 @@ func (e *StaticMethodHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res
 	}
 	return nil
 }
+
+// FinalityTracker provides the safe & finalized block numbers agreed by a consensus
+type FinalityTracker interface {
+	GetSafeBlockNumber() hexutil.Uint64
+	GetFinalizedBlockNumber() hexutil.Uint64
+}
+
+// finalityPolicy determines how long number-addressed entries are cached by the finality of the addressed block
+type finalityPolicy struct {
+	finalizedTTL time.Duration
+	safeTTL      time.Duration
+}
+
+// ttl returns the ttl of an entry addressing the block along with its finality, or false if the
+// block is not final enough to be cached. Zero block numbers are unknown, while the consensus is
+// being established.
+func (f *finalityPolicy) ttl(block hexutil.Uint64, safe hexutil.Uint64, finalized hexutil.Uint64) (time.Duration, string, bool) {
+	if finalized > 0 && block <= finalized {
+		return f.finalizedTTL, "finalized", true
+	}
+	if f.safeTTL > 0 && safe > 0 && block <= safe {
+		return f.safeTTL, "safe", true
+	}
+	return 0, "", false
+}
+
+// FinalityMethodHandler caches requests addressing a block by number once the block is finalized, or safe
+// when enabled, by the finality agreed by the backend group serving the request. The block is addressed
+// either by the request params or by the result of the request.
+//
+// Entries are only served while the addressed block remains final, such that a regression of the agreed
+// block numbers, i.e after a deep reorg, is observed by every proxyd instance sharing the cache. Entries
+// are otherwise bounded by their ttl.
+type FinalityMethodHandler struct {
+	cache  Cache
+	policy *finalityPolicy
+
+	// requestBlock returns the block number addressed by the params, along with the params normalized to
+	// address the block by its number. The finalized tag is resolved to finalizedTag, unless it is zero
+	requestBlock func(params json.RawMessage, finalizedTag hexutil.Uint64) (hexutil.Uint64, json.RawMessage, bool)
+	// resultBlock returns the block number of the result
+	resultBlock func(res *RPCRes) (hexutil.Uint64, bool)
+}
+
+func (e *FinalityMethodHandler) key(method string, group string, params json.RawMessage) string {
+	// signature is the hashed json.RawMessage param contents
+	h := sha256.New()
+	h.Write(params)
+	signature := fmt.Sprintf("%x", h.Sum(nil))
+	return strings.Join([]string{"cache", method, group, signature}, ":")
+}
+
+func (e *FinalityMethodHandler) getRPCMethod(ctx context.Context, group string, tracker FinalityTracker, req *RPCReq) (*RPCRes, error) {
+	if e.cache == nil {
+		return nil, nil
+	}
+
+	safe, finalized := tracker.GetSafeBlockNumber(), tracker.GetFinalizedBlockNumber()
+	params := req.Params
+	if e.requestBlock != nil {
+		block, normalized, ok := e.requestBlock(req.Params, finalized)
+		if !ok {
+			return nil, nil
+		}
+		if _, _, ok := e.policy.ttl(block, safe, finalized); !ok {
+			return nil, nil
+		}
+		params = normalized
+	}
+
+	key := e.key(req.Method, group, params)
+	val, err := e.cache.Get(ctx, key)
+	if err != nil {
+		log.Error("error reading from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	if val == "" {
+		return nil, nil
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(val), &result); err != nil {
+		log.Error("error unmarshalling value from cache", "key", key, "method", req.Method, "err", err)
+		return nil, err
+	}
+	res := &RPCRes{
+		JSONRPC: req.JSONRPC,
+		Result:  result,
+		ID:      req.ID,
+	}
+
+	// Result-addressed entries are checked against the current finality once read
+	if e.resultBlock != nil {
+		block, ok := e.resultBlock(res)
+		if !ok {
+			return nil, nil
+		}
+		if _, _, ok := e.policy.ttl(block, safe, finalized); !ok {
+			return nil, nil
+		}
+	}
+	return res, nil
+}
+
+func (e *FinalityMethodHandler) putRPCMethod(ctx context.Context, group string, tracker FinalityTracker, req *RPCReq, res *RPCRes) error {
+	if e.cache == nil {
+		return nil
+	}
+
+	safe, finalized := tracker.GetSafeBlockNumber(), tracker.GetFinalizedBlockNumber()
+	var (
+		block  hexutil.Uint64
+		params = req.Params
+		ok     bool
+	)
+	if e.requestBlock != nil {
+		// Consensus aware backend groups forward the finalized tag by number. Otherwise the
+		// tag may have been resolved by the backend to a different block than tracked
+		block, params, ok = e.requestBlock(req.Params, 0)
+	} else {
+		block, ok = e.resultBlock(res)
+	}
+	if !ok {
+		return nil
+	}
+	ttl, finality, ok := e.policy.ttl(block, safe, finalized)
+	if !ok {
+		return nil
+	}
+
+	key := e.key(req.Method, group, params)
+	value := mustMarshalJSON(res.Result)
+
+	err := e.cache.PutWithTTL(ctx, key, string(value), ttl)
+	if err != nil {
+		log.Error("error putting into cache", "key", key, "method", req.Method, "err", err)
+		return err
+	}
+	RecordCacheFinalityPut(req.Method, finality)
+	return nil
+}
+
+// groupFinalityHandler binds a FinalityMethodHandler to the backend group serving the request
+type groupFinalityHandler struct {
+	handler *FinalityMethodHandler
+	group   string
+	tracker FinalityTracker
+}
+
+func (g *groupFinalityHandler) GetRPCMethod(ctx context.Context, req *RPCReq) (*RPCRes, error) {
+	return g.handler.getRPCMethod(ctx, g.group, g.tracker, req)
+}
+
+func (g *groupFinalityHandler) PutRPCMethod(ctx context.Context, req *RPCReq, res *RPCRes) error {
+	return g.handler.putRPCMethod(ctx, g.group, g.tracker, req, res)
+}
+
+// blockNumberParam addresses the block of the param at the position, either a block number or
+// an EIP-1898 block number or hash. A missing param defaults to latest and is not addressed by number
+func blockNumberParam(pos int) func(json.RawMessage, hexutil.Uint64) (hexutil.Uint64, json.RawMessage, bool) {
+	return func(params json.RawMessage, finalizedTag hexutil.Uint64) (hexutil.Uint64, json.RawMessage, bool) {
+		var p []interface{}
+		if err := json.Unmarshal(params, &p); err != nil || len(p) <= pos {
+			return 0, nil, false
+		}
+
+		block, ok := resolveBlockNumber(p[pos], finalizedTag)
+		if !ok {
+			return 0, nil, false
+		}
+
+		p[pos] = block.String()
+		normalized, err := json.Marshal(p)
+		if err != nil {
+			return 0, nil, false
+		}
+		return block, normalized, true
+	}
+}
+
+// blockRangeParam addresses the last block of the range of a log filter. Filters without both ends of the
+// range, which default to latest, or filtering a block hash are not addressed by number
+func blockRangeParam(params json.RawMessage, finalizedTag hexutil.Uint64) (hexutil.Uint64, json.RawMessage, bool) {
+	var p []map[string]interface{}
+	if err := json.Unmarshal(params, &p); err != nil || len(p) != 1 {
+		return 0, nil, false
+	}
+
+	fromParam, hasFrom := p[0]["fromBlock"]
+	toParam, hasTo := p[0]["toBlock"]
+	if !hasFrom || !hasTo {
+		return 0, nil, false
+	}
+	from, ok := resolveBlockNumber(fromParam, finalizedTag)
+	if !ok {
+		return 0, nil, false
+	}
+	to, ok := resolveBlockNumber(toParam, finalizedTag)
+	if !ok || from > to {
+		return 0, nil, false
+	}
+
+	p[0]["fromBlock"], p[0]["toBlock"] = from.String(), to.String()
+	normalized, err := json.Marshal(p)
+	if err != nil {
+		return 0, nil, false
+	}
+	return to, normalized, true
+}
+
+// resolveBlockNumber resolves the block number of the param. Tags other than finalized, when resolved,
+// address blocks that may not be final and block hashes are not addressed by number
+func resolveBlockNumber(param interface{}, finalizedTag hexutil.Uint64) (hexutil.Uint64, bool) {
+	bnh, err := remarshalBlockNumberOrHash(param)
+	if err != nil || bnh.BlockNumber == nil {
+		return 0, false
+	}
+
+	switch {
+	case *bnh.BlockNumber == rpc.FinalizedBlockNumber && finalizedTag > 0:
+		return finalizedTag, true
+	case *bnh.BlockNumber < 0:
+		return 0, false
+	}
+	return hexutil.Uint64(*bnh.BlockNumber), true
+}
+
+// receiptBlockNumber addresses the block including the transaction of a receipt
+func receiptBlockNumber(res *RPCRes) (hexutil.Uint64, bool) {
+	receipt, ok := res.Result.(map[string]interface{})
+	if !ok {
+		return 0, false
+	}
+	blockNumber, ok := receipt["blockNumber"].(string)
+	if !ok {
+		return 0, false
+	}
+	block, err := hexutil.DecodeUint64(blockNumber)
+	if err != nil {
+		return 0, false
+	}
+	return hexutil.Uint64(block), true
+}
@@ -190,6 +190,139 @@ var (
 		"method",
 	})
 
+	cacheFinalityPutsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "cache_finality_puts_total",
+		Help:      "Number of number-addressed responses cached, by the finality of the addressed block.",
+	}, []string{
+		"method",
+		"finality",
+	})
+
+	tenantComputeUnitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tenant_compute_units_total",
+		Help:      "Count of compute units consumed by tenants, on this replica.",
+	}, []string{
+		"tenant",
+		"method",
+	})
+
+	tenantDailyComputeUnits = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tenant_daily_compute_units",
+		Help:      "Compute units consumed by tenants over the current day, across replicas when tracked in Redis.",
+	}, []string{
+		"tenant",
+	})
+
+	tenantRejectedRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tenant_rejected_requests_total",
+		Help:      "Count of tenant requests rejected by the tenant's policies.",
+	}, []string{
+		"tenant",
+		"reason",
+	})
+
+	wsMuxSubscriptionsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: MetricsNamespace,
+		Name:      "ws_mux_subscriptions",
+		Help:      "Gauge of upstream WS subscriptions shared by clients.",
+	}, []string{
+		"type",
+	})
+
+	wsMuxFailoversTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "ws_mux_failovers_total",
+		Help:      "Count of failovers of the shared upstream WS connection, by the backend failed over from.",
+	}, []string{
+		"backend_name",
+	})
+
+	wsMuxDuplicateNotificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "ws_mux_duplicate_notifications_total",
+		Help:      "Count of upstream WS notifications dropped as already delivered.",
+	}, []string{
+		"type",
+	})
+
+	wsMuxBackfilledNotificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "ws_mux_backfilled_notifications_total",
+		Help:      "Count of WS notifications backfilled after being missed by the upstream subscription.",
+	}, []string{
+		"type",
+	})
+
+	wsMuxNonCanonicalNotificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "ws_mux_non_canonical_notifications_total",
+		Help:      "Count of upstream WS notifications dropped as off the canonical chain of the consensus group.",
+	}, []string{
+		"type",
+	})
+
+	wsMuxDroppedClientsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "ws_mux_dropped_clients_total",
+		Help:      "Count of WS clients dropped for not consuming their messages fast enough.",
+	})
+
+	txForwardsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tx_forwards_total",
+		Help:      "Count of transactions forwarded to each backend, by route and outcome.",
+	}, []string{
+		"backend_name",
+		"route",
+		"outcome",
+	})
+
+	txForwardingAcceptedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tx_forwarding_accepted_total",
+		Help:      "Count of transactions accepted, by the backend responding first.",
+	}, []string{
+		"backend_name",
+		"route",
+	})
+
+	txForwardingLatencySumm = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tx_forwarding_latency_milliseconds",
+		Help:      "Histogram of the latency of the first successful transaction forward, in milliseconds.",
+		Buckets:   MillisecondDurationBuckets,
+	}, []string{
+		"route",
+	})
+
+	txForwardingRejectionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "tx_forwarding_rejections_total",
+		Help:      "Count of transactions rejected by the transaction forwarding policies.",
+	}, []string{
+		"reason",
+	})
+
+	captureRecordsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "capture_records_total",
+		Help:      "Count of sampled requests, by whether they were captured, dropped or failed to be written.",
+	}, []string{
+		"outcome",
+	})
+
+	configReloadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Name:      "config_reloads_total",
+		Help:      "Count of config reloads, by whether they were applied or rejected.",
+	}, []string{
+		"outcome",
+	})
+
 	batchRPCShortCircuitsTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: MetricsNamespace,
 		Name:      "batch_rpc_short_circuits_total",
@@ -461,6 +594,60 @@ func RecordCacheError(method string) {
 	cacheErrorsTotal.WithLabelValues(method).Inc()
 }
 
+func RecordTenantComputeUnits(tenant string, method string, units int64, dailyUnits int64) {
+	tenantComputeUnitsTotal.WithLabelValues(tenant, method).Add(float64(units))
+	tenantDailyComputeUnits.WithLabelValues(tenant).Set(float64(dailyUnits))
+}
+
+func RecordTenantRejectedRequest(tenant string, reason string) {
+	tenantRejectedRequestsTotal.WithLabelValues(tenant, reason).Inc()
+}
+
+func RecordWSMuxFailover(backendName string) {
+	wsMuxFailoversTotal.WithLabelValues(backendName).Inc()
+}
+
+func RecordWSMuxDuplicateNotification(subscription string) {
+	wsMuxDuplicateNotificationsTotal.WithLabelValues(subscription).Inc()
+}
+
+func RecordWSMuxBackfilledNotification(subscription string) {
+	wsMuxBackfilledNotificationsTotal.WithLabelValues(subscription).Inc()
+}
+
+func RecordWSMuxNonCanonicalNotification(subscription string) {
+	wsMuxNonCanonicalNotificationsTotal.WithLabelValues(subscription).Inc()
+}
+
+func RecordWSMuxDroppedClient() {
+	wsMuxDroppedClientsTotal.Inc()
+}
+
+func RecordTxForward(backendName string, route string, outcome string) {
+	txForwardsTotal.WithLabelValues(backendName, route, outcome).Inc()
+}
+
+func RecordTxForwardingSuccess(backendName string, route string, latency time.Duration) {
+	txForwardingAcceptedTotal.WithLabelValues(backendName, route).Inc()
+	txForwardingLatencySumm.WithLabelValues(route).Observe(float64(latency.Milliseconds()))
+}
+
+func RecordTxForwardingRejection(reason string) {
+	txForwardingRejectionsTotal.WithLabelValues(reason).Inc()
+}
+
+func RecordCapture(outcome string) {
+	captureRecordsTotal.WithLabelValues(outcome).Inc()
+}
+
+func RecordConfigReload(outcome string) {
+	configReloadsTotal.WithLabelValues(outcome).Inc()
+}
+
+func RecordCacheFinalityPut(method string, finality string) {
+	cacheFinalityPutsTotal.WithLabelValues(method, finality).Inc()
+}
+
 func RecordBatchSize(size int) {
 	batchSizeHistogram.Observe(float64(size))
 }
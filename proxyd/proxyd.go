@@ -63,13 +63,8 @@ func Start(config *Config) (*Server, func(), error) {
 		ErrTooManyBatchRequests.Message = config.BatchConfig.ErrorMessage
 	}
 
-	if config.SenderRateLimit.Enabled {
-		if config.SenderRateLimit.Limit <= 0 {
-			return nil, nil, errors.New("limit in sender_rate_limit must be > 0")
-		}
-		if time.Duration(config.SenderRateLimit.Interval) < time.Second {
-			return nil, nil, errors.New("interval in sender_rate_limit must be >= 1s")
-		}
+	if err := validateSenderRateLimit(config.SenderRateLimit); err != nil {
+		return nil, nil, err
 	}
 
 	maxConcurrentRPCs := config.Server.MaxConcurrentRPCs
@@ -81,107 +76,23 @@ func Start(config *Config) (*Server, func(), error) {
 	backendNames := make([]string, 0)
 	backendsByName := make(map[string]*Backend)
 	for name, cfg := range config.Backends {
-		opts := make([]BackendOpt, 0)
-
-		rpcURL, err := ReadFromEnvOrConfig(cfg.RPCURL)
-		if err != nil {
-			return nil, nil, err
-		}
-		wsURL, err := ReadFromEnvOrConfig(cfg.WSURL)
+		back, err := configureBackend(name, cfg, config.BackendOptions, rpcRequestSemaphore)
 		if err != nil {
 			return nil, nil, err
 		}
-		if rpcURL == "" {
-			return nil, nil, fmt.Errorf("must define an RPC URL for backend %s", name)
-		}
-
-		if config.BackendOptions.ResponseTimeoutSeconds != 0 {
-			timeout := secondsToDuration(config.BackendOptions.ResponseTimeoutSeconds)
-			opts = append(opts, WithTimeout(timeout))
-		}
-		if config.BackendOptions.MaxRetries != 0 {
-			opts = append(opts, WithMaxRetries(config.BackendOptions.MaxRetries))
-		}
-		if config.BackendOptions.MaxResponseSizeBytes != 0 {
-			opts = append(opts, WithMaxResponseSize(config.BackendOptions.MaxResponseSizeBytes))
-		}
-		if config.BackendOptions.OutOfServiceSeconds != 0 {
-			opts = append(opts, WithOutOfServiceDuration(secondsToDuration(config.BackendOptions.OutOfServiceSeconds)))
-		}
-		if config.BackendOptions.MaxDegradedLatencyThreshold > 0 {
-			opts = append(opts, WithMaxDegradedLatencyThreshold(time.Duration(config.BackendOptions.MaxDegradedLatencyThreshold)))
-		}
-		if config.BackendOptions.MaxLatencyThreshold > 0 {
-			opts = append(opts, WithMaxLatencyThreshold(time.Duration(config.BackendOptions.MaxLatencyThreshold)))
-		}
-		if config.BackendOptions.MaxErrorRateThreshold > 0 {
-			opts = append(opts, WithMaxErrorRateThreshold(config.BackendOptions.MaxErrorRateThreshold))
-		}
-		if cfg.MaxRPS != 0 {
-			opts = append(opts, WithMaxRPS(cfg.MaxRPS))
-		}
-		if cfg.MaxWSConns != 0 {
-			opts = append(opts, WithMaxWSConns(cfg.MaxWSConns))
-		}
-		if cfg.Password != "" {
-			passwordVal, err := ReadFromEnvOrConfig(cfg.Password)
-			if err != nil {
-				return nil, nil, err
-			}
-			opts = append(opts, WithBasicAuth(cfg.Username, passwordVal))
-		}
-
-		headers := map[string]string{}
-		for headerName, headerValue := range cfg.Headers {
-			headerValue, err := ReadFromEnvOrConfig(headerValue)
-			if err != nil {
-				return nil, nil, err
-			}
-
-			headers[headerName] = headerValue
-		}
-		opts = append(opts, WithHeaders(headers))
-
-		tlsConfig, err := configureBackendTLS(cfg)
-		if err != nil {
-			return nil, nil, err
-		}
-		if tlsConfig != nil {
-			log.Info("using custom TLS config for backend", "name", name)
-			opts = append(opts, WithTLSConfig(tlsConfig))
-		}
-		if cfg.StripTrailingXFF {
-			opts = append(opts, WithStrippedTrailingXFF())
-		}
-		opts = append(opts, WithProxydIP(os.Getenv("PROXYD_IP")))
-		opts = append(opts, WithConsensusSkipPeerCountCheck(cfg.ConsensusSkipPeerCountCheck))
-		opts = append(opts, WithConsensusForcedCandidate(cfg.ConsensusForcedCandidate))
-		opts = append(opts, WithWeight(cfg.Weight))
-
-		receiptsTarget, err := ReadFromEnvOrConfig(cfg.ConsensusReceiptsTarget)
-		if err != nil {
-			return nil, nil, err
-		}
-		receiptsTarget, err = validateReceiptsTarget(receiptsTarget)
-		if err != nil {
-			return nil, nil, err
-		}
-		opts = append(opts, WithConsensusReceiptTarget(receiptsTarget))
-
-		back := NewBackend(name, rpcURL, wsURL, rpcRequestSemaphore, opts...)
 		backendNames = append(backendNames, name)
 		backendsByName[name] = back
 		log.Info("configured backend",
 			"name", name,
 			"backend_names", backendNames,
-			"rpc_url", rpcURL,
-			"ws_url", wsURL)
+			"rpc_url", back.rpcURL,
+			"ws_url", back.wsURL)
 	}
 
 	backendGroups := make(map[string]*BackendGroup)
-	for bgName, bg := range config.BackendGroups {
+	for bgName, bgcfg := range config.BackendGroups {
 		backends := make([]*Backend, 0)
-		for _, bName := range bg.Backends {
+		for _, bName := range bgcfg.Backends {
 			if backendsByName[bName] == nil {
 				return nil, nil, fmt.Errorf("backend %s is not defined", bName)
 			}
@@ -191,7 +102,7 @@ func Start(config *Config) (*Server, func(), error) {
 		backendGroups[bgName] = &BackendGroup{
 			Name:            bgName,
 			Backends:        backends,
-			WeightedRouting: bg.WeightedRouting,
+			WeightedRouting: bgcfg.WeightedRouting,
 		}
 	}
 
@@ -207,6 +118,44 @@ func Start(config *Config) (*Server, func(), error) {
 		return nil, nil, fmt.Errorf("a ws port was defined, but no ws group was defined")
 	}
 
+	var txForwarder *TxForwarder
+	if config.TxForwarding.Enabled {
+		var nonceGroup *BackendGroup
+		if config.TxForwarding.NonceBackendGroup != "" {
+			nonceGroup = backendGroups[config.TxForwarding.NonceBackendGroup]
+			if nonceGroup == nil {
+				return nil, nil, fmt.Errorf("tx forwarding nonce backend group %s does not exist", config.TxForwarding.NonceBackendGroup)
+			}
+		}
+		var err error
+		txForwarder, err = NewTxForwarder(config.TxForwarding, backendsByName, backendGroups, nonceGroup)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating tx forwarder: %w", err)
+		}
+	}
+	for name, tenant := range config.Tenants {
+		if tenant.PrivateTransactions && (txForwarder == nil || !txForwarder.HasPrivateRoute()) {
+			return nil, nil, fmt.Errorf("tenant %s requires tx forwarding private backends", name)
+		}
+	}
+
+	var capturer *Capturer
+	if config.Capture.Enabled {
+		var err error
+		capturer, err = NewCapturer(config.Capture)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error creating capturer: %w", err)
+		}
+	}
+
+	var wsMux *WSMultiplexer
+	if config.WSMultiplexing.Enabled {
+		if wsBackendGroup == nil {
+			return nil, nil, errors.New("ws multiplexing requires a ws backend group")
+		}
+		wsMux = NewWSMultiplexer(wsBackendGroup, NewStringSetFromStrings(config.WSMethodWhitelist), config.WSMultiplexing.MaxBackfillBlocks, config.WSMultiplexing.ClientQueueSize)
+	}
+
 	for _, bg := range config.RPCMethodMappings {
 		if backendGroups[bg] == nil {
 			return nil, nil, fmt.Errorf("undefined backend group %s", bg)
@@ -226,6 +175,38 @@ func Start(config *Config) (*Server, func(), error) {
 		}
 	}
 
+	for name, tenant := range config.Tenants {
+		if name == "none" {
+			return nil, nil, errors.New("cannot use none as a tenant name")
+		}
+		if tenant.Secret == "" {
+			return nil, nil, fmt.Errorf("tenant %s must define a secret", name)
+		}
+		if tenant.BackendGroup != "" && backendGroups[tenant.BackendGroup] == nil {
+			return nil, nil, fmt.Errorf("tenant %s uses undefined backend group %s", name, tenant.BackendGroup)
+		}
+		if tenant.SenderRateLimit > 0 && tenant.SenderRateLimitInterval <= 0 {
+			return nil, nil, fmt.Errorf("tenant %s must define a sender rate limit interval", name)
+		}
+
+		resolvedSecret, err := ReadFromEnvOrConfig(tenant.Secret)
+		if err != nil {
+			return nil, nil, err
+		}
+		if resolvedAuth == nil {
+			resolvedAuth = make(map[string]string)
+		}
+		for secret, alias := range resolvedAuth {
+			if secret == resolvedSecret || alias == name {
+				return nil, nil, fmt.Errorf("tenant %s collides with another authentication", name)
+			}
+		}
+		resolvedAuth[resolvedSecret] = name
+	}
+	if len(config.Tenants) > 0 && redisClient == nil {
+		log.Warn("redis is not configured, tracking tenant usage in memory")
+	}
+
 	var (
 		cache    Cache
 		rpcCache RPCCache
@@ -237,7 +218,16 @@ func Start(config *Config) (*Server, func(), error) {
 		} else {
 			cache = newRedisCache(redisClient, config.Redis.Namespace)
 		}
-		rpcCache = newRPCCache(newCacheWithCompression(cache))
+
+		var cacheOpts []RPCCacheOpt
+		if config.Cache.Finality {
+			finalizedTTL := time.Duration(config.Cache.FinalizedTTL)
+			if finalizedTTL == 0 {
+				finalizedTTL = redisTTL
+			}
+			cacheOpts = append(cacheOpts, WithFinalityCache(finalizedTTL, time.Duration(config.Cache.SafeTTL)))
+		}
+		rpcCache = newRPCCache(newCacheWithCompression(cache), cacheOpts...)
 	}
 
 	srv, err := NewServer(
@@ -257,11 +247,22 @@ func Start(config *Config) (*Server, func(), error) {
 		config.Server.MaxRequestBodyLogLen,
 		config.BatchConfig.MaxSize,
 		redisClient,
+		config.Redis.Namespace,
+		config.Tenants,
+		config.ComputeUnits,
+		wsMux,
+		txForwarder,
+		capturer,
 	)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating server: %w", err)
 	}
 
+	srv.reloader, err = newReloader(srv, config, backendsByName, rpcRequestSemaphore, redisClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	if config.Metrics.Enabled {
 		addr := fmt.Sprintf("%s:%d", config.Metrics.Host, config.Metrics.Port)
 		log.Info("starting metrics server", "addr", addr)
@@ -307,53 +308,17 @@ func Start(config *Config) (*Server, func(), error) {
 		bgcfg := config.BackendGroups[bgName]
 		if bgcfg.ConsensusAware {
 			log.Info("creating poller for consensus aware backend_group", "name", bgName)
-
-			copts := make([]ConsensusOpt, 0)
-
-			if bgcfg.ConsensusAsyncHandler == "noop" {
-				copts = append(copts, WithAsyncHandler(NewNoopAsyncHandler()))
-			}
-			if bgcfg.ConsensusBanPeriod > 0 {
-				copts = append(copts, WithBanPeriod(time.Duration(bgcfg.ConsensusBanPeriod)))
-			}
-			if bgcfg.ConsensusMaxUpdateThreshold > 0 {
-				copts = append(copts, WithMaxUpdateThreshold(time.Duration(bgcfg.ConsensusMaxUpdateThreshold)))
-			}
-			if bgcfg.ConsensusMaxBlockLag > 0 {
-				copts = append(copts, WithMaxBlockLag(bgcfg.ConsensusMaxBlockLag))
-			}
-			if bgcfg.ConsensusMinPeerCount > 0 {
-				copts = append(copts, WithMinPeerCount(uint64(bgcfg.ConsensusMinPeerCount)))
-			}
-			if bgcfg.ConsensusMaxBlockRange > 0 {
-				copts = append(copts, WithMaxBlockRange(bgcfg.ConsensusMaxBlockRange))
-			}
-
-			var tracker ConsensusTracker
-			if bgcfg.ConsensusHA {
-				if redisClient == nil {
-					log.Crit("cant start - consensus high availability requires redis")
-				}
-				topts := make([]RedisConsensusTrackerOpt, 0)
-				if bgcfg.ConsensusHALockPeriod > 0 {
-					topts = append(topts, WithLockPeriod(time.Duration(bgcfg.ConsensusHALockPeriod)))
-				}
-				if bgcfg.ConsensusHAHeartbeatInterval > 0 {
-					topts = append(topts, WithLockPeriod(time.Duration(bgcfg.ConsensusHAHeartbeatInterval)))
-				}
-				tracker = NewRedisConsensusTracker(context.Background(), redisClient, bg, bg.Name, topts...)
-				copts = append(copts, WithTracker(tracker))
-			}
-
-			cp := NewConsensusPoller(bg, copts...)
-			bg.Consensus = cp
-
-			if bgcfg.ConsensusHA {
-				tracker.(*RedisConsensusTracker).Init()
-			}
+			// the server is already serving the group
+			backends, weightedRouting, _ := bg.state()
+			bg.update(backends, weightedRouting, configureConsensusPoller(bg, bgcfg, backends, redisClient, nil))
 		}
 	}
 
+	// started once the consensus of the ws backend group is tracked
+	if wsMux != nil {
+		wsMux.Start()
+	}
+
 	<-errTimer.C
 	log.Info("started proxyd")
 
@@ -366,6 +331,110 @@ func Start(config *Config) (*Server, func(), error) {
 	return srv, shutdownFunc, nil
 }
 
+// configureBackend creates the backend from its config and the options shared by every backend
+func configureBackend(name string, cfg *BackendConfig, backendOptions BackendOptions, rpcRequestSemaphore *semaphore.Weighted) (*Backend, error) {
+	opts := make([]BackendOpt, 0)
+
+	rpcURL, err := ReadFromEnvOrConfig(cfg.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	wsURL, err := ReadFromEnvOrConfig(cfg.WSURL)
+	if err != nil {
+		return nil, err
+	}
+	if rpcURL == "" {
+		return nil, fmt.Errorf("must define an RPC URL for backend %s", name)
+	}
+
+	if backendOptions.ResponseTimeoutSeconds != 0 {
+		timeout := secondsToDuration(backendOptions.ResponseTimeoutSeconds)
+		opts = append(opts, WithTimeout(timeout))
+	}
+	if backendOptions.MaxRetries != 0 {
+		opts = append(opts, WithMaxRetries(backendOptions.MaxRetries))
+	}
+	if backendOptions.MaxResponseSizeBytes != 0 {
+		opts = append(opts, WithMaxResponseSize(backendOptions.MaxResponseSizeBytes))
+	}
+	if backendOptions.OutOfServiceSeconds != 0 {
+		opts = append(opts, WithOutOfServiceDuration(secondsToDuration(backendOptions.OutOfServiceSeconds)))
+	}
+	if backendOptions.MaxDegradedLatencyThreshold > 0 {
+		opts = append(opts, WithMaxDegradedLatencyThreshold(time.Duration(backendOptions.MaxDegradedLatencyThreshold)))
+	}
+	if backendOptions.MaxLatencyThreshold > 0 {
+		opts = append(opts, WithMaxLatencyThreshold(time.Duration(backendOptions.MaxLatencyThreshold)))
+	}
+	if backendOptions.MaxErrorRateThreshold > 0 {
+		opts = append(opts, WithMaxErrorRateThreshold(backendOptions.MaxErrorRateThreshold))
+	}
+	if cfg.MaxRPS != 0 {
+		opts = append(opts, WithMaxRPS(cfg.MaxRPS))
+	}
+	if cfg.MaxWSConns != 0 {
+		opts = append(opts, WithMaxWSConns(cfg.MaxWSConns))
+	}
+	if cfg.Password != "" {
+		passwordVal, err := ReadFromEnvOrConfig(cfg.Password)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithBasicAuth(cfg.Username, passwordVal))
+	}
+
+	headers := map[string]string{}
+	for headerName, headerValue := range cfg.Headers {
+		headerValue, err := ReadFromEnvOrConfig(headerValue)
+		if err != nil {
+			return nil, err
+		}
+
+		headers[headerName] = headerValue
+	}
+	opts = append(opts, WithHeaders(headers))
+
+	tlsConfig, err := configureBackendTLS(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		log.Info("using custom TLS config for backend", "name", name)
+		opts = append(opts, WithTLSConfig(tlsConfig))
+	}
+	if cfg.StripTrailingXFF {
+		opts = append(opts, WithStrippedTrailingXFF())
+	}
+	opts = append(opts, WithProxydIP(os.Getenv("PROXYD_IP")))
+	opts = append(opts, WithConsensusSkipPeerCountCheck(cfg.ConsensusSkipPeerCountCheck))
+	opts = append(opts, WithConsensusForcedCandidate(cfg.ConsensusForcedCandidate))
+	opts = append(opts, WithWeight(cfg.Weight))
+
+	receiptsTarget, err := ReadFromEnvOrConfig(cfg.ConsensusReceiptsTarget)
+	if err != nil {
+		return nil, err
+	}
+	receiptsTarget, err = validateReceiptsTarget(receiptsTarget)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, WithConsensusReceiptTarget(receiptsTarget))
+
+	return NewBackend(name, rpcURL, wsURL, rpcRequestSemaphore, opts...), nil
+}
+
+func validateSenderRateLimit(cfg SenderRateLimitConfig) error {
+	if cfg.Enabled {
+		if cfg.Limit <= 0 {
+			return errors.New("limit in sender_rate_limit must be > 0")
+		}
+		if time.Duration(cfg.Interval) < time.Second {
+			return errors.New("interval in sender_rate_limit must be >= 1s")
+		}
+	}
+	return nil
+}
+
 func validateReceiptsTarget(val string) (string, error) {
 	if val == "" {
 		val = ReceiptsTargetDebugGetRawReceipts
@@ -405,3 +474,56 @@ func configureBackendTLS(cfg *BackendConfig) (*tls.Config, error) {
 
 	return tlsConfig, nil
 }
+
+// configureConsensusPoller creates the poller of the backends of the group, carrying over the state
+// of the previous poller of the group if any
+func configureConsensusPoller(bg *BackendGroup, bgcfg *BackendGroupConfig, backends []*Backend, redisClient *redis.Client, prev *ConsensusPoller) *ConsensusPoller {
+	copts := []ConsensusOpt{WithBackends(backends)}
+
+	if bgcfg.ConsensusAsyncHandler == "noop" {
+		copts = append(copts, WithAsyncHandler(NewNoopAsyncHandler()))
+	}
+	if bgcfg.ConsensusBanPeriod > 0 {
+		copts = append(copts, WithBanPeriod(time.Duration(bgcfg.ConsensusBanPeriod)))
+	}
+	if bgcfg.ConsensusMaxUpdateThreshold > 0 {
+		copts = append(copts, WithMaxUpdateThreshold(time.Duration(bgcfg.ConsensusMaxUpdateThreshold)))
+	}
+	if bgcfg.ConsensusMaxBlockLag > 0 {
+		copts = append(copts, WithMaxBlockLag(bgcfg.ConsensusMaxBlockLag))
+	}
+	if bgcfg.ConsensusMinPeerCount > 0 {
+		copts = append(copts, WithMinPeerCount(uint64(bgcfg.ConsensusMinPeerCount)))
+	}
+	if bgcfg.ConsensusMaxBlockRange > 0 {
+		copts = append(copts, WithMaxBlockRange(bgcfg.ConsensusMaxBlockRange))
+	}
+
+	if prev != nil {
+		copts = append(copts, WithPreviousState(prev))
+		return NewConsensusPoller(bg, copts...)
+	}
+
+	var tracker ConsensusTracker
+	if bgcfg.ConsensusHA {
+		if redisClient == nil {
+			log.Crit("cant start - consensus high availability requires redis")
+		}
+		topts := make([]RedisConsensusTrackerOpt, 0)
+		if bgcfg.ConsensusHALockPeriod > 0 {
+			topts = append(topts, WithLockPeriod(time.Duration(bgcfg.ConsensusHALockPeriod)))
+		}
+		if bgcfg.ConsensusHAHeartbeatInterval > 0 {
+			topts = append(topts, WithLockPeriod(time.Duration(bgcfg.ConsensusHAHeartbeatInterval)))
+		}
+		tracker = NewRedisConsensusTracker(context.Background(), redisClient, bg, bg.Name, topts...)
+		copts = append(copts, WithTracker(tracker))
+	}
+
+	cp := NewConsensusPoller(bg, copts...)
+
+	if bgcfg.ConsensusHA {
+		tracker.(*RedisConsensusTracker).Init()
+	}
+	return cp
+}
@@ -0,0 +1,336 @@
+package proxyd
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/semaphore"
+)
+
+const maxReloadBodySize = 10 * 1024 * 1024
+
+// reloader applies the backends, backend groups, rate limits, method mappings and tx forwarding of a new
+// config to the running server. Backends whose config is unchanged, other than their weight, are kept along
+// with their health and connections, and consensus pollers carry over the state of the backends they kept.
+type reloader struct {
+	mtx sync.Mutex
+	srv *Server
+
+	config              *Config
+	backends            map[string]*Backend
+	rpcRequestSemaphore *semaphore.Weighted
+	redisClient         *redis.Client
+	adminToken          string
+}
+
+func newReloader(srv *Server, config *Config, backends map[string]*Backend, rpcRequestSemaphore *semaphore.Weighted, redisClient *redis.Client) (*reloader, error) {
+	adminToken, err := ReadFromEnvOrConfig(config.Server.AdminToken)
+	if err != nil {
+		return nil, err
+	}
+	return &reloader{
+		srv:                 srv,
+		config:              config,
+		backends:            backends,
+		rpcRequestSemaphore: rpcRequestSemaphore,
+		redisClient:         redisClient,
+		adminToken:          adminToken,
+	}, nil
+}
+
+// Reload applies the new config to the server. The config is rejected as a whole, leaving the server
+// unchanged, if it is invalid or changes sections that require a restart.
+func (s *Server) Reload(config *Config) error {
+	if s.reloader == nil {
+		return errors.New("server does not support config reloads")
+	}
+	if err := s.reloader.reload(config); err != nil {
+		log.Error("rejected config reload", "err", err)
+		RecordConfigReload("rejected")
+		return err
+	}
+	RecordConfigReload("applied")
+	return nil
+}
+
+// HandleReload reloads the TOML config of the request body
+func (s *Server) HandleReload(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.reloader.adminToken)) != 1 {
+		log.Info("blocked unauthorized config reload")
+		httpResponseCodesTotal.WithLabelValues("401").Inc()
+		w.WriteHeader(401)
+		return
+	}
+
+	body, err := io.ReadAll(LimitReader(r.Body, maxReloadBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	config := new(Config)
+	if _, err := toml.Decode(string(body), config); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding config: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := s.Reload(config); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, _ = w.Write([]byte("OK"))
+}
+
+func (r *reloader) reload(config *Config) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if err := checkRestartRequired(r.config, config); err != nil {
+		return err
+	}
+	if len(config.Backends) == 0 {
+		return errors.New("must define at least one backend")
+	}
+	if len(config.BackendGroups) == 0 {
+		return errors.New("must define at least one backend group")
+	}
+	if len(config.RPCMethodMappings) == 0 {
+		return errors.New("must define at least one RPC method mapping")
+	}
+	if err := validateSenderRateLimit(config.SenderRateLimit); err != nil {
+		return err
+	}
+
+	// Everything is built and validated before the server is updated, such that an invalid config
+	// leaves the server unchanged
+	var added, replaced, removed []string
+	backends := make(map[string]*Backend)
+	for name, cfg := range config.Backends {
+		if be := r.backends[name]; be != nil && equalBackendConfig(r.config.Backends[name], cfg) {
+			backends[name] = be
+			continue
+		}
+		be, err := configureBackend(name, cfg, config.BackendOptions, r.rpcRequestSemaphore)
+		if err != nil {
+			return err
+		}
+		backends[name] = be
+		if r.backends[name] == nil {
+			added = append(added, name)
+		} else {
+			replaced = append(replaced, name)
+		}
+	}
+	for name := range r.backends {
+		if backends[name] == nil {
+			removed = append(removed, name)
+		}
+	}
+
+	current := r.srv.routing.Load()
+	backendGroups := make(map[string]*BackendGroup)
+	groupBackends := make(map[string][]*Backend)
+	for bgName, bgcfg := range config.BackendGroups {
+		members := make([]*Backend, 0)
+		for _, bName := range bgcfg.Backends {
+			if backends[bName] == nil {
+				return fmt.Errorf("backend %s is not defined", bName)
+			}
+			members = append(members, backends[bName])
+		}
+		groupBackends[bgName] = members
+
+		// existing groups are updated in place, as they are referenced by the ws proxying and caching
+		bg := current.backendGroups[bgName]
+		if bg == nil {
+			bg = &BackendGroup{
+				Name:            bgName,
+				Backends:        members,
+				WeightedRouting: bgcfg.WeightedRouting,
+			}
+		}
+		backendGroups[bgName] = bg
+	}
+	if err := validateBackendGroupReferences(config); err != nil {
+		return err
+	}
+
+	var txForwarder *TxForwarder
+	if config.TxForwarding.Enabled {
+		var err error
+		txForwarder, err = NewTxForwarder(config.TxForwarding, backends, backendGroups, backendGroups[config.TxForwarding.NonceBackendGroup])
+		if err != nil {
+			return fmt.Errorf("error creating tx forwarder: %w", err)
+		}
+	}
+	for name, tenant := range config.Tenants {
+		if tenant.PrivateTransactions && (txForwarder == nil || !txForwarder.HasPrivateRoute()) {
+			return fmt.Errorf("tenant %s requires tx forwarding private backends", name)
+		}
+	}
+
+	routing, err := newRoutingState(
+		backendGroups,
+		config.RPCMethodMappings,
+		config.RateLimit,
+		config.SenderRateLimit,
+		newLimiterFactory(config.RateLimit, r.redisClient),
+		txForwarder,
+	)
+	if err != nil {
+		return err
+	}
+	// unchanged rate limits keep their limiters, so that the clients' usage isn't reset
+	if reflect.DeepEqual(r.config.RateLimit, config.RateLimit) {
+		routing.mainLim = current.mainLim
+		routing.overrideLims = current.overrideLims
+	}
+	if reflect.DeepEqual(r.config.SenderRateLimit, config.SenderRateLimit) {
+		routing.senderLim = current.senderLim
+	}
+
+	// The new config is valid, apply it
+	for name, be := range backends {
+		be.weight.Store(int64(config.Backends[name].Weight))
+	}
+
+	var replacedPollers []*ConsensusPoller
+	for bgName, bg := range backendGroups {
+		bgcfg := config.BackendGroups[bgName]
+		members := groupBackends[bgName]
+		prevBackends, _, consensus := bg.state()
+
+		if !bgcfg.ConsensusAware {
+			consensus = nil
+		} else if consensus == nil ||
+			!equalBackends(prevBackends, members) ||
+			!equalConsensusConfig(r.config.BackendGroups[bgName], bgcfg) {
+			log.Info("creating poller for consensus aware backend_group", "name", bgName)
+			consensus = configureConsensusPoller(bg, bgcfg, members, r.redisClient, consensus)
+		}
+
+		if prev := bg.update(members, bgcfg.WeightedRouting, consensus); prev != nil && prev != consensus {
+			replacedPollers = append(replacedPollers, prev)
+		}
+	}
+
+	r.srv.routing.Store(routing)
+
+	for _, cp := range replacedPollers {
+		cp.Shutdown()
+	}
+	for bgName, bg := range current.backendGroups {
+		if backendGroups[bgName] == nil {
+			bg.Shutdown()
+		}
+	}
+	r.config = config
+	r.backends = backends
+
+	sort.Strings(added)
+	sort.Strings(replaced)
+	sort.Strings(removed)
+	log.Info("reloaded config",
+		"added_backends", added,
+		"replaced_backends", replaced,
+		"removed_backends", removed)
+	return nil
+}
+
+// checkRestartRequired returns an error if the new config changes sections that can't be reloaded
+func checkRestartRequired(prev *Config, next *Config) error {
+	// the error message is a shared global, and the limiters of tenants are bound to the limiter store
+	if prev.RateLimit.UseRedis != next.RateLimit.UseRedis || prev.RateLimit.ErrorMessage != next.RateLimit.ErrorMessage {
+		return errors.New("changes to rate_limit.use_redis or rate_limit.error_message require a restart")
+	}
+	for name, bgcfg := range next.BackendGroups {
+		prevBgcfg := prev.BackendGroups[name]
+		if prevBgcfg == nil {
+			continue
+		}
+		if prevBgcfg.ConsensusHA != bgcfg.ConsensusHA ||
+			prevBgcfg.ConsensusHAHeartbeatInterval != bgcfg.ConsensusHAHeartbeatInterval ||
+			prevBgcfg.ConsensusHALockPeriod != bgcfg.ConsensusHALockPeriod ||
+			prevBgcfg.ConsensusAsyncHandler != bgcfg.ConsensusAsyncHandler {
+			return fmt.Errorf("changes to the consensus tracker of backend group %s require a restart", name)
+		}
+	}
+
+	prevCopy, nextCopy := *prev, *next
+	for _, cfg := range []*Config{&prevCopy, &nextCopy} {
+		cfg.Backends = nil
+		cfg.BackendGroups = nil
+		cfg.RPCMethodMappings = nil
+		cfg.RateLimit = RateLimitConfig{}
+		cfg.SenderRateLimit = SenderRateLimitConfig{}
+		cfg.TxForwarding = TxForwardingConfig{}
+	}
+	prevVal, nextVal := reflect.ValueOf(prevCopy), reflect.ValueOf(nextCopy)
+	for i := 0; i < prevVal.NumField(); i++ {
+		if !reflect.DeepEqual(prevVal.Field(i).Interface(), nextVal.Field(i).Interface()) {
+			return fmt.Errorf("changes to %s require a restart", prevVal.Type().Field(i).Tag.Get("toml"))
+		}
+	}
+	return nil
+}
+
+// validateBackendGroupReferences checks that the backend groups referenced by the config are defined
+func validateBackendGroupReferences(config *Config) error {
+	if config.WSBackendGroup != "" && config.BackendGroups[config.WSBackendGroup] == nil {
+		return fmt.Errorf("ws backend group %s does not exist", config.WSBackendGroup)
+	}
+	for _, bg := range config.RPCMethodMappings {
+		if config.BackendGroups[bg] == nil {
+			return fmt.Errorf("undefined backend group %s", bg)
+		}
+	}
+	for name, tenant := range config.Tenants {
+		if tenant.BackendGroup != "" && config.BackendGroups[tenant.BackendGroup] == nil {
+			return fmt.Errorf("tenant %s uses undefined backend group %s", name, tenant.BackendGroup)
+		}
+	}
+	if config.TxForwarding.Enabled && config.TxForwarding.NonceBackendGroup != "" &&
+		config.BackendGroups[config.TxForwarding.NonceBackendGroup] == nil {
+		return fmt.Errorf("tx forwarding nonce backend group %s does not exist", config.TxForwarding.NonceBackendGroup)
+	}
+	return nil
+}
+
+// equalBackendConfig returns true if the backends' configs only differ by their weight
+func equalBackendConfig(a *BackendConfig, b *BackendConfig) bool {
+	aCopy, bCopy := *a, *b
+	aCopy.Weight, bCopy.Weight = 0, 0
+	return reflect.DeepEqual(aCopy, bCopy)
+}
+
+// equalConsensusConfig returns true if the groups' configs have the same consensus parameters
+func equalConsensusConfig(a *BackendGroupConfig, b *BackendGroupConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	aCopy, bCopy := *a, *b
+	aCopy.Backends, bCopy.Backends = nil, nil
+	aCopy.WeightedRouting, bCopy.WeightedRouting = false, false
+	return reflect.DeepEqual(aCopy, bCopy)
+}
+
+func equalBackends(a []*Backend, b []*Backend) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -15,9 +15,9 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -50,19 +50,38 @@ const (
 var emptyArrayResponse = json.RawMessage("[]")
 
 type Server struct {
-	BackendGroups          map[string]*BackendGroup
-	wsBackendGroup         *BackendGroup
-	wsMethodWhitelist      *StringSet
+	// BackendGroups are the backend groups configured at startup, which config reloads update in place.
+	// See CurrentBackendGroups for the groups of the current config
+	BackendGroups        map[string]*BackendGroup
+	wsBackendGroup       *BackendGroup
+	wsMux                *WSMultiplexer
+	capturer             *Capturer
+	wsMethodWhitelist    *StringSet
+	maxBodySize          int64
+	enableRequestLog     bool
+	maxRequestBodyLogLen int
+	authenticatedPaths   map[string]string
+	timeout              time.Duration
+	maxUpstreamBatchSize int
+	maxBatchSize         int
+	enableServedByHeader bool
+	upgrader             *websocket.Upgrader
+	routing              atomic.Pointer[routingState]
+	reloader             *reloader
+	tenants              map[string]*Tenant
+	computeUnits         map[string]int
+	rpcServer            *http.Server
+	wsServer             *http.Server
+	cache                RPCCache
+	srvMu                sync.Mutex
+}
+
+// routingState is the routing and rate limiting state of the server, replaced by config reloads.
+// Each request is served by a snapshot of it.
+type routingState struct {
+	backendGroups          map[string]*BackendGroup
 	rpcMethodMappings      map[string]string
-	maxBodySize            int64
-	enableRequestLog       bool
-	maxRequestBodyLogLen   int
-	authenticatedPaths     map[string]string
-	timeout                time.Duration
-	maxUpstreamBatchSize   int
-	maxBatchSize           int
-	enableServedByHeader   bool
-	upgrader               *websocket.Upgrader
+	txForwarder            *TxForwarder
 	mainLim                FrontendRateLimiter
 	overrideLims           map[string]FrontendRateLimiter
 	senderLim              FrontendRateLimiter
@@ -70,65 +89,29 @@ type Server struct {
 	limExemptOrigins       []*regexp.Regexp
 	limExemptUserAgents    []*regexp.Regexp
 	globallyLimitedMethods map[string]bool
-	rpcServer              *http.Server
-	wsServer               *http.Server
-	cache                  RPCCache
-	srvMu                  sync.Mutex
 	rateLimitHeader        string
 }
 
-type limiterFunc func(method string) bool
-
-func NewServer(
-	backendGroups map[string]*BackendGroup,
-	wsBackendGroup *BackendGroup,
-	wsMethodWhitelist *StringSet,
-	rpcMethodMappings map[string]string,
-	maxBodySize int64,
-	authenticatedPaths map[string]string,
-	timeout time.Duration,
-	maxUpstreamBatchSize int,
-	enableServedByHeader bool,
-	cache RPCCache,
-	rateLimitConfig RateLimitConfig,
-	senderRateLimitConfig SenderRateLimitConfig,
-	enableRequestLog bool,
-	maxRequestBodyLogLen int,
-	maxBatchSize int,
-	redisClient *redis.Client,
-) (*Server, error) {
-	if cache == nil {
-		cache = &NoopRPCCache{}
-	}
-
-	if maxBodySize == 0 {
-		maxBodySize = defaultBodySizeLimit
-	}
-
-	if timeout == 0 {
-		timeout = defaultRPCTimeout
-	}
+type limiterFactoryFunc func(dur time.Duration, max int, prefix string) FrontendRateLimiter
 
-	if maxUpstreamBatchSize == 0 {
-		maxUpstreamBatchSize = defaultMaxUpstreamBatchSize
-	}
-
-	if maxBatchSize == 0 {
-		maxBatchSize = DefaultMaxBatchRPCCallsLimit
-	}
-
-	if maxBatchSize > MaxBatchRPCCallsHardLimit {
-		maxBatchSize = MaxBatchRPCCallsHardLimit
-	}
-
-	limiterFactory := func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
+func newLimiterFactory(rateLimitConfig RateLimitConfig, redisClient *redis.Client) limiterFactoryFunc {
+	return func(dur time.Duration, max int, prefix string) FrontendRateLimiter {
 		if rateLimitConfig.UseRedis {
 			return NewRedisFrontendRateLimiter(redisClient, dur, max, prefix)
 		}
 
 		return NewMemoryFrontendRateLimit(dur, max)
 	}
+}
 
+func newRoutingState(
+	backendGroups map[string]*BackendGroup,
+	rpcMethodMappings map[string]string,
+	rateLimitConfig RateLimitConfig,
+	senderRateLimitConfig SenderRateLimitConfig,
+	limiterFactory limiterFactoryFunc,
+	txForwarder *TxForwarder,
+) (*routingState, error) {
 	var mainLim FrontendRateLimiter
 	limExemptOrigins := make([]*regexp.Regexp, 0)
 	limExemptUserAgents := make([]*regexp.Regexp, 0)
@@ -155,11 +138,7 @@ func NewServer(
 	overrideLims := make(map[string]FrontendRateLimiter)
 	globalMethodLims := make(map[string]bool)
 	for method, override := range rateLimitConfig.MethodOverrides {
-		var err error
 		overrideLims[method] = limiterFactory(time.Duration(override.Interval), override.Limit, method)
-		if err != nil {
-			return nil, err
-		}
 
 		if override.Global {
 			globalMethodLims[method] = true
@@ -175,11 +154,92 @@ func NewServer(
 		rateLimitHeader = rateLimitConfig.IPHeaderOverride
 	}
 
-	return &Server{
+	return &routingState{
+		backendGroups:          backendGroups,
+		rpcMethodMappings:      rpcMethodMappings,
+		txForwarder:            txForwarder,
+		mainLim:                mainLim,
+		overrideLims:           overrideLims,
+		senderLim:              senderLim,
+		allowedChainIds:        senderRateLimitConfig.AllowedChainIds,
+		limExemptOrigins:       limExemptOrigins,
+		limExemptUserAgents:    limExemptUserAgents,
+		globallyLimitedMethods: globalMethodLims,
+		rateLimitHeader:        rateLimitHeader,
+	}, nil
+}
+
+type limiterFunc func(method string) bool
+
+func NewServer(
+	backendGroups map[string]*BackendGroup,
+	wsBackendGroup *BackendGroup,
+	wsMethodWhitelist *StringSet,
+	rpcMethodMappings map[string]string,
+	maxBodySize int64,
+	authenticatedPaths map[string]string,
+	timeout time.Duration,
+	maxUpstreamBatchSize int,
+	enableServedByHeader bool,
+	cache RPCCache,
+	rateLimitConfig RateLimitConfig,
+	senderRateLimitConfig SenderRateLimitConfig,
+	enableRequestLog bool,
+	maxRequestBodyLogLen int,
+	maxBatchSize int,
+	redisClient *redis.Client,
+	redisNamespace string,
+	tenantsConfig TenantsConfig,
+	computeUnits map[string]int,
+	wsMux *WSMultiplexer,
+	txForwarder *TxForwarder,
+	capturer *Capturer,
+) (*Server, error) {
+	if cache == nil {
+		cache = &NoopRPCCache{}
+	}
+
+	if maxBodySize == 0 {
+		maxBodySize = defaultBodySizeLimit
+	}
+
+	if timeout == 0 {
+		timeout = defaultRPCTimeout
+	}
+
+	if maxUpstreamBatchSize == 0 {
+		maxUpstreamBatchSize = defaultMaxUpstreamBatchSize
+	}
+
+	if maxBatchSize == 0 {
+		maxBatchSize = DefaultMaxBatchRPCCallsLimit
+	}
+
+	if maxBatchSize > MaxBatchRPCCallsHardLimit {
+		maxBatchSize = MaxBatchRPCCallsHardLimit
+	}
+
+	limiterFactory := newLimiterFactory(rateLimitConfig, redisClient)
+	routing, err := newRoutingState(backendGroups, rpcMethodMappings, rateLimitConfig, senderRateLimitConfig, limiterFactory, txForwarder)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenantUsage TenantUsageTracker
+	if redisClient != nil {
+		tenantUsage = NewRedisTenantUsageTracker(redisClient, redisNamespace)
+	} else {
+		tenantUsage = NewMemoryTenantUsageTracker()
+	}
+	tenants := make(map[string]*Tenant)
+	for name, tenantConfig := range tenantsConfig {
+		tenants[name] = NewTenant(name, tenantConfig, limiterFactory, tenantUsage)
+	}
+
+	srv := &Server{
 		BackendGroups:        backendGroups,
 		wsBackendGroup:       wsBackendGroup,
 		wsMethodWhitelist:    wsMethodWhitelist,
-		rpcMethodMappings:    rpcMethodMappings,
 		maxBodySize:          maxBodySize,
 		authenticatedPaths:   authenticatedPaths,
 		timeout:              timeout,
@@ -192,21 +252,27 @@ func NewServer(
 		upgrader: &websocket.Upgrader{
 			HandshakeTimeout: defaultWSHandshakeTimeout,
 		},
-		mainLim:                mainLim,
-		overrideLims:           overrideLims,
-		globallyLimitedMethods: globalMethodLims,
-		senderLim:              senderLim,
-		allowedChainIds:        senderRateLimitConfig.AllowedChainIds,
-		limExemptOrigins:       limExemptOrigins,
-		limExemptUserAgents:    limExemptUserAgents,
-		rateLimitHeader:        rateLimitHeader,
-	}, nil
+		tenants:      tenants,
+		wsMux:        wsMux,
+		capturer:     capturer,
+		computeUnits: computeUnits,
+	}
+	srv.routing.Store(routing)
+	return srv, nil
+}
+
+// CurrentBackendGroups returns the backend groups of the current config
+func (s *Server) CurrentBackendGroups() map[string]*BackendGroup {
+	return s.routing.Load().backendGroups
 }
 
 func (s *Server) RPCListenAndServe(host string, port int) error {
 	s.srvMu.Lock()
 	hdlr := mux.NewRouter()
 	hdlr.HandleFunc("/healthz", s.HandleHealthz).Methods("GET")
+	if s.reloader != nil && s.reloader.adminToken != "" {
+		hdlr.HandleFunc("/admin/reload", s.HandleReload).Methods("POST")
+	}
 	hdlr.HandleFunc("/", s.HandleRPC).Methods("POST")
 	hdlr.HandleFunc("/{authorization}", s.HandleRPC).Methods("POST")
 	c := cors.New(cors.Options{
@@ -249,7 +315,13 @@ func (s *Server) Shutdown() {
 	if s.wsServer != nil {
 		_ = s.wsServer.Shutdown(context.Background())
 	}
-	for _, bg := range s.BackendGroups {
+	if s.wsMux != nil {
+		s.wsMux.Shutdown()
+	}
+	if s.capturer != nil {
+		s.capturer.Shutdown()
+	}
+	for _, bg := range s.routing.Load().backendGroups {
 		bg.Shutdown()
 	}
 }
@@ -259,7 +331,8 @@ func (s *Server) HandleHealthz(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
-	ctx := s.populateContext(w, r)
+	routing := s.routing.Load()
+	ctx := s.populateContext(w, r, routing)
 	if ctx == nil {
 		return
 	}
@@ -271,8 +344,8 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 	userAgent := r.Header.Get("User-Agent")
 	// Use XFF in context since it will automatically be replaced by the remote IP
 	xff := stripXFF(GetXForwardedFor(ctx))
-	isUnlimitedOrigin := s.isUnlimitedOrigin(origin)
-	isUnlimitedUserAgent := s.isUnlimitedUserAgent(userAgent)
+	isUnlimitedOrigin := routing.isUnlimitedOrigin(origin)
+	isUnlimitedUserAgent := routing.isUnlimitedUserAgent(userAgent)
 
 	if xff == "" {
 		writeRPCError(ctx, w, nil, ErrInvalidRequest("request does not include a remote IP"))
@@ -280,16 +353,16 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 	}
 
 	isLimited := func(method string) bool {
-		isGloballyLimitedMethod := s.isGlobalLimit(method)
+		isGloballyLimitedMethod := routing.isGlobalLimit(method)
 		if !isGloballyLimitedMethod && (isUnlimitedOrigin || isUnlimitedUserAgent) {
 			return false
 		}
 
 		var lim FrontendRateLimiter
 		if method == "" {
-			lim = s.mainLim
+			lim = routing.mainLim
 		} else {
-			lim = s.overrideLims[method]
+			lim = routing.overrideLims[method]
 		}
 
 		if lim == nil {
@@ -304,7 +377,9 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 		return !ok
 	}
 
-	if isLimited("") {
+	// Tenants with their own rate limit are not limited per remote IP
+	tenant := s.tenants[GetAuthCtx(ctx)]
+	if (tenant == nil || !tenant.HasRPSLimit()) && isLimited("") {
 		RecordRPCError(ctx, BackendProxyd, "unknown", ErrOverRateLimit)
 		log.Warn(
 			"rate limited request",
@@ -340,6 +415,7 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	RecordRequestPayloadSize(ctx, len(body))
+	capture := s.capturer != nil && s.capturer.Sample()
 
 	if s.enableRequestLog {
 		log.Info("Raw RPC request",
@@ -371,7 +447,7 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		batchRes, batchContainsCached, servedBy, err := s.handleBatchRPC(ctx, reqs, isLimited, true)
+		batchRes, batchContainsCached, servedBy, err := s.handleBatchRPC(ctx, routing, reqs, isLimited, true)
 		if err == context.DeadlineExceeded {
 			writeRPCError(ctx, w, nil, ErrGatewayTimeout)
 			return
@@ -389,12 +465,15 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("x-served-by", servedBy)
 		}
 		setCacheHeader(w, batchContainsCached)
+		if capture {
+			s.capturer.Capture(ctx, xff, servedBy, body, batchRes)
+		}
 		writeBatchRPCRes(ctx, w, batchRes)
 		return
 	}
 
 	rawBody := json.RawMessage(body)
-	backendRes, cached, servedBy, err := s.handleBatchRPC(ctx, []json.RawMessage{rawBody}, isLimited, false)
+	backendRes, cached, servedBy, err := s.handleBatchRPC(ctx, routing, []json.RawMessage{rawBody}, isLimited, false)
 	if err != nil {
 		if errors.Is(err, ErrConsensusGetReceiptsCantBeBatched) ||
 			errors.Is(err, ErrConsensusGetReceiptsInvalidTarget) {
@@ -408,10 +487,13 @@ func (s *Server) HandleRPC(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("x-served-by", servedBy)
 	}
 	setCacheHeader(w, cached)
+	if capture {
+		s.capturer.Capture(ctx, xff, servedBy, body, backendRes[0])
+	}
 	writeRPCRes(ctx, w, backendRes[0])
 }
 
-func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isLimited limiterFunc, isBatch bool) ([]*RPCRes, bool, string, error) {
+func (s *Server) handleBatchRPC(ctx context.Context, routing *routingState, reqs []json.RawMessage, isLimited limiterFunc, isBatch bool) ([]*RPCRes, bool, string, error) {
 	// A request set is transformed into groups of batches.
 	// Each batch group maps to a forwarded JSON-RPC batch request (subject to maxUpstreamBatchSize constraints)
 	// A groupID is used to decouple Requests that have duplicate ID so they're not part of the same batch that's
@@ -426,6 +508,9 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 	responses := make([]*RPCRes, len(reqs))
 	batches := make(map[batchGroup][]batchElem)
 	ids := make(map[string]int, len(reqs))
+	servedBy := make(map[string]bool, 0)
+	tenant := s.tenants[GetAuthCtx(ctx)]
+	var txReqs []batchElem
 
 	for i := range reqs {
 		parsedReq, err := ParseRPCReq(reqs[i])
@@ -447,8 +532,14 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			continue
 		}
 
-		group := s.rpcMethodMappings[parsedReq.Method]
+		group := routing.rpcMethodMappings[parsedReq.Method]
+		if tenant != nil {
+			group = tenant.MethodGroup(parsedReq.Method, group)
+		}
 		if group == "" {
+			if tenant != nil {
+				RecordTenantRejectedRequest(tenant.Name, "method_not_allowed")
+			}
 			// use unknown below to prevent DOS vector that fills up memory
 			// with arbitrary method names.
 			log.Info(
@@ -466,7 +557,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 		// NOTE: eventually, this should apply to all batch requests. However,
 		// since we don't have data right now on the size of each batch, we
 		// only apply this to the methods that have an additional rate limit.
-		if _, ok := s.overrideLims[parsedReq.Method]; ok && isLimited(parsedReq.Method) {
+		if _, ok := routing.overrideLims[parsedReq.Method]; ok && isLimited(parsedReq.Method) {
 			log.Info(
 				"rate limited specific RPC",
 				"source", "rpc",
@@ -478,17 +569,38 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			continue
 		}
 
+		if tenant != nil {
+			if err := s.takeTenantLimits(ctx, tenant, parsedReq); err != nil {
+				RecordRPCError(ctx, BackendProxyd, parsedReq.Method, err)
+				responses[i] = NewRPCErrorRes(parsedReq.ID, err)
+				continue
+			}
+		}
+
 		// Apply a sender-based rate limit if it is enabled. Note that sender-based rate
 		// limits apply regardless of origin or user-agent. As such, they don't use the
 		// isLimited method.
-		if parsedReq.Method == "eth_sendRawTransaction" && s.senderLim != nil {
-			if err := s.rateLimitSender(ctx, parsedReq); err != nil {
+		senderLim := routing.senderLim
+		if tenant != nil && tenant.senderLim != nil {
+			senderLim = tenant.senderLim
+		}
+		if parsedReq.Method == "eth_sendRawTransaction" && senderLim != nil {
+			if err := rateLimitSender(ctx, senderLim, routing.allowedChainIds, parsedReq); err != nil {
+				if tenant != nil && errors.Is(err, ErrOverSenderRateLimit) {
+					RecordTenantRejectedRequest(tenant.Name, "sender_rate_limit")
+				}
 				RecordRPCError(ctx, BackendProxyd, parsedReq.Method, err)
 				responses[i] = NewRPCErrorRes(parsedReq.ID, err)
 				continue
 			}
 		}
 
+		// Transactions are validated and forwarded to the backends of their route
+		if parsedReq.Method == "eth_sendRawTransaction" && routing.txForwarder != nil {
+			txReqs = append(txReqs, batchElem{parsedReq, i})
+			continue
+		}
+
 		id := string(parsedReq.ID)
 		// If this is a duplicate Request ID, move the Request to a new batchGroup
 		ids[id]++
@@ -497,13 +609,35 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 		batches[batchGroup] = append(batches[batchGroup], batchElem{parsedReq, i})
 	}
 
-	servedBy := make(map[string]bool, 0)
+	// The transactions of the batch are forwarded concurrently
+	if len(txReqs) > 0 {
+		private := tenant != nil && tenant.privateTransactions
+		txBackends := make([]string, len(txReqs))
+		var wg sync.WaitGroup
+		for j, elem := range txReqs {
+			wg.Add(1)
+			go func(j int, elem batchElem) {
+				defer wg.Done()
+				responses[elem.Index], txBackends[j] = routing.txForwarder.Forward(ctx, elem.Req, private)
+			}(j, elem)
+		}
+		wg.Wait()
+
+		for j, elem := range txReqs {
+			if res := responses[elem.Index]; res.IsError() {
+				RecordRPCError(ctx, BackendProxyd, elem.Req.Method, res.Error)
+			} else {
+				servedBy[txBackends[j]] = true
+			}
+		}
+	}
+
 	var cached bool
 	for group, batch := range batches {
 		var cacheMisses []batchElem
 
 		for _, req := range batch {
-			backendRes, _ := s.cache.GetRPC(ctx, req.Req)
+			backendRes, _ := s.cache.GetRPC(ctx, routing.backendGroups[group.backendGroup], req.Req)
 			if backendRes != nil {
 				responses[req.Index] = backendRes
 				cached = true
@@ -528,7 +662,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 			start := i * s.maxUpstreamBatchSize
 			end := int(math.Min(float64(start+s.maxUpstreamBatchSize), float64(len(cacheMisses))))
 			elems := cacheMisses[start:end]
-			res, sb, err := s.BackendGroups[group.backendGroup].Forward(ctx, createBatchRequest(elems), isBatch)
+			res, sb, err := routing.backendGroups[group.backendGroup].Forward(ctx, createBatchRequest(elems), isBatch)
 			servedBy[sb] = true
 			if err != nil {
 				if errors.Is(err, ErrConsensusGetReceiptsCantBeBatched) ||
@@ -553,7 +687,7 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 
 				// TODO(inphi): batch put these
 				if res[i].Error == nil && res[i].Result != nil {
-					if err := s.cache.PutRPC(ctx, elems[i].Req, res[i]); err != nil {
+					if err := s.cache.PutRPC(ctx, routing.backendGroups[group.backendGroup], elems[i].Req, res[i]); err != nil {
 						log.Warn(
 							"cache put error",
 							"req_id", GetReqID(ctx),
@@ -577,11 +711,20 @@ func (s *Server) handleBatchRPC(ctx context.Context, reqs []json.RawMessage, isL
 }
 
 func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
-	ctx := s.populateContext(w, r)
+	ctx := s.populateContext(w, r, s.routing.Load())
 	if ctx == nil {
 		return
 	}
 
+	// The policies of tenants are only enforced over HTTP, so their connections are rejected
+	// rather than served without them
+	if tenant := s.tenants[GetAuthCtx(ctx)]; tenant != nil {
+		log.Info("blocked WS connection of tenant", "tenant", tenant.Name, "req_id", GetReqID(ctx))
+		RecordTenantRejectedRequest(tenant.Name, "websocket")
+		writeRPCError(ctx, w, nil, ErrTenantWSNotSupported)
+		return
+	}
+
 	log.Info("received WS connection", "req_id", GetReqID(ctx))
 
 	clientConn, err := s.upgrader.Upgrade(w, r, nil)
@@ -591,6 +734,18 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 	}
 	clientConn.SetReadLimit(s.maxBodySize)
 
+	if s.wsMux != nil {
+		activeClientWsConnsGauge.WithLabelValues(GetAuthCtx(ctx)).Inc()
+		go func() {
+			if err := s.wsMux.ServeClient(ctx, clientConn); err != nil {
+				log.Info("ws client connection closed", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx), "err", err)
+			}
+			activeClientWsConnsGauge.WithLabelValues(GetAuthCtx(ctx)).Dec()
+		}()
+		log.Info("accepted multiplexed WS connection", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx))
+		return
+	}
+
 	proxier, err := s.wsBackendGroup.ProxyWS(ctx, clientConn, s.wsMethodWhitelist)
 	if err != nil {
 		if errors.Is(err, ErrNoBackends) {
@@ -613,10 +768,10 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 	log.Info("accepted WS connection", "auth", GetAuthCtx(ctx), "req_id", GetReqID(ctx))
 }
 
-func (s *Server) populateContext(w http.ResponseWriter, r *http.Request) context.Context {
+func (s *Server) populateContext(w http.ResponseWriter, r *http.Request, routing *routingState) context.Context {
 	vars := mux.Vars(r)
 	authorization := vars["authorization"]
-	xff := r.Header.Get(s.rateLimitHeader)
+	xff := r.Header.Get(routing.rateLimitHeader)
 	if xff == "" {
 		ipPort := strings.Split(r.RemoteAddr, ":")
 		if len(ipPort) == 2 {
@@ -651,8 +806,8 @@ func randStr(l int) string {
 	return hex.EncodeToString(b)
 }
 
-func (s *Server) isUnlimitedOrigin(origin string) bool {
-	for _, pat := range s.limExemptOrigins {
+func (r *routingState) isUnlimitedOrigin(origin string) bool {
+	for _, pat := range r.limExemptOrigins {
 		if pat.MatchString(origin) {
 			return true
 		}
@@ -661,8 +816,8 @@ func (s *Server) isUnlimitedOrigin(origin string) bool {
 	return false
 }
 
-func (s *Server) isUnlimitedUserAgent(origin string) bool {
-	for _, pat := range s.limExemptUserAgents {
+func (r *routingState) isUnlimitedUserAgent(origin string) bool {
+	for _, pat := range r.limExemptUserAgents {
 		if pat.MatchString(origin) {
 			return true
 		}
@@ -670,40 +825,60 @@ func (s *Server) isUnlimitedUserAgent(origin string) bool {
 	return false
 }
 
-func (s *Server) isGlobalLimit(method string) bool {
-	return s.globallyLimitedMethods[method]
+func (r *routingState) isGlobalLimit(method string) bool {
+	return r.globallyLimitedMethods[method]
 }
 
-func (s *Server) rateLimitSender(ctx context.Context, req *RPCReq) error {
-	var params []string
-	if err := json.Unmarshal(req.Params, &params); err != nil {
-		log.Debug("error unmarshaling raw transaction params", "err", err, "req_Id", GetReqID(ctx))
-		return ErrParseErr
+// takeTenantLimits takes the request from the rate limit and daily compute unit quota of the tenant
+func (s *Server) takeTenantLimits(ctx context.Context, tenant *Tenant, req *RPCReq) error {
+	ok, err := tenant.TakeRPS(ctx)
+	if err != nil {
+		log.Warn("error taking tenant rate limit", "tenant", tenant.Name, "req_id", GetReqID(ctx), "err", err)
 	}
-
-	if len(params) != 1 {
-		log.Debug("raw transaction request has invalid number of params", "req_id", GetReqID(ctx))
-		// The error below is identical to the one Geth responds with.
-		return ErrInvalidParams("missing value for required argument 0")
+	if err != nil || !ok {
+		log.Info(
+			"rate limited tenant RPC",
+			"source", "rpc",
+			"req_id", GetReqID(ctx),
+			"tenant", tenant.Name,
+			"method", req.Method,
+		)
+		RecordTenantRejectedRequest(tenant.Name, "rate_limit")
+		return ErrOverRateLimit
 	}
 
-	var data hexutil.Bytes
-	if err := data.UnmarshalText([]byte(params[0])); err != nil {
-		log.Debug("error decoding raw tx data", "err", err, "req_id", GetReqID(ctx))
-		// Geth returns the raw error from UnmarshalText.
-		return ErrInvalidParams(err.Error())
+	units := defaultComputeUnits
+	if methodUnits, ok := s.computeUnits[req.Method]; ok {
+		units = methodUnits
+	}
+	ok, err = tenant.TakeComputeUnits(ctx, req.Method, int64(units))
+	if err != nil {
+		log.Error("error taking tenant compute units", "tenant", tenant.Name, "req_id", GetReqID(ctx), "err", err)
+		return ErrInternal
+	}
+	if !ok {
+		log.Info(
+			"tenant over compute unit quota",
+			"source", "rpc",
+			"req_id", GetReqID(ctx),
+			"tenant", tenant.Name,
+			"method", req.Method,
+		)
+		RecordTenantRejectedRequest(tenant.Name, "compute_unit_quota")
+		return ErrOverComputeUnitQuota
 	}
+	return nil
+}
 
-	// Inflates a types.Transaction object from the transaction's raw bytes.
-	tx := new(types.Transaction)
-	if err := tx.UnmarshalBinary(data); err != nil {
-		log.Debug("could not unmarshal transaction", "err", err, "req_id", GetReqID(ctx))
-		return ErrInvalidParams(err.Error())
+func rateLimitSender(ctx context.Context, senderLim FrontendRateLimiter, allowedChainIds []*big.Int, req *RPCReq) error {
+	tx, err := decodeRawTransaction(ctx, req)
+	if err != nil {
+		return err
 	}
 
 	// Check if the transaction is for the expected chain,
 	// otherwise reject before rate limiting to avoid replay attacks.
-	if !s.isAllowedChainId(tx.ChainId()) {
+	if !isAllowedChainId(allowedChainIds, tx.ChainId()) {
 		log.Debug("chain id is not allowed", "req_id", GetReqID(ctx))
 		return txpool.ErrInvalidSender
 	}
@@ -715,7 +890,7 @@ func (s *Server) rateLimitSender(ctx context.Context, req *RPCReq) error {
 		log.Debug("could not get message from transaction", "err", err, "req_id", GetReqID(ctx))
 		return ErrInvalidParams(err.Error())
 	}
-	ok, err := s.senderLim.Take(ctx, fmt.Sprintf("%s:%d", msg.From.Hex(), tx.Nonce()))
+	ok, err := senderLim.Take(ctx, fmt.Sprintf("%s:%d", msg.From.Hex(), tx.Nonce()))
 	if err != nil {
 		log.Error("error taking from sender limiter", "err", err, "req_id", GetReqID(ctx))
 		return ErrInternal
@@ -728,18 +903,6 @@ func (s *Server) rateLimitSender(ctx context.Context, req *RPCReq) error {
 	return nil
 }
 
-func (s *Server) isAllowedChainId(chainId *big.Int) bool {
-	if s.allowedChainIds == nil || len(s.allowedChainIds) == 0 {
-		return true
-	}
-	for _, id := range s.allowedChainIds {
-		if chainId.Cmp(id) == 0 {
-			return true
-		}
-	}
-	return false
-}
-
 func setCacheHeader(w http.ResponseWriter, cached bool) {
 	if cached {
 		w.Header().Set(cacheStatusHdr, "HIT")
@@ -836,11 +999,11 @@ func (w *recordLenWriter) Write(p []byte) (n int, err error) {
 
 type NoopRPCCache struct{}
 
-func (n *NoopRPCCache) GetRPC(context.Context, *RPCReq) (*RPCRes, error) {
+func (n *NoopRPCCache) GetRPC(context.Context, *BackendGroup, *RPCReq) (*RPCRes, error) {
 	return nil, nil
 }
 
-func (n *NoopRPCCache) PutRPC(context.Context, *RPCReq, *RPCRes) error {
+func (n *NoopRPCCache) PutRPC(context.Context, *BackendGroup, *RPCReq, *RPCRes) error {
 	return nil
 }
 
@@ -0,0 +1,167 @@
+package proxyd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// defaultComputeUnits is the weight of methods without configured compute units
+	defaultComputeUnits = 1
+
+	tenantUsagePeriod = 24 * time.Hour
+)
+
+// Tenant serves the requests authenticated by an API key according to its own policies, on top of the
+// global method overrides. Tenants with their own rate limit are exempt from the per-IP rate limit.
+type Tenant struct {
+	Name string
+
+	// backendGroup, if set, serves the tenant's requests in place of the rpc method mappings
+	backendGroup string
+	// allowedMethods, if set, restricts the methods the tenant can call
+	allowedMethods *StringSet
+
+	// rpsLim, if set, replaces the per-IP rate limit
+	rpsLim    FrontendRateLimiter
+	senderLim FrontendRateLimiter
+
+	// privateTransactions routes the tenant's transactions to the private backends
+	privateTransactions bool
+
+	// dailyComputeUnits is the quota of compute units consumed per day. Unlimited if 0
+	dailyComputeUnits int64
+	usage             TenantUsageTracker
+}
+
+func NewTenant(name string, cfg *TenantConfig, limiterFactory func(dur time.Duration, max int, prefix string) FrontendRateLimiter, usage TenantUsageTracker) *Tenant {
+	tenant := &Tenant{
+		Name:                name,
+		backendGroup:        cfg.BackendGroup,
+		dailyComputeUnits:   cfg.DailyComputeUnits,
+		usage:               usage,
+		privateTransactions: cfg.PrivateTransactions,
+	}
+	if len(cfg.AllowedMethods) > 0 {
+		tenant.allowedMethods = NewStringSetFromStrings(cfg.AllowedMethods)
+	}
+	if cfg.MaxRPS > 0 {
+		tenant.rpsLim = limiterFactory(time.Second, cfg.MaxRPS, "tenant:"+name)
+	}
+	if cfg.SenderRateLimit > 0 {
+		tenant.senderLim = limiterFactory(time.Duration(cfg.SenderRateLimitInterval), cfg.SenderRateLimit, "tenant_senders:"+name)
+	}
+	return tenant
+}
+
+// MethodGroup returns the backend group serving the method for the tenant, given the backend group of
+// the rpc method mappings. Returns an empty string if the tenant is not allowed to call the method
+func (t *Tenant) MethodGroup(method string, mappedGroup string) string {
+	if t.allowedMethods != nil && !t.allowedMethods.Has(method) {
+		return ""
+	}
+	if t.backendGroup == "" {
+		return mappedGroup
+	}
+	// methods explicitly allowed to the tenant need not be mapped
+	if mappedGroup != "" || t.allowedMethods != nil {
+		return t.backendGroup
+	}
+	return ""
+}
+
+// HasRPSLimit returns whether the tenant is rate limited by its own limit rather than per remote IP
+func (t *Tenant) HasRPSLimit() bool {
+	return t.rpsLim != nil
+}
+
+// TakeRPS consumes a request of the tenant's rate limit, returning false if over the limit
+func (t *Tenant) TakeRPS(ctx context.Context) (bool, error) {
+	if t.rpsLim == nil {
+		return true, nil
+	}
+	return t.rpsLim.Take(ctx, t.Name)
+}
+
+// TakeComputeUnits consumes the compute units of the tenant's daily quota, returning false if over the
+// quota. Units of rejected requests are not consumed.
+func (t *Tenant) TakeComputeUnits(ctx context.Context, method string, units int64) (bool, error) {
+	used, err := t.usage.Add(ctx, t.Name, units)
+	if err != nil {
+		return false, err
+	}
+	if t.dailyComputeUnits > 0 && used > t.dailyComputeUnits {
+		if _, err := t.usage.Add(ctx, t.Name, -units); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	RecordTenantComputeUnits(t.Name, method, units, used)
+	return true, nil
+}
+
+// TenantUsageTracker accounts the compute units consumed by each tenant over the current day
+type TenantUsageTracker interface {
+	// Add adds the compute units to the tenant's usage, returning the usage of the current day
+	Add(ctx context.Context, tenant string, units int64) (int64, error)
+}
+
+// MemoryTenantUsageTracker tracks the usage of the tenants in local memory, resetting it daily.
+// The usage is not shared across replicas.
+type MemoryTenantUsageTracker struct {
+	truncTS int64
+	usage   map[string]int64
+	mtx     sync.Mutex
+}
+
+func NewMemoryTenantUsageTracker() TenantUsageTracker {
+	return &MemoryTenantUsageTracker{usage: make(map[string]int64)}
+}
+
+func (m *MemoryTenantUsageTracker) Add(ctx context.Context, tenant string, units int64) (int64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	truncTS := truncateNow(tenantUsagePeriod)
+	if m.truncTS != truncTS {
+		m.truncTS = truncTS
+		m.usage = make(map[string]int64)
+	}
+	m.usage[tenant] += units
+	return m.usage[tenant], nil
+}
+
+// RedisTenantUsageTracker tracks the usage of the tenants in Redis, shared across replicas
+type RedisTenantUsageTracker struct {
+	r         *redis.Client
+	namespace string
+}
+
+func NewRedisTenantUsageTracker(r *redis.Client, namespace string) TenantUsageTracker {
+	return &RedisTenantUsageTracker{r: r, namespace: namespace}
+}
+
+func (r *RedisTenantUsageTracker) Add(ctx context.Context, tenant string, units int64) (int64, error) {
+	var incr *redis.IntCmd
+	truncTS := truncateNow(tenantUsagePeriod)
+	key := fmt.Sprintf("tenant_usage:%s:%d", tenant, truncTS)
+	if r.namespace != "" {
+		key = fmt.Sprintf("%s:%s", r.namespace, key)
+	}
+	_, err := r.r.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.IncrBy(ctx, key, units)
+		// keep the usage of the previous day around for inspection
+		pipe.Expire(ctx, key, 2*tenantUsagePeriod)
+		return nil
+	})
+	if err != nil {
+		RecordRedisError("TenantUsageAdd")
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+)
+
+func main() {
+	targetsFlag := flag.String("targets", "", "comma separated RPC URLs to replay the capture against")
+	configFlag := flag.String("config", "", "proxyd config file, to replay the capture against the backends of -backend-group")
+	groupFlag := flag.String("backend-group", "", "backend group of the proxyd config to replay the capture against")
+	skipFlag := flag.String("skip-methods", "eth_sendRawTransaction,eth_sendTransaction", "comma separated methods not replayed")
+	quietFlag := flag.Bool("quiet", false, "only print the summary of each target")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "replay the requests of proxyd capture files and diff the responses with the captured ones\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: replay [-targets <urls> | -config <proxyd.toml> -backend-group <name>] <capture files...>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var targets []*Target
+	if *targetsFlag != "" {
+		for _, url := range strings.Split(*targetsFlag, ",") {
+			targets = append(targets, &Target{Name: url, URL: url})
+		}
+	}
+	if *configFlag != "" {
+		groupTargets, err := backendGroupTargets(*configFlag, *groupFlag)
+		if err != nil {
+			fmt.Printf("error reading backend group: %v\n", err)
+			os.Exit(1)
+		}
+		targets = append(targets, groupTargets...)
+	}
+	if len(targets) == 0 {
+		fmt.Printf("no targets to replay against\n")
+		os.Exit(1)
+	}
+
+	var records []*proxyd.CaptureRecord
+	for _, path := range flag.Args() {
+		fileRecords, err := proxyd.ReadCaptureFile(path)
+		if err != nil {
+			fmt.Printf("error reading capture file %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		records = append(records, fileRecords...)
+	}
+
+	skipMethods := make(map[string]bool)
+	for _, method := range strings.Split(*skipFlag, ",") {
+		skipMethods[strings.TrimSpace(method)] = true
+	}
+
+	mismatches := 0
+	for _, target := range targets {
+		summary := Replay(target, records, skipMethods, !*quietFlag)
+		fmt.Printf("%s: replayed %d, matched %d, mismatched %d, failed %d, skipped %d\n",
+			target.Name, summary.Replayed, summary.Matched, summary.Mismatched, summary.Failed, summary.Skipped)
+		mismatches += summary.Mismatched + summary.Failed
+	}
+	if mismatches > 0 {
+		os.Exit(2)
+	}
+}
+
+// backendGroupTargets returns the backends of the group in the proxyd config
+func backendGroupTargets(configPath string, group string) ([]*Target, error) {
+	config := new(proxyd.Config)
+	if _, err := toml.DecodeFile(configPath, config); err != nil {
+		return nil, err
+	}
+	groupConfig := config.BackendGroups[group]
+	if groupConfig == nil {
+		return nil, fmt.Errorf("backend group %s does not exist", group)
+	}
+
+	var targets []*Target
+	for _, name := range groupConfig.Backends {
+		backendConfig := config.Backends[name]
+		if backendConfig == nil {
+			return nil, fmt.Errorf("backend %s does not exist", name)
+		}
+		url, err := proxyd.ReadFromEnvOrConfig(backendConfig.RPCURL)
+		if err != nil {
+			return nil, err
+		}
+		target := &Target{Name: name, URL: url, Headers: backendConfig.Headers}
+		if backendConfig.Password != "" {
+			target.Username = backendConfig.Username
+			if target.Password, err = proxyd.ReadFromEnvOrConfig(backendConfig.Password); err != nil {
+				return nil, err
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
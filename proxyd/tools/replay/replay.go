@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+)
+
+// Target is an RPC endpoint the capture is replayed against
+type Target struct {
+	Name     string
+	URL      string
+	Username string
+	Password string
+	Headers  map[string]string
+}
+
+type Summary struct {
+	Replayed   int
+	Matched    int
+	Mismatched int
+	Failed     int
+	Skipped    int
+}
+
+// rpcMessage holds the fields of requests & responses relevant to the diff
+type rpcMessage struct {
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// Replay sends the captured requests to the target, diffing its responses with the captured ones
+func Replay(target *Target, records []*proxyd.CaptureRecord, skipMethods map[string]bool, verbose bool) Summary {
+	var summary Summary
+	for _, record := range records {
+		reqs, isBatch := decodeMessages(record.Request)
+		if reqs == nil || containsMethod(reqs, skipMethods) {
+			summary.Skipped++
+			continue
+		}
+		summary.Replayed++
+
+		body, err := send(target, record.Request)
+		if err != nil {
+			summary.Failed++
+			if verbose {
+				fmt.Printf("[%s] %s: error replaying request: %v\n", target.Name, record.ReqID, err)
+			}
+			continue
+		}
+
+		expected, _ := decodeMessages(record.Response)
+		actual, actualIsBatch := decodeMessages(body)
+		if actual == nil || actualIsBatch != isBatch || len(actual) != len(expected) {
+			summary.Mismatched++
+			if verbose {
+				fmt.Printf("[%s] %s: unexpected response %s\n", target.Name, record.ReqID, string(body))
+			}
+			continue
+		}
+
+		matched := true
+		for i := range expected {
+			if diff := diffResponse(expected[i], actual[i]); diff != "" {
+				matched = false
+				if verbose {
+					fmt.Printf("[%s] %s %s: %s\n", target.Name, record.ReqID, reqs[i].Method, diff)
+				}
+			}
+		}
+		if matched {
+			summary.Matched++
+		} else {
+			summary.Mismatched++
+		}
+	}
+	return summary
+}
+
+func send(target *Target, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("content-type", "application/json")
+	for name, value := range target.Headers {
+		req.Header.Set(name, value)
+	}
+	if target.Password != "" {
+		req.SetBasicAuth(target.Username, target.Password)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return io.ReadAll(res.Body)
+}
+
+// decodeMessages decodes a single or batch JSON-RPC message, returning nil if malformed
+func decodeMessages(raw json.RawMessage) ([]*rpcMessage, bool) {
+	if proxyd.IsBatch(raw) {
+		var msgs []*rpcMessage
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			return nil, true
+		}
+		return msgs, true
+	}
+	msg := new(rpcMessage)
+	if err := json.Unmarshal(raw, msg); err != nil {
+		return nil, false
+	}
+	return []*rpcMessage{msg}, false
+}
+
+func containsMethod(msgs []*rpcMessage, methods map[string]bool) bool {
+	for _, msg := range msgs {
+		if methods[msg.Method] {
+			return true
+		}
+	}
+	return false
+}
+
+// diffResponse compares the result & error of the responses, ignoring the formatting of their JSON
+func diffResponse(expected, actual *rpcMessage) string {
+	if !equalJSON(expected.Result, actual.Result) {
+		return fmt.Sprintf("result %s != captured %s", orNull(actual.Result), orNull(expected.Result))
+	}
+	if !equalJSON(expected.Error, actual.Error) {
+		return fmt.Sprintf("error %s != captured %s", orNull(actual.Error), orNull(expected.Error))
+	}
+	return ""
+}
+
+func equalJSON(a, b json.RawMessage) bool {
+	// proxyd omits null results
+	a, b = bytes.TrimSpace(a), bytes.TrimSpace(b)
+	if bytes.Equal(a, []byte("null")) {
+		a = nil
+	}
+	if bytes.Equal(b, []byte("null")) {
+		b = nil
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func orNull(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return "null"
+	}
+	return string(raw)
+}
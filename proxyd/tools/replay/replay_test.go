@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/proxyd"
+)
+
+func TestEqualJSON(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{`{"a":1,"b":[1,2]}`, `{ "b": [1, 2], "a": 1 }`, true},
+		{`{"a":1}`, `{"a":2}`, false},
+		{`[1,2]`, `[2,1]`, false},
+		{`"0x1"`, `"0x01"`, false},
+		// proxyd omits null results
+		{`null`, ``, true},
+		{` null `, ``, true},
+		{`null`, `"0x1"`, false},
+		{``, `"0x1"`, false},
+		// malformed JSON is compared byte for byte
+		{`{barf`, `{barf`, true},
+		{`{barf`, `{barf}`, false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.equal, equalJSON(json.RawMessage(test.a), json.RawMessage(test.b)), "%s == %s", test.a, test.b)
+		require.Equal(t, test.equal, equalJSON(json.RawMessage(test.b), json.RawMessage(test.a)), "%s == %s", test.b, test.a)
+	}
+}
+
+func TestDiffResponse(t *testing.T) {
+	decode := func(raw string) *rpcMessage {
+		msgs, isBatch := decodeMessages(json.RawMessage(raw))
+		require.False(t, isBatch)
+		require.Len(t, msgs, 1)
+		return msgs[0]
+	}
+
+	require.Empty(t, diffResponse(decode(`{"result":{"a":1,"b":2}}`), decode(`{"result":{"b":2,"a":1}}`)))
+	require.Empty(t, diffResponse(decode(`{"result":null}`), decode(`{}`)))
+	require.Equal(t, `result "0x2" != captured "0x1"`, diffResponse(decode(`{"result":"0x1"}`), decode(`{"result":"0x2"}`)))
+	require.Equal(t, `result null != captured "0x1"`,
+		diffResponse(decode(`{"result":"0x1"}`), decode(`{"error":{"code":-32000,"message":"nonce too low"}}`)))
+	require.Equal(t, `error {"code":-32001} != captured {"code":-32000}`,
+		diffResponse(decode(`{"error":{"code":-32000}}`), decode(`{"error":{"code":-32001}}`)))
+}
+
+func TestDecodeMessages(t *testing.T) {
+	msgs, isBatch := decodeMessages(json.RawMessage(`[{"method":"eth_chainId"},{"method":"eth_blockNumber"}]`))
+	require.True(t, isBatch)
+	require.Len(t, msgs, 2)
+	require.Equal(t, "eth_blockNumber", msgs[1].Method)
+
+	msgs, isBatch = decodeMessages(json.RawMessage(`{"method":"eth_chainId"}`))
+	require.False(t, isBatch)
+	require.Len(t, msgs, 1)
+
+	// malformed requests are captured as strings
+	msgs, _ = decodeMessages(json.RawMessage(`"barf"`))
+	require.Nil(t, msgs)
+	msgs, isBatch = decodeMessages(json.RawMessage(`[barf`))
+	require.Nil(t, msgs)
+	require.True(t, isBatch)
+}
+
+func TestReplay(t *testing.T) {
+	// the target serves the chain id of the capture, a different block number and an error for eth_call
+	responses := map[string]string{
+		"eth_chainId":     `{"jsonrpc":"2.0","result":"0xa","id":1}`,
+		"eth_blockNumber": `{"jsonrpc":"2.0","result":"0x2","id":1}`,
+		"eth_call":        `{"jsonrpc":"2.0","error":{"code":3,"message":"execution reverted"},"id":1}`,
+	}
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req proxyd.RPCReq
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			// batches are answered with a single response
+			_, _ = w.Write([]byte(responses["eth_chainId"]))
+			return
+		}
+		_, _ = w.Write([]byte(responses[req.Method]))
+	}))
+	defer target.Close()
+
+	record := func(req, res string) *proxyd.CaptureRecord {
+		return &proxyd.CaptureRecord{ReqID: "req", Request: json.RawMessage(req), Response: json.RawMessage(res)}
+	}
+	records := []*proxyd.CaptureRecord{
+		// matched, regardless of the formatting and id
+		record(`{"jsonrpc":"2.0","method":"eth_chainId","id":2}`, `{"id":2, "jsonrpc":"2.0", "result":"0xa"}`),
+		// mismatched result
+		record(`{"jsonrpc":"2.0","method":"eth_blockNumber","id":1}`, `{"jsonrpc":"2.0","result":"0x1","id":1}`),
+		// mismatched error
+		record(`{"jsonrpc":"2.0","method":"eth_call","id":1}`, `{"jsonrpc":"2.0","result":"0x","id":1}`),
+		// mismatched batch shape
+		record(`[{"jsonrpc":"2.0","method":"eth_chainId","id":1}]`, `[{"jsonrpc":"2.0","result":"0xa","id":1}]`),
+		// skipped method, and malformed request
+		record(`{"jsonrpc":"2.0","method":"eth_sendRawTransaction","id":1}`, `{"jsonrpc":"2.0","result":"0x1","id":1}`),
+		record(`"barf"`, `{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error"},"id":null}`),
+	}
+	skipMethods := map[string]bool{"eth_sendRawTransaction": true}
+
+	summary := Replay(&Target{Name: "target", URL: target.URL}, records, skipMethods, false)
+	require.Equal(t, Summary{Replayed: 4, Matched: 1, Mismatched: 3, Skipped: 2}, summary)
+
+	// requests to unavailable targets fail
+	target.Close()
+	summary = Replay(&Target{Name: "target", URL: target.URL}, records, skipMethods, false)
+	require.Equal(t, Summary{Replayed: 4, Failed: 4, Skipped: 2}, summary)
+}
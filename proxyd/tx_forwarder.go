@@ -0,0 +1,296 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	TxRouteBroadcast = "broadcast"
+	TxRoutePrivate   = "private"
+)
+
+// TxForwarder validates raw transactions and forwards them to every backend of their route in parallel,
+// in place of the backend group the method is mapped to. The first successful response is returned.
+type TxForwarder struct {
+	allowedChainIds []*big.Int
+	maxGasLimit     uint64
+	blocked         map[common.Address]bool
+
+	// nonceGroup, if set, serves the pending nonce of the senders to bound their nonce gap
+	nonceGroup  *BackendGroup
+	maxNonceGap uint64
+
+	broadcastBackends []*Backend
+	privateBackends   []*Backend
+	// privateOnly routes every transaction to the private backends
+	privateOnly bool
+
+	// backendGroups are checked for the backends banned by their consensus
+	backendGroups map[string]*BackendGroup
+}
+
+type txForwardResult struct {
+	backend string
+	res     *RPCRes
+	err     error
+}
+
+func NewTxForwarder(cfg TxForwardingConfig, backends map[string]*Backend, backendGroups map[string]*BackendGroup, nonceGroup *BackendGroup) (*TxForwarder, error) {
+	f := &TxForwarder{
+		allowedChainIds: cfg.AllowedChainIds,
+		maxGasLimit:     cfg.MaxGasLimit,
+		blocked:         make(map[common.Address]bool),
+		nonceGroup:      nonceGroup,
+		maxNonceGap:     cfg.MaxNonceGap,
+		privateOnly:     cfg.PrivateOnly,
+		backendGroups:   backendGroups,
+	}
+	for _, addr := range cfg.BlockedAddresses {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid blocked address %s", addr)
+		}
+		f.blocked[common.HexToAddress(addr)] = true
+	}
+	for _, name := range cfg.BroadcastBackends {
+		be := backends[name]
+		if be == nil {
+			return nil, fmt.Errorf("undefined tx broadcast backend %s", name)
+		}
+		f.broadcastBackends = append(f.broadcastBackends, be)
+	}
+	for _, name := range cfg.PrivateBackends {
+		be := backends[name]
+		if be == nil {
+			return nil, fmt.Errorf("undefined tx private backend %s", name)
+		}
+		f.privateBackends = append(f.privateBackends, be)
+	}
+	if len(f.broadcastBackends) == 0 && !f.privateOnly {
+		return nil, fmt.Errorf("tx forwarding requires broadcast backends")
+	}
+	if f.privateOnly && len(f.privateBackends) == 0 {
+		return nil, fmt.Errorf("private only tx forwarding requires private backends")
+	}
+	return f, nil
+}
+
+// HasPrivateRoute returns true if transactions can be routed to private backends
+func (f *TxForwarder) HasPrivateRoute() bool {
+	return len(f.privateBackends) > 0
+}
+
+// Forward validates the eth_sendRawTransaction request and forwards it to the available backends of its
+// route, returning the first successful response and the backend that served it
+func (f *TxForwarder) Forward(ctx context.Context, req *RPCReq, private bool) (*RPCRes, string) {
+	tx, err := decodeRawTransaction(ctx, req)
+	if err != nil {
+		RecordTxForwardingRejection("invalid")
+		return NewRPCErrorRes(req.ID, err), ""
+	}
+	from, err := f.validate(ctx, tx)
+	if err != nil {
+		return NewRPCErrorRes(req.ID, err), ""
+	}
+
+	route, backends := TxRouteBroadcast, f.broadcastBackends
+	if private || f.privateOnly {
+		route, backends = TxRoutePrivate, f.privateBackends
+	}
+	backends = f.available(backends)
+	if len(backends) == 0 {
+		log.Warn("no available backend to forward transaction", "tx_hash", tx.Hash(), "route", route, "req_id", GetReqID(ctx))
+		return NewRPCErrorRes(req.ID, ErrNoBackends), ""
+	}
+
+	// Keep forwarding to the remaining backends once a response is returned to the client
+	fwdCtx := context.WithoutCancel(ctx)
+	start := time.Now()
+	resC := make(chan txForwardResult, len(backends))
+	for _, be := range backends {
+		go func(be *Backend) {
+			res, err := be.Forward(fwdCtx, []*RPCReq{req}, false)
+			result := txForwardResult{backend: be.Name, err: err}
+			if err == nil {
+				result.res = res[0]
+			}
+			RecordTxForward(be.Name, route, txForwardOutcome(result))
+			resC <- result
+		}(be)
+	}
+
+	var firstErr *RPCRes
+	for range backends {
+		result := <-resC
+		if result.err == nil && !result.res.IsError() {
+			log.Info(
+				"forwarded transaction",
+				"tx_hash", tx.Hash(),
+				"sender", from,
+				"nonce", tx.Nonce(),
+				"route", route,
+				"backend", result.backend,
+				"req_id", GetReqID(ctx),
+			)
+			RecordTxForwardingSuccess(result.backend, route, time.Since(start))
+			return result.res, result.backend
+		}
+		if firstErr == nil && result.err == nil {
+			firstErr = result.res
+		}
+	}
+
+	log.Warn("transaction not accepted by any backend", "tx_hash", tx.Hash(), "route", route, "req_id", GetReqID(ctx))
+	// relay the error of the backends, such as nonce too low, over their unavailability
+	if firstErr != nil {
+		return firstErr, ""
+	}
+	return NewRPCErrorRes(req.ID, ErrNoBackends), ""
+}
+
+// available filters out the unhealthy backends, and those banned by the consensus of a group they belong to
+func (f *TxForwarder) available(backends []*Backend) []*Backend {
+	available := make([]*Backend, 0, len(backends))
+	for _, be := range backends {
+		if be.IsHealthy() && !f.isBanned(be) {
+			available = append(available, be)
+		}
+	}
+	return available
+}
+
+func (f *TxForwarder) isBanned(be *Backend) bool {
+	for _, bg := range f.backendGroups {
+		members, _, consensus := bg.state()
+		if consensus != nil && containsBackend(members, be) && consensus.IsBanned(be) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the transaction against the policies, returning its sender
+func (f *TxForwarder) validate(ctx context.Context, tx *types.Transaction) (common.Address, error) {
+	if !isAllowedChainId(f.allowedChainIds, tx.ChainId()) {
+		log.Debug("chain id is not allowed", "req_id", GetReqID(ctx))
+		RecordTxForwardingRejection("chain_id")
+		return common.Address{}, ErrTransactionRejected("chain id is not allowed")
+	}
+
+	if f.maxGasLimit > 0 && tx.Gas() > f.maxGasLimit {
+		RecordTxForwardingRejection("gas_limit")
+		return common.Address{}, ErrTransactionRejected(fmt.Sprintf("gas limit above %d", f.maxGasLimit))
+	}
+	intrinsicGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, true, true)
+	if err != nil || tx.Gas() < intrinsicGas {
+		RecordTxForwardingRejection("gas_limit")
+		return common.Address{}, ErrTransactionRejected("intrinsic gas too low")
+	}
+
+	// This performs an ecrecover, which can be expensive.
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		RecordTxForwardingRejection("invalid")
+		return common.Address{}, ErrInvalidParams(err.Error())
+	}
+	if f.blocked[from] || (tx.To() != nil && f.blocked[*tx.To()]) {
+		log.Info("rejected transaction of blocked address", "tx_hash", tx.Hash(), "sender", from, "req_id", GetReqID(ctx))
+		RecordTxForwardingRejection("blocked")
+		return common.Address{}, ErrTransactionRejected("address is blocked")
+	}
+
+	if f.nonceGroup != nil && f.maxNonceGap > 0 {
+		pendingNonce, err := f.pendingNonce(ctx, from)
+		if err != nil {
+			// the backends reject invalid nonces regardless
+			log.Warn("error fetching pending nonce, skipping nonce gap check", "sender", from, "req_id", GetReqID(ctx), "err", err)
+		} else if tx.Nonce() > pendingNonce+f.maxNonceGap {
+			RecordTxForwardingRejection("nonce_gap")
+			return common.Address{}, ErrTransactionRejected(fmt.Sprintf("nonce gap above %d", f.maxNonceGap))
+		}
+	}
+	return from, nil
+}
+
+func (f *TxForwarder) pendingNonce(ctx context.Context, from common.Address) (uint64, error) {
+	req := &RPCReq{
+		JSONRPC: JSONRPCVersion,
+		Method:  "eth_getTransactionCount",
+		Params:  mustMarshalJSON([]interface{}{from, "pending"}),
+		ID:      json.RawMessage("1"),
+	}
+	res, _, err := f.nonceGroup.Forward(ctx, []*RPCReq{req}, false)
+	if err != nil {
+		return 0, err
+	}
+	if res[0].IsError() {
+		return 0, res[0].Error
+	}
+	var nonce hexutil.Uint64
+	if err := json.Unmarshal(mustMarshalJSON(res[0].Result), &nonce); err != nil {
+		return 0, err
+	}
+	return uint64(nonce), nil
+}
+
+func txForwardOutcome(result txForwardResult) string {
+	if result.err != nil {
+		return "error"
+	}
+	if result.res.IsError() {
+		return "rpc_error"
+	}
+	return "success"
+}
+
+// decodeRawTransaction decodes the transaction of an eth_sendRawTransaction request, returning the
+// same errors as Geth for malformed params
+func decodeRawTransaction(ctx context.Context, req *RPCReq) (*types.Transaction, error) {
+	var params []string
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		log.Debug("error unmarshaling raw transaction params", "err", err, "req_Id", GetReqID(ctx))
+		return nil, ErrParseErr
+	}
+
+	if len(params) != 1 {
+		log.Debug("raw transaction request has invalid number of params", "req_id", GetReqID(ctx))
+		// The error below is identical to the one Geth responds with.
+		return nil, ErrInvalidParams("missing value for required argument 0")
+	}
+
+	var data hexutil.Bytes
+	if err := data.UnmarshalText([]byte(params[0])); err != nil {
+		log.Debug("error decoding raw tx data", "err", err, "req_id", GetReqID(ctx))
+		// Geth returns the raw error from UnmarshalText.
+		return nil, ErrInvalidParams(err.Error())
+	}
+
+	// Inflates a types.Transaction object from the transaction's raw bytes.
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		log.Debug("could not unmarshal transaction", "err", err, "req_id", GetReqID(ctx))
+		return nil, ErrInvalidParams(err.Error())
+	}
+	return tx, nil
+}
+
+func isAllowedChainId(allowedChainIds []*big.Int, chainId *big.Int) bool {
+	if len(allowedChainIds) == 0 {
+		return true
+	}
+	for _, id := range allowedChainIds {
+		if chainId.Cmp(id) == 0 {
+			return true
+		}
+	}
+	return false
+}
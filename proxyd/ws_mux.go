@@ -0,0 +1,958 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	SubscriptionNewHeads = "newHeads"
+	SubscriptionLogs     = "logs"
+
+	defaultWSMuxMaxBackfillBlocks = 64
+	defaultWSMuxClientQueueSize   = 256
+
+	wsMuxRequestTimeout    = 5 * time.Second
+	wsMuxRedialInterval    = time.Second
+	wsMuxConsensusCheck    = time.Second
+	wsMuxBackfillTimeout   = 10 * time.Second
+	wsMuxCanonicalTimeout  = 5 * time.Second
+	wsMuxDedupBlockWindow  = 128
+	wsMuxUpstreamQueueSize = 1024
+)
+
+var (
+	errWSMuxUpstreamClosed = errors.New("upstream connection closed")
+	errWSMuxSlowClient     = errors.New("client too slow to consume its messages")
+)
+
+// WSMultiplexer shares the newHeads & logs subscriptions of WS clients over a single upstream connection to
+// the WS backend group. Clients subscribing with identical parameters share the same upstream subscription.
+//
+// Should the upstream connection drop, or its backend fall out of the consensus group, the subscriptions are
+// resumed against another backend, preferring the members of the consensus group. Notifications already
+// delivered are dropped and the heads (and logs) missed in between are backfilled from the new backend.
+// Notifications of blocks agreed on by the consensus group are only delivered if on its canonical chain.
+// Other requests of the clients are forwarded to the WS backend group over HTTP.
+type WSMultiplexer struct {
+	backendGroup      *BackendGroup
+	methodWhitelist   *StringSet
+	maxBackfillBlocks uint64
+	clientQueueSize   int
+
+	// subMtx serializes the changes to the upstream subscriptions
+	subMtx sync.Mutex
+
+	mtx          sync.Mutex
+	upstream     *wsUpstream
+	subs         map[string]*muxSubscription
+	upstreamSubs map[string]*muxSubscription
+	pending      map[string]*pendingSubscribe
+	nextID       uint64
+
+	// canonical caches the hashes of the canonical blocks as of the canonicalAt latest consensus block.
+	// Only accessed by the notification loop
+	canonical   map[uint64]common.Hash
+	canonicalAt hexutil.Uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// muxSubscription is an upstream subscription shared by the clients subscribing with the same parameters
+type muxSubscription struct {
+	kind   string
+	key    string
+	params json.RawMessage
+
+	// guarded by the multiplexer's mtx
+	upstreamID string
+	clients    map[string]*wsMuxClient
+
+	// Only accessed by the notification loop. seen holds the notifications delivered in the latest blocks,
+	// keyed by block number for heads and by block number & log index for logs
+	seen      map[string]seenNotification
+	lastBlock uint64
+}
+
+type seenNotification struct {
+	number uint64
+	hash   common.Hash
+}
+
+type pendingSubscribe struct {
+	sub  *muxSubscription
+	done chan error
+
+	// abandoned is set once the subscriber stopped awaiting the response. Guarded by the multiplexer's mtx
+	abandoned bool
+}
+
+type wsUpstream struct {
+	backend *Backend
+	conn    *websocket.Conn
+	connMu  sync.Mutex
+	closed  chan struct{}
+
+	// events queues the notifications read from the connection to the notification loop
+	events chan wsMuxEvent
+}
+
+// wsMuxEvent is a notification of the subscription, or a request to backfill its logs if without result
+type wsMuxEvent struct {
+	sub    *muxSubscription
+	result json.RawMessage
+}
+
+type wsMuxClient struct {
+	ctx  context.Context
+	conn *websocket.Conn
+
+	// queue buffers the messages to the client, which is dropped if it fills up
+	queue     chan []byte
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// guarded by the multiplexer's mtx
+	subs map[string]*muxSubscription
+}
+
+// wsMuxMessage is either the response to a request or a subscription notification
+type wsMuxMessage struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *RPCErr         `json:"error"`
+	Method string          `json:"method"`
+	Params *struct {
+		Subscription string          `json:"subscription"`
+		Result       json.RawMessage `json:"result"`
+	} `json:"params"`
+}
+
+func NewWSMultiplexer(bg *BackendGroup, methodWhitelist *StringSet, maxBackfillBlocks uint64, clientQueueSize int) *WSMultiplexer {
+	if maxBackfillBlocks == 0 {
+		maxBackfillBlocks = defaultWSMuxMaxBackfillBlocks
+	}
+	if clientQueueSize == 0 {
+		clientQueueSize = defaultWSMuxClientQueueSize
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WSMultiplexer{
+		backendGroup:      bg,
+		methodWhitelist:   methodWhitelist,
+		maxBackfillBlocks: maxBackfillBlocks,
+		clientQueueSize:   clientQueueSize,
+		subs:              make(map[string]*muxSubscription),
+		upstreamSubs:      make(map[string]*muxSubscription),
+		pending:           make(map[string]*pendingSubscribe),
+		canonical:         make(map[uint64]common.Hash),
+		ctx:               ctx,
+		cancel:            cancel,
+	}
+}
+
+// Start maintains the upstream connection in the background
+func (m *WSMultiplexer) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.run()
+	}()
+}
+
+func (m *WSMultiplexer) Shutdown() {
+	m.cancel()
+	m.mtx.Lock()
+	if m.upstream != nil {
+		m.upstream.close()
+	}
+	m.mtx.Unlock()
+	m.wg.Wait()
+}
+
+func (m *WSMultiplexer) run() {
+	var failed *Backend
+	for {
+		if m.ctx.Err() != nil {
+			return
+		}
+
+		upstream, err := m.dial(failed)
+		if err != nil {
+			log.Error("error dialing ws upstream", "err", err)
+			sleepContext(m.ctx, wsMuxRedialInterval)
+			continue
+		}
+
+		err = m.serve(upstream)
+		if m.ctx.Err() != nil {
+			return
+		}
+		log.Warn("ws upstream connection lost, failing over", "name", upstream.backend.Name, "err", err)
+		RecordWSMuxFailover(upstream.backend.Name)
+		failed = upstream.backend
+	}
+}
+
+// dial connects to the first available candidate backend, trying the backend that last failed last
+func (m *WSMultiplexer) dial(failed *Backend) (*wsUpstream, error) {
+	candidates := m.candidates()
+	for i, be := range candidates {
+		if be == failed && i < len(candidates)-1 {
+			candidates = append(append(candidates[:i:i], candidates[i+1:]...), be)
+			break
+		}
+	}
+
+	for _, be := range candidates {
+		conn, _, err := be.dialer.Dial(be.wsURL, nil) // nolint:bodyclose
+		if err != nil {
+			log.Warn("error dialing ws backend", "name", be.Name, "err", err)
+			continue
+		}
+		activeBackendWsConnsGauge.WithLabelValues(be.Name).Inc()
+		log.Info("connected ws upstream", "name", be.Name)
+		return &wsUpstream{backend: be, conn: conn, closed: make(chan struct{}), events: make(chan wsMuxEvent, wsMuxUpstreamQueueSize)}, nil
+	}
+	return nil, ErrNoBackends
+}
+
+// candidates returns the backends of the group, the members of the consensus group first when consensus aware.
+// Banned backends are excluded.
+func (m *WSMultiplexer) candidates() []*Backend {
+	backends, _, consensus := m.backendGroup.state()
+	if consensus == nil {
+		return append([]*Backend{}, backends...)
+	}
+
+	candidates := consensus.GetConsensusGroup()
+	for _, be := range backends {
+		if !consensus.IsBanned(be) && !containsBackend(candidates, be) {
+			candidates = append(candidates, be)
+		}
+	}
+	return candidates
+}
+
+// outOfConsensus returns true if the backend fell out of a non-empty consensus group
+func (m *WSMultiplexer) outOfConsensus(be *Backend) bool {
+	consensus := m.backendGroup.consensus()
+	if consensus == nil {
+		return false
+	}
+	group := consensus.GetConsensusGroup()
+	return len(group) > 0 && !containsBackend(group, be)
+}
+
+// serve resumes the subscriptions over the upstream connection and routes its messages until it fails
+func (m *WSMultiplexer) serve(upstream *wsUpstream) error {
+	m.mtx.Lock()
+	m.upstream = upstream
+	m.upstreamSubs = make(map[string]*muxSubscription)
+	for _, sub := range m.subs {
+		sub.upstreamID = ""
+	}
+	m.mtx.Unlock()
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- m.readUpstream(upstream)
+	}()
+	// notifications are processed apart from the read loop, as backfilling them requests the backend
+	notified := make(chan struct{})
+	go func() {
+		defer close(notified)
+		m.notifyLoop(upstream)
+	}()
+
+	m.subMtx.Lock()
+	m.mtx.Lock()
+	subs := make([]*muxSubscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		subs = append(subs, sub)
+	}
+	m.mtx.Unlock()
+	for _, sub := range subs {
+		if err := m.subscribeUpstream(upstream, sub); err != nil {
+			log.Warn("error resuming ws subscription", "name", upstream.backend.Name, "key", sub.key, "err", err)
+			upstream.close()
+			break
+		}
+	}
+	m.subMtx.Unlock()
+
+	ticker := time.NewTicker(wsMuxConsensusCheck)
+	defer ticker.Stop()
+	var err error
+	for err == nil {
+		select {
+		case err = <-errC:
+		case <-ticker.C:
+			if m.outOfConsensus(upstream.backend) {
+				log.Warn("ws upstream fell out of the consensus group", "name", upstream.backend.Name)
+				upstream.close()
+			}
+		}
+	}
+
+	m.mtx.Lock()
+	m.upstream = nil
+	for id, pending := range m.pending {
+		pending.done <- errWSMuxUpstreamClosed
+		delete(m.pending, id)
+	}
+	m.mtx.Unlock()
+	upstream.close()
+	<-notified
+	activeBackendWsConnsGauge.WithLabelValues(upstream.backend.Name).Dec()
+	return err
+}
+
+func (m *WSMultiplexer) readUpstream(upstream *wsUpstream) error {
+	for {
+		_, data, err := upstream.conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		RecordWSMessage(m.ctx, upstream.backend.Name, SourceBackend)
+
+		var msg wsMuxMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			log.Warn("error parsing RPC response", "source", "ws", "name", upstream.backend.Name, "err", err)
+			continue
+		}
+		if msg.Method == "eth_subscription" && msg.Params != nil {
+			m.mtx.Lock()
+			sub := m.upstreamSubs[msg.Params.Subscription]
+			m.mtx.Unlock()
+			if sub != nil {
+				upstream.enqueue(wsMuxEvent{sub: sub, result: msg.Params.Result})
+			}
+		} else {
+			m.handleResponse(upstream, &msg)
+		}
+	}
+}
+
+// notifyLoop processes the notifications of the upstream connection until closed
+func (m *WSMultiplexer) notifyLoop(upstream *wsUpstream) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-upstream.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case ev := <-upstream.events:
+			if ev.result == nil {
+				m.backfillLogs(ctx, upstream, ev.sub)
+			} else {
+				m.handleNotification(ctx, upstream, ev.sub, ev.result)
+			}
+		case <-upstream.closed:
+			return
+		}
+	}
+}
+
+func (m *WSMultiplexer) handleResponse(upstream *wsUpstream, msg *wsMuxMessage) {
+	m.mtx.Lock()
+	pending := m.pending[string(msg.ID)]
+	delete(m.pending, string(msg.ID))
+	m.mtx.Unlock()
+	// responses to unsubscriptions are not awaited
+	if pending == nil {
+		return
+	}
+
+	if msg.Error != nil {
+		pending.done <- msg.Error
+		return
+	}
+	var upstreamID string
+	if err := json.Unmarshal(msg.Result, &upstreamID); err != nil {
+		pending.done <- ErrBackendBadResponse
+		return
+	}
+
+	sub := pending.sub
+	m.mtx.Lock()
+	// the subscriber timed out, the subscription is unused
+	if pending.abandoned {
+		m.mtx.Unlock()
+		m.unsubscribeUpstream(upstream, upstreamID)
+		return
+	}
+	sub.upstreamID = upstreamID
+	m.upstreamSubs[upstreamID] = sub
+	m.mtx.Unlock()
+
+	// Logs may be emitted in any block, so those missed while resuming can't be detected from the next
+	// notification. Backfill them before processing any notification of the new subscription
+	if sub.kind == SubscriptionLogs {
+		upstream.enqueue(wsMuxEvent{sub: sub})
+	}
+	pending.done <- nil
+}
+
+func (m *WSMultiplexer) handleNotification(ctx context.Context, upstream *wsUpstream, sub *muxSubscription, result json.RawMessage) {
+	switch sub.kind {
+	case SubscriptionNewHeads:
+		var head struct {
+			Number hexutil.Uint64 `json:"number"`
+			Hash   common.Hash    `json:"hash"`
+		}
+		if err := json.Unmarshal(result, &head); err != nil {
+			log.Warn("error parsing ws head notification", "name", upstream.backend.Name, "err", err)
+			return
+		}
+		if sub.lastBlock > 0 && uint64(head.Number) > sub.lastBlock+1 {
+			m.backfillHeads(ctx, upstream, sub, sub.lastBlock+1, uint64(head.Number)-1)
+		}
+		m.handleHead(ctx, sub, uint64(head.Number), head.Hash, result, false)
+
+	case SubscriptionLogs:
+		m.handleLog(ctx, sub, result, false)
+	}
+}
+
+// handleHead delivers the head unless already delivered or off the canonical chain
+func (m *WSMultiplexer) handleHead(ctx context.Context, sub *muxSubscription, number uint64, hash common.Hash, result json.RawMessage, backfilled bool) {
+	key := strconv.FormatUint(number, 10)
+	if seen, ok := sub.seen[key]; ok && seen.hash == hash {
+		RecordWSMuxDuplicateNotification(sub.kind)
+		return
+	}
+	if !m.isCanonical(ctx, number, hash) {
+		RecordWSMuxNonCanonicalNotification(sub.kind)
+		return
+	}
+	if backfilled {
+		RecordWSMuxBackfilledNotification(sub.kind)
+	}
+	m.deliver(sub, key, number, hash, result)
+}
+
+// handleLog delivers the log unless already delivered or off the canonical chain
+func (m *WSMultiplexer) handleLog(ctx context.Context, sub *muxSubscription, result json.RawMessage, backfilled bool) {
+	var l struct {
+		BlockNumber hexutil.Uint64 `json:"blockNumber"`
+		BlockHash   common.Hash    `json:"blockHash"`
+		Index       hexutil.Uint   `json:"logIndex"`
+		Removed     bool           `json:"removed"`
+	}
+	if err := json.Unmarshal(result, &l); err != nil {
+		log.Warn("error parsing ws log notification", "err", err)
+		return
+	}
+	key := fmt.Sprintf("%d:%d:%t", l.BlockNumber, l.Index, l.Removed)
+	if seen, ok := sub.seen[key]; ok && seen.hash == l.BlockHash {
+		RecordWSMuxDuplicateNotification(sub.kind)
+		return
+	}
+	// the removal of logs is notified once their block is off the canonical chain
+	if !l.Removed && !m.isCanonical(ctx, uint64(l.BlockNumber), l.BlockHash) {
+		RecordWSMuxNonCanonicalNotification(sub.kind)
+		return
+	}
+	if backfilled {
+		RecordWSMuxBackfilledNotification(sub.kind)
+	}
+	m.deliver(sub, key, uint64(l.BlockNumber), l.BlockHash, result)
+}
+
+// isCanonical returns false if the block is agreed on by the consensus group with another hash. Blocks
+// past the latest consensus block, or of groups that aren't consensus aware, are considered canonical
+func (m *WSMultiplexer) isCanonical(ctx context.Context, number uint64, hash common.Hash) bool {
+	consensus := m.backendGroup.consensus()
+	if consensus == nil {
+		return true
+	}
+	latest := consensus.GetLatestBlockNumber()
+	if number > uint64(latest) {
+		return true
+	}
+	// the consensus group may have reorged since
+	if latest != m.canonicalAt {
+		m.canonical = make(map[uint64]common.Hash)
+		m.canonicalAt = latest
+	}
+	if canonical, ok := m.canonical[number]; ok {
+		return canonical == hash
+	}
+
+	group := consensus.GetConsensusGroup()
+	if len(group) == 0 {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(ctx, wsMuxCanonicalTimeout)
+	defer cancel()
+	var res RPCRes
+	if err := group[0].ForwardRPC(ctx, &res, "1", "eth_getBlockByNumber", hexutil.Uint64(number), false); err != nil {
+		log.Warn("error fetching canonical block", "name", group[0].Name, "number", number, "err", err)
+		return true
+	}
+	_, canonical, err := blockToHeader(res.Result)
+	if err != nil {
+		log.Warn("error fetching canonical block", "name", group[0].Name, "number", number, "err", err)
+		return true
+	}
+	m.canonical[number] = canonical
+	return canonical == hash
+}
+
+// backfillHeads delivers the heads in [from, to] missed by the subscription, bounded to the latest blocks
+func (m *WSMultiplexer) backfillHeads(ctx context.Context, upstream *wsUpstream, sub *muxSubscription, from, to uint64) {
+	if to-from+1 > m.maxBackfillBlocks {
+		from = to - m.maxBackfillBlocks + 1
+	}
+	log.Info("backfilling ws heads", "name", upstream.backend.Name, "from", from, "to", to)
+
+	ctx, cancel := context.WithTimeout(ctx, wsMuxBackfillTimeout)
+	defer cancel()
+	for number := from; number <= to; number++ {
+		var res RPCRes
+		if err := upstream.backend.ForwardRPC(ctx, &res, "1", "eth_getBlockByNumber", hexutil.Uint64(number), false); err != nil {
+			log.Warn("error backfilling ws heads", "name", upstream.backend.Name, "number", number, "err", err)
+			return
+		}
+		head, hash, err := blockToHeader(res.Result)
+		if err != nil {
+			log.Warn("error backfilling ws heads", "name", upstream.backend.Name, "number", number, "err", err)
+			return
+		}
+		m.handleHead(ctx, sub, number, hash, head, true)
+	}
+}
+
+// backfillLogs delivers the logs matching the subscription since the last block it was notified of
+func (m *WSMultiplexer) backfillLogs(ctx context.Context, upstream *wsUpstream, sub *muxSubscription) {
+	if sub.lastBlock == 0 {
+		return
+	}
+	var params []json.RawMessage
+	filter := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(sub.params, &params); err != nil {
+		return
+	}
+	if len(params) > 1 {
+		if err := json.Unmarshal(params[1], &filter); err != nil {
+			return
+		}
+	}
+	filter["fromBlock"] = mustMarshalJSON(hexutil.Uint64(sub.lastBlock))
+	filter["toBlock"] = mustMarshalJSON("latest")
+	log.Info("backfilling ws logs", "name", upstream.backend.Name, "from", sub.lastBlock)
+
+	ctx, cancel := context.WithTimeout(ctx, wsMuxBackfillTimeout)
+	defer cancel()
+	var res RPCRes
+	if err := upstream.backend.ForwardRPC(ctx, &res, "1", "eth_getLogs", filter); err != nil {
+		log.Warn("error backfilling ws logs", "name", upstream.backend.Name, "err", err)
+		return
+	}
+	var logs []json.RawMessage
+	if err := json.Unmarshal(mustMarshalJSON(res.Result), &logs); err != nil {
+		log.Warn("error backfilling ws logs", "name", upstream.backend.Name, "err", err)
+		return
+	}
+	for _, l := range logs {
+		m.handleLog(ctx, sub, l, true)
+	}
+}
+
+// deliver notifies the clients of the subscription, remembering the notification to drop duplicates
+func (m *WSMultiplexer) deliver(sub *muxSubscription, key string, number uint64, hash common.Hash, result json.RawMessage) {
+	sub.seen[key] = seenNotification{number: number, hash: hash}
+	if number > sub.lastBlock {
+		sub.lastBlock = number
+		for k, seen := range sub.seen {
+			if seen.number+wsMuxDedupBlockWindow < number {
+				delete(sub.seen, k)
+			}
+		}
+	}
+
+	m.mtx.Lock()
+	clients := make(map[string]*wsMuxClient, len(sub.clients))
+	for id, client := range sub.clients {
+		clients[id] = client
+	}
+	m.mtx.Unlock()
+
+	for id, client := range clients {
+		notification := mustMarshalJSON(map[string]interface{}{
+			"jsonrpc": JSONRPCVersion,
+			"method":  "eth_subscription",
+			"params": map[string]interface{}{
+				"subscription": id,
+				"result":       result,
+			},
+		})
+		// the client's read loop cleans up the subscriptions of dropped clients
+		client.send(notification)
+	}
+}
+
+// subscribeUpstream subscribes over the upstream connection, awaiting the response
+func (m *WSMultiplexer) subscribeUpstream(upstream *wsUpstream, sub *muxSubscription) error {
+	done := make(chan error, 1)
+	m.mtx.Lock()
+	m.nextID++
+	id := strconv.FormatUint(m.nextID, 10)
+	m.pending[id] = &pendingSubscribe{sub: sub, done: done}
+	m.mtx.Unlock()
+
+	req := &RPCReq{JSONRPC: JSONRPCVersion, Method: "eth_subscribe", Params: sub.params, ID: json.RawMessage(id)}
+	if err := upstream.write(websocket.TextMessage, mustMarshalJSON(req)); err != nil {
+		m.mtx.Lock()
+		delete(m.pending, id)
+		m.mtx.Unlock()
+		return err
+	}
+
+	timer := time.NewTimer(wsMuxRequestTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-upstream.closed:
+		return errWSMuxUpstreamClosed
+	case <-timer.C:
+		m.mtx.Lock()
+		defer m.mtx.Unlock()
+		// the response arrived in the meantime
+		if sub.upstreamID != "" {
+			return nil
+		}
+		// a late response is unsubscribed
+		if pending := m.pending[id]; pending != nil {
+			pending.abandoned = true
+		}
+		return ErrGatewayTimeout
+	}
+}
+
+func (m *WSMultiplexer) unsubscribeUpstream(upstream *wsUpstream, upstreamID string) {
+	m.mtx.Lock()
+	m.nextID++
+	id := strconv.FormatUint(m.nextID, 10)
+	m.mtx.Unlock()
+
+	params := mustMarshalJSON([]string{upstreamID})
+	req := &RPCReq{JSONRPC: JSONRPCVersion, Method: "eth_unsubscribe", Params: params, ID: json.RawMessage(id)}
+	if err := upstream.write(websocket.TextMessage, mustMarshalJSON(req)); err != nil {
+		log.Warn("error unsubscribing ws upstream", "name", upstream.backend.Name, "err", err)
+	}
+}
+
+// subscribe adds the client to the subscription matching the params, returning the client's subscription id
+func (m *WSMultiplexer) subscribe(client *wsMuxClient, params json.RawMessage) (string, error) {
+	kind, key, err := parseSubscription(params)
+	if err != nil {
+		return "", err
+	}
+
+	m.subMtx.Lock()
+	defer m.subMtx.Unlock()
+
+	m.mtx.Lock()
+	sub := m.subs[key]
+	upstream := m.upstream
+	m.mtx.Unlock()
+
+	if sub == nil {
+		sub = &muxSubscription{
+			kind:    kind,
+			key:     key,
+			params:  params,
+			clients: make(map[string]*wsMuxClient),
+			seen:    make(map[string]seenNotification),
+		}
+		// Without an upstream connection, the subscription is resumed once connected
+		if upstream != nil {
+			if err := m.subscribeUpstream(upstream, sub); err != nil {
+				log.Warn("error subscribing ws upstream", "name", upstream.backend.Name, "key", key, "err", err)
+				var rpcErr *RPCErr
+				if errors.As(err, &rpcErr) {
+					return "", rpcErr
+				}
+				return "", ErrBackendOffline
+			}
+		}
+		m.mtx.Lock()
+		m.subs[key] = sub
+		m.mtx.Unlock()
+		wsMuxSubscriptionsGauge.WithLabelValues(kind).Inc()
+	}
+
+	id := "0x" + randStr(16)
+	m.mtx.Lock()
+	sub.clients[id] = client
+	client.subs[id] = sub
+	m.mtx.Unlock()
+	return id, nil
+}
+
+// unsubscribe removes the client's subscription, closing the upstream subscription once unused
+func (m *WSMultiplexer) unsubscribe(client *wsMuxClient, id string) bool {
+	m.subMtx.Lock()
+	defer m.subMtx.Unlock()
+
+	m.mtx.Lock()
+	sub := client.subs[id]
+	if sub == nil {
+		m.mtx.Unlock()
+		return false
+	}
+	delete(client.subs, id)
+	delete(sub.clients, id)
+	unused := len(sub.clients) == 0
+	upstreamID := sub.upstreamID
+	if unused {
+		delete(m.subs, sub.key)
+		delete(m.upstreamSubs, upstreamID)
+	}
+	upstream := m.upstream
+	m.mtx.Unlock()
+
+	if unused {
+		wsMuxSubscriptionsGauge.WithLabelValues(sub.kind).Dec()
+		if upstream != nil && upstreamID != "" {
+			m.unsubscribeUpstream(upstream, upstreamID)
+		}
+	}
+	return true
+}
+
+// ServeClient serves the client connection until closed
+func (m *WSMultiplexer) ServeClient(ctx context.Context, conn *websocket.Conn) error {
+	// the context of the upgraded request is canceled once the connection is hijacked
+	ctx = context.WithoutCancel(ctx)
+	client := &wsMuxClient{
+		ctx:    ctx,
+		conn:   conn,
+		queue:  make(chan []byte, m.clientQueueSize),
+		closed: make(chan struct{}),
+		subs:   make(map[string]*muxSubscription),
+	}
+	go client.writeLoop()
+	defer func() {
+		m.mtx.Lock()
+		ids := make([]string, 0, len(client.subs))
+		for id := range client.subs {
+			ids = append(ids, id)
+		}
+		m.mtx.Unlock()
+		for _, id := range ids {
+			m.unsubscribe(client, id)
+		}
+		client.close()
+	}()
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return nil
+			}
+			return err
+		}
+		RecordWSMessage(ctx, BackendProxyd, SourceClient)
+		rpcRequestsTotal.Inc()
+
+		res := m.handleClientMsg(ctx, client, msg)
+		if !client.send(mustMarshalJSON(res)) {
+			return errWSMuxSlowClient
+		}
+	}
+}
+
+func (m *WSMultiplexer) handleClientMsg(ctx context.Context, client *wsMuxClient, msg []byte) *RPCRes {
+	req, err := ParseRPCReq(msg)
+	if err == nil && !m.methodWhitelist.Has(req.Method) {
+		err = ErrMethodNotWhitelisted
+	}
+	if err != nil {
+		var id json.RawMessage
+		method := MethodUnknown
+		if req != nil {
+			id = req.ID
+			method = req.Method
+		}
+		log.Info(
+			"error preparing client message",
+			"auth", GetAuthCtx(ctx),
+			"req_id", GetReqID(ctx),
+			"err", err,
+		)
+		RecordRPCError(ctx, BackendProxyd, method, err)
+		return NewRPCErrorRes(id, err)
+	}
+
+	switch req.Method {
+	case "eth_accounts":
+		RecordRPCForward(ctx, BackendProxyd, req.Method, RPCRequestSourceWS)
+		return NewRPCRes(req.ID, emptyArrayResponse)
+
+	case "eth_subscribe":
+		RecordRPCForward(ctx, BackendProxyd, req.Method, RPCRequestSourceWS)
+		id, err := m.subscribe(client, req.Params)
+		if err != nil {
+			RecordRPCError(ctx, BackendProxyd, req.Method, err)
+			return NewRPCErrorRes(req.ID, err)
+		}
+		return NewRPCRes(req.ID, id)
+
+	case "eth_unsubscribe":
+		RecordRPCForward(ctx, BackendProxyd, req.Method, RPCRequestSourceWS)
+		var params []string
+		if err := json.Unmarshal(req.Params, &params); err != nil || len(params) != 1 {
+			RecordRPCError(ctx, BackendProxyd, req.Method, ErrInvalidParams("invalid subscription id"))
+			return NewRPCErrorRes(req.ID, ErrInvalidParams("invalid subscription id"))
+		}
+		return NewRPCRes(req.ID, m.unsubscribe(client, params[0]))
+	}
+
+	res, _, err := m.backendGroup.Forward(ctx, []*RPCReq{req}, false)
+	if err != nil {
+		log.Error(
+			"error forwarding WS request",
+			"method", req.Method,
+			"auth", GetAuthCtx(ctx),
+			"req_id", GetReqID(ctx),
+			"err", err,
+		)
+		RecordRPCError(ctx, BackendProxyd, req.Method, err)
+		return NewRPCErrorRes(req.ID, err)
+	}
+	return res[0]
+}
+
+// send queues the message to the client, dropping the client if its queue is full. Returns false if the
+// client is dropped
+func (c *wsMuxClient) send(msg []byte) bool {
+	select {
+	case c.queue <- msg:
+		return true
+	case <-c.closed:
+		return false
+	default:
+		log.Info("dropping slow ws client", "auth", GetAuthCtx(c.ctx), "req_id", GetReqID(c.ctx))
+		RecordWSMuxDroppedClient()
+		c.close()
+		return false
+	}
+}
+
+// writeLoop writes the queued messages to the client until closed
+func (c *wsMuxClient) writeLoop() {
+	for {
+		select {
+		case msg := <-c.queue:
+			if err := c.conn.SetWriteDeadline(time.Now().Add(defaultWSWriteTimeout)); err != nil {
+				c.close()
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				log.Info("error writing to ws client", "req_id", GetReqID(c.ctx), "err", err)
+				c.close()
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// close closes the client connection, ending its read loop
+func (c *wsMuxClient) close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.conn.Close()
+	})
+}
+
+func (u *wsUpstream) write(msgType int, msg []byte) error {
+	u.connMu.Lock()
+	defer u.connMu.Unlock()
+	if err := u.conn.SetWriteDeadline(time.Now().Add(defaultWSWriteTimeout)); err != nil {
+		return err
+	}
+	return u.conn.WriteMessage(msgType, msg)
+}
+
+// enqueue queues the event to the notification loop, unless the connection is closed
+func (u *wsUpstream) enqueue(ev wsMuxEvent) {
+	select {
+	case u.events <- ev:
+	case <-u.closed:
+	}
+}
+
+func (u *wsUpstream) close() {
+	u.connMu.Lock()
+	defer u.connMu.Unlock()
+	select {
+	case <-u.closed:
+	default:
+		close(u.closed)
+		u.conn.Close()
+	}
+}
+
+// parseSubscription returns the kind of the subscription and the key identifying its parameters. Only
+// newHeads & logs subscriptions are supported
+func parseSubscription(params json.RawMessage) (string, string, error) {
+	var args []interface{}
+	if err := json.Unmarshal(params, &args); err != nil || len(args) == 0 {
+		return "", "", ErrInvalidParams("invalid subscription params")
+	}
+	kind, ok := args[0].(string)
+	if !ok || (kind != SubscriptionNewHeads && kind != SubscriptionLogs) {
+		return "", "", ErrInvalidParams("unsupported subscription type")
+	}
+	// maps are marshalled sorted by key, so filters differing only in the order of their fields share a key
+	return kind, string(mustMarshalJSON(args)), nil
+}
+
+// blockToHeader strips the body of the block returned by eth_getBlockByNumber, as sent by newHeads
+func blockToHeader(block interface{}) (json.RawMessage, common.Hash, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(mustMarshalJSON(block), &fields); err != nil || fields == nil {
+		return nil, common.Hash{}, ErrBackendBadResponse
+	}
+	var hash common.Hash
+	if err := json.Unmarshal(fields["hash"], &hash); err != nil {
+		return nil, common.Hash{}, ErrBackendBadResponse
+	}
+	for _, field := range []string{"transactions", "uncles", "withdrawals", "size", "totalDifficulty"} {
+		delete(fields, field)
+	}
+	return mustMarshalJSON(fields), hash, nil
+}
+
+func containsBackend(backends []*Backend, be *Backend) bool {
+	for _, b := range backends {
+		if b == be {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package proxyd
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestParseSubscription(t *testing.T) {
+	kind, key, err := parseSubscription([]byte(`["logs",{"topics":["0x01"],"address":"0x02"}]`))
+	require.NoError(t, err)
+	require.Equal(t, SubscriptionLogs, kind)
+
+	// filters differing in the order of their fields share the subscription
+	_, otherKey, err := parseSubscription([]byte(`["logs",{"address":"0x02","topics":["0x01"]}]`))
+	require.NoError(t, err)
+	require.Equal(t, key, otherKey)
+
+	_, otherKey, err = parseSubscription([]byte(`["logs",{"address":"0x03"}]`))
+	require.NoError(t, err)
+	require.NotEqual(t, key, otherKey)
+
+	kind, _, err = parseSubscription([]byte(`["newHeads"]`))
+	require.NoError(t, err)
+	require.Equal(t, SubscriptionNewHeads, kind)
+
+	_, _, err = parseSubscription([]byte(`["newPendingTransactions"]`))
+	require.ErrorContains(t, err, "unsupported subscription type")
+	_, _, err = parseSubscription([]byte(`[]`))
+	require.Error(t, err)
+}
+
+func TestWSMultiplexerCanonicalChain(t *testing.T) {
+	// the consensus group serves the blocks hashed by their number
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RPCReq
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		var params []interface{}
+		require.NoError(t, json.Unmarshal(req.Params, &params))
+		number, err := hexutil.DecodeUint64(params[0].(string))
+		require.NoError(t, err)
+		block := map[string]interface{}{"number": hexutil.Uint64(number), "hash": common.BigToHash(new(big.Int).SetUint64(number))}
+		_, _ = w.Write(mustMarshalJSON(NewRPCRes(req.ID, block)))
+	}))
+	defer node.Close()
+
+	be := NewBackend("node", node.URL, "", semaphore.NewWeighted(100))
+	tracker := NewInMemoryConsensusTracker()
+	tracker.SetLatestBlockNumber(10)
+	bg := &BackendGroup{Name: "main", Backends: []*Backend{be}, Consensus: &ConsensusPoller{tracker: tracker, consensusGroup: []*Backend{be}}}
+	m := NewWSMultiplexer(bg, NewStringSet(), 0, 0)
+
+	client := &wsMuxClient{ctx: context.Background(), queue: make(chan []byte, 16), closed: make(chan struct{})}
+	sub := &muxSubscription{kind: SubscriptionNewHeads, clients: map[string]*wsMuxClient{"0x1": client}, seen: make(map[string]seenNotification)}
+	delivered := func() int {
+		n := len(client.queue)
+		for i := 0; i < n; i++ {
+			<-client.queue
+		}
+		return n
+	}
+
+	canonical := common.BigToHash(big.NewInt(5))
+	m.handleHead(context.Background(), sub, 5, canonical, []byte(`{}`), false)
+	require.Equal(t, 1, delivered())
+	m.handleHead(context.Background(), sub, 5, canonical, []byte(`{}`), false)
+	require.Equal(t, 0, delivered(), "duplicate")
+
+	// a head agreed on by the consensus group with another hash is off the canonical chain
+	m.handleHead(context.Background(), sub, 5, common.HexToHash("0xf0"), []byte(`{}`), false)
+	require.Equal(t, 0, delivered(), "off the canonical chain")
+
+	// heads past the latest consensus block are not agreed on yet, including reorgs
+	m.handleHead(context.Background(), sub, 11, common.HexToHash("0xf1"), []byte(`{}`), false)
+	require.Equal(t, 1, delivered())
+	m.handleHead(context.Background(), sub, 11, common.HexToHash("0xf2"), []byte(`{}`), false)
+	require.Equal(t, 1, delivered(), "reorg")
+
+	// logs of non-canonical blocks are dropped, unless removed
+	sub = &muxSubscription{kind: SubscriptionLogs, clients: map[string]*wsMuxClient{"0x1": client}, seen: make(map[string]seenNotification)}
+	m.handleLog(context.Background(), sub, mustMarshalJSON(map[string]interface{}{"blockNumber": "0x5", "blockHash": canonical, "logIndex": "0x0"}), false)
+	require.Equal(t, 1, delivered())
+	m.handleLog(context.Background(), sub, mustMarshalJSON(map[string]interface{}{"blockNumber": "0x5", "blockHash": common.HexToHash("0xf0"), "logIndex": "0x0"}), false)
+	require.Equal(t, 0, delivered(), "off the canonical chain")
+	m.handleLog(context.Background(), sub, mustMarshalJSON(map[string]interface{}{"blockNumber": "0x5", "blockHash": common.HexToHash("0xf0"), "logIndex": "0x0", "removed": true}), false)
+	require.Equal(t, 1, delivered(), "removed")
+}
+
+func TestWSMuxClientDropsSlowClient(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		// never reads
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(strings.Replace(server.URL, "http://", "ws://", 1), nil) // nolint:bodyclose
+	require.NoError(t, err)
+
+	// without a write loop consuming the queue
+	client := &wsMuxClient{ctx: context.Background(), conn: conn, queue: make(chan []byte, 2), closed: make(chan struct{})}
+	require.True(t, client.send([]byte("1")))
+	require.True(t, client.send([]byte("2")))
+	require.False(t, client.send([]byte("3")))
+
+	select {
+	case <-client.closed:
+	default:
+		t.Fatal("slow client not dropped")
+	}
+	require.False(t, client.send([]byte("4")))
+}